@@ -60,7 +60,8 @@ func init() {
 		operatorCmd,
 		osdCmd,
 		mgrCmd,
-		configCmd)
+		configCmd,
+		opsLogCmd)
 }
 
 func createContext() *clusterd.Context {
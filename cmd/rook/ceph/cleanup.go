@@ -18,18 +18,27 @@ package ceph
 
 import (
 	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/rook/rook/cmd/rook/rook"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	cleanup "github.com/rook/rook/pkg/daemon/ceph/cleanup"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	"github.com/rook/rook/pkg/util/flags"
 	"github.com/spf13/cobra"
 )
 
+// the timeout of the requests of the bucket migration, which copy objects of up to 5GiB
+const bucketMigrationHTTPTimeout = time.Hour
+
 var (
 	dataDirHostPath    string
 	namespaceDir       string
@@ -68,6 +77,12 @@ var cleanUpBlockPoolCmd = &cobra.Command{
 	Short: "Starts the cleanup process for a CephBlockPool",
 }
 
+var cleanUpObjectStoreCmd = &cobra.Command{
+	// the subcommand matches CRD kind of the custom resource to be cleaned up
+	Use:   "CephObjectStore",
+	Short: "Scans a CephObjectStore for orphans and removes them once confirmed, or migrates a bucket of a CephObjectStore to another placement",
+}
+
 func init() {
 	cleanUpHostCmd.Flags().StringVar(&dataDirHostPath, "data-dir-host-path", "", "dataDirHostPath on the node")
 	cleanUpHostCmd.Flags().StringVar(&namespaceDir, "namespace-dir", "", "dataDirHostPath on the node")
@@ -80,12 +95,13 @@ func init() {
 	flags.SetFlagsFromEnv(cleanUpHostCmd.Flags(), rook.RookEnvVarPrefix)
 	flags.SetFlagsFromEnv(cleanUpSubVolumeGroupCmd.Flags(), rook.RookEnvVarPrefix)
 
-	cleanUpCmd.AddCommand(cleanUpHostCmd, cleanUpSubVolumeGroupCmd, cleanUpRadosNamespaceCmd, cleanUpBlockPoolCmd)
+	cleanUpCmd.AddCommand(cleanUpHostCmd, cleanUpSubVolumeGroupCmd, cleanUpRadosNamespaceCmd, cleanUpBlockPoolCmd, cleanUpObjectStoreCmd)
 
 	cleanUpHostCmd.RunE = startHostCleanUp
 	cleanUpSubVolumeGroupCmd.RunE = startSubVolumeGroupCleanUp
 	cleanUpRadosNamespaceCmd.RunE = startRadosNamespaceCleanup
 	cleanUpBlockPoolCmd.RunE = startBlockPoolCleanup
+	cleanUpObjectStoreCmd.RunE = startObjectStoreCleanup
 }
 
 func startHostCleanUp(cmd *cobra.Command, args []string) error {
@@ -203,3 +219,128 @@ func startBlockPoolCleanup(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func startObjectStoreCleanup(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(cleanUpObjectStoreCmd.Flags())
+
+	if os.Getenv(opcontroller.CephObjectStoreBucketEnv) != "" {
+		return startBucketMigration(cmd)
+	}
+	return startObjectStoreOrphanScan(cmd)
+}
+
+func startObjectStoreOrphanScan(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	context := createContext()
+	namespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+	clusterInfo := client.AdminClusterInfo(ctx, namespace, "")
+
+	env := map[string]string{}
+	for _, name := range []string{
+		opcontroller.OrphanScanIDEnv,
+		opcontroller.CephObjectStoreRealmEnv,
+		opcontroller.CephObjectStoreZoneGroupEnv,
+		opcontroller.CephObjectStoreZoneEnv,
+		opcontroller.CephObjectStoreDataPoolsEnv,
+		opcontroller.CephObjectStoreLogPoolEnv,
+	} {
+		env[name] = os.Getenv(name)
+		if env[name] == "" {
+			rook.TerminateFatal(fmt.Errorf("%s is not available in the pod environment variables", name))
+		}
+	}
+
+	scan := cleanup.NewObjectStoreOrphanScan(context, clusterInfo,
+		env[opcontroller.OrphanScanIDEnv],
+		env[opcontroller.CephObjectStoreRealmEnv],
+		env[opcontroller.CephObjectStoreZoneGroupEnv],
+		env[opcontroller.CephObjectStoreZoneEnv],
+		env[opcontroller.CephObjectStoreLogPoolEnv],
+		strings.Split(env[opcontroller.CephObjectStoreDataPoolsEnv], ","))
+
+	var report *cleanup.OrphanScanReport
+	var err error
+	if os.Getenv(opcontroller.OrphanScanRemoveEnv) == "true" {
+		report, err = scan.Remove()
+	} else {
+		report, err = scan.Scan()
+	}
+	if err != nil {
+		rook.TerminateFatal(fmt.Errorf("failed orphan scan %q of zone %q. %v", scan.ID, scan.Zone, err))
+	}
+	if err := cleanup.WriteOrphanScanReport(report); err != nil {
+		rook.TerminateFatal(err)
+	}
+
+	return nil
+}
+
+func startBucketMigration(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	context := createContext()
+	namespace := os.Getenv(k8sutil.PodNamespaceEnvVar)
+	clusterInfo := client.AdminClusterInfo(ctx, namespace, "")
+
+	env := map[string]string{}
+	for _, name := range []string{
+		opcontroller.CephObjectStoreBucketEnv,
+		opcontroller.CephObjectStoreRealmEnv,
+		opcontroller.CephObjectStoreZoneGroupEnv,
+		opcontroller.CephObjectStoreZoneEnv,
+		opcontroller.CephObjectStoreLogPoolEnv,
+		opcontroller.CephObjectStoreEndpointEnv,
+		opcontroller.MigrationTargetPlacementEnv,
+		opcontroller.MigrationConcurrencyEnv,
+	} {
+		env[name] = os.Getenv(name)
+		if env[name] == "" {
+			rook.TerminateFatal(fmt.Errorf("%s is not available in the pod environment variables", name))
+		}
+	}
+	concurrency, err := strconv.Atoi(env[opcontroller.MigrationConcurrencyEnv])
+	if err != nil {
+		rook.TerminateFatal(fmt.Errorf("invalid %s. %v", opcontroller.MigrationConcurrencyEnv, err))
+	}
+	var maxObjectsPerSecond int
+	if value := os.Getenv(opcontroller.MigrationMaxObjectsPerSecondEnv); value != "" {
+		maxObjectsPerSecond, err = strconv.Atoi(value)
+		if err != nil {
+			rook.TerminateFatal(fmt.Errorf("invalid %s. %v", opcontroller.MigrationMaxObjectsPerSecondEnv, err))
+		}
+	}
+
+	tlsCert := []byte(os.Getenv(opcontroller.CephObjectStoreTLSCertEnv))
+	insecure := os.Getenv(opcontroller.CephObjectStoreInsecureTLSEnv) == "true"
+	// the copy of a large object takes longer than the timeout of the s3 client of the operator
+	httpClient := &http.Client{Timeout: bucketMigrationHTTPTimeout}
+	if len(tlsCert) > 0 || insecure {
+		httpClient.Transport = object.BuildTransportTLS(tlsCert, insecure)
+	}
+	newS3Client := func(accessKey, secretKey string) (s3iface.S3API, error) {
+		agent, err := object.NewS3Agent(accessKey, secretKey, env[opcontroller.CephObjectStoreEndpointEnv], false, tlsCert, insecure, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		return agent.Client, nil
+	}
+
+	migration := cleanup.NewBucketMigration(context, clusterInfo,
+		env[opcontroller.CephObjectStoreRealmEnv],
+		env[opcontroller.CephObjectStoreZoneGroupEnv],
+		env[opcontroller.CephObjectStoreZoneEnv],
+		env[opcontroller.CephObjectStoreLogPoolEnv],
+		env[opcontroller.MigrationTargetPlacementEnv],
+		concurrency, maxObjectsPerSecond, newS3Client)
+
+	bucket := env[opcontroller.CephObjectStoreBucketEnv]
+	report, err := migration.Migrate(bucket)
+	if err != nil {
+		rook.TerminateFatal(fmt.Errorf("failed to migrate bucket %q to placement %q. %v", bucket, migration.TargetPlacement, err))
+	}
+	if err := cleanup.WriteBucketMigrationReport(report); err != nil {
+		rook.TerminateFatal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ceph
+
+import (
+	"encoding/json"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/cmd/rook/rook"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/opslog"
+	"github.com/rook/rook/pkg/util/flags"
+	"github.com/spf13/cobra"
+)
+
+var opsLogCmd = &cobra.Command{
+	Use:   "ops-log",
+	Short: "Forwards the RGW ops log entries to the configured sinks",
+}
+
+var (
+	opsLogFile         string
+	opsLogConfig       string
+	opsLogPodName      string
+	opsLogPollInterval time.Duration
+)
+
+func init() {
+	opsLogCmd.Flags().StringVar(&opsLogFile, "log-file", "", "the path of the RGW ops log file")
+	opsLogCmd.Flags().StringVar(&opsLogConfig, "ops-log-config", "", "the ops log sidecar spec in JSON with the sinks, filter and sampling")
+	opsLogCmd.Flags().StringVar(&opsLogPodName, "pod-name", "", "the name of the RGW pod, which identifies the entries in the sinks")
+	opsLogCmd.Flags().DurationVar(&opsLogPollInterval, "poll-interval", time.Second, "the interval at which the log file is read and the sinks are flushed")
+
+	flags.SetFlagsFromEnv(opsLogCmd.Flags(), rook.RookEnvVarPrefix)
+	opsLogCmd.RunE = runOpsLog
+}
+
+func runOpsLog(cmd *cobra.Command, args []string) error {
+	rook.SetLogLevel()
+	rook.LogStartupInfo(opsLogCmd.Flags())
+
+	if err := flags.VerifyRequiredFlags(opsLogCmd, []string{"log-file", "ops-log-config"}); err != nil {
+		rook.TerminateFatal(err)
+	}
+
+	spec := &cephv1.OpsLogSidecar{}
+	if err := json.Unmarshal([]byte(opsLogConfig), spec); err != nil {
+		rook.TerminateFatal(errors.Wrap(err, "failed to parse the ops log config"))
+	}
+
+	sinks := []opslog.Sink{}
+	for i := range spec.Sinks {
+		sink, err := opslog.NewSink(i, &spec.Sinks[i], opsLogPodName)
+		if err != nil {
+			rook.TerminateFatal(errors.Wrapf(err, "invalid ops log sink %d", i))
+		}
+		logger.Infof("forwarding ops log entries to sink %q", sink.Name())
+		sinks = append(sinks, sink)
+	}
+
+	// flush the buffered entries when the pod is stopped
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return opslog.NewShipper(opsLogFile, spec, sinks).Run(ctx, opsLogPollInterval)
+}
@@ -282,16 +282,9 @@ func prepareOSD(cmd *cobra.Command, args []string) error {
 		metaDevice = cfg.metadataDevice
 	}
 
+	// the status map is updated with the failure details by the OSD orchestration
 	err = osddaemon.Provision(context, agent, crushLocation, topologyAffinity, deviceFilter, metaDevice)
 	if err != nil {
-		// something failed in the OSD orchestration, update the status map with failure details
-		status := oposd.OrchestrationStatus{
-			Status:       oposd.OrchestrationStatusFailed,
-			Message:      err.Error(),
-			PvcBackedOSD: cfg.pvcBacked,
-		}
-		oposd.UpdateNodeOrPVCStatus(clusterInfo.Context, kv, cfg.nodeName, status)
-
 		rook.TerminateFatal(err)
 	}
 
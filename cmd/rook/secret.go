@@ -20,14 +20,19 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"path"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/rook/rook/cmd/rook/rook"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/daemon/ceph/osd"
 	"github.com/rook/rook/pkg/daemon/ceph/osd/kms"
 	operator "github.com/rook/rook/pkg/operator/ceph"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,8 +44,8 @@ var KeyManagementCmd = &cobra.Command{
 	Use:   "key-management",
 	Short: "key-management interacts with a given Key Management System and perform actions.",
 	Long: `The secret sub-command helps interacting with Key Management System.
-	It can perform various actions such as retrieving the content of a Key Encryption Key and
-	rotating Key Encryption Key.`,
+	It can perform various actions such as retrieving the content of a Key Encryption Key,
+	rotating Key Encryption Key and re-encrypting the devices with a new volume key.`,
 	Hidden: true, // do not advertise to end users
 }
 
@@ -48,10 +53,11 @@ func init() {
 	KeyManagementCmd.AddCommand(
 		cliGetSecret(),
 		cliRotateSecret(),
+		cliReencrypt(),
 	)
 }
 
-func startSecret() (*kms.Config, *clusterd.Context) {
+func startSecret() (*kms.Config, *clusterd.Context, *cephv1.CephCluster) {
 	// Initialize the context
 	ctx, cancel := signal.NotifyContext(context.Background(), operator.ShutdownSignals...)
 	defer cancel()
@@ -84,7 +90,28 @@ func startSecret() (*kms.Config, *clusterd.Context) {
 		}
 	}
 
-	return kms.NewConfig(context, &cephCluster.Spec, clusterInfo), context
+	ownerRef := opcontroller.ClusterOwnerRef(cephCluster.Name, string(cephCluster.UID))
+	clusterInfo.OwnerInfo = k8sutil.NewOwnerInfoWithOwnerRef(&ownerRef, namespace)
+
+	return kms.NewConfig(context, &cephCluster.Spec, clusterInfo), context, cephCluster
+}
+
+// initCephConfig writes the ceph config of the cluster with the mon endpoints and the credentials
+// set in the environment of the job.
+func initCephConfig(context *clusterd.Context, clusterInfo *client.ClusterInfo) error {
+	clusterInfo.FSID = os.Getenv("ROOK_FSID")
+	clusterInfo.CephCred.Username = os.Getenv("ROOK_CEPH_USERNAME")
+	clusterInfo.InternalMonitors = opcontroller.ParseMonEndpoints(os.Getenv("ROOK_MON_ENDPOINTS"))
+	secret, err := os.ReadFile(path.Join(mon.CephSecretMountPath, mon.CephSecretFilename))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read ceph secret file from %q", mon.CephSecretMountPath)
+	}
+	clusterInfo.CephCred.Secret = string(secret)
+
+	if err := client.WriteCephConfig(context, clusterInfo); err != nil {
+		return errors.Wrap(err, "failed to generate ceph config")
+	}
+	return nil
 }
 
 // cliGetSecret is the Cobra CLI call
@@ -105,7 +132,7 @@ func getSecret(cmd *cobra.Command, args []string) {
 
 	secretName := args[0]
 	secretPath := args[1]
-	keyManagementService, _ := startSecret()
+	keyManagementService, _, _ := startSecret()
 	keyManagementService.ClusterInfo.Context = ctx
 
 	// Fetch the secret
@@ -146,7 +173,7 @@ func rotateSecret(cmd *cobra.Command, args []string) {
 	defer cancel()
 	secretName := args[0]
 	devicePaths := args[1:]
-	keyManagementService, context := startSecret()
+	keyManagementService, context, _ := startSecret()
 	keyManagementService.ClusterInfo.Context = ctx
 
 	err := osd.RotateKeyEncryptionKey(context, keyManagementService, secretName, devicePaths)
@@ -154,3 +181,43 @@ func rotateSecret(cmd *cobra.Command, args []string) {
 		rook.TerminateFatal(errors.Wrapf(err, "failed to rotate secret %q", secretName))
 	}
 }
+
+// cliReencrypt is the Cobra CLI call
+func cliReencrypt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reencrypt osd-id kms-secret-key data-device [metadata-device] [wal-device]",
+		Short: "Re-encrypt the devices with a new volume key",
+		Args:  cobra.RangeArgs(3, 5),
+		Run:   reencrypt,
+	}
+	return cmd
+}
+
+// reencrypt replaces the volume key of the encrypted devices of a given OSD.
+// It accepts the OSD ID, the name of the secret holding the Key Encryption Key,
+// and the path to the encrypted devices.
+func reencrypt(cmd *cobra.Command, args []string) {
+	rook.SetLogLevel()
+	// Initialize the context
+	ctx, cancel := signal.NotifyContext(context.Background(), operator.ShutdownSignals...)
+	defer cancel()
+	osdID, err := strconv.Atoi(args[0])
+	if err != nil {
+		rook.TerminateFatal(errors.Wrapf(err, "invalid osd id %q", args[0]))
+	}
+	secretName := args[1]
+	devicePaths := args[2:]
+	keyManagementService, context, cephCluster := startSecret()
+	keyManagementService.ClusterInfo.Context = ctx
+
+	// the ceph config is needed to check that the PGs are clean before re-encrypting
+	if err := initCephConfig(context, keyManagementService.ClusterInfo); err != nil {
+		rook.TerminateFatal(err)
+	}
+
+	pgHealthyRegex := cephCluster.Spec.DisruptionManagement.PGHealthyRegex
+	err = osd.ReencryptVolumeKey(context, keyManagementService, osdID, pgHealthyRegex, secretName, devicePaths)
+	if err != nil {
+		rook.TerminateFatal(errors.Wrapf(err, "failed to re-encrypt osd %d", osdID))
+	}
+}
@@ -61,6 +61,8 @@ rules:
       - cephnfses
       - cephobjectstores
       - cephobjectstoreusers
+      - cephobjectstoreroles
+      - cephobjectstorescripts
       - cephobjectrealms
       - cephobjectzonegroups
       - cephobjectzones
@@ -69,6 +69,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&CephObjectStoreList{},
 		&CephObjectStoreUser{},
 		&CephObjectStoreUserList{},
+		&CephObjectStoreRole{},
+		&CephObjectStoreRoleList{},
+		&CephObjectStoreScript{},
+		&CephObjectStoreScriptList{},
 		&CephObjectRealm{},
 		&CephObjectRealmList{},
 		&CephObjectZoneGroup{},
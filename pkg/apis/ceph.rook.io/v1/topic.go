@@ -80,5 +80,8 @@ func (t *CephBucketTopic) ValidateTopicSpec() error {
 	if !hasEndpoint {
 		return errors.New("missing endpoint spec")
 	}
+	if t.Spec.Delivery != nil && !t.Spec.Persistent {
+		return errors.New("delivery settings require a persistent topic")
+	}
 	return nil
 }
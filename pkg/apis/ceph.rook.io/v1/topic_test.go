@@ -60,6 +60,16 @@ func TestValidateHTTPTopicSpec(t *testing.T) {
 		err := topic.ValidateTopicSpec()
 		assert.Error(t, err)
 	})
+	t.Run("delivery settings", func(t *testing.T) {
+		topic.Spec.Endpoint.HTTP.URI = "http://myserver:9999"
+		maxRetries := int32(10)
+		topic.Spec.Delivery = &TopicDeliverySpec{MaxRetries: &maxRetries}
+		err := topic.ValidateTopicSpec()
+		assert.NoError(t, err)
+		topic.Spec.Persistent = false
+		err = topic.ValidateTopicSpec()
+		assert.Error(t, err)
+	})
 }
 
 func TestValidateAMQPTopicSpec(t *testing.T) {
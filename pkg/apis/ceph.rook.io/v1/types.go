@@ -368,6 +368,37 @@ type ObjectStoreSecuritySpec struct {
 	// +optional
 	// +nullable
 	ServerSideEncryptionS3 KeyManagementServiceSpec `json:"s3,omitempty"`
+
+	// BucketEncryption sets the default encryption of the buckets provisioned by object bucket
+	// claims and whether the uploads that do not request server-side encryption are denied
+	// +optional
+	// +nullable
+	BucketEncryption *BucketEncryptionSpec `json:"bucketEncryption,omitempty"`
+}
+
+// BucketEncryptionAlgorithm is the server-side encryption of the objects of a bucket
+// +kubebuilder:validation:Enum=SSE-S3;SSE-KMS
+type BucketEncryptionAlgorithm string
+
+const (
+	// BucketEncryptionSSES3 encrypts the objects with the keys of the SSE-S3 KMS of the object store
+	BucketEncryptionSSES3 BucketEncryptionAlgorithm = "SSE-S3"
+	// BucketEncryptionSSEKMS encrypts the objects with a key of the KMS of the object store
+	BucketEncryptionSSEKMS BucketEncryptionAlgorithm = "SSE-KMS"
+)
+
+// BucketEncryptionSpec represents the encryption policy of the buckets of an object store
+type BucketEncryptionSpec struct {
+	// DefaultAlgorithm is the default encryption of the buckets that do not request one.
+	// SSE-S3 requires the s3 settings and SSE-KMS requires the Vault kms settings of the object
+	// store. With SSE-KMS, a key is created in Vault for each bucket.
+	// +optional
+	DefaultAlgorithm BucketEncryptionAlgorithm `json:"defaultAlgorithm,omitempty"`
+
+	// RequireEncryption adds a statement to the policy of the buckets which denies the uploads
+	// that do not request server-side encryption with the x-amz-server-side-encryption header
+	// +optional
+	RequireEncryption bool `json:"requireEncryption,omitempty"`
 }
 
 // KeyManagementServiceSpec represent various details of the KMS server
@@ -391,6 +422,24 @@ type KeyRotationSpec struct {
 	// Schedule represents the cron schedule for key rotation.
 	// +optional
 	Schedule string `json:"schedule,omitempty"`
+	// Reencryption defines options for the online re-encryption of the LUKS volume key of
+	// encrypted OSDs. Key rotation only changes the key encrypting the volume key, while
+	// re-encryption replaces the volume key itself and rewrites all data on the device.
+	// Only applies to the CephCluster OSDs running on PVCs.
+	// +optional
+	Reencryption ReencryptionSpec `json:"reencryption,omitempty"`
+}
+
+// ReencryptionSpec represents the settings for the re-encryption of the OSD volume keys.
+type ReencryptionSpec struct {
+	// Enabled represents whether the re-encryption of the OSD volume keys is enabled.
+	// +optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+	// Schedule represents the cron schedule for the re-encryption. Defaults to "@yearly". The
+	// "@yearly", "@monthly" and "@weekly" schedules re-encrypt each OSD on a different day.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
 }
 
 // CephVersionSpec represents the settings for the Ceph version that Rook is orchestrating.
@@ -568,6 +617,102 @@ type OSDStatus struct {
 	// StoreType is a mapping between the OSD backend stores and number of OSDs using these stores
 	StoreType       map[string]int  `json:"storeType,omitempty"`
 	MigrationStatus MigrationStatus `json:"migrationStatus,omitempty"`
+	// Reencryption is the status of the volume key re-encryption of the encrypted OSDs
+	// +optional
+	Reencryption []OSDReencryptionStatus `json:"reencryption,omitempty"`
+	// Provisioning reports, for each node or PVC, the decision taken by the OSD prepare job
+	// for each device it discovered during the last reconcile
+	// +optional
+	Provisioning []OSDProvisioningStatus `json:"provisioning,omitempty"`
+	// WeightRampUp is the progress of the CRUSH weight ramp-up of the new OSDs
+	// +optional
+	WeightRampUp []OSDWeightRampUpStatus `json:"weightRampUp,omitempty"`
+	// Quarantined are the OSDs stopped because they were flapping
+	// +optional
+	Quarantined []OSDQuarantineStatus `json:"quarantined,omitempty"`
+}
+
+// OSDQuarantineStatus represents an OSD quarantined because it was flapping
+type OSDQuarantineStatus struct {
+	// ID is the OSD ID
+	ID int `json:"id"`
+	// Reason is why the OSD was quarantined
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// QuarantineTime is when the OSD was quarantined
+	// +optional
+	QuarantineTime string `json:"quarantineTime,omitempty"`
+}
+
+// OSDWeightRampUpStatus represents the progress of the CRUSH weight ramp-up of an OSD
+type OSDWeightRampUpStatus struct {
+	// ID is the OSD ID
+	ID int `json:"id"`
+	// CurrentWeight is the CRUSH weight set at the last step
+	// +optional
+	CurrentWeight string `json:"currentWeight,omitempty"`
+	// TargetWeight is the CRUSH weight at which the ramp-up completes
+	// +optional
+	TargetWeight string `json:"targetWeight,omitempty"`
+	// Message describes the state of the ramp-up
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OSDProvisioningStatus represents the decisions of the OSD prepare job of a node or PVC.
+type OSDProvisioningStatus struct {
+	// Name is the name of the node or the PVC
+	Name string `json:"name"`
+	// PVC is whether the OSD prepare job ran on a PVC
+	// +optional
+	PVC bool `json:"pvc,omitempty"`
+	// Devices are the decisions taken for each device discovered by the OSD prepare job
+	// +optional
+	Devices []OSDDeviceProvisioningStatus `json:"devices,omitempty"`
+}
+
+// OSDDeviceDecision is the decision of the OSD prepare job about a device
+type OSDDeviceDecision string
+
+const (
+	// OSDDeviceUsed means the device was selected to be configured as an OSD
+	OSDDeviceUsed OSDDeviceDecision = "Used"
+	// OSDDeviceSkipped means the device was not configured as an OSD
+	OSDDeviceSkipped OSDDeviceDecision = "Skipped"
+)
+
+// OSDDeviceProvisioningStatus represents the decision of the OSD prepare job about a device.
+type OSDDeviceProvisioningStatus struct {
+	// Name is the name of the device
+	Name string `json:"name"`
+	// Decision is whether the device was used or skipped for an OSD
+	Decision OSDDeviceDecision `json:"decision"`
+	// Reason is the reason the device was skipped
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// OSDID is the ID of the OSD found or created on the device
+	// +optional
+	// +nullable
+	OSDID *int `json:"osdID,omitempty"`
+}
+
+// OSDReencryptionStatus represents the status of the volume key re-encryption of an OSD.
+type OSDReencryptionStatus struct {
+	// ID is the OSD ID
+	ID int `json:"id"`
+	// Phase is the phase of the last re-encryption, one of InProgress, Completed or Failed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Progress is the number of devices of the OSD re-encrypted so far, e.g. "1/2"
+	// +optional
+	Progress string `json:"progress,omitempty"`
+	// Message contains details about a failed re-encryption
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastReencryptionTime is the time the last successful re-encryption completed
+	// +optional
+	// +nullable
+	LastReencryptionTime *metav1.Time `json:"lastReencryptionTime,omitempty"`
 }
 
 // MigrationStatus status represents the current status of any OSD migration.
@@ -640,6 +785,12 @@ const (
 	// RadosNamespaceEmptyReason represents when a rados namespace does not contain images or snapshots that are blocking
 	// deletion.
 	RadosNamespaceEmptyReason ConditionReason = "RadosNamespaceEmpty"
+	// SyncBehindReason represents when a zone has changes older than the lag threshold not synced
+	// from a source.
+	SyncBehindReason ConditionReason = "SyncBehind"
+	// SyncCaughtUpReason represents when a zone has no changes older than the lag threshold left to
+	// sync.
+	SyncCaughtUpReason ConditionReason = "SyncCaughtUp"
 )
 
 // ConditionType represent a resource's status
@@ -665,6 +816,8 @@ const (
 	ConditionPoolDeletionIsBlocked ConditionType = "PoolDeletionIsBlocked"
 	// ConditionRadosNSDeletionIsBlocked represents when deletion of the object is blocked.
 	ConditionRadosNSDeletionIsBlocked ConditionType = "RadosNamespaceDeletionIsBlocked"
+	// ConditionSyncLagging represents when the multisite sync of a zone is behind its sources.
+	ConditionSyncLagging ConditionType = "SyncLagging"
 )
 
 // ClusterState represents the state of a Ceph Cluster
@@ -1671,6 +1824,283 @@ type ObjectStoreSpec struct {
 	// +nullable
 	// +optional
 	Hosting *ObjectStoreHostingSpec `json:"hosting,omitempty"`
+
+	// RateLimits are the global default rate limits of the object store. They apply to every user,
+	// bucket or anonymous client that does not have its own rate limit configured.
+	// +nullable
+	// +optional
+	RateLimits *ObjectStoreRateLimitSpec `json:"rateLimits,omitempty"`
+
+	// UsageExporter enables the RGW usage log and periodically collects the usage of the users and
+	// buckets of the object store, exposing it as operator metrics and in the CephObjectStoreUser
+	// status.
+	// +nullable
+	// +optional
+	UsageExporter *ObjectStoreUsageExporterSpec `json:"usageExporter,omitempty"`
+
+	// OrphanScan configures the scans of the data pool for RADOS objects and bucket index entries
+	// that are not referenced by any bucket anymore. Scans can also be requested on demand with the
+	// `ceph.rook.io/orphan-scan` annotation.
+	// +nullable
+	// +optional
+	OrphanScan *ObjectStoreOrphanScanSpec `json:"orphanScan,omitempty"`
+
+	// Migration moves the object store to another pool placement of sharedPools.poolPlacements: the
+	// placement becomes the default placement of the new buckets, and the objects of the existing
+	// buckets are copied to buckets created in the placement by a background job
+	// +nullable
+	// +optional
+	Migration *ObjectStoreMigrationSpec `json:"migration,omitempty"`
+}
+
+// ObjectStoreMigrationSpec represents the migration of an object store to another pool placement
+type ObjectStoreMigrationSpec struct {
+	// TargetPlacement is the name of the pool placement of sharedPools.poolPlacements that becomes
+	// the default placement of the new buckets. It must not be marked as default, the placement of
+	// the existing buckets is kept in the zone until they are migrated.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]+$`
+	TargetPlacement string `json:"targetPlacement"`
+	// BucketInterval is the pause between the migration of two buckets, like 5m, to limit the load
+	// of the migration on the cluster. Buckets are migrated one after the other without pause when
+	// not set.
+	// +optional
+	BucketInterval *metav1.Duration `json:"bucketInterval,omitempty"`
+	// Concurrency is the number of objects of a bucket copied in parallel. Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// +optional
+	Concurrency int32 `json:"concurrency,omitempty"`
+	// MaxObjectsPerSecond limits the rate at which the objects of a bucket are copied. The rate is
+	// not limited when not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxObjectsPerSecond int32 `json:"maxObjectsPerSecond,omitempty"`
+}
+
+// MigrationPhase is the phase of the migration of an object store, or of the migration of a bucket
+type MigrationPhase string
+
+const (
+	// MigrationPhaseMigrating is set while the objects of the existing buckets are copied
+	MigrationPhaseMigrating MigrationPhase = "Migrating"
+	// MigrationPhaseCompleted is set when all the buckets were processed, or when a bucket was
+	// migrated
+	MigrationPhaseCompleted MigrationPhase = "Completed"
+	// MigrationPhaseFailed is set when the migration of a bucket failed
+	MigrationPhaseFailed MigrationPhase = "Failed"
+)
+
+// ObjectStoreMigrationStatus represents the progress of the migration of an object store
+type ObjectStoreMigrationStatus struct {
+	// TargetPlacement is the placement the object store is migrated to
+	// +optional
+	TargetPlacement string `json:"targetPlacement,omitempty"`
+	// Phase of the migration
+	// +optional
+	Phase MigrationPhase `json:"phase,omitempty"`
+	// SwitchTime is when the target placement became the default placement of the new buckets
+	// +optional
+	// +nullable
+	SwitchTime *metav1.Time `json:"switchTime,omitempty"`
+	// TotalBuckets is the number of buckets of the object store when they were last listed
+	// +optional
+	TotalBuckets int `json:"totalBuckets,omitempty"`
+	// MigratedBuckets is the number of buckets whose objects were copied to the target placement
+	// +optional
+	MigratedBuckets int `json:"migratedBuckets,omitempty"`
+	// SkippedBuckets is the number of buckets already in the target placement
+	// +optional
+	SkippedBuckets int `json:"skippedBuckets,omitempty"`
+	// FailedBuckets is the number of buckets whose migration failed
+	// +optional
+	FailedBuckets int `json:"failedBuckets,omitempty"`
+	// LastBucket is the last bucket that was processed. Buckets are migrated in the order of their
+	// names.
+	// +optional
+	LastBucket string `json:"lastBucket,omitempty"`
+	// Buckets is the progress of the bucket being migrated or last migrated, and of the last
+	// buckets whose migration failed
+	// +optional
+	Buckets []ObjectStoreBucketMigrationStatus `json:"buckets,omitempty"`
+	// StartTime is when the migration started
+	// +optional
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when all the buckets were processed
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ObjectStoreBucketMigrationStatus represents the migration of a bucket to the target placement
+type ObjectStoreBucketMigrationStatus struct {
+	// Name of the bucket
+	Name string `json:"name"`
+	// Phase of the migration of the bucket
+	// +optional
+	Phase MigrationPhase `json:"phase,omitempty"`
+	// CopiedObjects is the number of objects copied to the target placement
+	// +optional
+	CopiedObjects int64 `json:"copiedObjects,omitempty"`
+	// CopiedBytes is the size of the objects copied to the target placement
+	// +optional
+	CopiedBytes int64 `json:"copiedBytes,omitempty"`
+	// Message explains why the migration of the bucket failed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// StartTime is when the migration of the bucket started
+	// +optional
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the migration of the bucket completed
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ObjectStoreOrphanScanSpec represents the settings of the orphan scans of an object store
+type ObjectStoreOrphanScanSpec struct {
+	// Interval between two scheduled scans, like 168h for a weekly scan. Scans are only run on
+	// demand when not set.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// RemoveOrphans allows removing the orphans found by a scan. The removal must still be confirmed
+	// for each scan by setting the `ceph.rook.io/orphan-scan-remove` annotation to the scan ID
+	// reported in the status.
+	// +optional
+	RemoveOrphans bool `json:"removeOrphans,omitempty"`
+}
+
+// OrphanScanPhase is the phase of an orphan scan
+type OrphanScanPhase string
+
+const (
+	// OrphanScanPhaseScanning is set while the scan job is running
+	OrphanScanPhaseScanning OrphanScanPhase = "Scanning"
+	// OrphanScanPhaseCompleted is set when the scan completed
+	OrphanScanPhaseCompleted OrphanScanPhase = "Completed"
+	// OrphanScanPhaseRemoving is set while the orphans found by the scan are removed
+	OrphanScanPhaseRemoving OrphanScanPhase = "Removing"
+	// OrphanScanPhaseRemoved is set when the orphans found by the scan were removed
+	OrphanScanPhaseRemoved OrphanScanPhase = "Removed"
+	// OrphanScanPhaseFailed is set when the scan or the removal failed
+	OrphanScanPhaseFailed OrphanScanPhase = "Failed"
+)
+
+// ObjectStoreOrphanScanStatus represents the result of the last orphan scan of an object store
+type ObjectStoreOrphanScanStatus struct {
+	// ID of the scan, to confirm the removal of its orphans with the
+	// `ceph.rook.io/orphan-scan-remove` annotation
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Phase of the scan
+	// +optional
+	Phase OrphanScanPhase `json:"phase,omitempty"`
+	// Orphans is the number of RADOS objects of the data pools not referenced by any bucket
+	// +optional
+	Orphans int64 `json:"orphans,omitempty"`
+	// ReclaimableBytes is the size of the orphans
+	// +optional
+	ReclaimableBytes int64 `json:"reclaimableBytes,omitempty"`
+	// StaleIndexEntries is the number of bucket index entries of failed multipart uploads
+	// +optional
+	StaleIndexEntries int64 `json:"staleIndexEntries,omitempty"`
+	// ReportConfigMap is the name of the ConfigMap with the detailed report of the scan
+	// +optional
+	ReportConfigMap string `json:"reportConfigMap,omitempty"`
+	// Message explains why the scan failed
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Request is the last value of the `ceph.rook.io/orphan-scan` annotation that started a scan
+	// +optional
+	Request string `json:"request,omitempty"`
+	// StartTime is when the scan started
+	// +optional
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the scan, or the removal of its orphans, completed
+	// +optional
+	// +nullable
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ObjectStoreUsageExporterSpec represents the settings of the object store usage exporter
+type ObjectStoreUsageExporterSpec struct {
+	// Interval at which the usage is collected, like 5m for 5 minutes. Defaults to 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Retention is how long the usage log records are kept before they are trimmed, like 720h for
+	// 30 days. The collected usage only covers the retained records. Defaults to 720h.
+	// +optional
+	Retention *metav1.Duration `json:"retention,omitempty"`
+}
+
+// ObjectStoreRateLimitSpec represents the global default rate limits of an object store
+type ObjectStoreRateLimitSpec struct {
+	// User is the default rate limit applied to each user
+	// +nullable
+	// +optional
+	User *RateLimitSpec `json:"user,omitempty"`
+	// Bucket is the default rate limit applied to each bucket
+	// +nullable
+	// +optional
+	Bucket *RateLimitSpec `json:"bucket,omitempty"`
+	// Anonymous is the rate limit applied to unauthenticated requests
+	// +nullable
+	// +optional
+	Anonymous *RateLimitSpec `json:"anonymous,omitempty"`
+}
+
+// RateLimitSpec represents an RGW rate limit. The limits are enforced per RGW instance and per
+// minute. A limit that is not set (or set to 0) is unlimited.
+// See the [Ceph docs](https://docs.ceph.com/en/latest/radosgw/admin/#rate-limit-management) for more.
+type RateLimitSpec struct {
+	// MaxReadOps is the maximum number of read operations per minute
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	// +nullable
+	MaxReadOps *int64 `json:"maxReadOps,omitempty"`
+	// MaxWriteOps is the maximum number of write operations per minute
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	// +nullable
+	MaxWriteOps *int64 `json:"maxWriteOps,omitempty"`
+	// MaxReadBytes is the maximum number of bytes read per minute
+	// +optional
+	// +nullable
+	MaxReadBytes *resource.Quantity `json:"maxReadBytes,omitempty"`
+	// MaxWriteBytes is the maximum number of bytes written per minute
+	// +optional
+	// +nullable
+	MaxWriteBytes *resource.Quantity `json:"maxWriteBytes,omitempty"`
+}
+
+// RateLimitStatus represents the rate limit that is currently applied by RGW
+type RateLimitStatus struct {
+	// Enabled is whether the rate limit is enforced
+	Enabled bool `json:"enabled"`
+	// +optional
+	MaxReadOps int64 `json:"maxReadOps,omitempty"`
+	// +optional
+	MaxWriteOps int64 `json:"maxWriteOps,omitempty"`
+	// +optional
+	MaxReadBytes int64 `json:"maxReadBytes,omitempty"`
+	// +optional
+	MaxWriteBytes int64 `json:"maxWriteBytes,omitempty"`
+}
+
+// ObjectStoreRateLimitStatus represents the global rate limits currently applied by the object store
+type ObjectStoreRateLimitStatus struct {
+	// +optional
+	// +nullable
+	User *RateLimitStatus `json:"user,omitempty"`
+	// +optional
+	// +nullable
+	Bucket *RateLimitStatus `json:"bucket,omitempty"`
+	// +optional
+	// +nullable
+	Anonymous *RateLimitStatus `json:"anonymous,omitempty"`
 }
 
 // ObjectSharedPoolsSpec represents object store pool info when configuring RADOS namespaces in existing pools.
@@ -1736,6 +2166,7 @@ type PoolPlacementSpec struct {
 	StorageClasses []PlacementStorageClassSpec `json:"storageClasses,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:message="exactly one of dataPoolName or cloud must be set",rule="has(self.dataPoolName) != has(self.cloud)"
 type PlacementStorageClassSpec struct {
 	// Name is the StorageClass name. Ceph allows arbitrary name for StorageClasses,
 	// however most clients/libs insist on AWS names so it is recommended to use
@@ -1748,9 +2179,65 @@ type PlacementStorageClassSpec struct {
 	Name string `json:"name"`
 
 	// DataPoolName is the data pool used to store ObjectStore objects data.
-	// +kubebuilder:validation:Required
+	// Required unless the StorageClass is a cloud tier.
 	// +kubebuilder:validation:MinLength=1
-	DataPoolName string `json:"dataPoolName"`
+	// +optional
+	DataPoolName string `json:"dataPoolName,omitempty"`
+
+	// Cloud makes the StorageClass a cloud tier: objects transitioned to it by bucket lifecycle
+	// rules are moved to a remote S3 endpoint instead of a local pool.
+	// +optional
+	Cloud *PlacementCloudTierSpec `json:"cloud,omitempty"`
+}
+
+// CloudTierType is the type of a cloud tier StorageClass
+type CloudTierType string
+
+const (
+	// CloudTierTypeS3 transitions objects to a remote S3 endpoint
+	CloudTierTypeS3 CloudTierType = "cloud-s3"
+)
+
+// PlacementCloudTierSpec represents the remote endpoint of a cloud tier StorageClass
+type PlacementCloudTierSpec struct {
+	// TierType is the type of the cloud tier. Only "cloud-s3" is supported.
+	// +kubebuilder:validation:Enum=cloud-s3
+	// +kubebuilder:default=cloud-s3
+	// +optional
+	TierType CloudTierType `json:"tierType,omitempty"`
+
+	// Endpoint is the URL of the remote S3 endpoint, for example "http://minio.minio.svc:9000"
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// Region of the remote S3 endpoint
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// HostStyle is the addressing style of the remote S3 endpoint. Defaults to path.
+	// +kubebuilder:validation:Enum=path;virtual
+	// +optional
+	HostStyle string `json:"hostStyle,omitempty"`
+
+	// CredentialsSecretName is the name of a secret in the object store namespace with the
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys of the remote S3 endpoint
+	// +kubebuilder:validation:MinLength=1
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// TargetPath is the bucket on the remote endpoint the objects are transitioned to. If not set,
+	// RGW uses a bucket named "rgwx-${zonegroup}-${storage-class}-cloud-bucket".
+	// +optional
+	TargetPath string `json:"targetPath,omitempty"`
+
+	// TargetStorageClass is the storage class of the objects on the remote endpoint.
+	// Defaults to STANDARD.
+	// +optional
+	TargetStorageClass string `json:"targetStorageClass,omitempty"`
+
+	// RetainHeadObject keeps the head of transitioned objects in the local pool. The head only
+	// holds the metadata of the object, and its data can no longer be read through RGW.
+	// +optional
+	RetainHeadObject bool `json:"retainHeadObject,omitempty"`
 }
 
 // ObjectHealthCheckSpec represents the health check of an object store
@@ -1793,6 +2280,12 @@ type GatewaySpec struct {
 	// +optional
 	Instances int32 `json:"instances,omitempty"`
 
+	// Autoscaling scales the number of rgw pods with a HorizontalPodAutoscaler, in which case
+	// instances is ignored.
+	// +nullable
+	// +optional
+	Autoscaling *GatewayAutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// The name of the secret that stores the ssl certificate for secure rgw connections
 	// +nullable
 	// +optional
@@ -1919,72 +2412,233 @@ type OpsLogSidecar struct {
 	// Resources represents the way to specify resource requirements for the ops-log sidecar
 	// +optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
-}
 
-// EndpointAddress is a tuple that describes a single IP address or host name. This is a subset of
-// Kubernetes's v1.EndpointAddress.
-// +structType=atomic
-type EndpointAddress struct {
-	// The IP of this endpoint. As a legacy behavior, this supports being given a DNS-addressable hostname as well.
+	// Sinks the ops log entries are forwarded to. When no sink is configured, the sidecar prints
+	// the ops log file as is.
 	// +optional
-	IP string `json:"ip" protobuf:"bytes,1,opt,name=ip"`
+	Sinks []OpsLogSinkSpec `json:"sinks,omitempty"`
 
-	// The DNS-addressable Hostname of this endpoint. This field will be preferred over IP if both are given.
+	// Filter selects the ops log entries that are forwarded to the sinks
 	// +optional
-	Hostname string `json:"hostname,omitempty" protobuf:"bytes,3,opt,name=hostname"`
-}
+	// +nullable
+	Filter *OpsLogFilterSpec `json:"filter,omitempty"`
 
-// ProtocolSpec represents a Ceph Object Store protocol specification
-type ProtocolSpec struct {
-	// Represents RGW 'rgw_enable_apis' config option. See: https://docs.ceph.com/en/reef/radosgw/config-ref/#confval-rgw_enable_apis
-	// If no value provided then all APIs will be enabled: s3, s3website, swift, swift_auth, admin, sts, iam, notifications
-	// If enabled APIs are set, all remaining APIs will be disabled.
-	// This option overrides S3.Enabled value.
+	// SamplePercent is the percentage of the ops log entries forwarded to the sinks after filtering.
+	// Entries are sampled by their transaction ID. If not set, all entries are forwarded.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
 	// +optional
 	// +nullable
-	EnableAPIs []ObjectStoreAPI `json:"enableAPIs,omitempty"`
+	SamplePercent *int32 `json:"samplePercent,omitempty"`
+}
 
-	// The spec for S3
+// OpsLogFilterSpec selects ops log entries by bucket and user. An entry is forwarded when it
+// matches both lists, an empty list matching all entries.
+type OpsLogFilterSpec struct {
+	// Buckets whose operations are forwarded
 	// +optional
-	// +nullable
-	S3 *S3Spec `json:"s3,omitempty"`
+	Buckets []string `json:"buckets,omitempty"`
 
-	// The spec for Swift
+	// Users whose operations are forwarded. Users in a tenant are given as "tenant$user".
 	// +optional
-	// +nullable
-	Swift *SwiftSpec `json:"swift"`
+	Users []string `json:"users,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=s3;s3website;swift;swift_auth;admin;sts;iam;notifications
-type ObjectStoreAPI string
-
-// S3Spec represents Ceph Object Store specification for the S3 API
-type S3Spec struct {
-	// Deprecated: use protocol.enableAPIs instead.
-	// Whether to enable S3. This defaults to true (even if protocols.s3 is not present in the CRD). This maintains backwards compatibility – by default S3 is enabled.
-	// +nullable
+// OpsLogSinkSpec is a destination of the ops log entries. Exactly one sink type must be set.
+type OpsLogSinkSpec struct {
+	// Stdout prints the entries as JSON lines on the standard output of the sidecar
 	// +optional
-	Enabled *bool `json:"enabled,omitempty"`
-	// Whether to use Keystone for authentication. This option maps directly to the rgw_s3_auth_use_keystone option. Enabling it allows generating S3 credentials via an OpenStack API call, see the docs. If not given, the defaults of the corresponding RGW option apply.
 	// +nullable
-	// +optional
-	AuthUseKeystone *bool `json:"authUseKeystone,omitempty"`
-}
+	Stdout *OpsLogStdoutSinkSpec `json:"stdout,omitempty"`
 
-// SwiftSpec represents Ceph Object Store specification for the Swift API
-type SwiftSpec struct {
-	// Whether or not the Swift account name should be included in the Swift API URL. If set to false (the default), then the Swift API will listen on a URL formed like http://host:port/<rgw_swift_url_prefix>/v1. If set to true, the Swift API URL will be http://host:port/<rgw_swift_url_prefix>/v1/AUTH_<account_name>. You must set this option to true (and update the Keystone service catalog) if you want radosgw to support publicly-readable containers and temporary URLs.
-	// +nullable
+	// Syslog sends the entries to a syslog endpoint
 	// +optional
-	AccountInUrl *bool `json:"accountInUrl,omitempty"`
-	// The URL prefix for the Swift API, to distinguish it from the S3 API endpoint. The default is swift, which makes the Swift API available at the URL http://host:port/swift/v1 (or http://host:port/swift/v1/AUTH_%(tenant_id)s if rgw swift account in url is enabled).
 	// +nullable
+	Syslog *OpsLogSyslogSinkSpec `json:"syslog,omitempty"`
+
+	// Kafka produces the entries to a Kafka topic
 	// +optional
-	UrlPrefix *string `json:"urlPrefix,omitempty"`
-	// Enables the Object Versioning of OpenStack Object Storage API. This allows clients to put the X-Versions-Location attribute on containers that should be versioned.
 	// +nullable
+	Kafka *OpsLogKafkaSinkSpec `json:"kafka,omitempty"`
+
+	// S3 writes the entries to objects in an S3 bucket
 	// +optional
-	VersioningEnabled *bool `json:"versioningEnabled,omitempty"`
+	// +nullable
+	S3 *OpsLogS3SinkSpec `json:"s3,omitempty"`
+}
+
+// OpsLogStdoutSinkSpec prints ops log entries on the standard output of the sidecar
+type OpsLogStdoutSinkSpec struct{}
+
+// OpsLogSyslogSinkSpec sends ops log entries to a syslog endpoint in the RFC 5424 format
+type OpsLogSyslogSinkSpec struct {
+	// Address of the syslog endpoint in the "host:port" form
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// Protocol used to reach the syslog endpoint
+	// +kubebuilder:validation:Enum=udp;tcp
+	// +kubebuilder:default=udp
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// OpsLogKafkaSinkSpec produces ops log entries to a Kafka topic
+type OpsLogKafkaSinkSpec struct {
+	// Brokers used to discover the Kafka cluster, in the "host:port" form
+	// +kubebuilder:validation:MinItems=1
+	Brokers []string `json:"brokers"`
+
+	// Topic the entries are produced to
+	// +kubebuilder:validation:MinLength=1
+	Topic string `json:"topic"`
+
+	// UseSSL connects to the brokers with TLS
+	// +optional
+	UseSSL bool `json:"useSSL,omitempty"`
+
+	// DisableVerifySSL skips the validation of the certificates of the brokers
+	// +optional
+	DisableVerifySSL bool `json:"disableVerifySSL,omitempty"`
+
+	// Mechanism is the SASL mechanism authenticating to the brokers. No authentication is done
+	// when not set.
+	// +kubebuilder:validation:Enum=PLAIN;SCRAM-SHA-256;SCRAM-SHA-512
+	// +optional
+	Mechanism string `json:"mechanism,omitempty"`
+
+	// CredentialsSecretName is the name of a secret in the namespace of the object store with the
+	// "username" and "password" keys used by the SASL mechanism. Required when the mechanism is set.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+}
+
+// OpsLogS3SinkSpec writes ops log entries as JSON lines to objects in an S3 bucket. A new object is
+// started at every rotation interval.
+type OpsLogS3SinkSpec struct {
+	// Endpoint of the S3 service, for example "https://s3.example.com"
+	// +kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+
+	// Region of the bucket
+	// +kubebuilder:default=us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Bucket the objects are written to
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+
+	// Prefix of the object keys. The keys end with the pod name and the time of the first entry.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// CredentialsSecretName is the name of a secret in the object store namespace with the
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys, like the secret of an object bucket claim
+	// +kubebuilder:validation:MinLength=1
+	CredentialsSecretName string `json:"credentialsSecretName"`
+
+	// RotationInterval is the maximum time entries are buffered before they are written to a new
+	// object, for example "5m". Defaults to 10m.
+	// +optional
+	RotationInterval string `json:"rotationInterval,omitempty"`
+}
+
+// GatewayAutoscalingSpec represents the autoscaling of the rgw pods of an object store. The pods are
+// scaled on their CPU utilization, on the rate of requests reported by an external metric, or both.
+// +kubebuilder:validation:XValidation:message="maxInstances must be greater than or equal to minInstances",rule="self.maxInstances >= self.minInstances"
+type GatewayAutoscalingSpec struct {
+	// MinInstances is the minimum number of rgw pods
+	// +kubebuilder:validation:Minimum=1
+	MinInstances int32 `json:"minInstances"`
+
+	// MaxInstances is the maximum number of rgw pods
+	// +kubebuilder:validation:Minimum=1
+	MaxInstances int32 `json:"maxInstances"`
+
+	// TargetCPUUtilizationPercentage is the average CPU utilization of the rgw pods, relative to
+	// their CPU requests, that the autoscaler maintains. Defaults to 80 when no target is set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetRequestsPerSecond is the rate of requests per rgw pod that the autoscaler maintains.
+	// The rate of requests of the object store must be served as an external metric by a metrics
+	// adapter, for example from the rgw perf counters collected by the ceph exporter.
+	// +optional
+	TargetRequestsPerSecond *resource.Quantity `json:"targetRequestsPerSecond,omitempty"`
+
+	// RequestsMetric is the name of the external metric with the rate of requests of the object
+	// store. It is selected with the labels "namespace" and "object_store". Defaults to
+	// "ceph_rgw_requests_per_second".
+	// +optional
+	RequestsMetric string `json:"requestsMetric,omitempty"`
+}
+
+// EndpointAddress is a tuple that describes a single IP address or host name. This is a subset of
+// Kubernetes's v1.EndpointAddress.
+// +structType=atomic
+type EndpointAddress struct {
+	// The IP of this endpoint. As a legacy behavior, this supports being given a DNS-addressable hostname as well.
+	// +optional
+	IP string `json:"ip" protobuf:"bytes,1,opt,name=ip"`
+
+	// The DNS-addressable Hostname of this endpoint. This field will be preferred over IP if both are given.
+	// +optional
+	Hostname string `json:"hostname,omitempty" protobuf:"bytes,3,opt,name=hostname"`
+}
+
+// ProtocolSpec represents a Ceph Object Store protocol specification
+type ProtocolSpec struct {
+	// Represents RGW 'rgw_enable_apis' config option. See: https://docs.ceph.com/en/reef/radosgw/config-ref/#confval-rgw_enable_apis
+	// If no value provided then all APIs will be enabled: s3, s3website, swift, swift_auth, admin, sts, iam, notifications
+	// If enabled APIs are set, all remaining APIs will be disabled.
+	// This option overrides S3.Enabled value.
+	// +optional
+	// +nullable
+	EnableAPIs []ObjectStoreAPI `json:"enableAPIs,omitempty"`
+
+	// The spec for S3
+	// +optional
+	// +nullable
+	S3 *S3Spec `json:"s3,omitempty"`
+
+	// The spec for Swift
+	// +optional
+	// +nullable
+	Swift *SwiftSpec `json:"swift"`
+}
+
+// +kubebuilder:validation:Enum=s3;s3website;swift;swift_auth;admin;sts;iam;notifications
+type ObjectStoreAPI string
+
+// S3Spec represents Ceph Object Store specification for the S3 API
+type S3Spec struct {
+	// Deprecated: use protocol.enableAPIs instead.
+	// Whether to enable S3. This defaults to true (even if protocols.s3 is not present in the CRD). This maintains backwards compatibility – by default S3 is enabled.
+	// +nullable
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Whether to use Keystone for authentication. This option maps directly to the rgw_s3_auth_use_keystone option. Enabling it allows generating S3 credentials via an OpenStack API call, see the docs. If not given, the defaults of the corresponding RGW option apply.
+	// +nullable
+	// +optional
+	AuthUseKeystone *bool `json:"authUseKeystone,omitempty"`
+}
+
+// SwiftSpec represents Ceph Object Store specification for the Swift API
+type SwiftSpec struct {
+	// Whether or not the Swift account name should be included in the Swift API URL. If set to false (the default), then the Swift API will listen on a URL formed like http://host:port/<rgw_swift_url_prefix>/v1. If set to true, the Swift API URL will be http://host:port/<rgw_swift_url_prefix>/v1/AUTH_<account_name>. You must set this option to true (and update the Keystone service catalog) if you want radosgw to support publicly-readable containers and temporary URLs.
+	// +nullable
+	// +optional
+	AccountInUrl *bool `json:"accountInUrl,omitempty"`
+	// The URL prefix for the Swift API, to distinguish it from the S3 API endpoint. The default is swift, which makes the Swift API available at the URL http://host:port/swift/v1 (or http://host:port/swift/v1/AUTH_%(tenant_id)s if rgw swift account in url is enabled).
+	// +nullable
+	// +optional
+	UrlPrefix *string `json:"urlPrefix,omitempty"`
+	// Enables the Object Versioning of OpenStack Object Storage API. This allows clients to put the X-Versions-Location attribute on containers that should be versioned.
+	// +nullable
+	// +optional
+	VersioningEnabled *bool `json:"versioningEnabled,omitempty"`
 }
 
 // AuthSpec represents the authentication protocol configuration of a Ceph Object Store Gateway
@@ -1993,6 +2647,37 @@ type AuthSpec struct {
 	// +optional
 	// +nullable
 	Keystone *KeystoneSpec `json:"keystone,omitempty"`
+	// The spec for the Secure Token Service (STS) of the object store
+	// +optional
+	// +nullable
+	STS *STSSpec `json:"sts,omitempty"`
+}
+
+// STSSpec represents the Secure Token Service configuration of a Ceph Object Store Gateway
+type STSSpec struct {
+	// The name of the secret containing the key used to encrypt the session tokens in the "key" key.
+	// The key must be 16 characters long. It has to be in the same namespace as the object store
+	// resource. If not set, a key is generated and stored in the secret
+	// "rook-ceph-rgw-<store>-sts-key".
+	// +optional
+	KeySecretName string `json:"keySecretName,omitempty"`
+	// The OpenID Connect identity providers trusted by the object store for AssumeRoleWithWebIdentity,
+	// for example the service account issuer of the Kubernetes cluster
+	// +optional
+	OIDCProviders []OIDCProviderSpec `json:"oidcProviders,omitempty"`
+}
+
+// OIDCProviderSpec represents an OpenID Connect identity provider of the object store
+type OIDCProviderSpec struct {
+	// The URL of the issuer of the tokens, which must match the "iss" claim of the tokens
+	// +kubebuilder:validation:Pattern=`^https://`
+	IssuerURL string `json:"issuerURL"`
+	// The client IDs (audiences) accepted in the "aud" claim of the tokens
+	// +kubebuilder:validation:MinItems=1
+	ClientIDs []string `json:"clientIDs"`
+	// The SHA-1 thumbprints of the TLS certificates of the issuer
+	// +kubebuilder:validation:MinItems=1
+	Thumbprints []string `json:"thumbprints"`
 }
 
 // KeystoneSpec represents the Keystone authentication configuration of a Ceph Object Store Gateway
@@ -2048,6 +2733,28 @@ type ObjectStoreStatus struct {
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// RateLimits are the global rate limits currently applied by the object store
+	// +optional
+	// +nullable
+	RateLimits *ObjectStoreRateLimitStatus `json:"rateLimits,omitempty"`
+	// OIDCProviders are the ARNs of the OpenID Connect providers configured for the object store.
+	// They are the federated principals to use in the trust policies of the roles.
+	// +optional
+	// +nullable
+	OIDCProviders []string `json:"oidcProviders,omitempty"`
+	// OrphanScan is the result of the last orphan scan of the object store
+	// +optional
+	// +nullable
+	OrphanScan *ObjectStoreOrphanScanStatus `json:"orphanScan,omitempty"`
+	// Migration is the progress of the migration of the object store to another pool placement
+	// +optional
+	// +nullable
+	Migration *ObjectStoreMigrationStatus `json:"migration,omitempty"`
+	// ExposedResources are the kinds of the resources created to expose the object store with
+	// hosting.expose. They are deleted when they are not configured anymore.
+	// +optional
+	// +nullable
+	ExposedResources []string `json:"exposedResources,omitempty"`
 }
 
 type ObjectEndpoints struct {
@@ -2080,6 +2787,87 @@ type ObjectStoreHostingSpec struct {
 	// E.g., use "mystore.example.com" instead of "*.mystore.example.com".
 	// +optional
 	DNSNames []string `json:"dnsNames,omitempty"`
+	// Expose creates an Ingress or a Gateway API HTTPRoute owned by the object store to reach the
+	// gateways from outside of the cluster on the DNS names, including the wildcard of each DNS
+	// name for virtual-host style bucket access. The external URLs are added to the status
+	// endpoints.
+	// +nullable
+	// +optional
+	Expose *ObjectStoreExposeSpec `json:"expose,omitempty"`
+}
+
+// ObjectStoreExposeSpec represents how the object store is exposed outside of the cluster
+// +kubebuilder:validation:XValidation:message="only one of ingress or httpRoute can be set",rule="!(has(self.ingress) && has(self.httpRoute))"
+type ObjectStoreExposeSpec struct {
+	// Ingress creates an Ingress for the object store service
+	// +nullable
+	// +optional
+	Ingress *ObjectStoreIngressSpec `json:"ingress,omitempty"`
+	// HTTPRoute creates a Gateway API HTTPRoute for the object store service, attached to the
+	// given gateways
+	// +nullable
+	// +optional
+	HTTPRoute *ObjectStoreHTTPRouteSpec `json:"httpRoute,omitempty"`
+	// CertManager requests a certificate for the DNS names and their wildcards from cert-manager
+	// when `gateway.sslCertificateRef` is not set. The certificate is stored in the secret
+	// `rook-ceph-rgw-<store>-tls`, which terminates TLS on the Ingress or can be referenced by
+	// the listeners of the Gateway.
+	// +nullable
+	// +optional
+	CertManager *ObjectStoreCertManagerSpec `json:"certManager,omitempty"`
+}
+
+// ObjectStoreIngressSpec represents the Ingress of the object store
+type ObjectStoreIngressSpec struct {
+	// IngressClassName is the class of the Ingress. The default class of the cluster is used
+	// if not set.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Annotations are added to the Ingress, e.g. to configure the ingress controller
+	// +optional
+	Annotations Annotations `json:"annotations,omitempty"`
+}
+
+// ObjectStoreHTTPRouteSpec represents the Gateway API HTTPRoute of the object store
+type ObjectStoreHTTPRouteSpec struct {
+	// ParentRefs are the gateways the route is attached to
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []ObjectStoreGatewayRef `json:"parentRefs"`
+	// Annotations are added to the HTTPRoute
+	// +optional
+	Annotations Annotations `json:"annotations,omitempty"`
+}
+
+// ObjectStoreGatewayRef references a Gateway API gateway
+type ObjectStoreGatewayRef struct {
+	// Name of the gateway
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the gateway. The namespace of the object store is used if not set.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the listener of the gateway to attach to. All the listeners of
+	// the gateway are used if not set.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ObjectStoreCertManagerSpec represents the cert-manager certificate of the object store
+type ObjectStoreCertManagerSpec struct {
+	// IssuerRef is the cert-manager issuer of the certificate
+	IssuerRef ObjectStoreIssuerRef `json:"issuerRef"`
+}
+
+// ObjectStoreIssuerRef references a cert-manager issuer
+type ObjectStoreIssuerRef struct {
+	// Name of the issuer
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Kind of the issuer, Issuer in the namespace of the object store or ClusterIssuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
 }
 
 // ObjectEndpointSpec represents an object store endpoint
@@ -2132,6 +2920,160 @@ type ObjectStoreUserStatus struct {
 	// +optional
 	// +nullable
 	Keys []SecretReference `json:"keys,omitempty"`
+	// RateLimit is the rate limit currently applied to the user
+	// +optional
+	// +nullable
+	RateLimit *RateLimitStatus `json:"rateLimit,omitempty"`
+	// AccountID is the ID of the RGW account the user was placed in
+	// +optional
+	AccountID string `json:"accountID,omitempty"`
+	// Policies are the names of the inline IAM policies applied to the user
+	// +optional
+	Policies []string `json:"policies,omitempty"`
+	// Groups are the IAM groups of the account the user was added to
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// Usage is the usage of the user collected by the usage exporter of the object store
+	// +optional
+	// +nullable
+	Usage *ObjectUserUsageStatus `json:"usage,omitempty"`
+	// Quota is the usage of the user against its quota
+	// +optional
+	// +nullable
+	Quota *QuotaStatus `json:"quota,omitempty"`
+	// KeyRotation is the state of the keys rotated by the operator
+	// +optional
+	// +nullable
+	KeyRotation *ObjectUserKeyRotationStatus `json:"keyRotation,omitempty"`
+}
+
+// ObjectUserKeyRotationStatus represents the keys of an object store user rotated by the operator
+type ObjectUserKeyRotationStatus struct {
+	// Keys are the valid keys of the user, from the oldest to the current one
+	// +optional
+	Keys []ObjectUserKeyStatus `json:"keys,omitempty"`
+	// NextRotation is the time the current key will be rotated
+	// +optional
+	// +nullable
+	NextRotation *metav1.Time `json:"nextRotation,omitempty"`
+}
+
+// ObjectUserKeyStatus represents a key of an object store user
+type ObjectUserKeyStatus struct {
+	// AccessKey is the access key ID of the key
+	AccessKey string `json:"accessKey"`
+	// Created is the time the key was generated, or first seen by the operator
+	Created metav1.Time `json:"created"`
+	// Expires is the time a previous key will be removed
+	// +optional
+	// +nullable
+	Expires *metav1.Time `json:"expires,omitempty"`
+}
+
+// ObjectUserUsageStatus is the usage of an object store user. The operations and bytes transferred
+// cover the retained usage log records, the stored bytes and objects are current.
+type ObjectUserUsageStatus struct {
+	// Ops is the number of operations of the user
+	// +optional
+	Ops uint64 `json:"ops,omitempty"`
+	// SuccessfulOps is the number of successful operations of the user
+	// +optional
+	SuccessfulOps uint64 `json:"successfulOps,omitempty"`
+	// BytesSent is the number of bytes sent to the user
+	// +optional
+	BytesSent uint64 `json:"bytesSent,omitempty"`
+	// BytesReceived is the number of bytes received from the user
+	// +optional
+	BytesReceived uint64 `json:"bytesReceived,omitempty"`
+	// StoredBytes is the size of the objects of the user
+	// +optional
+	StoredBytes uint64 `json:"storedBytes,omitempty"`
+	// Objects is the number of objects of the user
+	// +optional
+	Objects uint64 `json:"objects,omitempty"`
+	// LastUpdated is the time the usage was collected
+	// +optional
+	// +nullable
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// QuotaState is the state of the usage of a user or bucket against its quota
+type QuotaState string
+
+const (
+	// QuotaStateOk means the usage is below the near full threshold of the quota
+	QuotaStateOk QuotaState = "Ok"
+	// QuotaStateNearFull means the usage is above the near full threshold of the quota
+	QuotaStateNearFull QuotaState = "NearFull"
+	// QuotaStateFull means the quota is reached, writes are rejected
+	QuotaStateFull QuotaState = "Full"
+)
+
+// QuotaStatus is the usage of a user or bucket against its quota
+type QuotaStatus struct {
+	// MaxSize is the maximum size in bytes allowed by the quota, not set if the size is unlimited
+	// +optional
+	// +nullable
+	MaxSize *int64 `json:"maxSize,omitempty"`
+	// Size is the size in bytes used
+	Size int64 `json:"size"`
+	// MaxObjects is the maximum number of objects allowed by the quota, not set if the number of
+	// objects is unlimited
+	// +optional
+	// +nullable
+	MaxObjects *int64 `json:"maxObjects,omitempty"`
+	// Objects is the number of objects stored
+	Objects int64 `json:"objects"`
+	// PercentUsed is the highest percentage used of the size and objects limits
+	PercentUsed int32 `json:"percentUsed"`
+	// NearFullThreshold is the percentage used above which the quota is near full
+	NearFullThreshold int32 `json:"nearFullThreshold"`
+	// State is whether the quota is Ok, NearFull or Full
+	State QuotaState `json:"state"`
+	// LastChecked is the time the usage was last checked
+	// +optional
+	// +nullable
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+}
+
+// BucketIndexState is the fill state of the index shards of a bucket
+type BucketIndexState string
+
+const (
+	// BucketIndexStateOk means the shards of the bucket index have room for more objects
+	BucketIndexStateOk BucketIndexState = "Ok"
+	// BucketIndexStateNearLimit means the shards of the bucket index are close to the maximum
+	// number of objects per shard
+	BucketIndexStateNearLimit BucketIndexState = "NearLimit"
+	// BucketIndexStateOverLimit means the shards of the bucket index hold more objects than the
+	// maximum number of objects per shard, the bucket needs to be resharded
+	BucketIndexStateOverLimit BucketIndexState = "OverLimit"
+)
+
+// BucketIndexStatus is the sharding of the index of a bucket
+type BucketIndexStatus struct {
+	// NumShards is the number of shards of the bucket index
+	NumShards int64 `json:"numShards"`
+	// Objects is the number of objects in the bucket
+	Objects int64 `json:"objects"`
+	// ObjectsPerShard is the average number of objects per shard
+	ObjectsPerShard int64 `json:"objectsPerShard"`
+	// FillStatus is the fill status reported by `radosgw-admin bucket limit check`
+	// +optional
+	FillStatus string `json:"fillStatus,omitempty"`
+	// State is whether the index shards are Ok, NearLimit or OverLimit
+	State BucketIndexState `json:"state"`
+	// TargetShards is the number of shards requested for the bucket index
+	// +optional
+	// +nullable
+	TargetShards *int64 `json:"targetShards,omitempty"`
+	// Resharding is true while the bucket is waiting in the reshard queue or being resharded
+	// +optional
+	Resharding bool `json:"resharding,omitempty"`
+	// LastChecked is the time the index was last checked
+	// +optional
+	// +nullable
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
 }
 
 type SecretReference struct {
@@ -2163,13 +3105,64 @@ type ObjectStoreUserSpec struct {
 	// +optional
 	// +nullable
 	Quotas *ObjectUserQuotaSpec `json:"quotas,omitempty"`
+	// RateLimit limits the read and write operations and bandwidth of the user
+	// +optional
+	// +nullable
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
 	// Allows specifying credentials for the user. If not provided, the operator
 	// will generate them.
 	// +optional
 	Keys []ObjectUserKey `json:"keys,omitempty"`
+	// KeyRotation rotates the keys generated by the operator on a schedule. Cannot be used with
+	// keys provided in spec.keys.
+	// +optional
+	// +nullable
+	KeyRotation *ObjectUserKeyRotationSpec `json:"keyRotation,omitempty"`
 	// The namespace where the parent CephCluster and CephObjectStore are found
 	// +optional
 	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+	// Account places the user in an RGW account, which owns the buckets and the IAM resources of
+	// its users. The account is created if it does not exist. Requires Ceph Squid or newer.
+	// +optional
+	// +nullable
+	Account *ObjectUserAccountSpec `json:"account,omitempty"`
+	// The inline IAM permission policies of the user. Policies previously applied by the operator
+	// that are no longer listed are removed.
+	// +optional
+	Policies []IAMPolicySpec `json:"policies,omitempty"`
+}
+
+// ObjectUserAccountSpec represents the RGW account of an object store user
+type ObjectUserAccountSpec struct {
+	// The ID of the account, "RGW" followed by 17 digits. The account of a user cannot be changed
+	// once set.
+	// +kubebuilder:validation:Pattern=`^RGW[0-9]{17}$`
+	ID string `json:"id"`
+	// The name of the account, set when the account is created
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Root makes the user the root user of the account. The root user has full permissions on the
+	// resources of the account, and its keys are used to manage the groups and policies of the
+	// other users of the account.
+	// +optional
+	Root bool `json:"root,omitempty"`
+	// The IAM groups of the account the user is a member of. Groups that do not exist are
+	// created. Groups the user was previously added to by the operator that are no longer
+	// listed are left.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// ObjectUserKeyRotationSpec represents the rotation of the keys of an object store user. When a
+// key is rotated, the new key is published in the user secret alongside the previous key, which
+// stays valid for the grace period before it is removed.
+type ObjectUserKeyRotationSpec struct {
+	// Period is the age of a key at which it is rotated, like 720h for 30 days
+	Period metav1.Duration `json:"period"`
+	// GracePeriod is how long the previous key stays valid after a rotation, like 24h. Defaults
+	// to 24h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 // Additional admin-level capabilities for the Ceph object store user
@@ -2255,6 +3248,13 @@ type ObjectUserQuotaSpec struct {
 	// +optional
 	// +nullable
 	MaxObjects *int64 `json:"maxObjects,omitempty"`
+	// NearFullThreshold is the percentage of the size or objects quota used above which a
+	// QuotaNearFull event is emitted. Defaults to 85.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	// +nullable
+	NearFullThreshold *int32 `json:"nearFullThreshold,omitempty"`
 }
 
 // ObjectUserKey defines a set of rgw user access credentials to be retrieved
@@ -2270,6 +3270,166 @@ type ObjectUserKey struct {
 // +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+// CephObjectStoreRole represents an IAM role of a Ceph Object Store Gateway, which can be assumed
+// through the Secure Token Service of the object store
+// +kubebuilder:resource:shortName=cephosr;objectrole
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="ARN",type=string,JSONPath=`.status.arn`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+type CephObjectStoreRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              ObjectStoreRoleSpec `json:"spec"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Status *ObjectStoreRoleStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephObjectStoreRoleList represents a list of Ceph Object Store Gateway roles
+type CephObjectStoreRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephObjectStoreRole `json:"items"`
+}
+
+// ObjectStoreRoleSpec represents the spec of an object store role
+type ObjectStoreRoleSpec struct {
+	// The store the role will be created in. The store must be in the same namespace as the role
+	// and have the Secure Token Service enabled.
+	Store string `json:"store"`
+	// The path of the role
+	// +kubebuilder:default="/"
+	// +optional
+	Path string `json:"path,omitempty"`
+	// The trust policy document in JSON format that grants an entity permission to assume the role
+	// +kubebuilder:validation:MinLength=1
+	AssumeRolePolicyDocument string `json:"assumeRolePolicyDocument"`
+	// The maximum duration in seconds of the sessions of the role. Defaults to one hour in Ceph.
+	// +kubebuilder:validation:Minimum=3600
+	// +kubebuilder:validation:Maximum=43200
+	// +optional
+	// +nullable
+	MaxSessionDuration *int64 `json:"maxSessionDuration,omitempty"`
+	// The inline permission policies of the role. Policies of the role that are not listed are
+	// removed.
+	// +optional
+	Policies []IAMPolicySpec `json:"policies,omitempty"`
+}
+
+// IAMPolicySpec represents an inline IAM permission policy
+type IAMPolicySpec struct {
+	// The name of the policy
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// The permission policy document in JSON format
+	// +kubebuilder:validation:MinLength=1
+	Document string `json:"document"`
+}
+
+// ObjectStoreRoleStatus represents the status of an object store role
+type ObjectStoreRoleStatus struct {
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// ARN is the Amazon Resource Name of the role, to use as the RoleArn when assuming the role
+	// +optional
+	ARN string `json:"arn,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephObjectStoreScript represents a Lua script run by the Ceph Object Store Gateways
+// +kubebuilder:resource:shortName=cephoss;objectscript
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Context",type=string,JSONPath=`.spec.context`
+// +kubebuilder:printcolumn:name="Hash",type=string,JSONPath=`.status.hash`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+type CephObjectStoreScript struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              ObjectStoreScriptSpec `json:"spec"`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Status *ObjectStoreScriptStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CephObjectStoreScriptList represents a list of Ceph Object Store Gateway Lua scripts
+type CephObjectStoreScriptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []CephObjectStoreScript `json:"items"`
+}
+
+// ObjectStoreScriptContext is the context in which the RGWs run a Lua script
+type ObjectStoreScriptContext string
+
+const (
+	// ObjectStoreScriptContextPreRequest runs the script before each request is executed
+	ObjectStoreScriptContextPreRequest ObjectStoreScriptContext = "preRequest"
+	// ObjectStoreScriptContextPostRequest runs the script after each request is executed
+	ObjectStoreScriptContextPostRequest ObjectStoreScriptContext = "postRequest"
+	// ObjectStoreScriptContextBackground runs the script periodically in the background
+	ObjectStoreScriptContextBackground ObjectStoreScriptContext = "background"
+	// ObjectStoreScriptContextGetData runs the script on the data of each object read
+	ObjectStoreScriptContextGetData ObjectStoreScriptContext = "getData"
+	// ObjectStoreScriptContextPutData runs the script on the data of each object written
+	ObjectStoreScriptContextPutData ObjectStoreScriptContext = "putData"
+)
+
+// ObjectStoreScriptSpec represents the spec of an object store Lua script
+type ObjectStoreScriptSpec struct {
+	// The name of the CephObjectStore the script is loaded in. The object store must be in the same
+	// namespace as the script.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:message="store is immutable",rule="self == oldSelf"
+	Store string `json:"store"`
+	// The context in which the RGWs run the script. Only one script can be loaded per context and
+	// tenant of an object store. When the context changes, the script is removed from the previous
+	// context.
+	// +kubebuilder:validation:Enum=preRequest;postRequest;background;getData;putData
+	Context ObjectStoreScriptContext `json:"context"`
+	// The tenant the script applies to. If not set, the script applies to the requests of all the
+	// users without a tenant. The background context does not support tenants. When the tenant
+	// changes, the script is removed from the previous tenant.
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+	// The key of a ConfigMap in the namespace of the script that holds the Lua script. The script
+	// is reloaded when the ConfigMap changes.
+	ConfigMapKeyRef v1.ConfigMapKeySelector `json:"configMapKeyRef"`
+}
+
+// ObjectStoreScriptStatus represents the status of an object store Lua script
+type ObjectStoreScriptStatus struct {
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Hash is the SHA-256 hash of the script loaded in the object store
+	// +optional
+	Hash string `json:"hash,omitempty"`
+	// Context is the context in which the script is loaded
+	// +optional
+	Context ObjectStoreScriptContext `json:"context,omitempty"`
+	// Tenant is the tenant for which the script is loaded
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 // CephObjectRealm represents a Ceph Object Store Gateway Realm
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=cephor
@@ -2333,6 +3493,27 @@ type CephObjectZoneGroupList struct {
 type ObjectZoneGroupSpec struct {
 	// The display name for the ceph users
 	Realm string `json:"realm"`
+
+	// Failover promotes a zone of the zone group to master, for example when the master zone is
+	// lost. It must be set on the zone group in the cluster of the zone to promote, and on the
+	// zone group in the cluster of the previous master to demote it when it comes back.
+	// +optional
+	// +nullable
+	Failover *ObjectZoneGroupFailoverSpec `json:"failover,omitempty"`
+}
+
+// ObjectZoneGroupFailoverSpec represents the promotion of a zone to master of its zone group
+type ObjectZoneGroupFailoverSpec struct {
+	// MasterZone is the name of the zone to promote to master. The zone is promoted by the
+	// CephObjectZone controller of the cluster where the CephObjectZone exists.
+	// +kubebuilder:validation:MinLength=1
+	MasterZone string `json:"masterZone"`
+
+	// DemoteOldMaster demotes a local zone that is still master in its own configuration period,
+	// typically the previous master coming back after a failover, by pulling the realm from the
+	// endpoints of the new master zone.
+	// +optional
+	DemoteOldMaster bool `json:"demoteOldMaster,omitempty"`
 }
 
 // +genclient
@@ -2350,7 +3531,93 @@ type CephObjectZone struct {
 	Spec              ObjectZoneSpec `json:"spec"`
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
-	Status *Status `json:"status,omitempty"`
+	Status *ObjectZoneStatus `json:"status,omitempty"`
+}
+
+// ObjectZoneStatus represents the status of a Ceph Object Store Gateway Zone
+type ObjectZoneStatus struct {
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64       `json:"observedGeneration,omitempty"`
+	Conditions         []Condition `json:"conditions,omitempty"`
+	// Failover is the history of the promotions and demotions of the zone
+	// +optional
+	// +nullable
+	Failover *ObjectZoneFailoverStatus `json:"failover,omitempty"`
+	// SyncStatus is the multisite sync status of the zone
+	// +optional
+	// +nullable
+	SyncStatus *ObjectZoneSyncStatus `json:"syncStatus,omitempty"`
+}
+
+// ObjectZoneSyncStatus represents the multisite sync status of a zone, as reported by
+// `radosgw-admin sync status` and `radosgw-admin sync error list`
+type ObjectZoneSyncStatus struct {
+	// Metadata is the status of the metadata sync from the master zone
+	// +optional
+	// +nullable
+	Metadata *ObjectZoneSyncSourceStatus `json:"metadata,omitempty"`
+	// Data is the status of the data sync from each source zone
+	// +optional
+	Data []ObjectZoneSyncSourceStatus `json:"data,omitempty"`
+	// Errors is the number of entries in the sync error log
+	// +optional
+	Errors int `json:"errors"`
+	// RecentErrors are the messages of the most recent entries of the sync error log
+	// +optional
+	RecentErrors []string `json:"recentErrors,omitempty"`
+	// LastChecked is the time the sync status was collected
+	// +optional
+	// +nullable
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+}
+
+// ObjectZoneSyncSourceStatus represents the metadata or data sync status of a zone from a source
+type ObjectZoneSyncSourceStatus struct {
+	// SourceZone is the name of the zone the data is synced from. Empty for the metadata sync.
+	// +optional
+	SourceZone string `json:"sourceZone,omitempty"`
+	// State is the sync state reported by RGW, like "syncing" or "no sync (zone is master)"
+	// +optional
+	State string `json:"state,omitempty"`
+	// ShardsBehind is the number of log shards with changes not applied yet
+	// +optional
+	ShardsBehind int `json:"shardsBehind"`
+	// OldestIncrementalChange is the time of the oldest change not applied yet
+	// +optional
+	// +nullable
+	OldestIncrementalChange *metav1.Time `json:"oldestIncrementalChange,omitempty"`
+}
+
+// ObjectZoneFailoverStatus represents the failovers of a zone
+type ObjectZoneFailoverStatus struct {
+	// Master is whether the zone is the master zone of its zone group
+	// +optional
+	Master bool `json:"master"`
+	// History are the latest promotions and demotions of the zone, the most recent last
+	// +optional
+	History []ObjectZoneFailoverRecord `json:"history,omitempty"`
+}
+
+// ObjectZoneFailoverRecord represents a promotion or demotion of a zone
+type ObjectZoneFailoverRecord struct {
+	// Time is when the zone was promoted or demoted
+	Time metav1.Time `json:"time"`
+	// Action is either Promoted or Demoted
+	// +kubebuilder:validation:Enum=Promoted;Demoted
+	Action string `json:"action"`
+	// PreviousMaster is the name of the master zone before the failover
+	// +optional
+	PreviousMaster string `json:"previousMaster,omitempty"`
+	// NewMaster is the name of the master zone after the failover
+	// +optional
+	NewMaster string `json:"newMaster,omitempty"`
+	// SyncStatus is the output of `radosgw-admin sync status` for the zone before the failover,
+	// which shows how far the zone was behind the previous master
+	// +optional
+	SyncStatus string `json:"syncStatus,omitempty"`
 }
 
 // CephObjectZoneList represents a list Ceph Object Store Gateway Zones
@@ -2399,6 +3666,26 @@ type ObjectZoneSpec struct {
 	// +optional
 	// +kubebuilder:default=true
 	PreservePoolsOnDelete bool `json:"preservePoolsOnDelete"`
+
+	// SyncStatus configures the periodic report of the multisite sync status of the zone in the
+	// status and as Prometheus metrics. The sync status is reported every 5 minutes by default.
+	// +optional
+	// +nullable
+	SyncStatus *ObjectZoneSyncStatusSpec `json:"syncStatus,omitempty"`
+}
+
+// ObjectZoneSyncStatusSpec represents the settings of the sync status report of a zone
+type ObjectZoneSyncStatusSpec struct {
+	// Disabled stops the report of the sync status
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+	// Interval is how often the sync status is collected, like 5m. Defaults to 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// LagThreshold is the age of the oldest change not applied from a source zone above which the
+	// SyncLagging condition is set on the zone, like 1h. Defaults to 1h.
+	// +optional
+	LagThreshold *metav1.Duration `json:"lagThreshold,omitempty"`
 }
 
 // +genclient
@@ -2432,6 +3719,24 @@ type BucketTopicStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// +optional
 	Secrets []SecretReference `json:"secrets,omitempty"`
+	// Delivery is the queue of notifications pending delivery of a persistent topic
+	// +optional
+	// +nullable
+	Delivery *TopicDeliveryStatus `json:"delivery,omitempty"`
+}
+
+// TopicDeliveryStatus represents the queue of notifications pending delivery of a persistent topic
+type TopicDeliveryStatus struct {
+	// PendingEntries is the number of notifications in the queue
+	PendingEntries int64 `json:"pendingEntries"`
+	// PendingSize is the size in bytes of the notifications in the queue
+	PendingSize int64 `json:"pendingSize"`
+	// Reservations is the number of notifications reserved in the queue by in-flight operations
+	Reservations int64 `json:"reservations"`
+	// LastChecked is the time the queue was last checked
+	// +optional
+	// +nullable
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
 }
 
 // CephBucketTopicList represents a list Ceph Object Store Bucket Notification Topics
@@ -2456,10 +3761,30 @@ type BucketTopicSpec struct {
 	// Indication whether notifications to this endpoint are persistent or not
 	// +optional
 	Persistent bool `json:"persistent,omitempty"`
+	// Delivery settings of persistent notifications. Can only be set when persistent is true.
+	// +optional
+	Delivery *TopicDeliverySpec `json:"delivery,omitempty"`
 	// Contains the endpoint spec of the topic
 	Endpoint TopicEndpointSpec `json:"endpoint"`
 }
 
+// TopicDeliverySpec represents the delivery settings of persistent notifications. Unset settings
+// default to the rgw_topic_persistency_* settings of the RGW, where 0 means unlimited.
+type TopicDeliverySpec struct {
+	// TimeToLive is the time in seconds a notification is kept in the queue before it is dropped
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TimeToLive *int32 `json:"timeToLive,omitempty"`
+	// MaxRetries is the number of delivery attempts of a notification before it is dropped
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// RetrySleepDuration is the time in seconds between delivery attempts of a notification
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RetrySleepDuration *int32 `json:"retrySleepDuration,omitempty"`
+}
+
 // TopicEndpointSpec contains exactly one of the endpoint specs of a Bucket Topic
 type TopicEndpointSpec struct {
 	// Spec of HTTP endpoint
@@ -3327,6 +4652,12 @@ type StorageScopeSpec struct {
 	// User needs to manually restart the OSD pod if they manage to fix the underlying OSD flapping issue before the restart interval.
 	// The sleep will be disabled if this interval is set to 0.
 	FlappingRestartIntervalHours int `json:"flappingRestartIntervalHours"`
+	// FlappingQuarantine stops the OSDs that keep restarting or being marked down, to prevent a bad disk
+	// from degrading the whole cluster. A quarantined OSD is marked out and its deployment is scaled down
+	// until it is released with the "ceph.rook.io/osd-quarantine-release" annotation on the deployment.
+	// +optional
+	// +nullable
+	FlappingQuarantine *OSDFlappingQuarantineSpec `json:"flappingQuarantine,omitempty"`
 	// FullRatio is the ratio at which the cluster is considered full and ceph will stop accepting writes. Default is 0.95.
 	// +kubebuilder:validation:Minimum=0.0
 	// +kubebuilder:validation:Maximum=1.0
@@ -3353,6 +4684,70 @@ type StorageScopeSpec struct {
 	// The default is false since data rebalancing can cause temporary cluster slowdown.
 	// +optional
 	AllowOsdCrushWeightUpdate bool `json:"allowOsdCrushWeightUpdate,omitempty"`
+	// OSDOverrides pins properties of individual OSDs. The key is either the ID of the OSD (e.g. "3"),
+	// a path of the device backing the OSD (e.g. "/dev/sdb" or "/dev/disk/by-id/...") or the name of
+	// the PVC backing the OSD. Overrides keyed by device or PVC also apply to a new OSD replacing a
+	// removed OSD on the same device. An override keyed by OSD ID takes precedence.
+	// +optional
+	// +nullable
+	OSDOverrides map[string]OSDOverride `json:"osdOverrides,omitempty"`
+	// WeightRampUp brings new OSDs in with a CRUSH weight of 0 and raises their weight in steps,
+	// waiting for the PGs to be clean between the steps, to avoid backfilling to a batch of new OSDs at once
+	// +optional
+	// +nullable
+	WeightRampUp *OSDWeightRampUpSpec `json:"weightRampUp,omitempty"`
+}
+
+// OSDFlappingQuarantineSpec represents the settings of the quarantine of the flapping OSDs
+type OSDFlappingQuarantineSpec struct {
+	// Enabled is whether the flapping OSDs are quarantined
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// Threshold is the number of times an OSD can come up again within the window before it is quarantined.
+	// Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Threshold int `json:"threshold,omitempty"`
+	// Window is the period over which the OSD restarts are counted. Defaults to 1h.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+	// MaxQuarantinedOSDs is the maximum number of OSDs quarantined at the same time, to avoid marking out
+	// many OSDs when the flapping is caused by a network issue. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxQuarantinedOSDs int `json:"maxQuarantinedOSDs,omitempty"`
+}
+
+// OSDWeightRampUpSpec represents the settings of the gradual CRUSH weight ramp-up of new OSDs
+type OSDWeightRampUpSpec struct {
+	// Enabled is whether new OSDs are brought in with a CRUSH weight of 0 and ramped up gradually
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// StepPercent is the percentage of the target CRUSH weight added at each step. Defaults to 25.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	StepPercent int `json:"stepPercent,omitempty"`
+}
+
+// OSDOverride represents the properties pinned for an OSD, reconciled after the OSD is created
+type OSDOverride struct {
+	// DeviceClass is the CRUSH device class of the OSD. Applied even if allowDeviceClassUpdate is false.
+	// +optional
+	DeviceClass string `json:"deviceClass,omitempty"`
+	// CrushWeight is the CRUSH weight of the OSD, usually its size in TiB. When set, the weight is
+	// not resized by allowOsdCrushWeightUpdate.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	CrushWeight string `json:"crushWeight,omitempty"`
+	// PrimaryAffinity is the primary affinity of the OSD, within [0.0, 1.0]
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	PrimaryAffinity string `json:"primaryAffinity,omitempty"`
+	// CrushLocation is the CRUSH location of the OSD, e.g. "root=default rack=rack1 host=host1".
+	// The OSD is moved to the location when its pod is restarted with the new location.
+	// +optional
+	CrushLocation string `json:"crushLocation,omitempty"`
 }
 
 // Migration handles the OSD migration
@@ -3507,6 +4902,10 @@ type StorageClassDeviceSet struct {
 	// Whether to encrypt the deviceSet
 	// +optional
 	Encrypted bool `json:"encrypted,omitempty"`
+	// WeightRampUp overrides the cluster-wide weight ramp-up settings for the OSDs of the set
+	// +optional
+	// +nullable
+	WeightRampUp *OSDWeightRampUpSpec `json:"weightRampUp,omitempty"`
 }
 
 // +genclient
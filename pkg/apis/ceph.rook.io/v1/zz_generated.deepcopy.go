@@ -174,6 +174,11 @@ func (in *AuthSpec) DeepCopyInto(out *AuthSpec) {
 		*out = new(KeystoneSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.STS != nil {
+		in, out := &in.STS, &out.STS
+		*out = new(STSSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -187,6 +192,47 @@ func (in *AuthSpec) DeepCopy() *AuthSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketEncryptionSpec) DeepCopyInto(out *BucketEncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketEncryptionSpec.
+func (in *BucketEncryptionSpec) DeepCopy() *BucketEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketIndexStatus) DeepCopyInto(out *BucketIndexStatus) {
+	*out = *in
+	if in.TargetShards != nil {
+		in, out := &in.TargetShards, &out.TargetShards
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketIndexStatus.
+func (in *BucketIndexStatus) DeepCopy() *BucketIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketNotificationSpec) DeepCopyInto(out *BucketNotificationSpec) {
 	*out = *in
@@ -216,6 +262,11 @@ func (in *BucketNotificationSpec) DeepCopy() *BucketNotificationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketTopicSpec) DeepCopyInto(out *BucketTopicSpec) {
 	*out = *in
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(TopicDeliverySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Endpoint.DeepCopyInto(&out.Endpoint)
 	return
 }
@@ -243,6 +294,11 @@ func (in *BucketTopicStatus) DeepCopyInto(out *BucketTopicStatus) {
 		*out = make([]SecretReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = new(TopicDeliveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1586,6 +1642,136 @@ func (in *CephObjectStoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreRole) DeepCopyInto(out *CephObjectStoreRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ObjectStoreRoleStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreRole.
+func (in *CephObjectStoreRole) DeepCopy() *CephObjectStoreRole {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreRoleList) DeepCopyInto(out *CephObjectStoreRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephObjectStoreRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreRoleList.
+func (in *CephObjectStoreRoleList) DeepCopy() *CephObjectStoreRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreScript) DeepCopyInto(out *CephObjectStoreScript) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ObjectStoreScriptStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreScript.
+func (in *CephObjectStoreScript) DeepCopy() *CephObjectStoreScript {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreScript) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CephObjectStoreScriptList) DeepCopyInto(out *CephObjectStoreScriptList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CephObjectStoreScript, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CephObjectStoreScriptList.
+func (in *CephObjectStoreScriptList) DeepCopy() *CephObjectStoreScriptList {
+	if in == nil {
+		return nil
+	}
+	out := new(CephObjectStoreScriptList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CephObjectStoreScriptList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CephObjectStoreUser) DeepCopyInto(out *CephObjectStoreUser) {
 	*out = *in
@@ -1659,7 +1845,7 @@ func (in *CephObjectZone) DeepCopyInto(out *CephObjectZone) {
 	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(Status)
+		*out = new(ObjectZoneStatus)
 		(*in).DeepCopyInto(*out)
 	}
 	return
@@ -1688,7 +1874,7 @@ func (in *CephObjectZoneGroup) DeepCopyInto(out *CephObjectZoneGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(Status)
@@ -2787,9 +2973,40 @@ func (in *GaneshaServerSpec) DeepCopy() *GaneshaServerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAutoscalingSpec) DeepCopyInto(out *GatewayAutoscalingSpec) {
+	*out = *in
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetRequestsPerSecond != nil {
+		in, out := &in.TargetRequestsPerSecond, &out.TargetRequestsPerSecond
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAutoscalingSpec.
+func (in *GatewayAutoscalingSpec) DeepCopy() *GatewayAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(GatewayAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Placement.DeepCopyInto(&out.Placement)
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
@@ -2930,6 +3147,22 @@ func (in *HybridStorageSpec) DeepCopy() *HybridStorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IAMPolicySpec) DeepCopyInto(out *IAMPolicySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IAMPolicySpec.
+func (in *IAMPolicySpec) DeepCopy() *IAMPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IAMPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KafkaEndpointSpec) DeepCopyInto(out *KafkaEndpointSpec) {
 	*out = *in
@@ -3042,6 +3275,7 @@ func (in *KeyManagementServiceSpec) DeepCopy() *KeyManagementServiceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyRotationSpec) DeepCopyInto(out *KeyRotationSpec) {
 	*out = *in
+	out.Reencryption = in.Reencryption
 	return
 }
 
@@ -3809,248 +4043,1355 @@ func (in *NotificationKeyFilterRule) DeepCopy() *NotificationKeyFilterRule {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OSDStatus) DeepCopyInto(out *OSDStatus) {
+func (in *OIDCProviderSpec) DeepCopyInto(out *OIDCProviderSpec) {
 	*out = *in
-	if in.StoreType != nil {
-		in, out := &in.StoreType, &out.StoreType
-		*out = make(map[string]int, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.ClientIDs != nil {
+		in, out := &in.ClientIDs, &out.ClientIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Thumbprints != nil {
+		in, out := &in.Thumbprints, &out.Thumbprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out.MigrationStatus = in.MigrationStatus
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStatus.
-func (in *OSDStatus) DeepCopy() *OSDStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProviderSpec.
+func (in *OIDCProviderSpec) DeepCopy() *OIDCProviderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OSDStatus)
+	out := new(OIDCProviderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OSDStore) DeepCopyInto(out *OSDStore) {
+func (in *OSDDeviceProvisioningStatus) DeepCopyInto(out *OSDDeviceProvisioningStatus) {
 	*out = *in
+	if in.OSDID != nil {
+		in, out := &in.OSDID, &out.OSDID
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStore.
-func (in *OSDStore) DeepCopy() *OSDStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDDeviceProvisioningStatus.
+func (in *OSDDeviceProvisioningStatus) DeepCopy() *OSDDeviceProvisioningStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OSDStore)
+	out := new(OSDDeviceProvisioningStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectEndpointSpec) DeepCopyInto(out *ObjectEndpointSpec) {
+func (in *OSDFlappingQuarantineSpec) DeepCopyInto(out *OSDFlappingQuarantineSpec) {
 	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpointSpec.
-func (in *ObjectEndpointSpec) DeepCopy() *ObjectEndpointSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDFlappingQuarantineSpec.
+func (in *OSDFlappingQuarantineSpec) DeepCopy() *OSDFlappingQuarantineSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectEndpointSpec)
+	out := new(OSDFlappingQuarantineSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectEndpoints) DeepCopyInto(out *ObjectEndpoints) {
+func (in *OSDOverride) DeepCopyInto(out *OSDOverride) {
 	*out = *in
-	if in.Insecure != nil {
-		in, out := &in.Insecure, &out.Insecure
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Secure != nil {
-		in, out := &in.Secure, &out.Secure
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpoints.
-func (in *ObjectEndpoints) DeepCopy() *ObjectEndpoints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDOverride.
+func (in *OSDOverride) DeepCopy() *OSDOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectEndpoints)
+	out := new(OSDOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectHealthCheckSpec) DeepCopyInto(out *ObjectHealthCheckSpec) {
+func (in *OSDProvisioningStatus) DeepCopyInto(out *OSDProvisioningStatus) {
 	*out = *in
-	if in.ReadinessProbe != nil {
-		in, out := &in.ReadinessProbe, &out.ReadinessProbe
-		*out = new(ProbeSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.StartupProbe != nil {
-		in, out := &in.StartupProbe, &out.StartupProbe
-		*out = new(ProbeSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]OSDDeviceProvisioningStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectHealthCheckSpec.
-func (in *ObjectHealthCheckSpec) DeepCopy() *ObjectHealthCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDProvisioningStatus.
+func (in *OSDProvisioningStatus) DeepCopy() *OSDProvisioningStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectHealthCheckSpec)
+	out := new(OSDProvisioningStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectRealmSpec) DeepCopyInto(out *ObjectRealmSpec) {
+func (in *OSDQuarantineStatus) DeepCopyInto(out *OSDQuarantineStatus) {
 	*out = *in
-	out.Pull = in.Pull
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRealmSpec.
-func (in *ObjectRealmSpec) DeepCopy() *ObjectRealmSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDQuarantineStatus.
+func (in *OSDQuarantineStatus) DeepCopy() *OSDQuarantineStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectRealmSpec)
+	out := new(OSDQuarantineStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectSharedPoolsSpec) DeepCopyInto(out *ObjectSharedPoolsSpec) {
+func (in *OSDReencryptionStatus) DeepCopyInto(out *OSDReencryptionStatus) {
 	*out = *in
-	if in.PoolPlacements != nil {
-		in, out := &in.PoolPlacements, &out.PoolPlacements
-		*out = make([]PoolPlacementSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastReencryptionTime != nil {
+		in, out := &in.LastReencryptionTime, &out.LastReencryptionTime
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSharedPoolsSpec.
-func (in *ObjectSharedPoolsSpec) DeepCopy() *ObjectSharedPoolsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDReencryptionStatus.
+func (in *OSDReencryptionStatus) DeepCopy() *OSDReencryptionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectSharedPoolsSpec)
+	out := new(OSDReencryptionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStoreHostingSpec) DeepCopyInto(out *ObjectStoreHostingSpec) {
+func (in *OSDStatus) DeepCopyInto(out *OSDStatus) {
 	*out = *in
-	if in.AdvertiseEndpoint != nil {
-		in, out := &in.AdvertiseEndpoint, &out.AdvertiseEndpoint
-		*out = new(ObjectEndpointSpec)
+	if in.StoreType != nil {
+		in, out := &in.StoreType, &out.StoreType
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.MigrationStatus = in.MigrationStatus
+	if in.Reencryption != nil {
+		in, out := &in.Reencryption, &out.Reencryption
+		*out = make([]OSDReencryptionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = make([]OSDProvisioningStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WeightRampUp != nil {
+		in, out := &in.WeightRampUp, &out.WeightRampUp
+		*out = make([]OSDWeightRampUpStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quarantined != nil {
+		in, out := &in.Quarantined, &out.Quarantined
+		*out = make([]OSDQuarantineStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStatus.
+func (in *OSDStatus) DeepCopy() *OSDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDStore) DeepCopyInto(out *OSDStore) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDStore.
+func (in *OSDStore) DeepCopy() *OSDStore {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDWeightRampUpSpec) DeepCopyInto(out *OSDWeightRampUpSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDWeightRampUpSpec.
+func (in *OSDWeightRampUpSpec) DeepCopy() *OSDWeightRampUpSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDWeightRampUpSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSDWeightRampUpStatus) DeepCopyInto(out *OSDWeightRampUpStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSDWeightRampUpStatus.
+func (in *OSDWeightRampUpStatus) DeepCopy() *OSDWeightRampUpStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OSDWeightRampUpStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectEndpointSpec) DeepCopyInto(out *ObjectEndpointSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpointSpec.
+func (in *ObjectEndpointSpec) DeepCopy() *ObjectEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectEndpoints) DeepCopyInto(out *ObjectEndpoints) {
+	*out = *in
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Secure != nil {
+		in, out := &in.Secure, &out.Secure
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectEndpoints.
+func (in *ObjectEndpoints) DeepCopy() *ObjectEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectHealthCheckSpec) DeepCopyInto(out *ObjectHealthCheckSpec) {
+	*out = *in
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectHealthCheckSpec.
+func (in *ObjectHealthCheckSpec) DeepCopy() *ObjectHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRealmSpec) DeepCopyInto(out *ObjectRealmSpec) {
+	*out = *in
+	out.Pull = in.Pull
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRealmSpec.
+func (in *ObjectRealmSpec) DeepCopy() *ObjectRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectSharedPoolsSpec) DeepCopyInto(out *ObjectSharedPoolsSpec) {
+	*out = *in
+	if in.PoolPlacements != nil {
+		in, out := &in.PoolPlacements, &out.PoolPlacements
+		*out = make([]PoolPlacementSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectSharedPoolsSpec.
+func (in *ObjectSharedPoolsSpec) DeepCopy() *ObjectSharedPoolsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectSharedPoolsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreBucketMigrationStatus) DeepCopyInto(out *ObjectStoreBucketMigrationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreBucketMigrationStatus.
+func (in *ObjectStoreBucketMigrationStatus) DeepCopy() *ObjectStoreBucketMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreBucketMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreCertManagerSpec) DeepCopyInto(out *ObjectStoreCertManagerSpec) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreCertManagerSpec.
+func (in *ObjectStoreCertManagerSpec) DeepCopy() *ObjectStoreCertManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreCertManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreExposeSpec) DeepCopyInto(out *ObjectStoreExposeSpec) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ObjectStoreIngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(ObjectStoreHTTPRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(ObjectStoreCertManagerSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreExposeSpec.
+func (in *ObjectStoreExposeSpec) DeepCopy() *ObjectStoreExposeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreExposeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreGatewayRef) DeepCopyInto(out *ObjectStoreGatewayRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreGatewayRef.
+func (in *ObjectStoreGatewayRef) DeepCopy() *ObjectStoreGatewayRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreGatewayRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreHTTPRouteSpec) DeepCopyInto(out *ObjectStoreHTTPRouteSpec) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]ObjectStoreGatewayRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(Annotations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreHTTPRouteSpec.
+func (in *ObjectStoreHTTPRouteSpec) DeepCopy() *ObjectStoreHTTPRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreHTTPRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreHostingSpec) DeepCopyInto(out *ObjectStoreHostingSpec) {
+	*out = *in
+	if in.AdvertiseEndpoint != nil {
+		in, out := &in.AdvertiseEndpoint, &out.AdvertiseEndpoint
+		*out = new(ObjectEndpointSpec)
+		**out = **in
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ObjectStoreExposeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreHostingSpec.
+func (in *ObjectStoreHostingSpec) DeepCopy() *ObjectStoreHostingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreHostingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreIngressSpec) DeepCopyInto(out *ObjectStoreIngressSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(Annotations, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreIngressSpec.
+func (in *ObjectStoreIngressSpec) DeepCopy() *ObjectStoreIngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreIssuerRef) DeepCopyInto(out *ObjectStoreIssuerRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreIssuerRef.
+func (in *ObjectStoreIssuerRef) DeepCopy() *ObjectStoreIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreMigrationSpec) DeepCopyInto(out *ObjectStoreMigrationSpec) {
+	*out = *in
+	if in.BucketInterval != nil {
+		in, out := &in.BucketInterval, &out.BucketInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreMigrationSpec.
+func (in *ObjectStoreMigrationSpec) DeepCopy() *ObjectStoreMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreMigrationStatus) DeepCopyInto(out *ObjectStoreMigrationStatus) {
+	*out = *in
+	if in.SwitchTime != nil {
+		in, out := &in.SwitchTime, &out.SwitchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]ObjectStoreBucketMigrationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreMigrationStatus.
+func (in *ObjectStoreMigrationStatus) DeepCopy() *ObjectStoreMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreOrphanScanSpec) DeepCopyInto(out *ObjectStoreOrphanScanSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreOrphanScanSpec.
+func (in *ObjectStoreOrphanScanSpec) DeepCopy() *ObjectStoreOrphanScanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreOrphanScanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreOrphanScanStatus) DeepCopyInto(out *ObjectStoreOrphanScanStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreOrphanScanStatus.
+func (in *ObjectStoreOrphanScanStatus) DeepCopy() *ObjectStoreOrphanScanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreOrphanScanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreRateLimitSpec) DeepCopyInto(out *ObjectStoreRateLimitSpec) {
+	*out = *in
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Anonymous != nil {
+		in, out := &in.Anonymous, &out.Anonymous
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreRateLimitSpec.
+func (in *ObjectStoreRateLimitSpec) DeepCopy() *ObjectStoreRateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreRateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreRateLimitStatus) DeepCopyInto(out *ObjectStoreRateLimitStatus) {
+	*out = *in
+	if in.User != nil {
+		in, out := &in.User, &out.User
+		*out = new(RateLimitStatus)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(RateLimitStatus)
+		**out = **in
+	}
+	if in.Anonymous != nil {
+		in, out := &in.Anonymous, &out.Anonymous
+		*out = new(RateLimitStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreRateLimitStatus.
+func (in *ObjectStoreRateLimitStatus) DeepCopy() *ObjectStoreRateLimitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreRateLimitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreRoleSpec) DeepCopyInto(out *ObjectStoreRoleSpec) {
+	*out = *in
+	if in.MaxSessionDuration != nil {
+		in, out := &in.MaxSessionDuration, &out.MaxSessionDuration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]IAMPolicySpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreRoleSpec.
+func (in *ObjectStoreRoleSpec) DeepCopy() *ObjectStoreRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreRoleStatus) DeepCopyInto(out *ObjectStoreRoleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreRoleStatus.
+func (in *ObjectStoreRoleStatus) DeepCopy() *ObjectStoreRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreScriptSpec) DeepCopyInto(out *ObjectStoreScriptSpec) {
+	*out = *in
+	in.ConfigMapKeyRef.DeepCopyInto(&out.ConfigMapKeyRef)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreScriptSpec.
+func (in *ObjectStoreScriptSpec) DeepCopy() *ObjectStoreScriptSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreScriptSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreScriptStatus) DeepCopyInto(out *ObjectStoreScriptStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreScriptStatus.
+func (in *ObjectStoreScriptStatus) DeepCopy() *ObjectStoreScriptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreScriptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreSecuritySpec) DeepCopyInto(out *ObjectStoreSecuritySpec) {
+	*out = *in
+	in.SecuritySpec.DeepCopyInto(&out.SecuritySpec)
+	in.ServerSideEncryptionS3.DeepCopyInto(&out.ServerSideEncryptionS3)
+	if in.BucketEncryption != nil {
+		in, out := &in.BucketEncryption, &out.BucketEncryption
+		*out = new(BucketEncryptionSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreSecuritySpec.
+func (in *ObjectStoreSecuritySpec) DeepCopy() *ObjectStoreSecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreSecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreSpec) DeepCopyInto(out *ObjectStoreSpec) {
+	*out = *in
+	in.MetadataPool.DeepCopyInto(&out.MetadataPool)
+	in.DataPool.DeepCopyInto(&out.DataPool)
+	in.SharedPools.DeepCopyInto(&out.SharedPools)
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.Protocols.DeepCopyInto(&out.Protocols)
+	in.Auth.DeepCopyInto(&out.Auth)
+	out.Zone = in.Zone
+	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(ObjectStoreSecuritySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowUsersInNamespaces != nil {
+		in, out := &in.AllowUsersInNamespaces, &out.AllowUsersInNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hosting != nil {
+		in, out := &in.Hosting, &out.Hosting
+		*out = new(ObjectStoreHostingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(ObjectStoreRateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageExporter != nil {
+		in, out := &in.UsageExporter, &out.UsageExporter
+		*out = new(ObjectStoreUsageExporterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrphanScan != nil {
+		in, out := &in.OrphanScan, &out.OrphanScan
+		*out = new(ObjectStoreOrphanScanSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(ObjectStoreMigrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreSpec.
+func (in *ObjectStoreSpec) DeepCopy() *ObjectStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreStatus) DeepCopyInto(out *ObjectStoreStatus) {
+	*out = *in
+	in.Endpoints.DeepCopyInto(&out.Endpoints)
+	if in.Info != nil {
+		in, out := &in.Info, &out.Info
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Cephx = in.Cephx
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(ObjectStoreRateLimitStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDCProviders != nil {
+		in, out := &in.OIDCProviders, &out.OIDCProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrphanScan != nil {
+		in, out := &in.OrphanScan, &out.OrphanScan
+		*out = new(ObjectStoreOrphanScanStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(ObjectStoreMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExposedResources != nil {
+		in, out := &in.ExposedResources, &out.ExposedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreStatus.
+func (in *ObjectStoreStatus) DeepCopy() *ObjectStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreUsageExporterSpec) DeepCopyInto(out *ObjectStoreUsageExporterSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreUsageExporterSpec.
+func (in *ObjectStoreUsageExporterSpec) DeepCopy() *ObjectStoreUsageExporterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreUsageExporterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreUserSpec) DeepCopyInto(out *ObjectStoreUserSpec) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(ObjectUserCapSpec)
+		**out = **in
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(ObjectUserQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]ObjectUserKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(ObjectUserKeyRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Account != nil {
+		in, out := &in.Account, &out.Account
+		*out = new(ObjectUserAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]IAMPolicySpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreUserSpec.
+func (in *ObjectStoreUserSpec) DeepCopy() *ObjectStoreUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStoreUserStatus) DeepCopyInto(out *ObjectStoreUserStatus) {
+	*out = *in
+	if in.Info != nil {
+		in, out := &in.Info, &out.Info
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SecretReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitStatus)
+		**out = **in
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ObjectUserUsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(QuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(ObjectUserKeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreUserStatus.
+func (in *ObjectStoreUserStatus) DeepCopy() *ObjectStoreUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStoreUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectUserAccountSpec) DeepCopyInto(out *ObjectUserAccountSpec) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserAccountSpec.
+func (in *ObjectUserAccountSpec) DeepCopy() *ObjectUserAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectUserAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectUserCapSpec) DeepCopyInto(out *ObjectUserCapSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserCapSpec.
+func (in *ObjectUserCapSpec) DeepCopy() *ObjectUserCapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectUserCapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectUserKey) DeepCopyInto(out *ObjectUserKey) {
+	*out = *in
+	if in.AccessKeyRef != nil {
+		in, out := &in.AccessKeyRef, &out.AccessKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserKey.
+func (in *ObjectUserKey) DeepCopy() *ObjectUserKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectUserKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectUserKeyRotationSpec) DeepCopyInto(out *ObjectUserKeyRotationSpec) {
+	*out = *in
+	out.Period = in.Period
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	if in.DNSNames != nil {
-		in, out := &in.DNSNames, &out.DNSNames
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserKeyRotationSpec.
+func (in *ObjectUserKeyRotationSpec) DeepCopy() *ObjectUserKeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectUserKeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectUserKeyRotationStatus) DeepCopyInto(out *ObjectUserKeyRotationStatus) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]ObjectUserKeyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextRotation != nil {
+		in, out := &in.NextRotation, &out.NextRotation
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreHostingSpec.
-func (in *ObjectStoreHostingSpec) DeepCopy() *ObjectStoreHostingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserKeyRotationStatus.
+func (in *ObjectUserKeyRotationStatus) DeepCopy() *ObjectUserKeyRotationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStoreHostingSpec)
+	out := new(ObjectUserKeyRotationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStoreSecuritySpec) DeepCopyInto(out *ObjectStoreSecuritySpec) {
+func (in *ObjectUserKeyStatus) DeepCopyInto(out *ObjectUserKeyStatus) {
 	*out = *in
-	in.SecuritySpec.DeepCopyInto(&out.SecuritySpec)
-	in.ServerSideEncryptionS3.DeepCopyInto(&out.ServerSideEncryptionS3)
+	in.Created.DeepCopyInto(&out.Created)
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreSecuritySpec.
-func (in *ObjectStoreSecuritySpec) DeepCopy() *ObjectStoreSecuritySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserKeyStatus.
+func (in *ObjectUserKeyStatus) DeepCopy() *ObjectUserKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStoreSecuritySpec)
+	out := new(ObjectUserKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStoreSpec) DeepCopyInto(out *ObjectStoreSpec) {
+func (in *ObjectUserQuotaSpec) DeepCopyInto(out *ObjectUserQuotaSpec) {
+	*out = *in
+	if in.MaxBuckets != nil {
+		in, out := &in.MaxBuckets, &out.MaxBuckets
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxObjects != nil {
+		in, out := &in.MaxObjects, &out.MaxObjects
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NearFullThreshold != nil {
+		in, out := &in.NearFullThreshold, &out.NearFullThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserQuotaSpec.
+func (in *ObjectUserQuotaSpec) DeepCopy() *ObjectUserQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectUserQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectUserUsageStatus) DeepCopyInto(out *ObjectUserUsageStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserUsageStatus.
+func (in *ObjectUserUsageStatus) DeepCopy() *ObjectUserUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectUserUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneFailoverRecord) DeepCopyInto(out *ObjectZoneFailoverRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneFailoverRecord.
+func (in *ObjectZoneFailoverRecord) DeepCopy() *ObjectZoneFailoverRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneFailoverRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneFailoverStatus) DeepCopyInto(out *ObjectZoneFailoverStatus) {
+	*out = *in
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ObjectZoneFailoverRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneFailoverStatus.
+func (in *ObjectZoneFailoverStatus) DeepCopy() *ObjectZoneFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneGroupFailoverSpec) DeepCopyInto(out *ObjectZoneGroupFailoverSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneGroupFailoverSpec.
+func (in *ObjectZoneGroupFailoverSpec) DeepCopy() *ObjectZoneGroupFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneGroupFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneGroupSpec) DeepCopyInto(out *ObjectZoneGroupSpec) {
+	*out = *in
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(ObjectZoneGroupFailoverSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneGroupSpec.
+func (in *ObjectZoneGroupSpec) DeepCopy() *ObjectZoneGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneSpec) DeepCopyInto(out *ObjectZoneSpec) {
 	*out = *in
 	in.MetadataPool.DeepCopyInto(&out.MetadataPool)
 	in.DataPool.DeepCopyInto(&out.DataPool)
 	in.SharedPools.DeepCopyInto(&out.SharedPools)
-	in.Gateway.DeepCopyInto(&out.Gateway)
-	in.Protocols.DeepCopyInto(&out.Protocols)
-	in.Auth.DeepCopyInto(&out.Auth)
-	out.Zone = in.Zone
-	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
-	if in.Security != nil {
-		in, out := &in.Security, &out.Security
-		*out = new(ObjectStoreSecuritySpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AllowUsersInNamespaces != nil {
-		in, out := &in.AllowUsersInNamespaces, &out.AllowUsersInNamespaces
+	if in.CustomEndpoints != nil {
+		in, out := &in.CustomEndpoints, &out.CustomEndpoints
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Hosting != nil {
-		in, out := &in.Hosting, &out.Hosting
-		*out = new(ObjectStoreHostingSpec)
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(ObjectZoneSyncStatusSpec)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreSpec.
-func (in *ObjectStoreSpec) DeepCopy() *ObjectStoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneSpec.
+func (in *ObjectZoneSpec) DeepCopy() *ObjectZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStoreSpec)
+	out := new(ObjectZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStoreStatus) DeepCopyInto(out *ObjectStoreStatus) {
+func (in *ObjectZoneStatus) DeepCopyInto(out *ObjectZoneStatus) {
 	*out = *in
-	in.Endpoints.DeepCopyInto(&out.Endpoints)
-	if in.Info != nil {
-		in, out := &in.Info, &out.Info
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	out.Cephx = in.Cephx
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]Condition, len(*in))
@@ -4058,206 +5399,273 @@ func (in *ObjectStoreStatus) DeepCopyInto(out *ObjectStoreStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(ObjectZoneFailoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = new(ObjectZoneSyncStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreStatus.
-func (in *ObjectStoreStatus) DeepCopy() *ObjectStoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneStatus.
+func (in *ObjectZoneStatus) DeepCopy() *ObjectZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStoreStatus)
+	out := new(ObjectZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStoreUserSpec) DeepCopyInto(out *ObjectStoreUserSpec) {
+func (in *ObjectZoneSyncSourceStatus) DeepCopyInto(out *ObjectZoneSyncSourceStatus) {
 	*out = *in
-	if in.Capabilities != nil {
-		in, out := &in.Capabilities, &out.Capabilities
-		*out = new(ObjectUserCapSpec)
-		**out = **in
+	if in.OldestIncrementalChange != nil {
+		in, out := &in.OldestIncrementalChange, &out.OldestIncrementalChange
+		*out = (*in).DeepCopy()
 	}
-	if in.Quotas != nil {
-		in, out := &in.Quotas, &out.Quotas
-		*out = new(ObjectUserQuotaSpec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneSyncSourceStatus.
+func (in *ObjectZoneSyncSourceStatus) DeepCopy() *ObjectZoneSyncSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneSyncSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneSyncStatus) DeepCopyInto(out *ObjectZoneSyncStatus) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(ObjectZoneSyncSourceStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Keys != nil {
-		in, out := &in.Keys, &out.Keys
-		*out = make([]ObjectUserKey, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]ObjectZoneSyncSourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RecentErrors != nil {
+		in, out := &in.RecentErrors, &out.RecentErrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneSyncStatus.
+func (in *ObjectZoneSyncStatus) DeepCopy() *ObjectZoneSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectZoneSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectZoneSyncStatusSpec) DeepCopyInto(out *ObjectZoneSyncStatusSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LagThreshold != nil {
+		in, out := &in.LagThreshold, &out.LagThreshold
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreUserSpec.
-func (in *ObjectStoreUserSpec) DeepCopy() *ObjectStoreUserSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneSyncStatusSpec.
+func (in *ObjectZoneSyncStatusSpec) DeepCopy() *ObjectZoneSyncStatusSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStoreUserSpec)
+	out := new(ObjectZoneSyncStatusSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectStoreUserStatus) DeepCopyInto(out *ObjectStoreUserStatus) {
+func (in *OpsLogFilterSpec) DeepCopyInto(out *OpsLogFilterSpec) {
 	*out = *in
-	if in.Info != nil {
-		in, out := &in.Info, &out.Info
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Keys != nil {
-		in, out := &in.Keys, &out.Keys
-		*out = make([]SecretReference, len(*in))
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStoreUserStatus.
-func (in *ObjectStoreUserStatus) DeepCopy() *ObjectStoreUserStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogFilterSpec.
+func (in *OpsLogFilterSpec) DeepCopy() *OpsLogFilterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectStoreUserStatus)
+	out := new(OpsLogFilterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectUserCapSpec) DeepCopyInto(out *ObjectUserCapSpec) {
+func (in *OpsLogKafkaSinkSpec) DeepCopyInto(out *OpsLogKafkaSinkSpec) {
 	*out = *in
+	if in.Brokers != nil {
+		in, out := &in.Brokers, &out.Brokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserCapSpec.
-func (in *ObjectUserCapSpec) DeepCopy() *ObjectUserCapSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogKafkaSinkSpec.
+func (in *OpsLogKafkaSinkSpec) DeepCopy() *OpsLogKafkaSinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectUserCapSpec)
+	out := new(OpsLogKafkaSinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectUserKey) DeepCopyInto(out *ObjectUserKey) {
+func (in *OpsLogS3SinkSpec) DeepCopyInto(out *OpsLogS3SinkSpec) {
 	*out = *in
-	if in.AccessKeyRef != nil {
-		in, out := &in.AccessKeyRef, &out.AccessKeyRef
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecretKeyRef != nil {
-		in, out := &in.SecretKeyRef, &out.SecretKeyRef
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserKey.
-func (in *ObjectUserKey) DeepCopy() *ObjectUserKey {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogS3SinkSpec.
+func (in *OpsLogS3SinkSpec) DeepCopy() *OpsLogS3SinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectUserKey)
+	out := new(OpsLogS3SinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectUserQuotaSpec) DeepCopyInto(out *ObjectUserQuotaSpec) {
+func (in *OpsLogSidecar) DeepCopyInto(out *OpsLogSidecar) {
 	*out = *in
-	if in.MaxBuckets != nil {
-		in, out := &in.MaxBuckets, &out.MaxBuckets
-		*out = new(int)
-		**out = **in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]OpsLogSinkSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.MaxSize != nil {
-		in, out := &in.MaxSize, &out.MaxSize
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(OpsLogFilterSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.MaxObjects != nil {
-		in, out := &in.MaxObjects, &out.MaxObjects
-		*out = new(int64)
+	if in.SamplePercent != nil {
+		in, out := &in.SamplePercent, &out.SamplePercent
+		*out = new(int32)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectUserQuotaSpec.
-func (in *ObjectUserQuotaSpec) DeepCopy() *ObjectUserQuotaSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogSidecar.
+func (in *OpsLogSidecar) DeepCopy() *OpsLogSidecar {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectUserQuotaSpec)
+	out := new(OpsLogSidecar)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectZoneGroupSpec) DeepCopyInto(out *ObjectZoneGroupSpec) {
+func (in *OpsLogSinkSpec) DeepCopyInto(out *OpsLogSinkSpec) {
 	*out = *in
+	if in.Stdout != nil {
+		in, out := &in.Stdout, &out.Stdout
+		*out = new(OpsLogStdoutSinkSpec)
+		**out = **in
+	}
+	if in.Syslog != nil {
+		in, out := &in.Syslog, &out.Syslog
+		*out = new(OpsLogSyslogSinkSpec)
+		**out = **in
+	}
+	if in.Kafka != nil {
+		in, out := &in.Kafka, &out.Kafka
+		*out = new(OpsLogKafkaSinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(OpsLogS3SinkSpec)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneGroupSpec.
-func (in *ObjectZoneGroupSpec) DeepCopy() *ObjectZoneGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogSinkSpec.
+func (in *OpsLogSinkSpec) DeepCopy() *OpsLogSinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectZoneGroupSpec)
+	out := new(OpsLogSinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectZoneSpec) DeepCopyInto(out *ObjectZoneSpec) {
+func (in *OpsLogStdoutSinkSpec) DeepCopyInto(out *OpsLogStdoutSinkSpec) {
 	*out = *in
-	in.MetadataPool.DeepCopyInto(&out.MetadataPool)
-	in.DataPool.DeepCopyInto(&out.DataPool)
-	in.SharedPools.DeepCopyInto(&out.SharedPools)
-	if in.CustomEndpoints != nil {
-		in, out := &in.CustomEndpoints, &out.CustomEndpoints
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectZoneSpec.
-func (in *ObjectZoneSpec) DeepCopy() *ObjectZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogStdoutSinkSpec.
+func (in *OpsLogStdoutSinkSpec) DeepCopy() *OpsLogStdoutSinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectZoneSpec)
+	out := new(OpsLogStdoutSinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpsLogSidecar) DeepCopyInto(out *OpsLogSidecar) {
+func (in *OpsLogSyslogSinkSpec) DeepCopyInto(out *OpsLogSyslogSinkSpec) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogSidecar.
-func (in *OpsLogSidecar) DeepCopy() *OpsLogSidecar {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsLogSyslogSinkSpec.
+func (in *OpsLogSyslogSinkSpec) DeepCopy() *OpsLogSyslogSinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpsLogSidecar)
+	out := new(OpsLogSyslogSinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4355,6 +5763,22 @@ func (in *Placement) DeepCopy() *Placement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementCloudTierSpec) DeepCopyInto(out *PlacementCloudTierSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementCloudTierSpec.
+func (in *PlacementCloudTierSpec) DeepCopy() *PlacementCloudTierSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementCloudTierSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PlacementSpec) DeepCopyInto(out *PlacementSpec) {
 	{
@@ -4380,6 +5804,11 @@ func (in PlacementSpec) DeepCopy() PlacementSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlacementStorageClassSpec) DeepCopyInto(out *PlacementStorageClassSpec) {
 	*out = *in
+	if in.Cloud != nil {
+		in, out := &in.Cloud, &out.Cloud
+		*out = new(PlacementCloudTierSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -4399,7 +5828,9 @@ func (in *PoolPlacementSpec) DeepCopyInto(out *PoolPlacementSpec) {
 	if in.StorageClasses != nil {
 		in, out := &in.StorageClasses, &out.StorageClasses
 		*out = make([]PlacementStorageClassSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -4563,6 +5994,36 @@ func (in *QuotaSpec) DeepCopy() *QuotaSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaStatus) DeepCopyInto(out *QuotaStatus) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxObjects != nil {
+		in, out := &in.MaxObjects, &out.MaxObjects
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaStatus.
+func (in *QuotaStatus) DeepCopy() *QuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RBDMirroringSpec) DeepCopyInto(out *RBDMirroringSpec) {
 	*out = *in
@@ -4645,6 +6106,58 @@ func (in *RadosNamespaceMirroring) DeepCopy() *RadosNamespaceMirroring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+	if in.MaxReadOps != nil {
+		in, out := &in.MaxReadOps, &out.MaxReadOps
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxWriteOps != nil {
+		in, out := &in.MaxWriteOps, &out.MaxWriteOps
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxReadBytes != nil {
+		in, out := &in.MaxReadBytes, &out.MaxReadBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxWriteBytes != nil {
+		in, out := &in.MaxWriteBytes, &out.MaxWriteBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitStatus) DeepCopyInto(out *RateLimitStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitStatus.
+func (in *RateLimitStatus) DeepCopy() *RateLimitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReadAffinitySpec) DeepCopyInto(out *ReadAffinitySpec) {
 	*out = *in
@@ -4666,6 +6179,22 @@ func (in *ReadAffinitySpec) DeepCopy() *ReadAffinitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReencryptionSpec) DeepCopyInto(out *ReencryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReencryptionSpec.
+func (in *ReencryptionSpec) DeepCopy() *ReencryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReencryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicatedSpec) DeepCopyInto(out *ReplicatedSpec) {
 	*out = *in
@@ -4818,6 +6347,29 @@ func (in *SSSDSpec) DeepCopy() *SSSDSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *STSSpec) DeepCopyInto(out *STSSpec) {
+	*out = *in
+	if in.OIDCProviders != nil {
+		in, out := &in.OIDCProviders, &out.OIDCProviders
+		*out = make([]OIDCProviderSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new STSSpec.
+func (in *STSSpec) DeepCopy() *STSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(STSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SanitizeDisksSpec) DeepCopyInto(out *SanitizeDisksSpec) {
 	*out = *in
@@ -5059,6 +6611,11 @@ func (in *StorageClassDeviceSet) DeepCopyInto(out *StorageClassDeviceSet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.WeightRampUp != nil {
+		in, out := &in.WeightRampUp, &out.WeightRampUp
+		*out = new(OSDWeightRampUpSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -5099,6 +6656,11 @@ func (in *StorageScopeSpec) DeepCopyInto(out *StorageScopeSpec) {
 	}
 	out.Migration = in.Migration
 	out.Store = in.Store
+	if in.FlappingQuarantine != nil {
+		in, out := &in.FlappingQuarantine, &out.FlappingQuarantine
+		*out = new(OSDFlappingQuarantineSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FullRatio != nil {
 		in, out := &in.FullRatio, &out.FullRatio
 		*out = new(float64)
@@ -5114,6 +6676,18 @@ func (in *StorageScopeSpec) DeepCopyInto(out *StorageScopeSpec) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.OSDOverrides != nil {
+		in, out := &in.OSDOverrides, &out.OSDOverrides
+		*out = make(map[string]OSDOverride, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WeightRampUp != nil {
+		in, out := &in.WeightRampUp, &out.WeightRampUp
+		*out = new(OSDWeightRampUpSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -5181,6 +6755,57 @@ func (in *SwiftSpec) DeepCopy() *SwiftSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicDeliverySpec) DeepCopyInto(out *TopicDeliverySpec) {
+	*out = *in
+	if in.TimeToLive != nil {
+		in, out := &in.TimeToLive, &out.TimeToLive
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetrySleepDuration != nil {
+		in, out := &in.RetrySleepDuration, &out.RetrySleepDuration
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicDeliverySpec.
+func (in *TopicDeliverySpec) DeepCopy() *TopicDeliverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicDeliverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicDeliveryStatus) DeepCopyInto(out *TopicDeliveryStatus) {
+	*out = *in
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicDeliveryStatus.
+func (in *TopicDeliveryStatus) DeepCopy() *TopicDeliveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TopicDeliveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicEndpointSpec) DeepCopyInto(out *TopicEndpointSpec) {
 	*out = *in
@@ -41,6 +41,8 @@ type CephV1Interface interface {
 	CephNFSesGetter
 	CephObjectRealmsGetter
 	CephObjectStoresGetter
+	CephObjectStoreRolesGetter
+	CephObjectStoreScriptsGetter
 	CephObjectStoreUsersGetter
 	CephObjectZonesGetter
 	CephObjectZoneGroupsGetter
@@ -104,6 +106,14 @@ func (c *CephV1Client) CephObjectStores(namespace string) CephObjectStoreInterfa
 	return newCephObjectStores(c, namespace)
 }
 
+func (c *CephV1Client) CephObjectStoreRoles(namespace string) CephObjectStoreRoleInterface {
+	return newCephObjectStoreRoles(c, namespace)
+}
+
+func (c *CephV1Client) CephObjectStoreScripts(namespace string) CephObjectStoreScriptInterface {
+	return newCephObjectStoreScripts(c, namespace)
+}
+
 func (c *CephV1Client) CephObjectStoreUsers(namespace string) CephObjectStoreUserInterface {
 	return newCephObjectStoreUsers(c, namespace)
 }
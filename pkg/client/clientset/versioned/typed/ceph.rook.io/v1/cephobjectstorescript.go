@@ -0,0 +1,67 @@
+/*
+Copyright 2018 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+
+	v1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	scheme "github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	gentype "k8s.io/client-go/gentype"
+)
+
+// CephObjectStoreScriptsGetter has a method to return a CephObjectStoreScriptInterface.
+// A group's client should implement this interface.
+type CephObjectStoreScriptsGetter interface {
+	CephObjectStoreScripts(namespace string) CephObjectStoreScriptInterface
+}
+
+// CephObjectStoreScriptInterface has methods to work with CephObjectStoreScript resources.
+type CephObjectStoreScriptInterface interface {
+	Create(ctx context.Context, cephObjectStoreScript *v1.CephObjectStoreScript, opts metav1.CreateOptions) (*v1.CephObjectStoreScript, error)
+	Update(ctx context.Context, cephObjectStoreScript *v1.CephObjectStoreScript, opts metav1.UpdateOptions) (*v1.CephObjectStoreScript, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.CephObjectStoreScript, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.CephObjectStoreScriptList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.CephObjectStoreScript, err error)
+	CephObjectStoreScriptExpansion
+}
+
+// cephObjectStoreScripts implements CephObjectStoreScriptInterface
+type cephObjectStoreScripts struct {
+	*gentype.ClientWithList[*v1.CephObjectStoreScript, *v1.CephObjectStoreScriptList]
+}
+
+// newCephObjectStoreScripts returns a CephObjectStoreScripts
+func newCephObjectStoreScripts(c *CephV1Client, namespace string) *cephObjectStoreScripts {
+	return &cephObjectStoreScripts{
+		gentype.NewClientWithList[*v1.CephObjectStoreScript, *v1.CephObjectStoreScriptList](
+			"cephobjectstorescripts",
+			c.RESTClient(),
+			scheme.ParameterCodec,
+			namespace,
+			func() *v1.CephObjectStoreScript { return &v1.CephObjectStoreScript{} },
+			func() *v1.CephObjectStoreScriptList { return &v1.CephObjectStoreScriptList{} }),
+	}
+}
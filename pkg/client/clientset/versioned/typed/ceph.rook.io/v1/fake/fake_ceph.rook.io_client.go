@@ -80,6 +80,14 @@ func (c *FakeCephV1) CephObjectStores(namespace string) v1.CephObjectStoreInterf
 	return &FakeCephObjectStores{c, namespace}
 }
 
+func (c *FakeCephV1) CephObjectStoreRoles(namespace string) v1.CephObjectStoreRoleInterface {
+	return &FakeCephObjectStoreRoles{c, namespace}
+}
+
+func (c *FakeCephV1) CephObjectStoreScripts(namespace string) v1.CephObjectStoreScriptInterface {
+	return &FakeCephObjectStoreScripts{c, namespace}
+}
+
 func (c *FakeCephV1) CephObjectStoreUsers(namespace string) v1.CephObjectStoreUserInterface {
 	return &FakeCephObjectStoreUsers{c, namespace}
 }
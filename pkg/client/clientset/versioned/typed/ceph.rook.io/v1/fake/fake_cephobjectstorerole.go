@@ -0,0 +1,134 @@
+/*
+Copyright 2018 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCephObjectStoreRoles implements CephObjectStoreRoleInterface
+type FakeCephObjectStoreRoles struct {
+	Fake *FakeCephV1
+	ns   string
+}
+
+var cephobjectstorerolesResource = v1.SchemeGroupVersion.WithResource("cephobjectstoreroles")
+
+var cephobjectstorerolesKind = v1.SchemeGroupVersion.WithKind("CephObjectStoreRole")
+
+// Get takes name of the cephObjectStoreRole, and returns the corresponding cephObjectStoreRole object, and an error if there is any.
+func (c *FakeCephObjectStoreRoles) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.CephObjectStoreRole, err error) {
+	emptyResult := &v1.CephObjectStoreRole{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(cephobjectstorerolesResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreRole), err
+}
+
+// List takes label and field selectors, and returns the list of CephObjectStoreRoles that match those selectors.
+func (c *FakeCephObjectStoreRoles) List(ctx context.Context, opts metav1.ListOptions) (result *v1.CephObjectStoreRoleList, err error) {
+	emptyResult := &v1.CephObjectStoreRoleList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(cephobjectstorerolesResource, cephobjectstorerolesKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.CephObjectStoreRoleList{ListMeta: obj.(*v1.CephObjectStoreRoleList).ListMeta}
+	for _, item := range obj.(*v1.CephObjectStoreRoleList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cephObjectStoreRoles.
+func (c *FakeCephObjectStoreRoles) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(cephobjectstorerolesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cephObjectStoreRole and creates it.  Returns the server's representation of the cephObjectStoreRole, and an error, if there is any.
+func (c *FakeCephObjectStoreRoles) Create(ctx context.Context, cephObjectStoreRole *v1.CephObjectStoreRole, opts metav1.CreateOptions) (result *v1.CephObjectStoreRole, err error) {
+	emptyResult := &v1.CephObjectStoreRole{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(cephobjectstorerolesResource, c.ns, cephObjectStoreRole, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreRole), err
+}
+
+// Update takes the representation of a cephObjectStoreRole and updates it. Returns the server's representation of the cephObjectStoreRole, and an error, if there is any.
+func (c *FakeCephObjectStoreRoles) Update(ctx context.Context, cephObjectStoreRole *v1.CephObjectStoreRole, opts metav1.UpdateOptions) (result *v1.CephObjectStoreRole, err error) {
+	emptyResult := &v1.CephObjectStoreRole{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(cephobjectstorerolesResource, c.ns, cephObjectStoreRole, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreRole), err
+}
+
+// Delete takes name of the cephObjectStoreRole and deletes it. Returns an error if one occurs.
+func (c *FakeCephObjectStoreRoles) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(cephobjectstorerolesResource, c.ns, name, opts), &v1.CephObjectStoreRole{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCephObjectStoreRoles) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(cephobjectstorerolesResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.CephObjectStoreRoleList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cephObjectStoreRole.
+func (c *FakeCephObjectStoreRoles) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.CephObjectStoreRole, err error) {
+	emptyResult := &v1.CephObjectStoreRole{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(cephobjectstorerolesResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreRole), err
+}
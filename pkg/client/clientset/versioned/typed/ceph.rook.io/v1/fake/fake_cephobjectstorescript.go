@@ -0,0 +1,134 @@
+/*
+Copyright 2018 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeCephObjectStoreScripts implements CephObjectStoreScriptInterface
+type FakeCephObjectStoreScripts struct {
+	Fake *FakeCephV1
+	ns   string
+}
+
+var cephobjectstorescriptsResource = v1.SchemeGroupVersion.WithResource("cephobjectstorescripts")
+
+var cephobjectstorescriptsKind = v1.SchemeGroupVersion.WithKind("CephObjectStoreScript")
+
+// Get takes name of the cephObjectStoreScript, and returns the corresponding cephObjectStoreScript object, and an error if there is any.
+func (c *FakeCephObjectStoreScripts) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.CephObjectStoreScript, err error) {
+	emptyResult := &v1.CephObjectStoreScript{}
+	obj, err := c.Fake.
+		Invokes(testing.NewGetActionWithOptions(cephobjectstorescriptsResource, c.ns, name, options), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreScript), err
+}
+
+// List takes label and field selectors, and returns the list of CephObjectStoreScripts that match those selectors.
+func (c *FakeCephObjectStoreScripts) List(ctx context.Context, opts metav1.ListOptions) (result *v1.CephObjectStoreScriptList, err error) {
+	emptyResult := &v1.CephObjectStoreScriptList{}
+	obj, err := c.Fake.
+		Invokes(testing.NewListActionWithOptions(cephobjectstorescriptsResource, cephobjectstorescriptsKind, c.ns, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.CephObjectStoreScriptList{ListMeta: obj.(*v1.CephObjectStoreScriptList).ListMeta}
+	for _, item := range obj.(*v1.CephObjectStoreScriptList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested cephObjectStoreScripts.
+func (c *FakeCephObjectStoreScripts) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchActionWithOptions(cephobjectstorescriptsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a cephObjectStoreScript and creates it.  Returns the server's representation of the cephObjectStoreScript, and an error, if there is any.
+func (c *FakeCephObjectStoreScripts) Create(ctx context.Context, cephObjectStoreScript *v1.CephObjectStoreScript, opts metav1.CreateOptions) (result *v1.CephObjectStoreScript, err error) {
+	emptyResult := &v1.CephObjectStoreScript{}
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateActionWithOptions(cephobjectstorescriptsResource, c.ns, cephObjectStoreScript, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreScript), err
+}
+
+// Update takes the representation of a cephObjectStoreScript and updates it. Returns the server's representation of the cephObjectStoreScript, and an error, if there is any.
+func (c *FakeCephObjectStoreScripts) Update(ctx context.Context, cephObjectStoreScript *v1.CephObjectStoreScript, opts metav1.UpdateOptions) (result *v1.CephObjectStoreScript, err error) {
+	emptyResult := &v1.CephObjectStoreScript{}
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateActionWithOptions(cephobjectstorescriptsResource, c.ns, cephObjectStoreScript, opts), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreScript), err
+}
+
+// Delete takes name of the cephObjectStoreScript and deletes it. Returns an error if one occurs.
+func (c *FakeCephObjectStoreScripts) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(cephobjectstorescriptsResource, c.ns, name, opts), &v1.CephObjectStoreScript{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeCephObjectStoreScripts) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionActionWithOptions(cephobjectstorescriptsResource, c.ns, opts, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.CephObjectStoreScriptList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched cephObjectStoreScript.
+func (c *FakeCephObjectStoreScripts) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.CephObjectStoreScript, err error) {
+	emptyResult := &v1.CephObjectStoreScript{}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceActionWithOptions(cephobjectstorescriptsResource, c.ns, name, pt, data, opts, subresources...), emptyResult)
+
+	if obj == nil {
+		return emptyResult, err
+	}
+	return obj.(*v1.CephObjectStoreScript), err
+}
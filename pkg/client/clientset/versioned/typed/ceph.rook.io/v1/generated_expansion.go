@@ -44,6 +44,10 @@ type CephObjectRealmExpansion interface{}
 
 type CephObjectStoreExpansion interface{}
 
+type CephObjectStoreRoleExpansion interface{}
+
+type CephObjectStoreScriptExpansion interface{}
+
 type CephObjectStoreUserExpansion interface{}
 
 type CephObjectZoneExpansion interface{}
@@ -50,6 +50,10 @@ type Interface interface {
 	CephObjectRealms() CephObjectRealmInformer
 	// CephObjectStores returns a CephObjectStoreInformer.
 	CephObjectStores() CephObjectStoreInformer
+	// CephObjectStoreRoles returns a CephObjectStoreRoleInformer.
+	CephObjectStoreRoles() CephObjectStoreRoleInformer
+	// CephObjectStoreScripts returns a CephObjectStoreScriptInformer.
+	CephObjectStoreScripts() CephObjectStoreScriptInformer
 	// CephObjectStoreUsers returns a CephObjectStoreUserInformer.
 	CephObjectStoreUsers() CephObjectStoreUserInformer
 	// CephObjectZones returns a CephObjectZoneInformer.
@@ -136,6 +140,16 @@ func (v *version) CephObjectStores() CephObjectStoreInformer {
 	return &cephObjectStoreInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// CephObjectStoreRoles returns a CephObjectStoreRoleInformer.
+func (v *version) CephObjectStoreRoles() CephObjectStoreRoleInformer {
+	return &cephObjectStoreRoleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// CephObjectStoreScripts returns a CephObjectStoreScriptInformer.
+func (v *version) CephObjectStoreScripts() CephObjectStoreScriptInformer {
+	return &cephObjectStoreScriptInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // CephObjectStoreUsers returns a CephObjectStoreUserInformer.
 func (v *version) CephObjectStoreUsers() CephObjectStoreUserInformer {
 	return &cephObjectStoreUserInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CephObjectStoreRoleLister helps list CephObjectStoreRoles.
+// All objects returned here must be treated as read-only.
+type CephObjectStoreRoleLister interface {
+	// List lists all CephObjectStoreRoles in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.CephObjectStoreRole, err error)
+	// CephObjectStoreRoles returns an object that can list and get CephObjectStoreRoles.
+	CephObjectStoreRoles(namespace string) CephObjectStoreRoleNamespaceLister
+	CephObjectStoreRoleListerExpansion
+}
+
+// cephObjectStoreRoleLister implements the CephObjectStoreRoleLister interface.
+type cephObjectStoreRoleLister struct {
+	listers.ResourceIndexer[*v1.CephObjectStoreRole]
+}
+
+// NewCephObjectStoreRoleLister returns a new CephObjectStoreRoleLister.
+func NewCephObjectStoreRoleLister(indexer cache.Indexer) CephObjectStoreRoleLister {
+	return &cephObjectStoreRoleLister{listers.New[*v1.CephObjectStoreRole](indexer, v1.Resource("cephobjectstorerole"))}
+}
+
+// CephObjectStoreRoles returns an object that can list and get CephObjectStoreRoles.
+func (s *cephObjectStoreRoleLister) CephObjectStoreRoles(namespace string) CephObjectStoreRoleNamespaceLister {
+	return cephObjectStoreRoleNamespaceLister{listers.NewNamespaced[*v1.CephObjectStoreRole](s.ResourceIndexer, namespace)}
+}
+
+// CephObjectStoreRoleNamespaceLister helps list and get CephObjectStoreRoles.
+// All objects returned here must be treated as read-only.
+type CephObjectStoreRoleNamespaceLister interface {
+	// List lists all CephObjectStoreRoles in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.CephObjectStoreRole, err error)
+	// Get retrieves the CephObjectStoreRole from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.CephObjectStoreRole, error)
+	CephObjectStoreRoleNamespaceListerExpansion
+}
+
+// cephObjectStoreRoleNamespaceLister implements the CephObjectStoreRoleNamespaceLister
+// interface.
+type cephObjectStoreRoleNamespaceLister struct {
+	listers.ResourceIndexer[*v1.CephObjectStoreRole]
+}
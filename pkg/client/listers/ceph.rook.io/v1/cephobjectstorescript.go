@@ -0,0 +1,70 @@
+/*
+Copyright 2018 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/listers"
+	"k8s.io/client-go/tools/cache"
+)
+
+// CephObjectStoreScriptLister helps list CephObjectStoreScripts.
+// All objects returned here must be treated as read-only.
+type CephObjectStoreScriptLister interface {
+	// List lists all CephObjectStoreScripts in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.CephObjectStoreScript, err error)
+	// CephObjectStoreScripts returns an object that can list and get CephObjectStoreScripts.
+	CephObjectStoreScripts(namespace string) CephObjectStoreScriptNamespaceLister
+	CephObjectStoreScriptListerExpansion
+}
+
+// cephObjectStoreScriptLister implements the CephObjectStoreScriptLister interface.
+type cephObjectStoreScriptLister struct {
+	listers.ResourceIndexer[*v1.CephObjectStoreScript]
+}
+
+// NewCephObjectStoreScriptLister returns a new CephObjectStoreScriptLister.
+func NewCephObjectStoreScriptLister(indexer cache.Indexer) CephObjectStoreScriptLister {
+	return &cephObjectStoreScriptLister{listers.New[*v1.CephObjectStoreScript](indexer, v1.Resource("cephobjectstorescript"))}
+}
+
+// CephObjectStoreScripts returns an object that can list and get CephObjectStoreScripts.
+func (s *cephObjectStoreScriptLister) CephObjectStoreScripts(namespace string) CephObjectStoreScriptNamespaceLister {
+	return cephObjectStoreScriptNamespaceLister{listers.NewNamespaced[*v1.CephObjectStoreScript](s.ResourceIndexer, namespace)}
+}
+
+// CephObjectStoreScriptNamespaceLister helps list and get CephObjectStoreScripts.
+// All objects returned here must be treated as read-only.
+type CephObjectStoreScriptNamespaceLister interface {
+	// List lists all CephObjectStoreScripts in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.CephObjectStoreScript, err error)
+	// Get retrieves the CephObjectStoreScript from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.CephObjectStoreScript, error)
+	CephObjectStoreScriptNamespaceListerExpansion
+}
+
+// cephObjectStoreScriptNamespaceLister implements the CephObjectStoreScriptNamespaceLister
+// interface.
+type cephObjectStoreScriptNamespaceLister struct {
+	listers.ResourceIndexer[*v1.CephObjectStoreScript]
+}
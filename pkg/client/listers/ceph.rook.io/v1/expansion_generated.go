@@ -122,6 +122,22 @@ type CephObjectStoreListerExpansion interface{}
 // CephObjectStoreNamespaceLister.
 type CephObjectStoreNamespaceListerExpansion interface{}
 
+// CephObjectStoreRoleListerExpansion allows custom methods to be added to
+// CephObjectStoreRoleLister.
+type CephObjectStoreRoleListerExpansion interface{}
+
+// CephObjectStoreRoleNamespaceListerExpansion allows custom methods to be added to
+// CephObjectStoreRoleNamespaceLister.
+type CephObjectStoreRoleNamespaceListerExpansion interface{}
+
+// CephObjectStoreScriptListerExpansion allows custom methods to be added to
+// CephObjectStoreScriptLister.
+type CephObjectStoreScriptListerExpansion interface{}
+
+// CephObjectStoreScriptNamespaceListerExpansion allows custom methods to be added to
+// CephObjectStoreScriptNamespaceLister.
+type CephObjectStoreScriptNamespaceListerExpansion interface{}
+
 // CephObjectStoreUserListerExpansion allows custom methods to be added to
 // CephObjectStoreUserLister.
 type CephObjectStoreUserListerExpansion interface{}
@@ -0,0 +1,448 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// OrphanScanNamespace is the rados namespace of the log pool of the object store where the
+	// orphans found by a scan are stored until they are removed
+	OrphanScanNamespace = "rook-orphan-scan"
+
+	// the termination message of the job is limited to 4096 bytes, the buckets with the most stale
+	// index entries are reported
+	maxReportedBuckets = 20
+)
+
+// the report is written in the termination message of the job, which is read by the operator
+var terminationMessagePath = v1.TerminationMessagePathDefault
+
+// OrphanScanReport is the result of an orphan scan of an object store, or of the removal of the
+// orphans it found
+type OrphanScanReport struct {
+	ID                string `json:"id"`
+	Removed           bool   `json:"removed,omitempty"`
+	Orphans           int64  `json:"orphans"`
+	ReclaimableBytes  int64  `json:"reclaimableBytes"`
+	StaleIndexEntries int64  `json:"staleIndexEntries"`
+	// Pools are the orphans found in each data pool
+	Pools map[string]OrphanScanPoolReport `json:"pools,omitempty"`
+	// Buckets are the number of stale index entries of the buckets that have the most of them
+	Buckets map[string]int64 `json:"buckets,omitempty"`
+	// Location is the rados object listing all the orphans
+	Location string `json:"location,omitempty"`
+}
+
+// OrphanScanPoolReport is the result of an orphan scan for a data pool
+type OrphanScanPoolReport struct {
+	Orphans          int64 `json:"orphans"`
+	ReclaimableBytes int64 `json:"reclaimableBytes"`
+}
+
+// ObjectStoreOrphanScan scans the data pools of an object store for orphans
+type ObjectStoreOrphanScan struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	// ID of the scan
+	ID        string
+	Realm     string
+	ZoneGroup string
+	Zone      string
+	DataPools []string
+	LogPool   string
+}
+
+type rgwBucketCheck struct {
+	InvalidMultipartEntries []string `json:"invalid_multipart_entries"`
+}
+
+func NewObjectStoreOrphanScan(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, id, realm, zoneGroup, zone, logPool string, dataPools []string) *ObjectStoreOrphanScan {
+	return &ObjectStoreOrphanScan{
+		context:     context,
+		clusterInfo: clusterInfo,
+		ID:          id,
+		Realm:       realm,
+		ZoneGroup:   zoneGroup,
+		Zone:        zone,
+		DataPools:   dataPools,
+		LogPool:     logPool,
+	}
+}
+
+// Scan lists the RADOS objects of the data pools that are not referenced by any bucket with
+// rgw-orphan-list, and the stale entries of the bucket indexes with `radosgw-admin bucket check`.
+// The orphans are stored in the log pool of the object store so that the removal only removes the
+// orphans that were reported.
+func (s *ObjectStoreOrphanScan) Scan() (*OrphanScanReport, error) {
+	logger.Infof("starting orphan scan %q of data pools %v", s.ID, s.DataPools)
+	if err := s.removeScanObjects(); err != nil {
+		return nil, err
+	}
+
+	report := &OrphanScanReport{ID: s.ID, Pools: map[string]OrphanScanPoolReport{}}
+	var orphans []string
+	for _, pool := range s.DataPools {
+		objects, err := s.listOrphans(pool)
+		if err != nil {
+			return nil, err
+		}
+		poolReport := OrphanScanPoolReport{}
+		for _, object := range objects {
+			size, err := s.objectSize(pool, object)
+			if err != nil {
+				// the object may have been removed since it was listed
+				logger.Debugf("failed to get the size of orphan %q in pool %q. %v", object, pool, err)
+				continue
+			}
+			poolReport.Orphans++
+			poolReport.ReclaimableBytes += size
+			orphans = append(orphans, fmt.Sprintf("%s\t%s", pool, object))
+		}
+		report.Pools[pool] = poolReport
+		report.Orphans += poolReport.Orphans
+		report.ReclaimableBytes += poolReport.ReclaimableBytes
+		logger.Infof("found %d orphans of %d bytes in pool %q", poolReport.Orphans, poolReport.ReclaimableBytes, pool)
+	}
+
+	buckets, err := s.listBuckets()
+	if err != nil {
+		return nil, err
+	}
+	staleBuckets := map[string]int64{}
+	var staleBucketNames []string
+	for _, bucket := range buckets {
+		entries, err := s.checkBucket(bucket, false)
+		if err != nil {
+			return nil, err
+		}
+		if entries > 0 {
+			staleBuckets[bucket] = entries
+			staleBucketNames = append(staleBucketNames, bucket)
+			report.StaleIndexEntries += entries
+		}
+	}
+	report.Buckets = largestBuckets(staleBuckets, maxReportedBuckets)
+	logger.Infof("found %d stale index entries in %d buckets", report.StaleIndexEntries, len(staleBuckets))
+
+	if err := s.putScanObject(orphansObjectName(s.ID), orphans); err != nil {
+		return nil, err
+	}
+	if err := s.putScanObject(staleIndexObjectName(s.ID), staleBucketNames); err != nil {
+		return nil, err
+	}
+	report.Location = fmt.Sprintf("rados://%s/%s/%s", s.LogPool, OrphanScanNamespace, orphansObjectName(s.ID))
+
+	logger.Infof("successfully completed orphan scan %q", s.ID)
+	return report, nil
+}
+
+// Remove removes the orphans and fixes the bucket indexes reported by the scan
+func (s *ObjectStoreOrphanScan) Remove() (*OrphanScanReport, error) {
+	logger.Infof("removing the orphans of scan %q", s.ID)
+	orphans, err := s.getScanObject(orphansObjectName(s.ID))
+	if err != nil {
+		return nil, err
+	}
+	staleBuckets, err := s.getScanObject(staleIndexObjectName(s.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OrphanScanReport{ID: s.ID, Removed: true, Pools: map[string]OrphanScanPoolReport{}}
+	var retErr error
+	for _, line := range orphans {
+		pool, object, found := strings.Cut(line, "\t")
+		if !found {
+			continue
+		}
+		size, err := s.objectSize(pool, object)
+		if err != nil {
+			logger.Debugf("orphan %q in pool %q was already removed. %v", object, pool, err)
+			continue
+		}
+		if err := cephclient.RadosRemoveObject(s.context, s.clusterInfo, pool, "", object); err != nil {
+			retErr = errors.Wrapf(err, "failed to remove orphan %q in pool %q", object, pool)
+			logger.Error(retErr)
+			continue
+		}
+		poolReport := report.Pools[pool]
+		poolReport.Orphans++
+		poolReport.ReclaimableBytes += size
+		report.Pools[pool] = poolReport
+		report.Orphans++
+		report.ReclaimableBytes += size
+	}
+
+	fixedBuckets := map[string]int64{}
+	for _, bucket := range staleBuckets {
+		entries, err := s.checkBucket(bucket, true)
+		if err != nil {
+			retErr = err
+			logger.Error(retErr)
+			continue
+		}
+		fixedBuckets[bucket] = entries
+		report.StaleIndexEntries += entries
+	}
+	report.Buckets = largestBuckets(fixedBuckets, maxReportedBuckets)
+	if retErr != nil {
+		return nil, retErr
+	}
+
+	if err := s.removeScanObjects(); err != nil {
+		return nil, err
+	}
+	logger.Infof("successfully removed %d orphans of %d bytes and %d stale index entries of scan %q", report.Orphans, report.ReclaimableBytes, report.StaleIndexEntries, s.ID)
+	return report, nil
+}
+
+// WriteOrphanScanReport writes the report in the termination message of the job
+func WriteOrphanScanReport(report *OrphanScanReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the orphan scan report")
+	}
+	if err := os.WriteFile(terminationMessagePath, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the orphan scan report to %q", terminationMessagePath)
+	}
+	return nil
+}
+
+// cephArgs are the arguments for the tools run by rgw-orphan-list, so that they connect to the
+// cluster and use the zone of the object store instead of the default zone
+func (s *ObjectStoreOrphanScan) cephArgs() []string {
+	_, args := cephclient.FinalizeCephCommandArgs("radosgw-admin", s.clusterInfo, []string{
+		fmt.Sprintf("--rgw-realm=%s", s.Realm),
+		fmt.Sprintf("--rgw-zonegroup=%s", s.ZoneGroup),
+		fmt.Sprintf("--rgw-zone=%s", s.Zone),
+	}, s.context.ConfigDir)
+	return args
+}
+
+func (s *ObjectStoreOrphanScan) runAdminCommand(args ...string) (string, error) {
+	// scans can take hours on large object stores, the command is not run with a timeout
+	command, args := cephclient.FinalizeCephCommandArgs("radosgw-admin", s.clusterInfo, append(args,
+		fmt.Sprintf("--rgw-realm=%s", s.Realm),
+		fmt.Sprintf("--rgw-zonegroup=%s", s.ZoneGroup),
+		fmt.Sprintf("--rgw-zone=%s", s.Zone),
+	), s.context.ConfigDir)
+	return s.context.Executor.ExecuteCommandWithOutput(command, args...)
+}
+
+// listOrphans runs rgw-orphan-list on the pool. The tool writes the orphans to a file named
+// orphan-list-<timestamp>.out in the working directory.
+func (s *ObjectStoreOrphanScan) listOrphans(pool string) ([]string, error) {
+	dir, err := os.MkdirTemp("", "orphan-scan")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the orphan scan directory")
+	}
+	defer os.RemoveAll(dir)
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the working directory")
+	}
+	if err := os.Chdir(dir); err != nil {
+		return nil, errors.Wrapf(err, "failed to change to directory %q", dir)
+	}
+	defer func() {
+		if err := os.Chdir(wd); err != nil {
+			logger.Errorf("failed to change back to directory %q. %v", wd, err)
+		}
+	}()
+
+	env := append(os.Environ(), fmt.Sprintf("CEPH_ARGS=%s", strings.Join(s.cephArgs(), " ")))
+	if err := s.context.Executor.ExecuteCommandWithEnv(env, "rgw-orphan-list", pool); err != nil {
+		return nil, errors.Wrapf(err, "failed to list the orphans of pool %q", pool)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "orphan-list-*.out"))
+	if err != nil || len(files) == 0 {
+		return nil, errors.Errorf("failed to find the orphan list of pool %q", pool)
+	}
+	return readLines(files[0])
+}
+
+// objectSize returns the size of a rados object from `rados stat`, which prints
+// "<pool>/<object> mtime <time>, size <bytes>"
+func (s *ObjectStoreOrphanScan) objectSize(pool, object string) (int64, error) {
+	cmd := cephclient.NewRadosCommand(s.context, s.clusterInfo, []string{"--pool", pool, "stat", object})
+	output, err := cmd.Run()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to stat object %q in pool %q", object, pool)
+	}
+	_, size, found := strings.Cut(strings.TrimSpace(string(output)), ", size ")
+	if !found {
+		return 0, errors.Errorf("failed to parse the size of object %q in pool %q. %s", object, pool, string(output))
+	}
+	return strconv.ParseInt(size, 10, 64)
+}
+
+func (s *ObjectStoreOrphanScan) listBuckets() ([]string, error) {
+	output, err := s.runAdminCommand("bucket", "list")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list buckets")
+	}
+	var buckets []string
+	if err := json.Unmarshal([]byte(output), &buckets); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the bucket list. %s", output)
+	}
+	return buckets, nil
+}
+
+// checkBucket returns the number of index entries of failed multipart uploads of the bucket, and
+// removes them if fix is set
+func (s *ObjectStoreOrphanScan) checkBucket(bucket string, fix bool) (int64, error) {
+	args := []string{"bucket", "check", "--bucket", bucket}
+	if fix {
+		args = append(args, "--fix")
+	}
+	output, err := s.runAdminCommand(args...)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to check the index of bucket %q", bucket)
+	}
+	var check rgwBucketCheck
+	if err := json.Unmarshal([]byte(output), &check); err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the index check of bucket %q. %s", bucket, output)
+	}
+	return int64(len(check.InvalidMultipartEntries)), nil
+}
+
+func (s *ObjectStoreOrphanScan) putScanObject(name string, lines []string) error {
+	file, err := os.CreateTemp("", name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file for object %q", name)
+	}
+	defer os.Remove(file.Name())
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(file, line); err != nil {
+			file.Close()
+			return errors.Wrapf(err, "failed to write file for object %q", name)
+		}
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write file for object %q", name)
+	}
+
+	cmd := cephclient.NewRadosCommand(s.context, s.clusterInfo, []string{
+		"--pool", s.LogPool, "--namespace", OrphanScanNamespace, "put", name, file.Name(),
+	})
+	if _, err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "failed to write rados object rados://%s/%s/%s", s.LogPool, OrphanScanNamespace, name)
+	}
+	return nil
+}
+
+func (s *ObjectStoreOrphanScan) getScanObject(name string) ([]string, error) {
+	dir, err := os.MkdirTemp("", "orphan-scan")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the orphan scan directory")
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, name)
+
+	cmd := cephclient.NewRadosCommand(s.context, s.clusterInfo, []string{
+		"--pool", s.LogPool, "--namespace", OrphanScanNamespace, "get", name, path,
+	})
+	if _, err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read rados object rados://%s/%s/%s", s.LogPool, OrphanScanNamespace, name)
+	}
+	return readLines(path)
+}
+
+// removeScanObjects removes the orphans stored by the previous scans
+func (s *ObjectStoreOrphanScan) removeScanObjects() error {
+	cmd := cephclient.NewRadosCommand(s.context, s.clusterInfo, []string{
+		"--pool", s.LogPool, "--namespace", OrphanScanNamespace, "ls",
+	})
+	output, err := cmd.Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the objects of previous scans in pool %q", s.LogPool)
+	}
+	for _, name := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if name == "" {
+			continue
+		}
+		if err := cephclient.RadosRemoveObject(s.context, s.clusterInfo, s.LogPool, OrphanScanNamespace, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func orphansObjectName(id string) string {
+	return fmt.Sprintf("orphans.%s", id)
+}
+
+func staleIndexObjectName(id string) string {
+	return fmt.Sprintf("stale-index.%s", id)
+}
+
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %q", path)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %q", path)
+	}
+	return lines, nil
+}
+
+// largestBuckets returns the buckets with the most stale index entries
+func largestBuckets(buckets map[string]int64, max int) map[string]int64 {
+	if len(buckets) <= max {
+		return buckets
+	}
+	names := make([]string, 0, len(buckets))
+	for name := range buckets {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if buckets[names[i]] == buckets[names[j]] {
+			return names[i] < names[j]
+		}
+		return buckets[names[i]] > buckets[names[j]]
+	})
+	largest := map[string]int64{}
+	for _, name := range names[:max] {
+		largest[name] = buckets[name]
+	}
+	return largest
+}
@@ -0,0 +1,814 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/util/exec"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// MigrationNamespace is the rados namespace of the log pool of the object store where the
+	// policies of the buckets are saved while their writes are denied during their migration
+	MigrationNamespace = "rook-migration"
+	// MigrationBucketPrefix is the prefix of the bucket the objects of a bucket are copied to
+	MigrationBucketPrefix = "rook-migration-"
+	// MigratedBucketPrefix is the prefix of a migrated bucket once it is replaced, until it is removed
+	MigratedBucketPrefix = "rook-migrated-"
+
+	// the statement of the bucket policy denying the writes to a bucket while it is migrated
+	migrationDenySid = "rook-migration-deny-writes"
+	// the objects larger than the maximum size of a copy are copied in parts
+	maxSingleCopySize = 5 << 30
+	copyPartSize      = 512 << 20
+)
+
+// S3ClientFunc returns a client of the S3 API of the object store authenticated with the keys
+type S3ClientFunc func(accessKey, secretKey string) (s3iface.S3API, error)
+
+// BucketMigrationReport is the result of the migration of a bucket to another placement, which is
+// written in the termination message of the job
+type BucketMigrationReport struct {
+	Bucket string `json:"bucket"`
+	// Skipped is set when the bucket was already in the target placement
+	Skipped       bool  `json:"skipped,omitempty"`
+	CopiedObjects int64 `json:"copiedObjects"`
+	CopiedBytes   int64 `json:"copiedBytes"`
+}
+
+// BucketMigration moves a bucket of an object store to another placement. The objects of the bucket
+// are copied to a new bucket created in the placement, which then replaces the bucket. The writes
+// to the bucket are denied by its policy while its objects are copied.
+type BucketMigration struct {
+	context     *clusterd.Context
+	clusterInfo *cephclient.ClusterInfo
+	newS3Client S3ClientFunc
+	Realm       string
+	ZoneGroup   string
+	Zone        string
+	LogPool     string
+	// TargetPlacement is the placement the bucket is moved to
+	TargetPlacement string
+	// Concurrency is the number of objects copied in parallel
+	Concurrency int
+	// MaxObjectsPerSecond limits the rate of the copies when it is positive
+	MaxObjectsPerSecond int
+}
+
+type rgwBucketStats struct {
+	Owner         string `json:"owner"`
+	PlacementRule string `json:"placement_rule"`
+	BucketQuota   struct {
+		Enabled    bool  `json:"enabled"`
+		MaxSize    int64 `json:"max_size"`
+		MaxObjects int64 `json:"max_objects"`
+	} `json:"bucket_quota"`
+}
+
+type rgwUserInfo struct {
+	Keys []struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+	} `json:"keys"`
+}
+
+// bucketPolicy is the part of a bucket policy read to find the statement denying the writes
+type bucketPolicy struct {
+	Statement []struct {
+		Sid string `json:"Sid"`
+	} `json:"Statement"`
+}
+
+func NewBucketMigration(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, realm, zoneGroup, zone, logPool, targetPlacement string,
+	concurrency, maxObjectsPerSecond int, newS3Client S3ClientFunc) *BucketMigration {
+	return &BucketMigration{
+		context:             context,
+		clusterInfo:         clusterInfo,
+		newS3Client:         newS3Client,
+		Realm:               realm,
+		ZoneGroup:           zoneGroup,
+		Zone:                zone,
+		LogPool:             logPool,
+		TargetPlacement:     targetPlacement,
+		Concurrency:         concurrency,
+		MaxObjectsPerSecond: maxObjectsPerSecond,
+	}
+}
+
+// Migrate moves the bucket to the target placement. A bucket is given as "<tenant>/<name>" when it
+// belongs to a tenant. The migration resumes where it stopped when the job was interrupted.
+func (m *BucketMigration) Migrate(bucket string) (*BucketMigrationReport, error) {
+	report := &BucketMigrationReport{Bucket: bucket}
+	tenant, name := splitBucketTenant(bucket)
+	newBucket, oldBucket := migrationBucketNames(tenant, name)
+
+	stats, found, err := m.bucketStats(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		// the job was interrupted between the renames of the buckets
+		stats, found, err = m.bucketStats(newBucket)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.Errorf("bucket %q not found", bucket)
+		}
+		if err := m.renameBucket(newBucket, name, stats.Owner); err != nil {
+			return nil, err
+		}
+	}
+	if inPlacement(stats.PlacementRule, m.TargetPlacement) {
+		completed, err := m.completeMigration(bucket, oldBucket, stats.Owner)
+		if err != nil {
+			return nil, err
+		}
+		report.Skipped = !completed
+		return report, nil
+	}
+
+	client, err := m.ownerClient(stats.Owner)
+	if err != nil {
+		return nil, err
+	}
+	versioning, err := client.GetBucketVersioning(&s3.GetBucketVersioningInput{Bucket: aws.String(name)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the versioning of bucket %q", bucket)
+	}
+	if aws.StringValue(versioning.Status) != "" {
+		return nil, errors.Errorf("bucket %q is versioned, only the buckets that were never versioned can be migrated", bucket)
+	}
+
+	logger.Infof("denying the writes to bucket %q while its objects are copied to placement %q", bucket, m.TargetPlacement)
+	if err := m.denyWrites(client, tenant, name); err != nil {
+		return nil, err
+	}
+	if err := m.copyBucket(client, stats, tenant, name, newBucket, report); err != nil {
+		// the writes are allowed again and the copies are removed, the bucket is unchanged
+		m.abortMigration(client, bucket, name, newBucket)
+		return nil, err
+	}
+
+	// the bucket is replaced by the new bucket, which keeps its name
+	if err := m.renameBucket(bucket, bucketName(oldBucket), stats.Owner); err != nil {
+		m.abortMigration(client, bucket, name, newBucket)
+		return nil, err
+	}
+	if err := m.renameBucket(newBucket, name, stats.Owner); err != nil {
+		return nil, err
+	}
+	if _, err := m.completeMigration(bucket, oldBucket, stats.Owner); err != nil {
+		return nil, err
+	}
+	logger.Infof("migrated bucket %q to placement %q with %d objects of %d bytes", bucket, m.TargetPlacement, report.CopiedObjects, report.CopiedBytes)
+	return report, nil
+}
+
+// copyBucket creates the new bucket in the target placement and copies the objects and the
+// settings of the bucket to it
+func (m *BucketMigration) copyBucket(client s3iface.S3API, stats *rgwBucketStats, tenant, name, newBucket string, report *BucketMigrationReport) error {
+	newName := bucketName(newBucket)
+	_, err := client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(newName),
+		// rgw reads the location constraint as "<zonegroup>:<placement>"
+		CreateBucketConfiguration: &s3.CreateBucketConfiguration{LocationConstraint: aws.String(":" + m.TargetPlacement)},
+	})
+	if err != nil && !isS3ErrorCode(err, s3.ErrCodeBucketAlreadyOwnedByYou) {
+		return errors.Wrapf(err, "failed to create bucket %q in placement %q", newBucket, m.TargetPlacement)
+	}
+
+	// the objects copied before the job was interrupted are not copied again
+	copied, err := listObjectETags(client, newName)
+	if err != nil {
+		return err
+	}
+	count, err := m.copyObjects(client, name, newName, copied, report)
+	if err != nil {
+		return err
+	}
+	// the writes are denied, the new bucket must have all the objects of the bucket
+	copied, err = listObjectETags(client, newName)
+	if err != nil {
+		return err
+	}
+	if int64(len(copied)) != count {
+		return errors.Errorf("bucket %q has %d objects after the copy of the %d objects of bucket %q", newBucket, len(copied), count, withTenant(tenant, name))
+	}
+
+	if err := copyBucketSettings(client, name, newName); err != nil {
+		return err
+	}
+	if stats.BucketQuota.Enabled {
+		if _, err := m.runAdminCommand("quota", "set", "--quota-scope=bucket", "--bucket", newBucket,
+			fmt.Sprintf("--max-objects=%d", stats.BucketQuota.MaxObjects), fmt.Sprintf("--max-size=%d", stats.BucketQuota.MaxSize)); err != nil {
+			return errors.Wrapf(err, "failed to set the quota of bucket %q", newBucket)
+		}
+		if _, err := m.runAdminCommand("quota", "enable", "--quota-scope=bucket", "--bucket", newBucket); err != nil {
+			return errors.Wrapf(err, "failed to enable the quota of bucket %q", newBucket)
+		}
+	}
+	return nil
+}
+
+// copyObjects copies the objects of the bucket that are not in the new bucket yet with the
+// concurrency and the rate of the migration, and returns the number of objects of the bucket
+func (m *BucketMigration) copyObjects(client s3iface.S3API, name, newName string, copied map[string]string, report *BucketMigrationReport) (int64, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	limiter := rate.NewLimiter(rate.Inf, 1)
+	if m.MaxObjectsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(m.MaxObjectsPerSecond), 1)
+	}
+	concurrency := m.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	objects := make(chan *s3.Object)
+	var copiedObjects, copiedBytes int64
+	var copyErr error
+	var errOnce sync.Once
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for object := range objects {
+				if err := limiter.Wait(ctx); err != nil {
+					continue
+				}
+				if err := copyObject(ctx, client, name, newName, object); err != nil {
+					errOnce.Do(func() {
+						copyErr = err
+						cancel()
+					})
+					continue
+				}
+				atomic.AddInt64(&copiedObjects, 1)
+				atomic.AddInt64(&copiedBytes, aws.Int64Value(object.Size))
+			}
+		}()
+	}
+
+	var count int64
+	listErr := client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{Bucket: aws.String(name)},
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, object := range page.Contents {
+				count++
+				if etag, ok := copied[aws.StringValue(object.Key)]; ok && etag == aws.StringValue(object.ETag) {
+					continue
+				}
+				select {
+				case objects <- object:
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		})
+	close(objects)
+	wg.Wait()
+
+	report.CopiedObjects += copiedObjects
+	report.CopiedBytes += copiedBytes
+	if copyErr != nil {
+		return 0, copyErr
+	}
+	if listErr != nil {
+		return 0, errors.Wrapf(listErr, "failed to list the objects of bucket %q", name)
+	}
+	return count, nil
+}
+
+// copyObject copies an object with its metadata, tags and ACL
+func copyObject(ctx context.Context, client s3iface.S3API, name, newName string, object *s3.Object) error {
+	key := aws.StringValue(object.Key)
+	source := (&url.URL{Path: name + "/" + key}).EscapedPath()
+	var storageClass *string
+	if class := aws.StringValue(object.StorageClass); class != "" && class != s3.StorageClassStandard {
+		storageClass = object.StorageClass
+	}
+
+	if aws.Int64Value(object.Size) <= maxSingleCopySize {
+		_, err := client.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+			Bucket:            aws.String(newName),
+			Key:               object.Key,
+			CopySource:        aws.String(source),
+			MetadataDirective: aws.String(s3.MetadataDirectiveCopy),
+			TaggingDirective:  aws.String(s3.TaggingDirectiveCopy),
+			StorageClass:      storageClass,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to copy object %q of bucket %q", key, name)
+		}
+	} else if err := copyLargeObject(ctx, client, name, newName, source, object, storageClass); err != nil {
+		return err
+	}
+
+	acl, err := client.GetObjectAclWithContext(ctx, &s3.GetObjectAclInput{Bucket: aws.String(name), Key: object.Key})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the acl of object %q of bucket %q", key, name)
+	}
+	_, err = client.PutObjectAclWithContext(ctx, &s3.PutObjectAclInput{
+		Bucket:              aws.String(newName),
+		Key:                 object.Key,
+		AccessControlPolicy: &s3.AccessControlPolicy{Owner: acl.Owner, Grants: acl.Grants},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to set the acl of object %q of bucket %q", key, newName)
+	}
+	return nil
+}
+
+// copyLargeObject copies an object larger than the maximum size of a copy with a multipart upload.
+// The metadata and the tags of the object are not copied by the upload, they are set explicitly.
+func copyLargeObject(ctx context.Context, client s3iface.S3API, name, newName, source string, object *s3.Object, storageClass *string) error {
+	key := aws.StringValue(object.Key)
+	head, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: aws.String(name), Key: object.Key})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the metadata of object %q of bucket %q", key, name)
+	}
+	tags, err := client.GetObjectTaggingWithContext(ctx, &s3.GetObjectTaggingInput{Bucket: aws.String(name), Key: object.Key})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the tags of object %q of bucket %q", key, name)
+	}
+	tagging := url.Values{}
+	for _, tag := range tags.TagSet {
+		tagging.Add(aws.StringValue(tag.Key), aws.StringValue(tag.Value))
+	}
+
+	upload, err := client.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:             aws.String(newName),
+		Key:                object.Key,
+		CacheControl:       head.CacheControl,
+		ContentDisposition: head.ContentDisposition,
+		ContentEncoding:    head.ContentEncoding,
+		ContentLanguage:    head.ContentLanguage,
+		ContentType:        head.ContentType,
+		Metadata:           head.Metadata,
+		StorageClass:       storageClass,
+		Tagging:            aws.String(tagging.Encode()),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to start the copy of object %q of bucket %q", key, name)
+	}
+
+	size := aws.Int64Value(head.ContentLength)
+	parts := []*s3.CompletedPart{}
+	for start := int64(0); start < size; start += copyPartSize {
+		end := min(start+copyPartSize, size) - 1
+		partNumber := int64(len(parts) + 1)
+		part, err := client.UploadPartCopyWithContext(ctx, &s3.UploadPartCopyInput{
+			Bucket:          aws.String(newName),
+			Key:             object.Key,
+			CopySource:      aws.String(source),
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", start, end)),
+			PartNumber:      aws.Int64(partNumber),
+			UploadId:        upload.UploadId,
+		})
+		if err != nil {
+			abortUpload(client, newName, object.Key, upload.UploadId)
+			return errors.Wrapf(err, "failed to copy part %d of object %q of bucket %q", partNumber, key, name)
+		}
+		parts = append(parts, &s3.CompletedPart{ETag: part.CopyPartResult.ETag, PartNumber: aws.Int64(partNumber)})
+	}
+
+	_, err = client.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(newName),
+		Key:             object.Key,
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		abortUpload(client, newName, object.Key, upload.UploadId)
+		return errors.Wrapf(err, "failed to complete the copy of object %q of bucket %q", key, name)
+	}
+	return nil
+}
+
+func abortUpload(client s3iface.S3API, bucket string, key, uploadID *string) {
+	_, err := client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{Bucket: aws.String(bucket), Key: key, UploadId: uploadID})
+	if err != nil {
+		logger.Warningf("failed to abort the copy of object %q to bucket %q. %v", aws.StringValue(key), bucket, err)
+	}
+}
+
+// copyBucketSettings copies the ACL, CORS, lifecycle, tags, encryption and notifications of the
+// bucket to the new bucket. The policy is restored once the bucket is replaced.
+func copyBucketSettings(client s3iface.S3API, name, newName string) error {
+	bucket, newBucket := aws.String(name), aws.String(newName)
+
+	acl, err := client.GetBucketAcl(&s3.GetBucketAclInput{Bucket: bucket})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the acl of bucket %q", name)
+	}
+	if _, err := client.PutBucketAcl(&s3.PutBucketAclInput{Bucket: newBucket, AccessControlPolicy: &s3.AccessControlPolicy{Owner: acl.Owner, Grants: acl.Grants}}); err != nil {
+		return errors.Wrapf(err, "failed to set the acl of bucket %q", newName)
+	}
+
+	cors, err := client.GetBucketCors(&s3.GetBucketCorsInput{Bucket: bucket})
+	if err == nil {
+		_, err = client.PutBucketCors(&s3.PutBucketCorsInput{Bucket: newBucket, CORSConfiguration: &s3.CORSConfiguration{CORSRules: cors.CORSRules}})
+	}
+	if err != nil && !isS3ErrorCode(err, "NoSuchCORSConfiguration") {
+		return errors.Wrapf(err, "failed to copy the cors configuration of bucket %q", name)
+	}
+
+	lifecycle, err := client.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{Bucket: bucket})
+	if err == nil {
+		_, err = client.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+			Bucket: newBucket, LifecycleConfiguration: &s3.BucketLifecycleConfiguration{Rules: lifecycle.Rules},
+		})
+	}
+	if err != nil && !isS3ErrorCode(err, "NoSuchLifecycleConfiguration") {
+		return errors.Wrapf(err, "failed to copy the lifecycle configuration of bucket %q", name)
+	}
+
+	tags, err := client.GetBucketTagging(&s3.GetBucketTaggingInput{Bucket: bucket})
+	if err == nil {
+		_, err = client.PutBucketTagging(&s3.PutBucketTaggingInput{Bucket: newBucket, Tagging: &s3.Tagging{TagSet: tags.TagSet}})
+	}
+	if err != nil && !isS3ErrorCode(err, "NoSuchTagSet", "NoSuchTagSetError") {
+		return errors.Wrapf(err, "failed to copy the tags of bucket %q", name)
+	}
+
+	encryption, err := client.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: bucket})
+	if err == nil {
+		_, err = client.PutBucketEncryption(&s3.PutBucketEncryptionInput{Bucket: newBucket, ServerSideEncryptionConfiguration: encryption.ServerSideEncryptionConfiguration})
+	}
+	if err != nil && !isS3ErrorCode(err, "ServerSideEncryptionConfigurationNotFoundError") {
+		return errors.Wrapf(err, "failed to copy the encryption of bucket %q", name)
+	}
+
+	notifications, err := client.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{Bucket: bucket})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the notifications of bucket %q", name)
+	}
+	if len(notifications.TopicConfigurations) > 0 {
+		_, err = client.PutBucketNotificationConfiguration(&s3.PutBucketNotificationConfigurationInput{Bucket: newBucket, NotificationConfiguration: notifications})
+		if err != nil {
+			return errors.Wrapf(err, "failed to set the notifications of bucket %q", newName)
+		}
+	}
+	return nil
+}
+
+// denyWrites saves the policy of the bucket and replaces it with a policy denying the writes to the
+// objects of the bucket. The policy is not saved again when the writes are already denied.
+func (m *BucketMigration) denyWrites(client s3iface.S3API, tenant, name string) error {
+	bucket := withTenant(tenant, name)
+	policy, err := getBucketPolicy(client, name)
+	if err != nil {
+		return err
+	}
+	if !isDenyWritesPolicy(policy) {
+		if err := m.putMigrationObject(m.policyObjectName(bucket), policy); err != nil {
+			return err
+		}
+	}
+
+	resource := fmt.Sprintf("arn:aws:s3:::%s/*", name)
+	if tenant != "" {
+		resource = fmt.Sprintf("arn:aws:s3::%s:%s/*", tenant, name)
+	}
+	deny, err := json.Marshal(map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{{
+			"Sid":       migrationDenySid,
+			"Effect":    "Deny",
+			"Principal": map[string][]string{"AWS": {"*"}},
+			"Action": []string{
+				"s3:PutObject", "s3:DeleteObject", "s3:PutObjectAcl", "s3:PutObjectTagging", "s3:DeleteObjectTagging",
+				"s3:PutObjectRetention", "s3:PutObjectLegalHold", "s3:AbortMultipartUpload",
+			},
+			"Resource": []string{resource},
+		}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the policy denying the writes")
+	}
+	if _, err := client.PutBucketPolicy(&s3.PutBucketPolicyInput{Bucket: aws.String(name), Policy: aws.String(string(deny))}); err != nil {
+		return errors.Wrapf(err, "failed to deny the writes to bucket %q", bucket)
+	}
+	return nil
+}
+
+// restorePolicy sets the policy saved when the writes to the bucket were denied, and returns false
+// if no policy was saved
+func (m *BucketMigration) restorePolicy(client s3iface.S3API, bucket string) (bool, error) {
+	objectName := m.policyObjectName(bucket)
+	policy, found, err := m.getMigrationObject(objectName)
+	if err != nil || !found {
+		return false, err
+	}
+	_, name := splitBucketTenant(bucket)
+	if policy == "" {
+		_, err = client.DeleteBucketPolicy(&s3.DeleteBucketPolicyInput{Bucket: aws.String(name)})
+	} else {
+		_, err = client.PutBucketPolicy(&s3.PutBucketPolicyInput{Bucket: aws.String(name), Policy: aws.String(policy)})
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to restore the policy of bucket %q", bucket)
+	}
+	if err := cephclient.RadosRemoveObject(m.context, m.clusterInfo, m.LogPool, MigrationNamespace, objectName); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// abortMigration allows the writes to the bucket again and removes the new bucket after a failure
+func (m *BucketMigration) abortMigration(client s3iface.S3API, bucket, name, newBucket string) {
+	if _, err := m.restorePolicy(client, bucket); err != nil {
+		logger.Errorf("failed to allow the writes to bucket %q again. %v", bucket, err)
+	}
+	if err := m.removeBucket(newBucket); err != nil {
+		logger.Errorf("failed to remove bucket %q of the failed migration of bucket %q. %v", newBucket, bucket, err)
+	}
+}
+
+// completeMigration restores the policy of the migrated bucket and removes the old bucket. It
+// returns false when there was nothing to complete.
+func (m *BucketMigration) completeMigration(bucket, oldBucket, owner string) (bool, error) {
+	_, saved, err := m.getMigrationObject(m.policyObjectName(bucket))
+	if err != nil {
+		return false, err
+	}
+	if saved {
+		client, err := m.ownerClient(owner)
+		if err != nil {
+			return false, err
+		}
+		if _, err := m.restorePolicy(client, bucket); err != nil {
+			return false, err
+		}
+	}
+
+	_, oldFound, err := m.bucketStats(oldBucket)
+	if err != nil {
+		return false, err
+	}
+	if oldFound {
+		if err := m.removeBucket(oldBucket); err != nil {
+			return false, err
+		}
+	}
+	return saved || oldFound, nil
+}
+
+// ownerClient returns an S3 client authenticated with the keys of the owner of a bucket
+func (m *BucketMigration) ownerClient(owner string) (s3iface.S3API, error) {
+	output, err := m.runAdminCommand("user", "info", "--uid", owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the keys of user %q", owner)
+	}
+	info := rgwUserInfo{}
+	if err := json.Unmarshal([]byte(output), &info); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the info of user %q. %s", owner, output)
+	}
+	if len(info.Keys) == 0 {
+		return nil, errors.Errorf("user %q has no s3 keys to copy its buckets", owner)
+	}
+	client, err := m.newS3Client(info.Keys[0].AccessKey, info.Keys[0].SecretKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the s3 client of user %q", owner)
+	}
+	return client, nil
+}
+
+// bucketStats returns the stats of the bucket, and false if it does not exist
+func (m *BucketMigration) bucketStats(bucket string) (*rgwBucketStats, bool, error) {
+	output, err := m.runAdminCommand("bucket", "stats", "--bucket", bucket)
+	if err != nil {
+		// ENOENT means "No such file or directory"
+		if code, ok := exec.ExitStatus(err); ok && code == int(syscall.ENOENT) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrapf(err, "failed to get the stats of bucket %q", bucket)
+	}
+	stats := &rgwBucketStats{}
+	if err := json.Unmarshal([]byte(output), stats); err != nil {
+		return nil, false, errors.Wrapf(err, "failed to parse the stats of bucket %q. %s", bucket, output)
+	}
+	return stats, true, nil
+}
+
+func (m *BucketMigration) renameBucket(bucket, newName, owner string) error {
+	if _, err := m.runAdminCommand("bucket", "link", "--bucket", bucket, "--bucket-new-name", newName, "--uid", owner); err != nil {
+		return errors.Wrapf(err, "failed to rename bucket %q to %q", bucket, newName)
+	}
+	logger.Infof("renamed bucket %q to %q", bucket, newName)
+	return nil
+}
+
+func (m *BucketMigration) removeBucket(bucket string) error {
+	if _, err := m.runAdminCommand("bucket", "rm", "--bucket", bucket, "--purge-objects"); err != nil {
+		if code, ok := exec.ExitStatus(err); ok && code == int(syscall.ENOENT) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to remove bucket %q", bucket)
+	}
+	logger.Infof("removed bucket %q", bucket)
+	return nil
+}
+
+func (m *BucketMigration) runAdminCommand(args ...string) (string, error) {
+	// the removal of a large bucket can take hours, the command is not run with a timeout
+	command, args := cephclient.FinalizeCephCommandArgs("radosgw-admin", m.clusterInfo, append(args,
+		fmt.Sprintf("--rgw-realm=%s", m.Realm),
+		fmt.Sprintf("--rgw-zonegroup=%s", m.ZoneGroup),
+		fmt.Sprintf("--rgw-zone=%s", m.Zone),
+	), m.context.ConfigDir)
+	return m.context.Executor.ExecuteCommandWithOutput(command, args...)
+}
+
+func (m *BucketMigration) putMigrationObject(name, content string) error {
+	file, err := os.CreateTemp("", "migration")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file for object %q", name)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "failed to write file for object %q", name)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write file for object %q", name)
+	}
+
+	cmd := cephclient.NewRadosCommand(m.context, m.clusterInfo, []string{
+		"--pool", m.LogPool, "--namespace", MigrationNamespace, "put", name, file.Name(),
+	})
+	if _, err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "failed to write rados object rados://%s/%s/%s", m.LogPool, MigrationNamespace, name)
+	}
+	return nil
+}
+
+// getMigrationObject returns the content of the rados object, and false if it does not exist
+func (m *BucketMigration) getMigrationObject(name string) (string, bool, error) {
+	cmd := cephclient.NewRadosCommand(m.context, m.clusterInfo, []string{
+		"--pool", m.LogPool, "--namespace", MigrationNamespace, "stat", name,
+	})
+	if _, err := cmd.Run(); err != nil {
+		// like for the removal of rados objects, an error means the object does not exist
+		logger.Debugf("rados object rados://%s/%s/%s is assumed to not exist. %v", m.LogPool, MigrationNamespace, name, err)
+		return "", false, nil
+	}
+
+	dir, err := os.MkdirTemp("", "migration")
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to create the migration directory")
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "object")
+	cmd = cephclient.NewRadosCommand(m.context, m.clusterInfo, []string{
+		"--pool", m.LogPool, "--namespace", MigrationNamespace, "get", name, path,
+	})
+	if _, err := cmd.Run(); err != nil {
+		return "", false, errors.Wrapf(err, "failed to read rados object rados://%s/%s/%s", m.LogPool, MigrationNamespace, name)
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", false, errors.Wrapf(err, "failed to read rados object rados://%s/%s/%s", m.LogPool, MigrationNamespace, name)
+	}
+	return string(content), true, nil
+}
+
+// WriteBucketMigrationReport writes the report in the termination message of the job
+func WriteBucketMigrationReport(report *BucketMigrationReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize the bucket migration report")
+	}
+	if err := os.WriteFile(terminationMessagePath, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the bucket migration report to %q", terminationMessagePath)
+	}
+	return nil
+}
+
+func getBucketPolicy(client s3iface.S3API, name string) (string, error) {
+	output, err := client.GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: aws.String(name)})
+	if err != nil {
+		if isS3ErrorCode(err, "NoSuchBucketPolicy") {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get the policy of bucket %q", name)
+	}
+	return aws.StringValue(output.Policy), nil
+}
+
+func isDenyWritesPolicy(policy string) bool {
+	p := bucketPolicy{}
+	if policy == "" || json.Unmarshal([]byte(policy), &p) != nil {
+		return false
+	}
+	for _, statement := range p.Statement {
+		if statement.Sid == migrationDenySid {
+			return true
+		}
+	}
+	return false
+}
+
+// listObjectETags returns the ETag of the objects of the bucket by key
+func listObjectETags(client s3iface.S3API, name string) (map[string]string, error) {
+	etags := map[string]string{}
+	err := client.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: aws.String(name)}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			etags[aws.StringValue(object.Key)] = aws.StringValue(object.ETag)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the objects of bucket %q", name)
+	}
+	return etags, nil
+}
+
+func isS3ErrorCode(err error, codes ...string) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+	for _, code := range codes {
+		if awsErr.Code() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// inPlacement returns whether the placement rule of a bucket, "<placement>[/<storage class>]", is
+// in the placement
+func inPlacement(placementRule, placement string) bool {
+	return placementRule == placement || strings.HasPrefix(placementRule, placement+"/")
+}
+
+// migrationBucketNames returns the name of the bucket the objects of a bucket are copied to, and
+// the name of the bucket once it is replaced. They are derived from the bucket name so that an
+// interrupted migration is resumed.
+func migrationBucketNames(tenant, name string) (string, string) {
+	hash := sha256.Sum256([]byte(withTenant(tenant, name)))
+	suffix := hex.EncodeToString(hash[:8])
+	return withTenant(tenant, MigrationBucketPrefix+suffix), withTenant(tenant, MigratedBucketPrefix+suffix)
+}
+
+// policyObjectName returns the name of the rados object of the saved policy of the bucket. The pool
+// can be shared by the zones of several object stores.
+func (m *BucketMigration) policyObjectName(bucket string) string {
+	return fmt.Sprintf("policy.%s.%s", m.Zone, bucket)
+}
+
+func splitBucketTenant(bucket string) (string, string) {
+	if tenant, name, found := strings.Cut(bucket, "/"); found {
+		return tenant, name
+	}
+	return "", bucket
+}
+
+func withTenant(tenant, name string) string {
+	if tenant == "" {
+		return name
+	}
+	return tenant + "/" + name
+}
+
+func bucketName(bucket string) string {
+	_, name := splitBucketTenant(bucket)
+	return name
+}
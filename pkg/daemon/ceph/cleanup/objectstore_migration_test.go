@@ -0,0 +1,395 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBucket struct {
+	placement string
+	policy    string
+	versioned bool
+	// the ETag of the objects by key
+	objects map[string]string
+	tags    []*s3.Tag
+}
+
+// fakeObjectStore is the state of the buckets shared by the fake S3 API and radosgw-admin
+type fakeObjectStore struct {
+	mutex   sync.Mutex
+	buckets map[string]*fakeBucket
+	// the copies of this object fail
+	failKey string
+}
+
+type fakeS3 struct {
+	s3iface.S3API
+	store *fakeObjectStore
+}
+
+func (s *fakeObjectStore) bucket(name string) (*fakeBucket, error) {
+	bucket, ok := s.buckets[name]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchBucket, "no such bucket", nil)
+	}
+	return bucket, nil
+}
+
+func (f *fakeS3) GetBucketVersioning(input *s3.GetBucketVersioningInput) (*s3.GetBucketVersioningOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	bucket, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if bucket.versioned {
+		return &s3.GetBucketVersioningOutput{Status: aws.String(s3.BucketVersioningStatusEnabled)}, nil
+	}
+	return &s3.GetBucketVersioningOutput{}, nil
+}
+
+func (f *fakeS3) GetBucketPolicy(input *s3.GetBucketPolicyInput) (*s3.GetBucketPolicyOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	bucket, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if bucket.policy == "" {
+		return nil, awserr.New("NoSuchBucketPolicy", "no policy", nil)
+	}
+	return &s3.GetBucketPolicyOutput{Policy: aws.String(bucket.policy)}, nil
+}
+
+func (f *fakeS3) PutBucketPolicy(input *s3.PutBucketPolicyInput) (*s3.PutBucketPolicyOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	bucket, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	bucket.policy = *input.Policy
+	return &s3.PutBucketPolicyOutput{}, nil
+}
+
+func (f *fakeS3) DeleteBucketPolicy(input *s3.DeleteBucketPolicyInput) (*s3.DeleteBucketPolicyOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	bucket, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	bucket.policy = ""
+	return &s3.DeleteBucketPolicyOutput{}, nil
+}
+
+func (f *fakeS3) CreateBucket(input *s3.CreateBucketInput) (*s3.CreateBucketOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	if _, ok := f.store.buckets[*input.Bucket]; ok {
+		return nil, awserr.New(s3.ErrCodeBucketAlreadyOwnedByYou, "bucket exists", nil)
+	}
+	placement := strings.TrimPrefix(*input.CreateBucketConfiguration.LocationConstraint, ":")
+	f.store.buckets[*input.Bucket] = &fakeBucket{placement: placement, objects: map[string]string{}}
+	return &s3.CreateBucketOutput{}, nil
+}
+
+func (f *fakeS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	return f.ListObjectsV2PagesWithContext(context.Background(), input, fn)
+}
+
+func (f *fakeS3) ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool, opts ...request.Option) error {
+	f.store.mutex.Lock()
+	bucket, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		f.store.mutex.Unlock()
+		return err
+	}
+	page := &s3.ListObjectsV2Output{}
+	for key, etag := range bucket.objects {
+		page.Contents = append(page.Contents, &s3.Object{Key: aws.String(key), ETag: aws.String(etag), Size: aws.Int64(int64(len(key)))})
+	}
+	f.store.mutex.Unlock()
+	fn(page, true)
+	return nil
+}
+
+func (f *fakeS3) CopyObjectWithContext(ctx aws.Context, input *s3.CopyObjectInput, opts ...request.Option) (*s3.CopyObjectOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	name, key, _ := strings.Cut(*input.CopySource, "/")
+	if key == f.store.failKey {
+		return nil, awserr.New("InternalError", "copy failed", nil)
+	}
+	source, err := f.store.bucket(name)
+	if err != nil {
+		return nil, err
+	}
+	target, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if *input.MetadataDirective != s3.MetadataDirectiveCopy || *input.TaggingDirective != s3.TaggingDirectiveCopy {
+		return nil, errors.New("the metadata and the tags must be copied")
+	}
+	target.objects[*input.Key] = source.objects[key]
+	return &s3.CopyObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObjectAclWithContext(ctx aws.Context, input *s3.GetObjectAclInput, opts ...request.Option) (*s3.GetObjectAclOutput, error) {
+	return &s3.GetObjectAclOutput{Owner: &s3.Owner{ID: aws.String("my-user")}}, nil
+}
+
+func (f *fakeS3) PutObjectAclWithContext(ctx aws.Context, input *s3.PutObjectAclInput, opts ...request.Option) (*s3.PutObjectAclOutput, error) {
+	return &s3.PutObjectAclOutput{}, nil
+}
+
+func (f *fakeS3) GetBucketAcl(input *s3.GetBucketAclInput) (*s3.GetBucketAclOutput, error) {
+	return &s3.GetBucketAclOutput{Owner: &s3.Owner{ID: aws.String("my-user")}}, nil
+}
+
+func (f *fakeS3) PutBucketAcl(input *s3.PutBucketAclInput) (*s3.PutBucketAclOutput, error) {
+	return &s3.PutBucketAclOutput{}, nil
+}
+
+func (f *fakeS3) GetBucketCors(input *s3.GetBucketCorsInput) (*s3.GetBucketCorsOutput, error) {
+	return nil, awserr.New("NoSuchCORSConfiguration", "no cors", nil)
+}
+
+func (f *fakeS3) GetBucketLifecycleConfiguration(input *s3.GetBucketLifecycleConfigurationInput) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	return nil, awserr.New("NoSuchLifecycleConfiguration", "no lifecycle", nil)
+}
+
+func (f *fakeS3) GetBucketTagging(input *s3.GetBucketTaggingInput) (*s3.GetBucketTaggingOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	bucket, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if len(bucket.tags) == 0 {
+		return nil, awserr.New("NoSuchTagSet", "no tags", nil)
+	}
+	return &s3.GetBucketTaggingOutput{TagSet: bucket.tags}, nil
+}
+
+func (f *fakeS3) PutBucketTagging(input *s3.PutBucketTaggingInput) (*s3.PutBucketTaggingOutput, error) {
+	f.store.mutex.Lock()
+	defer f.store.mutex.Unlock()
+	bucket, err := f.store.bucket(*input.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	bucket.tags = input.Tagging.TagSet
+	return &s3.PutBucketTaggingOutput{}, nil
+}
+
+func (f *fakeS3) GetBucketEncryption(input *s3.GetBucketEncryptionInput) (*s3.GetBucketEncryptionOutput, error) {
+	return nil, awserr.New("ServerSideEncryptionConfigurationNotFoundError", "no encryption", nil)
+}
+
+func (f *fakeS3) GetBucketNotificationConfiguration(input *s3.GetBucketNotificationConfigurationRequest) (*s3.NotificationConfiguration, error) {
+	return &s3.NotificationConfiguration{}, nil
+}
+
+// import TestMockExecHelperProcess
+func TestMockExecHelperProcess(t *testing.T) {
+	exectest.TestMockExecHelperProcess(t)
+}
+
+func TestBucketMigration(t *testing.T) {
+	clusterInfo := cephclient.AdminTestClusterInfo("mycluster")
+	logPool := "my-store.rgw.log"
+	newBucket, oldBucket := migrationBucketNames("", "my-bucket")
+	policy := `{"Version":"2012-10-17","Statement":[{"Sid":"read","Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::my-bucket/*"]}]}`
+
+	newMigration := func(t *testing.T, store *fakeObjectStore, migrationObjects map[string]string) *BucketMigration {
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+				store.mutex.Lock()
+				defer store.mutex.Unlock()
+				switch {
+				case command == "rados" && args[4] == "stat":
+					assert.Equal(t, []string{"--pool", logPool, "--namespace", MigrationNamespace}, args[:4])
+					if _, ok := migrationObjects[args[5]]; !ok {
+						return "", errors.New("no such file or directory")
+					}
+					return "", nil
+				case command == "rados" && args[4] == "put":
+					data, err := os.ReadFile(args[6])
+					require.NoError(t, err)
+					migrationObjects[args[5]] = string(data)
+					return "", nil
+				case command == "rados" && args[4] == "get":
+					return "", os.WriteFile(args[6], []byte(migrationObjects[args[5]]), 0600)
+				case command == "rados" && args[4] == "rm":
+					delete(migrationObjects, args[5])
+					return "", nil
+				case command == "radosgw-admin" && args[0] == "bucket" && args[1] == "stats":
+					assert.Contains(t, args, "--rgw-zone=my-zone")
+					bucket, ok := store.buckets[args[3]]
+					if !ok {
+						return "", exectest.MockExecCommandReturns(t, "", "", int(syscall.ENOENT))
+					}
+					return fmt.Sprintf(`{"bucket":%q,"owner":"my-user","placement_rule":%q,"bucket_quota":{"enabled":false}}`, args[3], bucket.placement), nil
+				case command == "radosgw-admin" && args[0] == "bucket" && args[1] == "link":
+					assert.Equal(t, []string{"--uid", "my-user"}, args[6:8])
+					store.buckets[args[5]] = store.buckets[args[3]]
+					delete(store.buckets, args[3])
+					return "", nil
+				case command == "radosgw-admin" && args[0] == "bucket" && args[1] == "rm":
+					assert.Equal(t, "--purge-objects", args[4])
+					delete(store.buckets, args[3])
+					return "", nil
+				case command == "radosgw-admin" && args[0] == "user" && args[1] == "info":
+					assert.Equal(t, "my-user", args[3])
+					return `{"user_id":"my-user","keys":[{"user":"my-user","access_key":"access","secret_key":"secret"}]}`, nil
+				}
+				return "", errors.Errorf("unexpected command %s %v", command, args)
+			},
+		}
+		executor.MockExecuteCommandWithTimeout = func(timeout time.Duration, command string, args ...string) (string, error) {
+			return executor.MockExecuteCommandWithOutput(command, args...)
+		}
+		newS3Client := func(accessKey, secretKey string) (s3iface.S3API, error) {
+			assert.Equal(t, "access", accessKey)
+			assert.Equal(t, "secret", secretKey)
+			return &fakeS3{store: store}, nil
+		}
+		return NewBucketMigration(&clusterd.Context{Executor: executor}, clusterInfo, "my-realm", "my-zonegroup", "my-zone", logPool, "fast", 2, 100, newS3Client)
+	}
+
+	t.Run("migrate", func(t *testing.T) {
+		store := &fakeObjectStore{buckets: map[string]*fakeBucket{
+			"my-bucket": {
+				placement: "default-placement", policy: policy,
+				objects: map[string]string{"a": "etag-a", "bb": "etag-b", "ccc": "etag-c"},
+				tags:    []*s3.Tag{{Key: aws.String("team"), Value: aws.String("storage")}},
+			},
+			// the job was interrupted after the copy of an object
+			newBucket: {placement: "fast", objects: map[string]string{"a": "etag-a"}},
+		}}
+		migrationObjects := map[string]string{}
+		report, err := newMigration(t, store, migrationObjects).Migrate("my-bucket")
+		require.NoError(t, err)
+		assert.Equal(t, &BucketMigrationReport{Bucket: "my-bucket", CopiedObjects: 2, CopiedBytes: 5}, report)
+
+		// the bucket is replaced by the copy, and the old bucket is removed
+		require.Len(t, store.buckets, 1)
+		bucket := store.buckets["my-bucket"]
+		assert.Equal(t, "fast", bucket.placement)
+		assert.Equal(t, map[string]string{"a": "etag-a", "bb": "etag-b", "ccc": "etag-c"}, bucket.objects)
+		assert.Equal(t, policy, bucket.policy)
+		assert.Equal(t, []*s3.Tag{{Key: aws.String("team"), Value: aws.String("storage")}}, bucket.tags)
+		assert.Empty(t, migrationObjects)
+	})
+
+	t.Run("resume after the rename", func(t *testing.T) {
+		store := &fakeObjectStore{buckets: map[string]*fakeBucket{
+			newBucket: {placement: "fast", policy: `{"Statement":[{"Sid":"rook-migration-deny-writes"}]}`, objects: map[string]string{"a": "etag-a"}},
+			oldBucket: {placement: "default-placement", objects: map[string]string{"a": "etag-a"}},
+		}}
+		migrationObjects := map[string]string{"policy.my-zone.my-bucket": ""}
+		report, err := newMigration(t, store, migrationObjects).Migrate("my-bucket")
+		require.NoError(t, err)
+		assert.Equal(t, &BucketMigrationReport{Bucket: "my-bucket"}, report)
+		require.Len(t, store.buckets, 1)
+		assert.Equal(t, "fast", store.buckets["my-bucket"].placement)
+		// the bucket had no policy
+		assert.Empty(t, store.buckets["my-bucket"].policy)
+		assert.Empty(t, migrationObjects)
+	})
+
+	t.Run("already in the placement", func(t *testing.T) {
+		store := &fakeObjectStore{buckets: map[string]*fakeBucket{
+			"my-bucket": {placement: "fast/COLD", objects: map[string]string{}},
+		}}
+		report, err := newMigration(t, store, map[string]string{}).Migrate("my-bucket")
+		require.NoError(t, err)
+		assert.Equal(t, &BucketMigrationReport{Bucket: "my-bucket", Skipped: true}, report)
+	})
+
+	t.Run("copy failure", func(t *testing.T) {
+		store := &fakeObjectStore{failKey: "bb", buckets: map[string]*fakeBucket{
+			"my-bucket": {placement: "default-placement", policy: policy, objects: map[string]string{"a": "etag-a", "bb": "etag-b"}},
+		}}
+		migrationObjects := map[string]string{}
+		_, err := newMigration(t, store, migrationObjects).Migrate("my-bucket")
+		assert.ErrorContains(t, err, `failed to copy object "bb"`)
+		// the writes are allowed again and the copy is removed
+		require.Len(t, store.buckets, 1)
+		assert.Equal(t, "default-placement", store.buckets["my-bucket"].placement)
+		assert.Equal(t, policy, store.buckets["my-bucket"].policy)
+		assert.Empty(t, migrationObjects)
+	})
+
+	t.Run("versioned bucket", func(t *testing.T) {
+		store := &fakeObjectStore{buckets: map[string]*fakeBucket{
+			"my-bucket": {placement: "default-placement", versioned: true, objects: map[string]string{}},
+		}}
+		_, err := newMigration(t, store, map[string]string{}).Migrate("my-bucket")
+		assert.ErrorContains(t, err, "is versioned")
+	})
+
+	t.Run("report", func(t *testing.T) {
+		terminationMessagePath = filepath.Join(t.TempDir(), "termination-log")
+		defer func() { terminationMessagePath = "/dev/termination-log" }()
+		require.NoError(t, WriteBucketMigrationReport(&BucketMigrationReport{Bucket: "my-bucket", CopiedObjects: 2, CopiedBytes: 5}))
+		data, err := os.ReadFile(terminationMessagePath)
+		require.NoError(t, err)
+		report := &BucketMigrationReport{}
+		require.NoError(t, json.Unmarshal(data, report))
+		assert.Equal(t, &BucketMigrationReport{Bucket: "my-bucket", CopiedObjects: 2, CopiedBytes: 5}, report)
+	})
+}
+
+func TestMigrationBucketNames(t *testing.T) {
+	newBucket, oldBucket := migrationBucketNames("", "my-bucket")
+	assert.True(t, strings.HasPrefix(newBucket, "rook-migration-"))
+	assert.True(t, strings.HasPrefix(oldBucket, "rook-migrated-"))
+	assert.Equal(t, strings.TrimPrefix(newBucket, "rook-migration-"), strings.TrimPrefix(oldBucket, "rook-migrated-"))
+
+	newBucket, oldBucket = migrationBucketNames("tenant", "my-bucket")
+	assert.True(t, strings.HasPrefix(newBucket, "tenant/rook-migration-"))
+	assert.True(t, strings.HasPrefix(oldBucket, "tenant/rook-migrated-"))
+
+	assert.True(t, inPlacement("fast", "fast"))
+	assert.True(t, inPlacement("fast/COLD", "fast"))
+	assert.False(t, inPlacement("faster", "fast"))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleanup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectStoreOrphanScan(t *testing.T) {
+	clusterInfo := cephclient.AdminTestClusterInfo("mycluster")
+	dataPool := "my-store.rgw.buckets.data"
+	logPool := "my-store.rgw.log"
+
+	// the rados objects of the log pool, by name
+	scanObjects := map[string]string{"orphans.previous": "my-store.rgw.buckets.data\told\n"}
+	removed := []string{}
+	fixed := []string{}
+	newExecutor := func(t *testing.T) *exectest.MockExecutor {
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithEnv: func(env []string, command string, args ...string) error {
+				assert.Equal(t, "rgw-orphan-list", command)
+				assert.Equal(t, []string{dataPool}, args)
+				var cephArgs string
+				for _, e := range env {
+					if strings.HasPrefix(e, "CEPH_ARGS=") {
+						cephArgs = e
+					}
+				}
+				assert.Contains(t, cephArgs, "--rgw-realm=my-realm --rgw-zonegroup=my-zonegroup --rgw-zone=my-zone")
+				assert.Contains(t, cephArgs, "--cluster=mycluster")
+				// the orphans are written in the working directory
+				return os.WriteFile("orphan-list-202501010000.out", []byte("orphan-1\norphan-2\nremoved\n"), 0600)
+			},
+			MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+				switch {
+				case command == "rados" && args[2] == "stat" && args[3] == "removed":
+					return "", errors.New("no such file or directory")
+				case command == "rados" && args[2] == "stat":
+					return fmt.Sprintf("%s/%s mtime 2025-01-01T00:00:00.000000+0000, size 4194304", args[1], args[3]), nil
+				case command == "rados" && args[4] == "ls":
+					assert.Equal(t, []string{"--pool", logPool, "--namespace", OrphanScanNamespace}, args[:4])
+					names := []string{}
+					for name := range scanObjects {
+						names = append(names, name)
+					}
+					return strings.Join(names, "\n"), nil
+				case command == "rados" && args[4] == "stat":
+					return "", nil
+				case command == "rados" && args[4] == "rm" && args[1] == logPool:
+					delete(scanObjects, args[5])
+					return "", nil
+				case command == "rados" && args[4] == "rm":
+					removed = append(removed, args[5])
+					return "", nil
+				case command == "rados" && args[4] == "put":
+					data, err := os.ReadFile(args[6])
+					require.NoError(t, err)
+					scanObjects[args[5]] = string(data)
+					return "", nil
+				case command == "rados" && args[4] == "get":
+					return "", os.WriteFile(args[6], []byte(scanObjects[args[5]]), 0600)
+				case command == "radosgw-admin" && args[0] == "bucket" && args[1] == "list":
+					assert.Contains(t, args, "--rgw-zone=my-zone")
+					return `["my-bucket","other"]`, nil
+				case command == "radosgw-admin" && args[0] == "bucket" && args[1] == "check":
+					if args[3] == "other" {
+						return `{"invalid_multipart_entries":[],"check_result":{}}`, nil
+					}
+					if args[4] == "--fix" {
+						fixed = append(fixed, args[3])
+					}
+					return `{"invalid_multipart_entries":["_multipart_a.2~abc.meta","_multipart_b.2~def.meta"],"check_result":{}}`, nil
+				}
+				return "", errors.Errorf("unexpected command %s %v", command, args)
+			},
+		}
+		// the rados objects are removed with a timeout
+		executor.MockExecuteCommandWithTimeout = func(timeout time.Duration, command string, args ...string) (string, error) {
+			return executor.MockExecuteCommandWithOutput(command, args...)
+		}
+		return executor
+	}
+
+	t.Run("scan", func(t *testing.T) {
+		context := &clusterd.Context{Executor: newExecutor(t)}
+		scan := NewObjectStoreOrphanScan(context, clusterInfo, "20250101-000000", "my-realm", "my-zonegroup", "my-zone", logPool, []string{dataPool})
+		report, err := scan.Scan()
+		require.NoError(t, err)
+		assert.Equal(t, &OrphanScanReport{
+			ID:                "20250101-000000",
+			Orphans:           2,
+			ReclaimableBytes:  8388608,
+			StaleIndexEntries: 2,
+			Pools:             map[string]OrphanScanPoolReport{dataPool: {Orphans: 2, ReclaimableBytes: 8388608}},
+			Buckets:           map[string]int64{"my-bucket": 2},
+			Location:          "rados://my-store.rgw.log/rook-orphan-scan/orphans.20250101-000000",
+		}, report)
+		// the orphans of the previous scans are replaced
+		assert.Equal(t, map[string]string{
+			"orphans.20250101-000000":     "my-store.rgw.buckets.data\torphan-1\nmy-store.rgw.buckets.data\torphan-2\n",
+			"stale-index.20250101-000000": "my-bucket\n",
+		}, scanObjects)
+		assert.Empty(t, removed)
+		assert.Empty(t, fixed)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		context := &clusterd.Context{Executor: newExecutor(t)}
+		scan := NewObjectStoreOrphanScan(context, clusterInfo, "20250101-000000", "my-realm", "my-zonegroup", "my-zone", logPool, []string{dataPool})
+		report, err := scan.Remove()
+		require.NoError(t, err)
+		assert.True(t, report.Removed)
+		assert.Equal(t, int64(2), report.Orphans)
+		assert.Equal(t, int64(8388608), report.ReclaimableBytes)
+		assert.Equal(t, int64(2), report.StaleIndexEntries)
+		assert.Equal(t, []string{"orphan-1", "orphan-2"}, removed)
+		assert.Equal(t, []string{"my-bucket"}, fixed)
+		assert.Empty(t, scanObjects)
+	})
+
+	t.Run("report", func(t *testing.T) {
+		terminationMessagePath = filepath.Join(t.TempDir(), "termination-log")
+		defer func() { terminationMessagePath = "/dev/termination-log" }()
+		require.NoError(t, WriteOrphanScanReport(&OrphanScanReport{ID: "20250101-000000", Orphans: 2}))
+		data, err := os.ReadFile(terminationMessagePath)
+		require.NoError(t, err)
+		report := &OrphanScanReport{}
+		require.NoError(t, json.Unmarshal(data, report))
+		assert.Equal(t, &OrphanScanReport{ID: "20250101-000000", Orphans: 2}, report)
+	})
+}
+
+func TestLargestBuckets(t *testing.T) {
+	buckets := map[string]int64{"a": 1, "b": 5, "c": 3, "d": 5}
+	assert.Equal(t, buckets, largestBuckets(buckets, 4))
+	assert.Equal(t, map[string]int64{"b": 5, "d": 5}, largestBuckets(buckets, 2))
+	assert.Equal(t, map[string]int64{"b": 5, "c": 3, "d": 5}, largestBuckets(buckets, 3))
+}
@@ -63,9 +63,11 @@ type OSDPerfStats struct {
 
 type OSDDump struct {
 	OSDs []struct {
-		OSD json.Number `json:"osd"`
-		Up  json.Number `json:"up"`
-		In  json.Number `json:"in"`
+		OSD             json.Number `json:"osd"`
+		Up              json.Number `json:"up"`
+		In              json.Number `json:"in"`
+		PrimaryAffinity json.Number `json:"primary_affinity"`
+		UpFrom          json.Number `json:"up_from"`
 	} `json:"osds"`
 	Flags             string              `json:"flags"`
 	CrushNodeFlags    map[string][]string `json:"crush_node_flags"`
@@ -220,7 +222,8 @@ func GetOSDUsage(context *clusterd.Context, clusterInfo *ClusterInfo) (*OSDUsage
 	return &osdUsage, nil
 }
 
-func convertKibibytesToTebibytes(kib string) (float64, error) {
+// ConvertKibibytesToTebibytes converts a size in KiB, as reported by "osd df", to TiB, the unit of the CRUSH weights.
+func ConvertKibibytesToTebibytes(kib string) (float64, error) {
 	kibFloat, err := strconv.ParseFloat(kib, 64)
 	if err != nil {
 		return float64(0), errors.Wrap(err, "failed to convert string to float")
@@ -234,7 +237,7 @@ func ResizeOsdCrushWeight(actualOSD OSDNodeUsage, ctx *clusterd.Context, cluster
 		return false, errors.Wrapf(err, "failed converting string to float for osd.%d crush weight %q", actualOSD.ID, actualOSD.CrushWeight.String())
 	}
 	// actualOSD.KB is in KiB units
-	calculatedCrushWeight, err := convertKibibytesToTebibytes(actualOSD.KB.String())
+	calculatedCrushWeight, err := ConvertKibibytesToTebibytes(actualOSD.KB.String())
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to convert KiB to TiB for osd.%d crush weight %q", actualOSD.ID, actualOSD.KB.String())
 	}
@@ -246,6 +249,9 @@ func ResizeOsdCrushWeight(actualOSD OSDNodeUsage, ctx *clusterd.Context, cluster
 	} else if calculatedCrushWeight <= currentCrushWeight {
 		logger.Debugf("calculatedCrushWeight %f is less then current currentCrushWeight %f for osd.%d, not resizing the crush weights", calculatedCrushWeight, currentCrushWeight, actualOSD.ID)
 		return false, nil
+	} else if currentCrushWeight == float64(0) {
+		logger.Debugf("crush weight is 0 for osd.%d, not resizing the crush weights", actualOSD.ID)
+		return false, nil
 	} else if math.Abs(((calculatedCrushWeight - currentCrushWeight) / currentCrushWeight)) <= 0.01 {
 		logger.Debugf("calculatedCrushWeight %f is less then 1 percent increased from currentCrushWeight %f for osd.%d, not resizing the crush weights", calculatedCrushWeight, currentCrushWeight, actualOSD.ID)
 		return false, nil
@@ -262,6 +268,17 @@ func ResizeOsdCrushWeight(actualOSD OSDNodeUsage, ctx *clusterd.Context, cluster
 	return true, nil
 }
 
+// SetCrushWeight sets the CRUSH weight of the given OSD.
+func SetCrushWeight(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int, weight string) error {
+	logger.Infof("setting osd.%d crush weight to %q", osdID, weight)
+	args := []string{"osd", "crush", "reweight", fmt.Sprintf("osd.%d", osdID), weight}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set osd.%d crush weight to %q. %s", osdID, weight, string(buf))
+	}
+	return nil
+}
+
 func SetDeviceClass(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int, deviceClass string) error {
 	// First remove the existing device class
 	args := []string{"osd", "crush", "rm-device-class", fmt.Sprintf("osd.%d", osdID)}
@@ -317,6 +334,12 @@ func OSDOut(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int) (str
 	return string(buf), err
 }
 
+func OSDIn(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int) (string, error) {
+	args := []string{"osd", "in", strconv.Itoa(osdID)}
+	buf, err := NewCephCommand(context, clusterInfo, args).Run()
+	return string(buf), err
+}
+
 func OsdSafeToDestroy(context *clusterd.Context, clusterInfo *ClusterInfo, osdID int) (bool, error) {
 	args := []string{"osd", "safe-to-destroy", strconv.Itoa(osdID)}
 	cmd := NewCephCommand(context, clusterInfo, args)
@@ -451,6 +474,10 @@ func SetPrimaryAffinity(context *clusterd.Context, clusterInfo *ClusterInfo, osd
 type OSDMetadata struct {
 	Id       int    `json:"id"`
 	HostName string `json:"hostname"`
+	// Devices is the comma separated list of the kernel names of the devices used by the OSD
+	Devices string `json:"devices"`
+	// DevicePaths is the comma separated list of "<device>=<persistent path>" of the devices used by the OSD
+	DevicePaths string `json:"device_paths"`
 }
 
 // GetOSDMetadata returns the output of `ceph osd metadata`
@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -142,16 +143,45 @@ func TestOSDDeviceClasses(t *testing.T) {
 
 func TestConvertKibibytesToTebibytes(t *testing.T) {
 	kib := "1024"
-	terabyte, err := convertKibibytesToTebibytes(kib)
+	terabyte, err := ConvertKibibytesToTebibytes(kib)
 	assert.NoError(t, err)
 	assert.Equal(t, float64(9.5367431640625e-07), terabyte)
 
 	kib = "1073741824"
-	terabyte, err = convertKibibytesToTebibytes(kib)
+	terabyte, err = ConvertKibibytesToTebibytes(kib)
 	assert.NoError(t, err)
 	assert.Equal(t, float64(1), terabyte)
 }
 
+func TestResizeOsdCrushWeight(t *testing.T) {
+	var reweights []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			reweights = append(reweights, args[3]+"="+args[4])
+			return "", nil
+		},
+	}
+	context := &clusterd.Context{Executor: executor}
+	clusterInfo := AdminTestClusterInfo("mycluster")
+	osd := func(crushWeight string) OSDNodeUsage {
+		return OSDNodeUsage{ID: 1, CrushWeight: json.Number(crushWeight), KB: "1073741824"}
+	}
+
+	resized, err := ResizeOsdCrushWeight(osd("0"), context, clusterInfo)
+	assert.NoError(t, err)
+	assert.False(t, resized)
+
+	resized, err = ResizeOsdCrushWeight(osd("0.995"), context, clusterInfo)
+	assert.NoError(t, err)
+	assert.False(t, resized)
+	assert.Empty(t, reweights)
+
+	resized, err = ResizeOsdCrushWeight(osd("0.5"), context, clusterInfo)
+	assert.NoError(t, err)
+	assert.True(t, resized)
+	assert.Equal(t, []string{"osd.1=1.000000"}, reweights)
+}
+
 func TestOSDOkToStop(t *testing.T) {
 	returnString := ""
 	returnOkResult := true
@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	kafkaClientID        = "rook-ops-log"
+	kafkaRequestTimeout  = 30 * time.Second
+	kafkaMaxBatchRecords = 500
+	// records buffered while the brokers are unreachable, the oldest records are dropped beyond
+	kafkaMaxBufferedRecords = 10000
+)
+
+// KafkaUsernameEnvVar returns the name of the environment variable holding the SASL user name of
+// the Kafka sink at the index of the sidecar spec
+func KafkaUsernameEnvVar(index int) string {
+	return fmt.Sprintf("ROOK_OPS_LOG_KAFKA_USERNAME_%d", index)
+}
+
+// KafkaPasswordEnvVar returns the name of the environment variable holding the SASL password of
+// the Kafka sink at the index of the sidecar spec
+func KafkaPasswordEnvVar(index int) string {
+	return fmt.Sprintf("ROOK_OPS_LOG_KAFKA_PASSWORD_%d", index)
+}
+
+// messageWriter is the part of the Kafka writer used by the sink
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// kafkaSink buffers the entries and produces them to the topic on flush
+type kafkaSink struct {
+	writer  messageWriter
+	brokers []string
+	topic   string
+	records [][]byte
+	dropped int
+}
+
+func newKafkaSink(index int, spec *cephv1.OpsLogKafkaSinkSpec, podName string) (*kafkaSink, error) {
+	transport := &kafka.Transport{
+		ClientID: fmt.Sprintf("%s-%s", kafkaClientID, podName),
+	}
+	if spec.UseSSL {
+		//#nosec G402 -- The verification is only skipped when requested in the spec
+		transport.TLS = &tls.Config{InsecureSkipVerify: spec.DisableVerifySSL, MinVersion: tls.VersionTLS12}
+	}
+	if spec.Mechanism != "" {
+		mechanism, err := kafkaSASLMechanism(index, spec)
+		if err != nil {
+			return nil, err
+		}
+		transport.SASL = mechanism
+	}
+
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(spec.Brokers...),
+		Topic:        spec.Topic,
+		Balancer:     &kafka.RoundRobin{},
+		RequiredAcks: kafka.RequireOne,
+		BatchSize:    kafkaMaxBatchRecords,
+		// the records are sent on flush, which batches them already
+		BatchTimeout: time.Millisecond,
+		Transport:    transport,
+	}
+	return newKafkaSinkWithWriter(writer, spec), nil
+}
+
+func newKafkaSinkWithWriter(writer messageWriter, spec *cephv1.OpsLogKafkaSinkSpec) *kafkaSink {
+	return &kafkaSink{writer: writer, brokers: spec.Brokers, topic: spec.Topic}
+}
+
+func kafkaSASLMechanism(index int, spec *cephv1.OpsLogKafkaSinkSpec) (sasl.Mechanism, error) {
+	username, password := os.Getenv(KafkaUsernameEnvVar(index)), os.Getenv(KafkaPasswordEnvVar(index))
+	if username == "" || password == "" {
+		return nil, errors.Errorf("credentials of the kafka sink to topic %q are not set", spec.Topic)
+	}
+	switch spec.Mechanism {
+	case "PLAIN":
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case "SCRAM-SHA-256":
+		mechanism, err := scram.Mechanism(scram.SHA256, username, password)
+		return mechanism, errors.Wrap(err, "failed to create kafka scram mechanism")
+	case "SCRAM-SHA-512":
+		mechanism, err := scram.Mechanism(scram.SHA512, username, password)
+		return mechanism, errors.Wrap(err, "failed to create kafka scram mechanism")
+	default:
+		return nil, errors.Errorf("unsupported kafka sasl mechanism %q", spec.Mechanism)
+	}
+}
+
+func (s *kafkaSink) Name() string {
+	return fmt.Sprintf("kafka %s/%s", strings.Join(s.brokers, ","), s.topic)
+}
+
+func (s *kafkaSink) Write(entry *Entry) error {
+	if len(s.records) >= kafkaMaxBufferedRecords {
+		s.records = s.records[1:]
+		s.dropped++
+	}
+	s.records = append(s.records, entry.Raw)
+	return nil
+}
+
+func (s *kafkaSink) Flush() error {
+	if s.dropped > 0 {
+		logger.Warningf("dropped %d ops log entries that could not be produced to kafka", s.dropped)
+		s.dropped = 0
+	}
+	for len(s.records) > 0 {
+		batch := s.records
+		if len(batch) > kafkaMaxBatchRecords {
+			batch = batch[:kafkaMaxBatchRecords]
+		}
+		if err := s.produce(batch); err != nil {
+			return err
+		}
+		s.records = s.records[len(batch):]
+	}
+	return nil
+}
+
+func (s *kafkaSink) produce(records [][]byte) error {
+	msgs := make([]kafka.Message, len(records))
+	for i, record := range records {
+		msgs[i] = kafka.Message{Value: record}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaRequestTimeout)
+	defer cancel()
+	if err := s.writer.WriteMessages(ctx, msgs...); err != nil {
+		return errors.Wrapf(err, "failed to produce %d ops log entries to kafka topic %q", len(records), s.topic)
+	}
+	return nil
+}
+
+func (s *kafkaSink) Close() error {
+	err := s.Flush()
+	if closeErr := s.writer.Close(); closeErr != nil {
+		logger.Warningf("failed to close kafka writer. %v", closeErr)
+	}
+	return err
+}
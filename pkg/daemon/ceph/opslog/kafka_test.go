@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWriter struct {
+	batches [][]string
+	err     error
+	closed  bool
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if w.err != nil {
+		return w.err
+	}
+	var values []string
+	for _, msg := range msgs {
+		values = append(values, string(msg.Value))
+	}
+	w.batches = append(w.batches, values)
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestNewKafkaSink(t *testing.T) {
+	spec := &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka-0:9093", "kafka-1:9093"}, Topic: "ops"}
+
+	t.Run("plaintext", func(t *testing.T) {
+		sink, err := newKafkaSink(0, spec, "rgw-pod")
+		require.NoError(t, err)
+		assert.Equal(t, "kafka kafka-0:9093,kafka-1:9093/ops", sink.Name())
+		transport := sink.writer.(*kafka.Writer).Transport.(*kafka.Transport)
+		assert.Equal(t, "rook-ops-log-rgw-pod", transport.ClientID)
+		assert.Nil(t, transport.TLS)
+		assert.Nil(t, transport.SASL)
+	})
+
+	t.Run("tls and sasl", func(t *testing.T) {
+		spec := spec.DeepCopy()
+		spec.UseSSL = true
+		spec.DisableVerifySSL = true
+		spec.Mechanism = "PLAIN"
+		spec.CredentialsSecretName = "kafka-user"
+
+		// the credentials are not set
+		_, err := newKafkaSink(1, spec, "rgw-pod")
+		assert.Error(t, err)
+
+		t.Setenv("ROOK_OPS_LOG_KAFKA_USERNAME_1", "alice")
+		t.Setenv("ROOK_OPS_LOG_KAFKA_PASSWORD_1", "secret")
+		sink, err := newKafkaSink(1, spec, "rgw-pod")
+		require.NoError(t, err)
+		transport := sink.writer.(*kafka.Writer).Transport.(*kafka.Transport)
+		require.NotNil(t, transport.TLS)
+		assert.True(t, transport.TLS.InsecureSkipVerify)
+		assert.Equal(t, plain.Mechanism{Username: "alice", Password: "secret"}, transport.SASL)
+
+		for _, mechanism := range []string{"SCRAM-SHA-256", "SCRAM-SHA-512"} {
+			spec.Mechanism = mechanism
+			sink, err := newKafkaSink(1, spec, "rgw-pod")
+			require.NoError(t, err)
+			transport := sink.writer.(*kafka.Writer).Transport.(*kafka.Transport)
+			assert.Equal(t, mechanism, transport.SASL.Name())
+		}
+	})
+}
+
+func TestKafkaSink(t *testing.T) {
+	writer := &fakeWriter{}
+	sink := newKafkaSinkWithWriter(writer, &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9092"}, Topic: "ops"})
+
+	assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+	assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketB)}))
+	assert.NoError(t, sink.Flush())
+	assert.Equal(t, [][]string{{entryBucketA, entryBucketB}}, writer.batches)
+
+	t.Run("entries are produced in batches", func(t *testing.T) {
+		writer.batches = nil
+		for i := 0; i < kafkaMaxBatchRecords+1; i++ {
+			assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+		}
+		assert.NoError(t, sink.Flush())
+		require.Len(t, writer.batches, 2)
+		assert.Len(t, writer.batches[0], kafkaMaxBatchRecords)
+		assert.Len(t, writer.batches[1], 1)
+	})
+
+	t.Run("entries stay buffered on failure", func(t *testing.T) {
+		writer.batches = nil
+		writer.err = errors.New("not leader for partition")
+		assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+		assert.Error(t, sink.Flush())
+		assert.Len(t, sink.records, 1)
+
+		writer.err = nil
+		assert.NoError(t, sink.Close())
+		assert.Empty(t, sink.records)
+		assert.Equal(t, [][]string{{entryBucketA}}, writer.batches)
+		assert.True(t, writer.closed)
+	})
+
+	t.Run("oldest entries are dropped beyond the buffer", func(t *testing.T) {
+		sink := newKafkaSinkWithWriter(&fakeWriter{}, &cephv1.OpsLogKafkaSinkSpec{Topic: "ops"})
+		for i := 0; i < kafkaMaxBufferedRecords; i++ {
+			assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+		}
+		assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketB)}))
+		assert.Len(t, sink.records, kafkaMaxBufferedRecords)
+		assert.Equal(t, 1, sink.dropped)
+		assert.Equal(t, entryBucketB, string(sink.records[kafkaMaxBufferedRecords-1]))
+	})
+}
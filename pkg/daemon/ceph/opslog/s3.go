@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awssession "github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+)
+
+const (
+	defaultS3Region           = "us-east-1"
+	defaultS3RotationInterval = 10 * time.Minute
+	// an object is written before the rotation interval when the buffered entries reach this size
+	maxS3ObjectSize = 64 << 20
+)
+
+// S3AccessKeyEnvVar returns the name of the environment variable holding the access key of the S3
+// sink at the index of the sidecar spec
+func S3AccessKeyEnvVar(index int) string {
+	return fmt.Sprintf("ROOK_OPS_LOG_S3_ACCESS_KEY_ID_%d", index)
+}
+
+// S3SecretKeyEnvVar returns the name of the environment variable holding the secret key of the S3
+// sink at the index of the sidecar spec
+func S3SecretKeyEnvVar(index int) string {
+	return fmt.Sprintf("ROOK_OPS_LOG_S3_SECRET_ACCESS_KEY_%d", index)
+}
+
+// objectPutter is the part of the S3 API used by the sink
+type objectPutter interface {
+	PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error)
+}
+
+// s3Sink buffers the entries as JSON lines and writes them to a new object at every rotation
+type s3Sink struct {
+	client   objectPutter
+	endpoint string
+	bucket   string
+	prefix   string
+	podName  string
+	interval time.Duration
+	buffer   bytes.Buffer
+	// time of the first buffered entry, which names the object
+	started time.Time
+	now     func() time.Time
+}
+
+func newS3Sink(index int, spec *cephv1.OpsLogS3SinkSpec, podName string) (*s3Sink, error) {
+	accessKey, secretKey := os.Getenv(S3AccessKeyEnvVar(index)), os.Getenv(S3SecretKeyEnvVar(index))
+	if accessKey == "" || secretKey == "" {
+		return nil, errors.Errorf("credentials of the s3 sink to bucket %q are not set", spec.Bucket)
+	}
+	region := spec.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+	session, err := awssession.NewSession(
+		aws.NewConfig().
+			WithRegion(region).
+			WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, "")).
+			WithEndpoint(spec.Endpoint).
+			WithS3ForcePathStyle(true).
+			WithMaxRetries(5),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create s3 session")
+	}
+
+	sink := newS3SinkWithClient(s3.New(session), spec, podName)
+	if spec.RotationInterval != "" {
+		// the interval was validated with the spec
+		sink.interval, _ = time.ParseDuration(spec.RotationInterval)
+	}
+	return sink, nil
+}
+
+func newS3SinkWithClient(client objectPutter, spec *cephv1.OpsLogS3SinkSpec, podName string) *s3Sink {
+	return &s3Sink{
+		client:   client,
+		endpoint: spec.Endpoint,
+		bucket:   spec.Bucket,
+		prefix:   spec.Prefix,
+		podName:  podName,
+		interval: defaultS3RotationInterval,
+		now:      time.Now,
+	}
+}
+
+func (s *s3Sink) Name() string {
+	return fmt.Sprintf("s3 %s/%s", s.endpoint, s.bucket)
+}
+
+func (s *s3Sink) Write(entry *Entry) error {
+	if s.buffer.Len() == 0 {
+		s.started = s.now()
+	}
+	s.buffer.Write(entry.Raw)
+	s.buffer.WriteByte('\n')
+	if s.buffer.Len() >= maxS3ObjectSize {
+		return s.upload()
+	}
+	return nil
+}
+
+func (s *s3Sink) Flush() error {
+	if s.buffer.Len() == 0 || s.now().Sub(s.started) < s.interval {
+		return nil
+	}
+	return s.upload()
+}
+
+func (s *s3Sink) Close() error {
+	if s.buffer.Len() == 0 {
+		return nil
+	}
+	return s.upload()
+}
+
+// objectKey returns the key of the object holding the buffered entries
+func (s *s3Sink) objectKey() string {
+	return path.Join(s.prefix, s.podName, s.started.UTC().Format("2006/01/02/15-04-05.000000000")+".log")
+}
+
+// upload writes the buffered entries to a new object. The entries stay buffered if the object
+// cannot be written, until the buffer grows too large.
+func (s *s3Sink) upload() error {
+	key := s.objectKey()
+	_, err := s.client.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(s.buffer.Bytes()),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	if err != nil {
+		if s.buffer.Len() >= 2*maxS3ObjectSize {
+			logger.Errorf("dropping %d bytes of ops log entries that could not be written to object %q", s.buffer.Len(), key)
+			s.buffer.Reset()
+		}
+		return errors.Wrapf(err, "failed to write object %q", key)
+	}
+	logger.Debugf("wrote %d bytes of ops log entries to object %q", s.buffer.Len(), key)
+	s.buffer.Reset()
+	return nil
+}
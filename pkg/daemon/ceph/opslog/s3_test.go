@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePutter struct {
+	objects map[string]string
+	err     error
+}
+
+func (p *fakePutter) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	body, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	p.objects[*input.Bucket+"/"+*input.Key] = string(body)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3Sink(t *testing.T) {
+	putter := &fakePutter{objects: map[string]string{}}
+	spec := &cephv1.OpsLogS3SinkSpec{Endpoint: "http://s3", Bucket: "audit", Prefix: "rgw"}
+	sink := newS3SinkWithClient(putter, spec, "rgw-pod")
+	now := time.Date(2025, 5, 6, 10, 11, 12, 0, time.UTC)
+	sink.now = func() time.Time { return now }
+
+	assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+	now = now.Add(time.Minute)
+	assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketB)}))
+
+	// the rotation interval did not elapse since the first entry
+	assert.NoError(t, sink.Flush())
+	assert.Empty(t, putter.objects)
+
+	now = now.Add(defaultS3RotationInterval)
+	assert.NoError(t, sink.Flush())
+	assert.Equal(t, map[string]string{
+		"audit/rgw/rgw-pod/2025/05/06/10-11-12.000000000.log": entryBucketA + "\n" + entryBucketB + "\n",
+	}, putter.objects)
+
+	t.Run("entries stay buffered on failure", func(t *testing.T) {
+		putter.objects = map[string]string{}
+		putter.err = errors.New("unavailable")
+		assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+		assert.Error(t, sink.Close())
+
+		putter.err = nil
+		assert.NoError(t, sink.Close())
+		assert.Equal(t, map[string]string{
+			"audit/rgw/rgw-pod/2025/05/06/10-22-12.000000000.log": entryBucketA + "\n",
+		}, putter.objects)
+		// nothing left to write
+		putter.objects = map[string]string{}
+		assert.NoError(t, sink.Close())
+		assert.Empty(t, putter.objects)
+	})
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package opslog forwards the entries of the RGW ops log to external sinks
+package opslog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"io"
+	"os"
+	"time"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", "opslog")
+
+// Entry is an entry of the RGW ops log
+type Entry struct {
+	Bucket  string `json:"bucket"`
+	User    string `json:"user"`
+	TransID string `json:"trans_id"`
+	// Raw is the entry as written by RGW
+	Raw []byte `json:"-"`
+}
+
+// ParseEntry parses a line of the RGW ops log
+func ParseEntry(line []byte) (*Entry, error) {
+	// entries may be separated by a comma when RGW writes them as a JSON list
+	line = bytes.TrimRight(bytes.TrimSpace(line), ",")
+	entry := &Entry{}
+	if err := json.Unmarshal(line, entry); err != nil {
+		return nil, errors.Wrap(err, "failed to parse ops log entry")
+	}
+	entry.Raw = line
+	return entry, nil
+}
+
+// Shipper forwards the entries of an RGW ops log file to sinks
+type Shipper struct {
+	logFile       string
+	sinks         []Sink
+	buckets       map[string]bool
+	users         map[string]bool
+	samplePercent uint32
+	// number of entries that failed to be forwarded to each sink since the last poll, and the last error
+	failures  []int
+	lastError []error
+}
+
+// NewShipper returns a shipper of the ops log file to the sinks, with the filter and sampling of
+// the sidecar spec
+func NewShipper(logFile string, spec *cephv1.OpsLogSidecar, sinks []Sink) *Shipper {
+	s := &Shipper{
+		logFile:       logFile,
+		sinks:         sinks,
+		samplePercent: 100,
+		failures:      make([]int, len(sinks)),
+		lastError:     make([]error, len(sinks)),
+	}
+	if spec.Filter != nil {
+		s.buckets = toSet(spec.Filter.Buckets)
+		s.users = toSet(spec.Filter.Users)
+	}
+	if spec.SamplePercent != nil {
+		s.samplePercent = uint32(*spec.SamplePercent)
+	}
+	return s
+}
+
+func toSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// selected returns whether the entry passes the filter and the sampling
+func (s *Shipper) selected(entry *Entry) bool {
+	if s.buckets != nil && !s.buckets[entry.Bucket] {
+		return false
+	}
+	if s.users != nil && !s.users[entry.User] {
+		return false
+	}
+	if s.samplePercent >= 100 {
+		return true
+	}
+	// sample by transaction ID so that the decision does not depend on the sidecar instance
+	h := fnv.New32a()
+	if entry.TransID != "" {
+		_, _ = h.Write([]byte(entry.TransID))
+	} else {
+		_, _ = h.Write(entry.Raw)
+	}
+	return h.Sum32()%100 < s.samplePercent
+}
+
+// ship forwards a line of the ops log to the sinks
+func (s *Shipper) ship(line []byte) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+	entry, err := ParseEntry(line)
+	if err != nil {
+		logger.Debugf("skipping ops log line %q. %v", string(line), err)
+		return
+	}
+	if !s.selected(entry) {
+		return
+	}
+	for i, sink := range s.sinks {
+		if err := sink.Write(entry); err != nil {
+			s.failures[i]++
+			s.lastError[i] = err
+		}
+	}
+}
+
+// flush flushes the sinks and reports the entries that could not be forwarded since the last flush
+func (s *Shipper) flush() {
+	for i, sink := range s.sinks {
+		if err := sink.Flush(); err != nil {
+			logger.Errorf("failed to flush ops log sink %q. %v", sink.Name(), err)
+		}
+		if s.failures[i] > 0 {
+			logger.Errorf("failed to forward %d ops log entries to sink %q. %v", s.failures[i], sink.Name(), s.lastError[i])
+			s.failures[i] = 0
+			s.lastError[i] = nil
+		}
+	}
+}
+
+// Run follows the ops log file and forwards its entries until the context is canceled. The sinks
+// are flushed at every poll interval and closed when the context is canceled.
+func (s *Shipper) Run(ctx context.Context, pollInterval time.Duration) error {
+	f := &follower{path: s.logFile}
+	defer f.close()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := f.readLines(s.ship); err != nil {
+			logger.Errorf("failed to read ops log file %q. %v", s.logFile, err)
+		}
+		s.flush()
+
+		select {
+		case <-ctx.Done():
+			for _, sink := range s.sinks {
+				if err := sink.Close(); err != nil {
+					logger.Errorf("failed to close ops log sink %q. %v", sink.Name(), err)
+				}
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// follower reads the lines appended to a file, following the file when it is truncated or
+// replaced by the log rotation
+type follower struct {
+	path    string
+	file    *os.File
+	reader  *bufio.Reader
+	offset  int64
+	partial []byte
+}
+
+// readLines calls the handler with every complete line appended to the file since the last call
+func (f *follower) readLines(handler func(line []byte)) error {
+	if f.file == nil {
+		file, err := os.Open(f.path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// RGW did not write the log file yet
+				return nil
+			}
+			return errors.Wrap(err, "failed to open file")
+		}
+		f.file = file
+		f.reader = bufio.NewReader(file)
+		f.offset = 0
+		f.partial = nil
+	}
+
+	if err := f.readAvailable(handler); err != nil {
+		return err
+	}
+
+	current, err := f.file.Stat()
+	if err != nil {
+		return errors.Wrap(err, "failed to stat open file")
+	}
+	latest, err := os.Stat(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// the file was rotated and not created again yet, keep reading the old one
+			return nil
+		}
+		return errors.Wrap(err, "failed to stat file")
+	}
+	if !os.SameFile(current, latest) {
+		logger.Infof("ops log file %q was rotated, following the new file", f.path)
+		f.close()
+		return f.readLines(handler)
+	}
+	if latest.Size() < f.offset {
+		logger.Infof("ops log file %q was truncated, reading it from the start", f.path)
+		if _, err := f.file.Seek(0, io.SeekStart); err != nil {
+			return errors.Wrap(err, "failed to seek to the start of the file")
+		}
+		f.reader.Reset(f.file)
+		f.offset = 0
+		f.partial = nil
+		return f.readAvailable(handler)
+	}
+	return nil
+}
+
+func (f *follower) readAvailable(handler func(line []byte)) error {
+	for {
+		chunk, err := f.reader.ReadBytes('\n')
+		f.offset += int64(len(chunk))
+		if err != nil {
+			// keep the incomplete line until the rest is written
+			f.partial = append(f.partial, chunk...)
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrap(err, "failed to read file")
+		}
+		if len(f.partial) > 0 {
+			chunk = append(f.partial, chunk...)
+			f.partial = nil
+		}
+		handler(chunk)
+	}
+}
+
+func (f *follower) close() {
+	if f.file != nil {
+		_ = f.file.Close()
+		f.file = nil
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	entryBucketA = `{"bucket":"a","time":"2025-05-06T10:11:12.131415Z","user":"alice","operation":"get_obj","uri":"GET /a/obj HTTP/1.1","http_status":"200","trans_id":"tx1"}`
+	entryBucketB = `{"bucket":"b","time":"2025-05-06T10:11:13.131415Z","user":"bob","operation":"put_obj","uri":"PUT /b/obj HTTP/1.1","http_status":"200","trans_id":"tx2"}`
+)
+
+// recordingSink keeps the entries written to it
+type recordingSink struct {
+	entries []string
+	flushes int
+	closed  bool
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Write(entry *Entry) error {
+	s.entries = append(s.entries, string(entry.Raw))
+	return nil
+}
+
+func (s *recordingSink) Flush() error {
+	s.flushes++
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestParseEntry(t *testing.T) {
+	entry, err := ParseEntry([]byte(entryBucketA + "\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a", entry.Bucket)
+	assert.Equal(t, "alice", entry.User)
+	assert.Equal(t, "tx1", entry.TransID)
+	assert.Equal(t, entryBucketA, string(entry.Raw))
+
+	// entries of a JSON list are separated by commas
+	entry, err = ParseEntry([]byte(entryBucketB + ",\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, entryBucketB, string(entry.Raw))
+
+	_, err = ParseEntry([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestShipperSelected(t *testing.T) {
+	a, err := ParseEntry([]byte(entryBucketA))
+	require.NoError(t, err)
+	b, err := ParseEntry([]byte(entryBucketB))
+	require.NoError(t, err)
+
+	t.Run("no filter", func(t *testing.T) {
+		s := NewShipper("", &cephv1.OpsLogSidecar{}, nil)
+		assert.True(t, s.selected(a))
+		assert.True(t, s.selected(b))
+	})
+
+	t.Run("bucket filter", func(t *testing.T) {
+		s := NewShipper("", &cephv1.OpsLogSidecar{Filter: &cephv1.OpsLogFilterSpec{Buckets: []string{"a"}}}, nil)
+		assert.True(t, s.selected(a))
+		assert.False(t, s.selected(b))
+	})
+
+	t.Run("bucket and user filters", func(t *testing.T) {
+		s := NewShipper("", &cephv1.OpsLogSidecar{Filter: &cephv1.OpsLogFilterSpec{Buckets: []string{"a", "b"}, Users: []string{"bob"}}}, nil)
+		assert.False(t, s.selected(a))
+		assert.True(t, s.selected(b))
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		percent := int32(25)
+		s := NewShipper("", &cephv1.OpsLogSidecar{SamplePercent: &percent}, nil)
+		selected := 0
+		for i := 0; i < 1000; i++ {
+			entry := &Entry{TransID: fmt.Sprintf("tx%d", i)}
+			if s.selected(entry) {
+				selected++
+			}
+			// the decision is the same for the same transaction
+			assert.Equal(t, s.selected(entry), s.selected(&Entry{TransID: entry.TransID}))
+		}
+		assert.InDelta(t, 250, selected, 60)
+	})
+}
+
+func TestFollower(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "ops-log.log")
+	f := &follower{path: logFile}
+	defer f.close()
+
+	var lines []string
+	read := func() []string {
+		lines = nil
+		assert.NoError(t, f.readLines(func(line []byte) { lines = append(lines, string(line)) }))
+		return lines
+	}
+
+	// the file does not exist yet
+	assert.Empty(t, read())
+
+	require.NoError(t, os.WriteFile(logFile, []byte("one\ntw"), 0o600))
+	assert.Equal(t, []string{"one\n"}, read())
+
+	appendFile(t, logFile, "o\nthree\n")
+	assert.Equal(t, []string{"two\n", "three\n"}, read())
+	assert.Empty(t, read())
+
+	t.Run("truncated", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(logFile, []byte("four\n"), 0o600))
+		assert.Equal(t, []string{"four\n"}, read())
+	})
+
+	t.Run("rotated", func(t *testing.T) {
+		appendFile(t, logFile, "five\n")
+		require.NoError(t, os.Rename(logFile, logFile+".1"))
+		require.NoError(t, os.WriteFile(logFile, []byte("six\n"), 0o600))
+		assert.Equal(t, []string{"five\n", "six\n"}, read())
+	})
+}
+
+func appendFile(t *testing.T, name, content string) {
+	file, err := os.OpenFile(name, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+}
+
+func TestShipperRun(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "ops-log.log")
+	require.NoError(t, os.WriteFile(logFile, []byte(entryBucketA+"\nnot json\n"+entryBucketB+"\n"), 0o600))
+
+	sink := &recordingSink{}
+	spec := &cephv1.OpsLogSidecar{Filter: &cephv1.OpsLogFilterSpec{Users: []string{"bob"}}}
+	s := NewShipper(logFile, spec, []Sink{sink})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, s.Run(ctx, time.Hour))
+
+	assert.Equal(t, []string{entryBucketB}, sink.entries)
+	assert.Equal(t, 1, sink.flushes)
+	assert.True(t, sink.closed)
+}
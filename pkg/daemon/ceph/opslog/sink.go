@@ -0,0 +1,205 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+)
+
+const (
+	// syslog priority of the entries, facility local0 and severity informational
+	syslogPriority = 16*8 + 6
+	syslogAppName  = "rgw-ops-log"
+	dialTimeout    = 10 * time.Second
+	writeTimeout   = 10 * time.Second
+)
+
+// Sink is a destination of the ops log entries
+type Sink interface {
+	// Name identifies the sink in the logs
+	Name() string
+	// Write forwards an entry, or buffers it until the next flush
+	Write(entry *Entry) error
+	// Flush forwards the buffered entries that are due
+	Flush() error
+	// Close forwards all buffered entries and releases the sink
+	Close() error
+}
+
+// ValidateSinkSpec returns an error if the sink spec does not set exactly one valid sink type
+func ValidateSinkSpec(spec *cephv1.OpsLogSinkSpec) error {
+	count := 0
+	if spec.Stdout != nil {
+		count++
+	}
+	if spec.Syslog != nil {
+		count++
+		if spec.Syslog.Address == "" {
+			return errors.New("syslog address must be set")
+		}
+		if p := spec.Syslog.Protocol; p != "" && p != "udp" && p != "tcp" {
+			return errors.Errorf("invalid syslog protocol %q, must be udp or tcp", p)
+		}
+	}
+	if spec.Kafka != nil {
+		count++
+		if len(spec.Kafka.Brokers) == 0 {
+			return errors.New("at least one kafka broker must be set")
+		}
+		if spec.Kafka.Topic == "" {
+			return errors.New("kafka topic must be set")
+		}
+		if (spec.Kafka.Mechanism == "") != (spec.Kafka.CredentialsSecretName == "") {
+			return errors.New("kafka mechanism and credentialsSecretName must be set together")
+		}
+		if m := spec.Kafka.Mechanism; m != "" && m != "PLAIN" && m != "SCRAM-SHA-256" && m != "SCRAM-SHA-512" {
+			return errors.Errorf("invalid kafka mechanism %q, must be PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512", m)
+		}
+	}
+	if spec.S3 != nil {
+		count++
+		if spec.S3.Endpoint == "" || spec.S3.Bucket == "" || spec.S3.CredentialsSecretName == "" {
+			return errors.New("s3 endpoint, bucket and credentialsSecretName must be set")
+		}
+		if spec.S3.RotationInterval != "" {
+			interval, err := time.ParseDuration(spec.S3.RotationInterval)
+			if err != nil {
+				return errors.Wrapf(err, "invalid s3 rotation interval %q", spec.S3.RotationInterval)
+			}
+			if interval <= 0 {
+				return errors.Errorf("invalid s3 rotation interval %q, must be positive", spec.S3.RotationInterval)
+			}
+		}
+	}
+	if count != 1 {
+		return errors.Errorf("exactly one sink type must be set, found %d", count)
+	}
+	return nil
+}
+
+// NewSink returns the sink of the spec. The index is the position of the sink in the sidecar spec,
+// which selects the environment variables holding the credentials of the sink.
+func NewSink(index int, spec *cephv1.OpsLogSinkSpec, podName string) (Sink, error) {
+	if err := ValidateSinkSpec(spec); err != nil {
+		return nil, err
+	}
+	switch {
+	case spec.Syslog != nil:
+		return newSyslogSink(spec.Syslog, podName), nil
+	case spec.Kafka != nil:
+		return newKafkaSink(index, spec.Kafka, podName)
+	case spec.S3 != nil:
+		return newS3Sink(index, spec.S3, podName)
+	default:
+		return newStdoutSink(os.Stdout), nil
+	}
+}
+
+// stdoutSink prints the entries as JSON lines
+type stdoutSink struct {
+	writer *bufio.Writer
+}
+
+func newStdoutSink(w io.Writer) *stdoutSink {
+	return &stdoutSink{writer: bufio.NewWriter(w)}
+}
+
+func (s *stdoutSink) Name() string {
+	return "stdout"
+}
+
+func (s *stdoutSink) Write(entry *Entry) error {
+	if _, err := s.writer.Write(entry.Raw); err != nil {
+		return err
+	}
+	return s.writer.WriteByte('\n')
+}
+
+func (s *stdoutSink) Flush() error {
+	return s.writer.Flush()
+}
+
+func (s *stdoutSink) Close() error {
+	return s.writer.Flush()
+}
+
+// syslogSink sends the entries to a syslog endpoint in the RFC 5424 format. Messages sent over TCP
+// are framed with their length as described by RFC 6587.
+type syslogSink struct {
+	address  string
+	protocol string
+	hostname string
+	conn     net.Conn
+}
+
+func newSyslogSink(spec *cephv1.OpsLogSyslogSinkSpec, hostname string) *syslogSink {
+	protocol := spec.Protocol
+	if protocol == "" {
+		protocol = "udp"
+	}
+	return &syslogSink{address: spec.Address, protocol: protocol, hostname: hostname}
+}
+
+func (s *syslogSink) Name() string {
+	return fmt.Sprintf("syslog %s://%s", s.protocol, s.address)
+}
+
+func (s *syslogSink) Write(entry *Entry) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout(s.protocol, s.address, dialTimeout)
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to syslog endpoint")
+		}
+		s.conn = conn
+	}
+
+	msg := fmt.Sprintf("<%d>1 %s %s %s - - - %s", syslogPriority, time.Now().UTC().Format(time.RFC3339Nano), s.hostname, syslogAppName, entry.Raw)
+	if s.protocol == "tcp" {
+		msg = fmt.Sprintf("%d %s", len(msg), msg)
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return errors.Wrap(err, "failed to set syslog write deadline")
+	}
+	if _, err := s.conn.Write([]byte(msg)); err != nil {
+		// reconnect on the next entry
+		_ = s.conn.Close()
+		s.conn = nil
+		return errors.Wrap(err, "failed to send syslog message")
+	}
+	return nil
+}
+
+func (s *syslogSink) Flush() error {
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
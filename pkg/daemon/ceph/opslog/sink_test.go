@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opslog
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSinkSpec(t *testing.T) {
+	valid := []cephv1.OpsLogSinkSpec{
+		{Stdout: &cephv1.OpsLogStdoutSinkSpec{}},
+		{Syslog: &cephv1.OpsLogSyslogSinkSpec{Address: "syslog:514"}},
+		{Syslog: &cephv1.OpsLogSyslogSinkSpec{Address: "syslog:601", Protocol: "tcp"}},
+		{Kafka: &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9092"}, Topic: "ops"}},
+		{Kafka: &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9093"}, Topic: "ops", UseSSL: true, Mechanism: "SCRAM-SHA-256", CredentialsSecretName: "creds"}},
+		{S3: &cephv1.OpsLogS3SinkSpec{Endpoint: "http://s3", Bucket: "audit", CredentialsSecretName: "creds", RotationInterval: "5m"}},
+	}
+	for _, spec := range valid {
+		assert.NoError(t, ValidateSinkSpec(&spec))
+	}
+
+	invalid := []cephv1.OpsLogSinkSpec{
+		{},
+		{Stdout: &cephv1.OpsLogStdoutSinkSpec{}, Syslog: &cephv1.OpsLogSyslogSinkSpec{Address: "syslog:514"}},
+		{Syslog: &cephv1.OpsLogSyslogSinkSpec{}},
+		{Syslog: &cephv1.OpsLogSyslogSinkSpec{Address: "syslog:514", Protocol: "http"}},
+		{Kafka: &cephv1.OpsLogKafkaSinkSpec{Topic: "ops"}},
+		{Kafka: &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9092"}}},
+		{Kafka: &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9092"}, Topic: "ops", Mechanism: "PLAIN"}},
+		{Kafka: &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9092"}, Topic: "ops", CredentialsSecretName: "creds"}},
+		{Kafka: &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9092"}, Topic: "ops", Mechanism: "GSSAPI", CredentialsSecretName: "creds"}},
+		{S3: &cephv1.OpsLogS3SinkSpec{Endpoint: "http://s3", Bucket: "audit"}},
+		{S3: &cephv1.OpsLogS3SinkSpec{Endpoint: "http://s3", Bucket: "audit", CredentialsSecretName: "creds", RotationInterval: "5"}},
+		{S3: &cephv1.OpsLogS3SinkSpec{Endpoint: "http://s3", Bucket: "audit", CredentialsSecretName: "creds", RotationInterval: "-5m"}},
+	}
+	for _, spec := range invalid {
+		assert.Error(t, ValidateSinkSpec(&spec))
+	}
+}
+
+func TestStdoutSink(t *testing.T) {
+	var out bytes.Buffer
+	sink := newStdoutSink(&out)
+	assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+	assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketB)}))
+	assert.NoError(t, sink.Flush())
+	assert.Equal(t, entryBucketA+"\n"+entryBucketB+"\n", out.String())
+}
+
+func TestSyslogSink(t *testing.T) {
+	t.Run("udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		sink := newSyslogSink(&cephv1.OpsLogSyslogSinkSpec{Address: conn.LocalAddr().String()}, "rgw-pod")
+		defer sink.Close()
+		assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+
+		buf := make([]byte, 4096)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		msg := string(buf[:n])
+		assert.True(t, strings.HasPrefix(msg, "<134>1 "), msg)
+		assert.Contains(t, msg, " rgw-pod rgw-ops-log - - - "+entryBucketA)
+	})
+
+	t.Run("tcp", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		sink := newSyslogSink(&cephv1.OpsLogSyslogSinkSpec{Address: listener.Addr().String(), Protocol: "tcp"}, "rgw-pod")
+		defer sink.Close()
+		assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketA)}))
+		assert.NoError(t, sink.Write(&Entry{Raw: []byte(entryBucketB)}))
+
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		reader := bufio.NewReader(conn)
+		for _, expected := range []string{entryBucketA, entryBucketB} {
+			// messages are framed by their length
+			prefix, err := reader.ReadString(' ')
+			require.NoError(t, err)
+			length, err := strconv.Atoi(strings.TrimSpace(prefix))
+			require.NoError(t, err)
+			msg := make([]byte, length)
+			_, err = io.ReadFull(reader, msg)
+			require.NoError(t, err)
+			assert.True(t, strings.HasSuffix(string(msg), " - - - "+expected), string(msg))
+		}
+	})
+}
@@ -155,8 +155,16 @@ func configRawDevice(name string, context *clusterd.Context) (*sys.LocalDisk, er
 	return rawDevice, nil
 }
 
-// Provision provisions an OSD
-func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topologyAffinity, deviceFilter, metaDevice string) error {
+// Provision provisions an OSD. If the provisioning fails, the failure is reported in the status
+// along with the decisions taken for the devices, if any.
+func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topologyAffinity, deviceFilter, metaDevice string) (err error) {
+	var report *deviceReport
+	defer func() {
+		if err != nil {
+			updateFailedStatus(agent, report, err)
+		}
+	}()
+
 	if agent.pvcBacked && os.Getenv(oposd.EncryptedDeviceEnvVarName) == "true" {
 		logger.Debug("encryption configuration detecting, populating kek to an env variable")
 		// Init KMS store, retrieve the KEK and store it as an env var for ceph-volume
@@ -167,7 +175,7 @@ func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topolo
 	}
 
 	// Print dmsetup version
-	err := dmsetupVersion(context)
+	err = dmsetupVersion(context)
 	if err != nil {
 		return errors.Wrap(err, "failed to print device mapper version")
 	}
@@ -213,13 +221,13 @@ func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topolo
 	logger.Info("creating and starting the osds")
 
 	// determine the set of devices that can/should be used for OSDs.
-	devices, err := getAvailableDevices(context, agent)
+	devices, report, err := getAvailableDevices(context, agent)
 	if err != nil {
 		return errors.Wrap(err, "failed to get available devices")
 	}
 
 	// orchestration is about to start, update the status
-	status = oposd.OrchestrationStatus{Status: oposd.OrchestrationStatusOrchestrating, PvcBackedOSD: agent.pvcBacked}
+	status = oposd.OrchestrationStatus{Status: oposd.OrchestrationStatusOrchestrating, PvcBackedOSD: agent.pvcBacked, Devices: report.devices}
 	oposd.UpdateNodeOrPVCStatus(agent.clusterInfo.Context, agent.kv, agent.nodeName, status)
 
 	// start the desired OSDs on devices
@@ -229,6 +237,7 @@ func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topolo
 	if err != nil {
 		return errors.Wrap(err, "failed to configure devices")
 	}
+	report.setOSDIDs(context.Devices, deviceOSDs)
 
 	// Let's fail if no OSDs were configured
 	// This likely means the filter for available devices passed (in PVC case)
@@ -236,7 +245,7 @@ func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topolo
 	// So we need to make sure the list is filled up, otherwise fail
 	if len(deviceOSDs) == 0 {
 		logger.Warningf("skipping OSD configuration as no devices matched the storage settings for this node %q", agent.nodeName)
-		status = oposd.OrchestrationStatus{OSDs: deviceOSDs, Status: oposd.OrchestrationStatusCompleted, PvcBackedOSD: agent.pvcBacked}
+		status = oposd.OrchestrationStatus{OSDs: deviceOSDs, Status: oposd.OrchestrationStatusCompleted, PvcBackedOSD: agent.pvcBacked, Devices: report.devices}
 		oposd.UpdateNodeOrPVCStatus(agent.clusterInfo.Context, agent.kv, agent.nodeName, status)
 		return nil
 	}
@@ -276,12 +285,22 @@ func Provision(context *clusterd.Context, agent *OsdAgent, crushLocation, topolo
 	}
 
 	// orchestration is completed, update the status
-	status = oposd.OrchestrationStatus{OSDs: deviceOSDs, Status: oposd.OrchestrationStatusCompleted, PvcBackedOSD: agent.pvcBacked}
+	status = oposd.OrchestrationStatus{OSDs: deviceOSDs, Status: oposd.OrchestrationStatusCompleted, PvcBackedOSD: agent.pvcBacked, Devices: report.devices}
 	oposd.UpdateNodeOrPVCStatus(agent.clusterInfo.Context, agent.kv, agent.nodeName, status)
 
 	return nil
 }
 
+// updateFailedStatus updates the status map with the failure details and the decisions taken for
+// the devices before the failure
+func updateFailedStatus(agent *OsdAgent, report *deviceReport, err error) {
+	status := oposd.OrchestrationStatus{Status: oposd.OrchestrationStatusFailed, Message: err.Error(), PvcBackedOSD: agent.pvcBacked}
+	if report != nil {
+		status.Devices = report.devices
+	}
+	oposd.UpdateNodeOrPVCStatus(agent.clusterInfo.Context, agent.kv, agent.nodeName, status)
+}
+
 func matchDevLinks(devLinks, deviceName string) bool {
 	for _, link := range strings.Split(devLinks, " ") {
 		if link == deviceName {
@@ -338,7 +357,7 @@ func getOsdUUIDImpl(device *sys.LocalDisk) (string, error) {
 
 var getOsdUUID func(device *sys.LocalDisk) (string, error) = getOsdUUIDImpl
 
-func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsdMapping, error) {
+func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsdMapping, *deviceReport, error) {
 	desiredDevices := agent.devices
 	logger.Debugf("desiredDevices are %+v", desiredDevices)
 
@@ -348,10 +367,12 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 	}
 
 	available := &DeviceOsdMapping{Entries: map[string]*DeviceOsdIDEntry{}}
+	report := &deviceReport{}
 	for _, device := range context.Devices {
 		// Add detection for mounted device and skip if mounted
 		if device.Mountpoint != "" {
 			logger.Infof("skipping device %q with mountpoint %q", device.Name, device.Mountpoint)
+			report.skip(device.Name, "device is mounted on %q", device.Mountpoint)
 			continue
 		}
 
@@ -363,8 +384,10 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 			if device.Filesystem == "crypto_LUKS" && agent.pvcBacked {
 				if isCephEncryptedBlock(context, agent.clusterInfo.FSID, device.Name) {
 					logger.Infof("encrypted disk %q is an OSD part of this cluster, skipping it", device.Name)
+					report.skip(device.Name, "encrypted disk is an existing OSD of this cluster")
 				} else {
 					logger.Infof("encrypted disk %q is unknown, skipping it", device.Name)
+					report.skip(device.Name, "encrypted disk is not an OSD of this cluster")
 				}
 				// We must skip so that the device is not marked as available, but will later be
 				// picked up by the GetCephVolumeRawOSDs() call.
@@ -376,17 +399,20 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 				logger.Infof("allowing multipath disk %q with filesystem %q", device.Name, device.Filesystem)
 			} else {
 				logger.Infof("skipping device %q because it contains a filesystem %q", device.Name, device.Filesystem)
+				report.skip(device.Name, "device contains a filesystem %q", device.Filesystem)
 				continue
 			}
 		} else {
 			uuid, err := getOsdUUID(device)
 			if err != nil {
 				logger.Errorf("skipping device %q, failed to get OSD information. %v", device.Name, err)
+				report.skip(device.Name, "failed to get OSD information. %v", err)
 				continue
 			}
 
 			if uuid != "" {
 				logger.Infof("skipping device %q, detected an existing OSD. UUID=%s", device.Name, uuid)
+				report.skip(device.Name, "detected an existing OSD. UUID=%s", uuid)
 				continue
 			}
 		}
@@ -395,6 +421,7 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 			device, err := clusterd.PopulateDeviceUdevInfo(device.Name, context.Executor, device)
 			if err != nil {
 				logger.Errorf("failed to get udev info of partition %q. %v", device.Name, err)
+				report.skip(device.Name, "failed to get udev info of partition. %v", err)
 				continue
 			}
 		}
@@ -435,12 +462,14 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 
 		if !isAvailable {
 			logger.Infof("skipping device %q: %s.", device.Name, rejectedReason)
+			report.skip(device.Name, "%s", rejectedReason)
 			continue
 		}
 		logger.Infof("device %q is available.", device.Name)
 
 		if device.Type == sys.PartType && agent.storeConfig.EncryptedDevice {
 			logger.Infof("partition %q is not picked because encrypted OSD on partition is not allowed", device.Name)
+			report.skip(device.Name, "encrypted OSD on partition is not allowed")
 			continue
 		}
 
@@ -452,9 +481,11 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 			// user has specified all devices, use the current one for data
 			if device.Type == sys.LVMType {
 				logger.Infof("logical volume %q is not picked by `useAllDevices: true`. please specify the exact device name (e.g. /dev/vg/lv) in `devices` field instead", device.Name)
+				report.skip(device.Name, "logical volume is not picked by `useAllDevices: true`")
 				continue
 			} else if device.Type == sys.LoopType {
 				logger.Infof("loop device %q is not picked by `useAllDevices: true`. please specify the exact device name (e.g. /dev/loop0) in `devices` field  instead", device.Name)
+				report.skip(device.Name, "loop device is not picked by `useAllDevices: true`")
 				continue
 			}
 			deviceInfo = &DeviceOsdIDEntry{Data: unassignedOSDID, DeviceInfo: device}
@@ -548,12 +579,15 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 				}
 			} else {
 				logger.Infof("skipping device %q that does not match the device filter/list (%v). %v", device.Name, desiredDevices, err)
+				report.skip(device.Name, "device does not match the device filter/list")
 			}
 		} else {
 			logger.Infof("skipping device %q until the admin specifies it can be used by an osd", device.Name)
+			report.skip(device.Name, "no device is specified to be used by an osd")
 		}
 
 		if deviceInfo != nil {
+			report.use(device.Name)
 			// When running on PVC, we typically have a single device only
 			// So it's fine to name the first entry of the map "data" instead of the PVC name
 			// It is particularly useful when a metadata PVC is used because we need to identify it in the map
@@ -573,7 +607,7 @@ func getAvailableDevices(context *clusterd.Context, agent *OsdAgent) (*DeviceOsd
 		}
 	}
 
-	return available, nil
+	return available, report, nil
 }
 
 // releaseLVMDevice deactivates the LV to release the device.
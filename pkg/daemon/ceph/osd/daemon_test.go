@@ -16,17 +16,25 @@ limitations under the License.
 package osd
 
 import (
+	"context"
+	"encoding/json"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
 	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
 	cephver "github.com/rook/rook/pkg/operator/ceph/version"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
 	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/rook/rook/pkg/util/sys"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -389,9 +397,19 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 		pvcBacked:      pvcBackedOSD,
 		clusterInfo:    &cephclient.ClusterInfo{},
 	}
-	mapping, err := getAvailableDevices(context, agent)
+	mapping, report, err := getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 7, len(mapping.Entries))
+	assert.Equal(t, len(context.Devices), len(report.devices))
+	decisions := map[string]cephv1.OSDDeviceProvisioningStatus{}
+	for _, d := range report.devices {
+		decisions[d.Name] = d
+	}
+	assert.Equal(t, cephv1.OSDDeviceUsed, decisions["sda"].Decision)
+	assert.Equal(t, cephv1.OSDDeviceSkipped, decisions["sdv1"].Decision)
+	assert.Equal(t, `device contains a filesystem "ext2"`, decisions["sdv1"].Reason)
+	assert.Equal(t, cephv1.OSDDeviceSkipped, decisions["dm-0"].Decision)
+	assert.Contains(t, decisions["dm-0"].Reason, "useAllDevices")
 	assert.Equal(t, -1, mapping.Entries["sda"].Data)
 	assert.Equal(t, -1, mapping.Entries["sdd"].Data)
 	assert.Equal(t, -1, mapping.Entries["sde"].Data)
@@ -409,17 +427,17 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 	// select no devices both using and not using a filter
 	agent.metadataDevice = ""
 	agent.devices = nil
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(mapping.Entries))
 
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(mapping.Entries))
 
 	// select the sd* devices
 	agent.devices = []DesiredDevice{{Name: "^sd.$", IsFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 3, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sda"].Data)
@@ -427,27 +445,27 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 
 	// select a logical volume by deviceFilter
 	agent.devices = []DesiredDevice{{Name: "dm-0", IsFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(mapping.Entries))
 
 	// select an exact device
 	agent.devices = []DesiredDevice{{Name: "sdd"}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sdd"].Data)
 
 	// select an exact logical volume
 	agent.devices = []DesiredDevice{{Name: "/dev/mapper/vg1-lv1"}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["dm-0"].Data)
 
 	// select all devices except those that have a prefix of "s"
 	agent.devices = []DesiredDevice{{Name: "^[^s]", IsFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 3, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["rda"].Data)
@@ -456,7 +474,7 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 
 	// select the sd* devices by devicePathFilter
 	agent.devices = []DesiredDevice{{Name: "^/dev/sd.$", IsDevicePathFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 3, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sda"].Data)
@@ -464,31 +482,31 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 
 	// select a logical volume by devicePathFilter
 	agent.devices = []DesiredDevice{{Name: "dm-0", IsDevicePathFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(mapping.Entries))
 
 	// select the devices that have udev persistent names by devicePathFilter
 	agent.devices = []DesiredDevice{{Name: "^/dev/disk/by-path/.*-scsi-.*", IsDevicePathFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sda"].Data)
 	assert.Equal(t, -1, mapping.Entries["sdd"].Data)
 	agent.devices = []DesiredDevice{{Name: "^/dev/disk/by-partlabel/te.*", IsDevicePathFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sdt1"].Data)
 
 	// select a device by explicit link
 	agent.devices = []DesiredDevice{{Name: "/dev/disk/by-id/sde-0x0000"}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sde"].Data)
 	agent.devices = []DesiredDevice{{Name: "/dev/disk/by-partlabel/test"}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sdt1"].Data)
@@ -499,7 +517,7 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 	}
 	agent.devices = []DesiredDevice{{Name: "all"}}
 	agent.pvcBacked = true
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries), mapping)
 
@@ -507,7 +525,7 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 	context.Devices = []*sys.LocalDisk{
 		{Name: "/mnt/set1-0-data-wjkla", RealPath: "/dev/mapper/vg1-lv1", Type: "data"},
 	}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries), mapping)
 
@@ -516,7 +534,7 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 	getOsdUUID = func(device *sys.LocalDisk) (string, error) {
 		return "c6416047-1e71-412a-9b61-ca398b815e50", nil
 	}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(mapping.Entries), mapping)
 	getOsdUUID = func(device *sys.LocalDisk) (string, error) {
@@ -536,27 +554,27 @@ NAME="sdb1" SIZE="30" TYPE="part" PKNAME="sdb"`, nil
 	agent.clusterInfo.CephVersion = cephver.Squid
 	agent.pvcBacked = false
 	agent.devices = []DesiredDevice{{Name: "loop0"}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["loop0"].Data)
 
 	// loop device: useAllDevices
 	agent.devices = []DesiredDevice{{Name: "all"}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(mapping.Entries))
 	assert.Equal(t, -1, mapping.Entries["sda"].Data)
 
 	// loop device: deviceFilter
 	agent.devices = []DesiredDevice{{Name: "loop0", IsFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(mapping.Entries))
 
 	// loop device: devicePathFilter
 	agent.devices = []DesiredDevice{{Name: "^/dev/loop.$", IsDevicePathFilter: true}}
-	mapping, err = getAvailableDevices(context, agent)
+	mapping, _, err = getAvailableDevices(context, agent)
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(mapping.Entries))
 }
@@ -575,3 +593,78 @@ func TestGetVolumeGroupName(t *testing.T) {
 	vgName = getVolumeGroupName(invalidLVPath2)
 	assert.Equal(t, vgName, "")
 }
+
+func TestDeviceReportSetOSDIDs(t *testing.T) {
+	disks := []*sys.LocalDisk{
+		{Name: "sda", RealPath: "/dev/sda"},
+		{Name: "sdb", RealPath: "/dev/sdb", DevLinks: "/dev/disk/by-id/scsi-4567"},
+		{Name: "sdc", RealPath: "/dev/sdc"},
+	}
+	report := &deviceReport{}
+	report.use("sda")
+	report.skip("sdb", "detected an existing OSD. UUID=%s", "1234")
+	report.skip("sdc", "device is mounted on %q", "/var")
+
+	report.setOSDIDs(disks, []oposd.OSDInfo{{ID: 3, BlockPath: "/dev/sda"}, {ID: 1, BlockPath: "/dev/disk/by-id/scsi-4567"}})
+	assert.Equal(t, 3, *report.devices[0].OSDID)
+	assert.Equal(t, 1, *report.devices[1].OSDID)
+	assert.Equal(t, "detected an existing OSD. UUID=1234", report.devices[1].Reason)
+	assert.Nil(t, report.devices[2].OSDID)
+	assert.Equal(t, `device is mounted on "/var"`, report.devices[2].Reason)
+}
+
+func TestProvisionFailureReportsDevices(t *testing.T) {
+	getOsdUUID = func(device *sys.LocalDisk) (string, error) {
+		return "", nil
+	}
+
+	// sdc has a file system, so no device is configured and listing the existing OSDs fails
+	mockExec := func(command string, args ...string) (string, error) {
+		logger.Infof("OUTPUT for %s %v", command, args)
+		switch command {
+		case "dmsetup":
+			return "Library version: 1.02.181", nil
+		case "lsblk":
+			if args[0] == "--all" {
+				return "sdc", nil
+			}
+			return `SIZE="65" ROTA="1" RO="0" TYPE="disk" PKNAME="" NAME="/dev/sdc" KNAME="/dev/sdc" MOUNTPOINT="" FSTYPE=""`, nil
+		case "udevadm":
+			return udevFSOutput, nil
+		case "ceph-volume", "stdbuf":
+			return "", errors.New("ceph-volume failed")
+		}
+		return "", nil
+	}
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput:         mockExec,
+		MockExecuteCommandWithCombinedOutput: mockExec,
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			return mockExec(command, args...)
+		},
+	}
+
+	ctx := context.TODO()
+	clientset := test.New(t, 1)
+	kv := k8sutil.NewConfigMapKVStore("rook-ceph", clientset, &k8sutil.OwnerInfo{})
+	agent := &OsdAgent{
+		devices:     []DesiredDevice{{Name: "all"}},
+		clusterInfo: &cephclient.ClusterInfo{Namespace: "rook-ceph", Context: ctx},
+		nodeName:    "node1",
+		kv:          kv,
+	}
+	err := Provision(&clusterd.Context{Executor: executor}, agent, "", "", "", "")
+	assert.Error(t, err)
+
+	configMaps, err := clientset.CoreV1().ConfigMaps("rook-ceph").List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, configMaps.Items, 1)
+	var status oposd.OrchestrationStatus
+	assert.NoError(t, json.Unmarshal([]byte(configMaps.Items[0].Data["status"]), &status))
+	assert.Equal(t, oposd.OrchestrationStatusFailed, status.Status)
+	assert.Contains(t, status.Message, "failed to configure devices")
+	assert.Len(t, status.Devices, 1)
+	assert.Equal(t, "sdc", status.Devices[0].Name)
+	assert.Equal(t, cephv1.OSDDeviceSkipped, status.Devices[0].Decision)
+	assert.Contains(t, status.Devices[0].Reason, "filesystem")
+}
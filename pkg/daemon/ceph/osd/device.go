@@ -18,8 +18,13 @@ package osd
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
 	"github.com/rook/rook/pkg/util/sys"
 )
@@ -96,3 +101,46 @@ func (d *DesiredDevice) UpdateDeviceClass(agent *OsdAgent, device *sys.LocalDisk
 
 	d.DeviceClass = sys.GetDiskDeviceType(device)
 }
+
+// deviceReport records the decision taken for each device discovered by the OSD prepare job so
+// that the operator can report why a device was or was not turned into an OSD.
+type deviceReport struct {
+	devices []cephv1.OSDDeviceProvisioningStatus
+}
+
+func (r *deviceReport) skip(name, reason string, args ...interface{}) {
+	r.devices = append(r.devices, cephv1.OSDDeviceProvisioningStatus{
+		Name:     name,
+		Decision: cephv1.OSDDeviceSkipped,
+		Reason:   fmt.Sprintf(reason, args...),
+	})
+}
+
+func (r *deviceReport) use(name string) {
+	r.devices = append(r.devices, cephv1.OSDDeviceProvisioningStatus{
+		Name:     name,
+		Decision: cephv1.OSDDeviceUsed,
+	})
+}
+
+// setOSDIDs sets the ID of the OSDs configured on the reported devices. The OSDs are matched
+// with the devices by their block path, so OSDs on logical volumes created by ceph-volume are not
+// matched with the underlying disk.
+func (r *deviceReport) setOSDIDs(disks []*sys.LocalDisk, osds []oposd.OSDInfo) {
+	for i := range r.devices {
+		var paths []string
+		for _, disk := range disks {
+			if disk.Name == r.devices[i].Name {
+				paths = append(strings.Fields(disk.DevLinks), disk.Name, disk.RealPath, filepath.Join("/dev", disk.Name))
+				break
+			}
+		}
+		for _, osd := range osds {
+			if osd.BlockPath != "" && slices.Contains(paths, osd.BlockPath) {
+				osdID := osd.ID
+				r.devices[i].OSDID = &osdID
+				break
+			}
+		}
+	}
+}
@@ -192,6 +192,34 @@ func removeEncryptionKeySlot(context *clusterd.Context, disk, passphrase, slot s
 	return nil
 }
 
+// reencryptDevice replaces the volume key of the given LUKS2 device and re-encrypts its data online
+// while the device stays open. Only the key slot of the given passphrase is kept. If a previous
+// re-encryption was interrupted, cryptsetup resumes it from the checkpoint stored in the LUKS header.
+func reencryptDevice(context *clusterd.Context, disk, passphrase, slot string) error {
+	passphraseFile, err := util.CreateTempFile(passphrase)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create passphrase file")
+	}
+	defer os.Remove(passphraseFile.Name())
+
+	args := []string{
+		"--verbose",
+		"--batch-mode",
+		fmt.Sprintf("--key-file=%s", passphraseFile.Name()),
+		fmt.Sprintf("--key-slot=%s", slot),
+		"--resilience=checksum",
+		"reencrypt",
+		disk,
+	}
+	// the re-encryption rewrites the whole device, so it cannot be bound by a short timeout
+	output, err := context.Executor.ExecuteCommandWithCombinedOutput(cryptsetupBinary, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to re-encrypt device %q: %q", disk, output)
+	}
+
+	return nil
+}
+
 // ensureEncryptionKey ensures the given key is in the given slot of the target disk.
 // If the error, received from lukChangeKey cmd, shows that the key did not match,
 // the function will return false and no error signify that the given key is not in the slot.
@@ -116,6 +116,38 @@ func TestRemoveEncryptionKeySlot(t *testing.T) {
 	})
 }
 
+func TestReencryptDevice(t *testing.T) {
+	t.Run("re-encryption succeeds", func(t *testing.T) {
+		executor := &exectest.MockExecutor{}
+		executor.MockExecuteCommandWithCombinedOutput = func(command string, args ...string) (string, error) {
+			logger.Infof("%s %v", command, args)
+			if command == "cryptsetup" && strings.HasPrefix(args[2], "--key-file=") &&
+				args[3] == "--key-slot=0" && args[5] == "reencrypt" {
+				return "success", nil
+			}
+
+			return "", errors.Errorf("unknown command %s %s", command, args)
+		}
+
+		context := &clusterd.Context{Executor: executor}
+		err := reencryptDevice(context, "/var/lib/ceph/osd/block-tmp", "passphrase", "0")
+		assert.NoError(t, err)
+	})
+	t.Run("re-encryption fails", func(t *testing.T) {
+		executor := &exectest.MockExecutor{}
+		executor.MockExecuteCommandWithCombinedOutput = func(command string, args ...string) (string, error) {
+			logger.Infof("%s %v", command, args)
+
+			return "Device is not LUKS2", errors.New("Failed")
+		}
+
+		context := &clusterd.Context{Executor: executor}
+		err := reencryptDevice(context, "/var/lib/ceph/osd/block-tmp", "passphrase", "0")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Device is not LUKS2")
+	})
+}
+
 func TestEnsureEncryptionKey(t *testing.T) {
 	t.Run("given passphrase matches with the one in key slot", func(t *testing.T) {
 		executor := &exectest.MockExecutor{}
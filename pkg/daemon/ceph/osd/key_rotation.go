@@ -17,18 +17,69 @@ limitations under the License.
 package osd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
 	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
 	"github.com/rook/rook/pkg/daemon/ceph/osd/kms"
 	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	slotZero string = "0"
 	slotOne  string = "1"
+
+	// keyManagementLockFile is created in the OSD directory shared by the key rotation and the
+	// re-encryption jobs of an OSD
+	keyManagementLockFile = "key-management.lock"
 )
 
+// isClusterClean is overridden in the unit tests
+var isClusterClean = cephclient.IsClusterClean
+
+// lockKeyManagement takes the lock shared by the key rotation and the re-encryption jobs of an OSD so
+// that they never change the LUKS headers of the OSD devices at the same time. It does not wait for
+// the lock, the job backs off and retries when the lock is held by the other job.
+func lockKeyManagement(devicePaths []string) (func(), error) {
+	if len(devicePaths) == 0 {
+		return nil, errors.New("no device to lock")
+	}
+	lockPath := filepath.Join(filepath.Dir(devicePaths[0]), keyManagementLockFile)
+	// #nosec G304 the lock file is in the OSD directory
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open lock file %q", lockPath)
+	}
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, errors.Errorf("the keys of the devices are being changed by another job, lock file %q is held", lockPath)
+		}
+		return nil, errors.Wrapf(err, "failed to lock %q", lockPath)
+	}
+	return func() {
+		if err := syscall.Flock(int(f.Fd()), syscall.LOCK_UN); err != nil {
+			logger.Warningf("failed to unlock %q. %v", lockPath, err)
+		}
+		f.Close()
+	}, nil
+}
+
 func RotateKeyEncryptionKey(context *clusterd.Context, kms *kms.Config, secretName string, devicePaths []string) error {
+	unlock, err := lockKeyManagement(devicePaths)
+	if err != nil {
+		return errors.Wrap(err, "failed to take the key management lock")
+	}
+	defer unlock()
+
 	logger.Info("fetching the current key")
 	// Fetch the currentKey.
 	currentKey, err := kms.GetSecret(secretName)
@@ -100,3 +151,94 @@ func RotateKeyEncryptionKey(context *clusterd.Context, kms *kms.Config, secretNa
 
 	return nil
 }
+
+// ReencryptVolumeKey replaces the LUKS volume key of the encrypted devices of the given OSD, one
+// device at a time. Unlike RotateKeyEncryptionKey, the data on the devices is rewritten with the new
+// volume key. The progress is recorded in the re-encryption status ConfigMap of the OSD.
+//
+// The re-encryption runs only when no other OSD is being re-encrypted and all the PGs are clean, so
+// that only one OSD at a time is slowed down by the re-encryption. Otherwise, the re-encryption is
+// postponed and an error is returned for the job to back off and retry.
+func ReencryptVolumeKey(context *clusterd.Context, kms *kms.Config, osdID int, pgHealthyRegex, secretName string, devicePaths []string) error {
+	ctx := kms.ClusterInfo.Context
+	kv := k8sutil.NewConfigMapKVStore(kms.ClusterInfo.Namespace, context.Clientset, kms.ClusterInfo.OwnerInfo)
+
+	unlock, err := lockKeyManagement(devicePaths)
+	if err != nil {
+		return errors.Wrap(err, "failed to take the key management lock")
+	}
+	defer unlock()
+
+	if err := checkReencryptionAllowed(context, kms.ClusterInfo, osdID, pgHealthyRegex); err != nil {
+		return errors.Wrapf(err, "postponing the re-encryption of osd %d", osdID)
+	}
+
+	status := cephv1.OSDReencryptionStatus{ID: osdID}
+	lastStatus, err := oposd.GetReencryptionStatus(ctx, kv, osdID)
+	if err != nil {
+		logger.Warningf("failed to get the last re-encryption status of osd %d. %v", osdID, err)
+	} else if lastStatus != nil {
+		status.LastReencryptionTime = lastStatus.LastReencryptionTime
+	}
+	updateStatus := func(phase, message string, done int) {
+		status.Phase = phase
+		status.Message = message
+		status.Progress = fmt.Sprintf("%d/%d", done, len(devicePaths))
+		if err := oposd.UpdateReencryptionStatus(ctx, kv, status); err != nil {
+			// the status is informative only, do not fail the re-encryption
+			logger.Errorf("failed to update re-encryption status of osd %d. %v", osdID, err)
+		}
+	}
+
+	logger.Info("fetching the current key")
+	currentKey, err := kms.GetSecret(secretName)
+	if err != nil {
+		updateStatus(oposd.ReencryptionPhaseFailed, "failed to get the current key", 0)
+		return errors.Wrapf(err, "failed to get secret %q", secretName)
+	}
+	if currentKey == "" {
+		updateStatus(oposd.ReencryptionPhaseFailed, "the current key is empty", 0)
+		return errors.Errorf("the secret %q is empty, cannot re-encrypt the devices", secretName)
+	}
+
+	for i, devicePath := range devicePaths {
+		updateStatus(oposd.ReencryptionPhaseInProgress, "", i)
+		// the key rotation always keeps the current key in slot 0
+		logger.Infof("re-encrypting the device %q of osd %d", devicePath, osdID)
+		err = reencryptDevice(context, devicePath, currentKey, slotZero)
+		if err != nil {
+			updateStatus(oposd.ReencryptionPhaseFailed, fmt.Sprintf("failed to re-encrypt device %q", devicePath), i)
+			return errors.Wrapf(err, "failed to re-encrypt device %q of osd %d", devicePath, osdID)
+		}
+	}
+
+	now := metav1.Now()
+	status.LastReencryptionTime = &now
+	updateStatus(oposd.ReencryptionPhaseCompleted, "", len(devicePaths))
+	logger.Infof("successfully re-encrypted the devices of osd %d", osdID)
+
+	return nil
+}
+
+// checkReencryptionAllowed returns an error if another OSD is being re-encrypted or if the PGs are not
+// all clean.
+func checkReencryptionAllowed(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, osdID int, pgHealthyRegex string) error {
+	statuses, err := oposd.ListReencryptionStatus(clusterInfo.Context, context.Clientset, clusterInfo.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if status.ID != osdID && status.Phase == oposd.ReencryptionPhaseInProgress {
+			return errors.Errorf("osd %d is being re-encrypted", status.ID)
+		}
+	}
+
+	msg, clean, err := isClusterClean(context, clusterInfo, pgHealthyRegex)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if the cluster is clean")
+	}
+	if !clean {
+		return errors.New(msg)
+	}
+	return nil
+}
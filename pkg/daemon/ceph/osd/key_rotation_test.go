@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	oposd "github.com/rook/rook/pkg/operator/ceph/cluster/osd"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockKeyManagement(t *testing.T) {
+	dir := t.TempDir()
+	devicePaths := []string{filepath.Join(dir, "block-tmp"), filepath.Join(dir, "block.db-tmp")}
+
+	_, err := lockKeyManagement(nil)
+	assert.Error(t, err)
+
+	unlock, err := lockKeyManagement(devicePaths)
+	assert.NoError(t, err)
+
+	// the other job backs off while the lock is held
+	_, err = lockKeyManagement(devicePaths[1:])
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is held")
+
+	unlock()
+	unlock, err = lockKeyManagement(devicePaths[1:])
+	assert.NoError(t, err)
+	unlock()
+}
+
+func TestCheckReencryptionAllowed(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+	clientset := test.New(t, 1)
+	clusterdContext := &clusterd.Context{Clientset: clientset}
+	clusterInfo := &cephclient.ClusterInfo{Namespace: namespace, Context: ctx}
+	kv := k8sutil.NewConfigMapKVStore(namespace, clientset, &k8sutil.OwnerInfo{})
+
+	clean := true
+	regex := ""
+	isClusterClean = func(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, pgHealthyRegex string) (string, bool, error) {
+		regex = pgHealthyRegex
+		if !clean {
+			return "cluster is not fully clean", false, nil
+		}
+		return "all PGs in cluster are clean", true, nil
+	}
+	defer func() { isClusterClean = cephclient.IsClusterClean }()
+
+	t.Run("allowed", func(t *testing.T) {
+		err := oposd.UpdateReencryptionStatus(ctx, kv, cephv1.OSDReencryptionStatus{ID: 1, Phase: oposd.ReencryptionPhaseCompleted})
+		assert.NoError(t, err)
+		err = checkReencryptionAllowed(clusterdContext, clusterInfo, 2, "^(active\\+clean)$")
+		assert.NoError(t, err)
+		assert.Equal(t, "^(active\\+clean)$", regex)
+	})
+
+	t.Run("cluster not clean", func(t *testing.T) {
+		clean = false
+		defer func() { clean = true }()
+		err := checkReencryptionAllowed(clusterdContext, clusterInfo, 2, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not fully clean")
+	})
+
+	t.Run("failed to check the cluster", func(t *testing.T) {
+		isClusterCleanOrig := isClusterClean
+		isClusterClean = func(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, pgHealthyRegex string) (string, bool, error) {
+			return "unable to get PG health", false, errors.New("timed out")
+		}
+		defer func() { isClusterClean = isClusterCleanOrig }()
+		err := checkReencryptionAllowed(clusterdContext, clusterInfo, 2, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("another osd in progress", func(t *testing.T) {
+		err := oposd.UpdateReencryptionStatus(ctx, kv, cephv1.OSDReencryptionStatus{ID: 3, Phase: oposd.ReencryptionPhaseInProgress})
+		assert.NoError(t, err)
+		err = checkReencryptionAllowed(clusterdContext, clusterInfo, 2, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "osd 3 is being re-encrypted")
+
+		// the interrupted re-encryption of the same osd can be resumed
+		err = checkReencryptionAllowed(clusterdContext, clusterInfo, 3, "")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"path"
+
+	"github.com/libopenstorage/secrets/vault"
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/clusterd"
+)
+
+const (
+	// rgwKeySize is the size of the AES-256 keys RGW reads from the kv secret engine
+	rgwKeySize = 32
+	// rgwTransitKeyType is the type of the keys created in the transit secret engine
+	rgwTransitKeyType = "aes256-gcm96"
+)
+
+// CreateRGWEncryptionKey creates the key with the given ID in Vault for the SSE-KMS encryption of
+// RGW objects, if it does not exist yet. The key is created where RGW looks it up: as a random
+// base64 key under the backend path of the kv (version 2) secret engine, or as an exportable
+// key of the transit secret engine.
+func CreateRGWEncryptionKey(ctx context.Context, clusterdContext *clusterd.Context, namespace string, secretConfig map[string]string, keyID string) error {
+	if keyID == "" {
+		return errors.New("key ID cannot be empty")
+	}
+
+	client, err := vaultClient(ctx, clusterdContext, namespace, secretConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize vault client")
+	}
+
+	var keyPath string
+	var data map[string]interface{}
+	secretEngine := GetParam(secretConfig, VaultSecretEngineKey)
+	switch secretEngine {
+	case VaultKVSecretEngineKey:
+		backendPath := GetParam(secretConfig, vault.VaultBackendPathKey)
+		if backendPath == "" {
+			backendPath = vault.DefaultBackendPath
+		}
+		keyPath = path.Join(backendPath, "data", keyID)
+
+		key := make([]byte, rgwKeySize)
+		if _, err := rand.Read(key); err != nil {
+			return errors.Wrap(err, "failed to generate key")
+		}
+		data = map[string]interface{}{
+			"data": map[string]interface{}{"key": base64.StdEncoding.EncodeToString(key)},
+		}
+	case VaultTransitSecretEngineKey:
+		keyPath = path.Join(VaultTransitSecretEngineKey, "keys", keyID)
+		data = map[string]interface{}{"type": rgwTransitKeyType, "exportable": true}
+	default:
+		return errors.Errorf("vault secret engine %q is not supported by rgw", secretEngine)
+	}
+
+	secret, err := client.Logical().ReadWithContext(ctx, keyPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read key %q in vault", keyID)
+	}
+	if secret != nil {
+		logger.Debugf("key %q already exists in vault", keyID)
+		return nil
+	}
+
+	_, err = client.Logical().WriteWithContext(ctx, keyPath, data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create key %q in vault", keyID)
+	}
+	logger.Infof("created key %q in vault", keyID)
+
+	return nil
+}
+
+// DeleteRGWEncryptionKey deletes the key with the given ID created by CreateRGWEncryptionKey. All the
+// versions of a kv secret engine key are deleted. The deletion of a transit secret engine key is
+// allowed before it is deleted.
+func DeleteRGWEncryptionKey(ctx context.Context, clusterdContext *clusterd.Context, namespace string, secretConfig map[string]string, keyID string) error {
+	if keyID == "" {
+		return errors.New("key ID cannot be empty")
+	}
+
+	client, err := vaultClient(ctx, clusterdContext, namespace, secretConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize vault client")
+	}
+
+	var keyPath, deletePath string
+	secretEngine := GetParam(secretConfig, VaultSecretEngineKey)
+	switch secretEngine {
+	case VaultKVSecretEngineKey:
+		backendPath := GetParam(secretConfig, vault.VaultBackendPathKey)
+		if backendPath == "" {
+			backendPath = vault.DefaultBackendPath
+		}
+		keyPath = path.Join(backendPath, "data", keyID)
+		deletePath = path.Join(backendPath, "metadata", keyID)
+	case VaultTransitSecretEngineKey:
+		keyPath = path.Join(VaultTransitSecretEngineKey, "keys", keyID)
+		deletePath = keyPath
+	default:
+		return errors.Errorf("vault secret engine %q is not supported by rgw", secretEngine)
+	}
+
+	secret, err := client.Logical().ReadWithContext(ctx, keyPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read key %q in vault", keyID)
+	}
+	if secret == nil {
+		logger.Debugf("key %q does not exist in vault", keyID)
+		return nil
+	}
+
+	if secretEngine == VaultTransitSecretEngineKey {
+		_, err = client.Logical().WriteWithContext(ctx, path.Join(keyPath, "config"), map[string]interface{}{"deletion_allowed": true})
+		if err != nil {
+			return errors.Wrapf(err, "failed to allow the deletion of key %q in vault", keyID)
+		}
+	}
+	_, err = client.Logical().DeleteWithContext(ctx, deletePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete key %q in vault", keyID)
+	}
+	logger.Infof("deleted key %q in vault", keyID)
+
+	return nil
+}
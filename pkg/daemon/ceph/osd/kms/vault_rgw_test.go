@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRGWEncryptionKey(t *testing.T) {
+	keys := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if _, ok := keys[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"data": keys[r.URL.Path]}))
+		case http.MethodPut, http.MethodPost:
+			data := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+			keys[r.URL.Path] = data
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	oldVaultClient := vaultClient
+	defer func() { vaultClient = oldVaultClient }()
+	vaultClient = func(ctx context.Context, clusterdContext *clusterd.Context, namespace string, secretConfig map[string]string) (*api.Client, error) {
+		client, err := api.NewClient(&api.Config{Address: server.URL})
+		if err != nil {
+			return nil, err
+		}
+		client.SetToken("token")
+		return client, nil
+	}
+	ctx := context.TODO()
+
+	t.Run("empty key ID", func(t *testing.T) {
+		err := CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", map[string]string{VaultSecretEngineKey: VaultKVSecretEngineKey}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported secret engine", func(t *testing.T) {
+		err := CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", map[string]string{}, "my-key")
+		assert.ErrorContains(t, err, "not supported")
+	})
+
+	t.Run("kv secret engine", func(t *testing.T) {
+		config := map[string]string{VaultSecretEngineKey: VaultKVSecretEngineKey, "VAULT_BACKEND_PATH": "rook"}
+		err := CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", config, "my-key")
+		assert.NoError(t, err)
+		assert.Contains(t, keys, "/v1/rook/data/my-key")
+		data := keys["/v1/rook/data/my-key"]["data"].(map[string]interface{})
+		key, err := base64.StdEncoding.DecodeString(data["key"].(string))
+		assert.NoError(t, err)
+		assert.Len(t, key, rgwKeySize)
+
+		// an existing key is not replaced
+		err = CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", config, "my-key")
+		assert.NoError(t, err)
+		assert.Equal(t, data, keys["/v1/rook/data/my-key"]["data"])
+	})
+
+	t.Run("kv secret engine with default backend path", func(t *testing.T) {
+		err := CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", map[string]string{VaultSecretEngineKey: VaultKVSecretEngineKey}, "other-key")
+		assert.NoError(t, err)
+		assert.Contains(t, keys, "/v1/secret/data/other-key")
+	})
+
+	t.Run("transit secret engine", func(t *testing.T) {
+		err := CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", map[string]string{VaultSecretEngineKey: VaultTransitSecretEngineKey}, "my-key")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "aes256-gcm96", "exportable": true}, keys["/v1/transit/keys/my-key"])
+	})
+}
+
+func TestDeleteRGWEncryptionKey(t *testing.T) {
+	keys := map[string]map[string]interface{}{}
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if _, ok := keys[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"data": keys[r.URL.Path]}))
+		case http.MethodPut, http.MethodPost:
+			data := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+			keys[r.URL.Path] = data
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	oldVaultClient := vaultClient
+	defer func() { vaultClient = oldVaultClient }()
+	vaultClient = func(ctx context.Context, clusterdContext *clusterd.Context, namespace string, secretConfig map[string]string) (*api.Client, error) {
+		client, err := api.NewClient(&api.Config{Address: server.URL})
+		if err != nil {
+			return nil, err
+		}
+		client.SetToken("token")
+		return client, nil
+	}
+	ctx := context.TODO()
+
+	t.Run("empty key ID", func(t *testing.T) {
+		err := DeleteRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", map[string]string{VaultSecretEngineKey: VaultKVSecretEngineKey}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("key does not exist", func(t *testing.T) {
+		err := DeleteRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", map[string]string{VaultSecretEngineKey: VaultKVSecretEngineKey}, "my-key")
+		assert.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("kv secret engine", func(t *testing.T) {
+		config := map[string]string{VaultSecretEngineKey: VaultKVSecretEngineKey, "VAULT_BACKEND_PATH": "rook"}
+		assert.NoError(t, CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", config, "my-key"))
+		err := DeleteRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", config, "my-key")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/v1/rook/metadata/my-key"}, deleted)
+	})
+
+	t.Run("transit secret engine", func(t *testing.T) {
+		deleted = nil
+		config := map[string]string{VaultSecretEngineKey: VaultTransitSecretEngineKey}
+		assert.NoError(t, CreateRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", config, "my-key"))
+		err := DeleteRGWEncryptionKey(ctx, &clusterd.Context{}, "ns", config, "my-key")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"deletion_allowed": true}, keys["/v1/transit/keys/my-key/config"])
+		assert.Equal(t, []string{"/v1/transit/keys/my-key"}, deleted)
+	})
+}
@@ -135,6 +135,9 @@ func removeOSD(clusterdContext *clusterd.Context, clusterInfo *client.ClusterInf
 			logger.Infof("did not find a pvc name to remove for osd %q", deploymentName)
 		}
 	}
+	if err := oposd.DeleteReencryptionStatus(clusterInfo.Context, clusterdContext.Clientset, clusterInfo.Namespace, osdID); err != nil {
+		logger.Errorf("failed to delete re-encryption status of osd %d. %v", osdID, err)
+	}
 
 	// purge the osd
 	logger.Infof("purging osd.%d", osdID)
@@ -370,6 +370,11 @@ func (c *Cluster) runPrepareJob(osdProps *osdProperties, config *provisionConfig
 }
 
 func createDaemonOnPVC(c *Cluster, osd *OSDInfo, pvcName string, config *provisionConfig) error {
+	// the ramp-up must be started before generating the deployment, which depends on it
+	if osdProps, err := c.getOSDPropsForPVC(pvcName); err == nil {
+		c.startWeightRampUp(osd, osdProps)
+	}
+
 	d, err := deploymentOnPVC(c, osd, pvcName, config)
 	if err != nil {
 		return err
@@ -400,6 +405,11 @@ func createDaemonOnPVC(c *Cluster, osd *OSDInfo, pvcName string, config *provisi
 }
 
 func createDaemonOnNode(c *Cluster, osd *OSDInfo, nodeName string, config *provisionConfig) error {
+	// the ramp-up must be started before generating the deployment, which depends on it
+	if osdProps, err := c.getOSDPropsForNode(nodeName, osd.DeviceClass); err == nil {
+		c.startWeightRampUp(osd, osdProps)
+	}
+
 	d, err := deploymentOnNode(c, osd, nodeName, config)
 	if err != nil {
 		return err
@@ -27,6 +27,7 @@ import (
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 )
 
 const (
@@ -43,15 +44,18 @@ type OSDHealthMonitor struct {
 	clusterInfo                    *client.ClusterInfo
 	removeOSDsIfOUTAndSafeToRemove bool
 	interval                       *time.Duration
+	recorder                       record.EventRecorder
+	flapStates                     map[int]*osdFlapState
 }
 
 // NewOSDHealthMonitor instantiates OSD monitoring
-func NewOSDHealthMonitor(context *clusterd.Context, clusterInfo *client.ClusterInfo, removeOSDsIfOUTAndSafeToRemove bool, healthCheck cephv1.CephClusterHealthCheckSpec) *OSDHealthMonitor {
+func NewOSDHealthMonitor(context *clusterd.Context, clusterInfo *client.ClusterInfo, removeOSDsIfOUTAndSafeToRemove bool, healthCheck cephv1.CephClusterHealthCheckSpec, recorder record.EventRecorder) *OSDHealthMonitor {
 	h := &OSDHealthMonitor{
 		context:                        context,
 		clusterInfo:                    clusterInfo,
 		removeOSDsIfOUTAndSafeToRemove: removeOSDsIfOUTAndSafeToRemove,
 		interval:                       &defaultHealthCheckInterval,
+		recorder:                       recorder,
 	}
 
 	// allow overriding the check interval
@@ -93,10 +97,21 @@ func (m *OSDHealthMonitor) Update(removeOSDsIfOUTAndSafeToRemove bool) {
 
 // checkOSDHealth takes action when needed if the OSDs are not healthy
 func (m *OSDHealthMonitor) checkOSDHealth() {
-	err := m.checkOSDDump()
+	// quarantine the flapping OSDs first so that their deployments are not removed below
+	err := m.checkOSDQuarantine()
+	if err != nil {
+		logger.Errorf("failed to check osd quarantine. %v", err)
+	}
+
+	err = m.checkOSDDump()
 	if err != nil {
 		logger.Debugf("failed to check OSD Dump. %v", err)
 	}
+
+	err = m.checkWeightRampUp()
+	if err != nil {
+		logger.Errorf("failed to ramp up osd crush weights. %v", err)
+	}
 }
 
 func (m *OSDHealthMonitor) checkOSDDump() error {
@@ -149,6 +164,11 @@ func (m *OSDHealthMonitor) removeOSDDeploymentIfSafeToDestroy(outOSDid int) erro
 		return errors.Wrapf(err, "failed to get osd deployment of osd id %d", outOSDid)
 	}
 	if len(dp.Items) != 0 {
+		if isQuarantined(&dp.Items[0]) {
+			// the deployment holds the quarantine of the OSD, which would be re-created without it
+			logger.Debugf("not removing the deployment of quarantined osd.%d", outOSDid)
+			return nil
+		}
 		safeToDestroyOSD, err := client.OsdSafeToDestroy(m.context, m.clusterInfo, outOSDid)
 		if err != nil {
 			return errors.Wrapf(err, "failed to get osd deployment of osd id %d", outOSDid)
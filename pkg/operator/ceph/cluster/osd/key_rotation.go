@@ -17,24 +17,43 @@ limitations under the License.
 package osd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
 
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	kms "github.com/rook/rook/pkg/daemon/ceph/osd/kms"
+	"github.com/rook/rook/pkg/operator/ceph/cluster/mon"
 	"github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/k8sutil"
+	appsv1 "k8s.io/api/apps/v1"
 	batch "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrl "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
-	keyRotationCronJobAppName    = "rook-ceph-osd-key-rotation"
-	keyRotationCronJobAppNameFmt = "rook-ceph-osd-key-rotation-%d"
+	keyRotationCronJobAppName     = "rook-ceph-osd-key-rotation"
+	keyRotationCronJobAppNameFmt  = "rook-ceph-osd-key-rotation-%d"
+	reencryptionCronJobAppName    = "rook-ceph-osd-reencryption"
+	reencryptionCronJobAppNameFmt = "rook-ceph-osd-reencryption-%d"
+	reencryptionStatusMapNameFmt  = "rook-ceph-osd-%d-reencryption-status"
+	reencryptionStatusKey         = "status"
+
+	// ReencryptionPhaseInProgress denotes the re-encryption of the OSD devices is running.
+	ReencryptionPhaseInProgress = "InProgress"
+	// ReencryptionPhaseCompleted denotes the re-encryption of all the OSD devices succeeded.
+	ReencryptionPhaseCompleted = "Completed"
+	// ReencryptionPhaseFailed denotes the re-encryption of the OSD devices failed.
+	ReencryptionPhaseFailed = "Failed"
 )
 
 // keyRotationCronJobName returns the name of the key rotation cron job for the given OSD ID.
@@ -42,6 +61,125 @@ func keyRotationCronJobName(osdID int) string {
 	return fmt.Sprintf(keyRotationCronJobAppNameFmt, osdID)
 }
 
+// reencryptionCronJobName returns the name of the re-encryption cron job for the given OSD ID.
+func reencryptionCronJobName(osdID int) string {
+	return fmt.Sprintf(reencryptionCronJobAppNameFmt, osdID)
+}
+
+func reencryptionStatusConfigMapName(osdID int) string {
+	return fmt.Sprintf(reencryptionStatusMapNameFmt, osdID)
+}
+
+// UpdateReencryptionStatus saves the re-encryption status of an OSD in its status ConfigMap so that
+// the operator can report it in the CephCluster status.
+func UpdateReencryptionStatus(ctx context.Context, kv *k8sutil.ConfigMapKVStore, status cephv1.OSDReencryptionStatus) error {
+	s, err := json.Marshal(status)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal re-encryption status of osd %d", status.ID)
+	}
+	labels := map[string]string{
+		k8sutil.AppAttr: reencryptionCronJobAppName,
+		OsdIdLabelKey:   strconv.Itoa(status.ID),
+	}
+	err = kv.SetValueWithLabels(ctx, reencryptionStatusConfigMapName(status.ID), reencryptionStatusKey, string(s), labels)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update re-encryption status of osd %d", status.ID)
+	}
+	return nil
+}
+
+// GetReencryptionStatus returns the last re-encryption status saved for the given OSD, if any.
+func GetReencryptionStatus(ctx context.Context, kv *k8sutil.ConfigMapKVStore, osdID int) (*cephv1.OSDReencryptionStatus, error) {
+	data, err := kv.GetStore(ctx, reencryptionStatusConfigMapName(osdID))
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get re-encryption status of osd %d", osdID)
+	}
+	return parseReencryptionStatus(data)
+}
+
+func parseReencryptionStatus(data map[string]string) (*cephv1.OSDReencryptionStatus, error) {
+	raw, ok := data[reencryptionStatusKey]
+	if !ok {
+		return nil, nil
+	}
+	status := &cephv1.OSDReencryptionStatus{}
+	if err := json.Unmarshal([]byte(raw), status); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal re-encryption status %q", raw)
+	}
+	return status, nil
+}
+
+// ListReencryptionStatus returns the re-encryption status saved in the status ConfigMaps of the OSDs.
+func ListReencryptionStatus(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]cephv1.OSDReencryptionStatus, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, reencryptionCronJobAppName)}
+	configMaps, err := clientset.CoreV1().ConfigMaps(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list re-encryption status configmaps")
+	}
+
+	statuses := []cephv1.OSDReencryptionStatus{}
+	for _, cm := range configMaps.Items {
+		status, err := parseReencryptionStatus(cm.Data)
+		if err != nil {
+			logger.Warningf("failed to parse re-encryption status in configmap %q. %v", cm.Name, err)
+			continue
+		}
+		if status != nil {
+			statuses = append(statuses, *status)
+		}
+	}
+	return statuses, nil
+}
+
+// DeleteReencryptionStatus deletes the re-encryption status ConfigMap of a removed OSD.
+func DeleteReencryptionStatus(ctx context.Context, clientset kubernetes.Interface, namespace string, osdID int) error {
+	name := reencryptionStatusConfigMapName(osdID)
+	err := clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete re-encryption status configmap %q", name)
+	}
+	return nil
+}
+
+// getOSDReencryptionStatus collects the re-encryption status of all the existing OSDs, sorted by OSD
+// ID. The status left behind by OSDs that have been removed is skipped.
+func (c *Cluster) getOSDReencryptionStatus() ([]cephv1.OSDReencryptionStatus, error) {
+	allStatuses, err := ListReencryptionStatus(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(allStatuses) == 0 {
+		return nil, nil
+	}
+
+	osdListOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName)}
+	deployments, err := c.context.Clientset.AppsV1().Deployments(c.clusterInfo.Namespace).List(c.clusterInfo.Context, osdListOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query existing OSD deployments")
+	}
+	osdIDs := map[string]bool{}
+	for _, d := range deployments.Items {
+		osdIDs[d.Labels[OsdIdLabelKey]] = true
+	}
+
+	statuses := []cephv1.OSDReencryptionStatus{}
+	for _, status := range allStatuses {
+		if !osdIDs[strconv.Itoa(status.ID)] {
+			logger.Debugf("skipping re-encryption status of removed osd %d", status.ID)
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses, nil
+}
+
 // applyKeyRotationPlacement applies the placement settings for the key rotation job
 // so that it is scheduled on the same node as the OSD for which the key rotation is scheduled.
 func applyKeyRotationPlacement(spec *v1.PodSpec, labels map[string]string) {
@@ -63,8 +201,8 @@ func applyKeyRotationPlacement(spec *v1.PodSpec, labels map[string]string) {
 	}
 }
 
-// getKeyRotationContainer returns the container spec for the key rotation job.
-func (c *Cluster) getKeyRotationContainer(osdProps osdProperties, volumeMounts []v1.VolumeMount, devices []string) (v1.Container, error) {
+// getKeyRotationContainer returns the container spec for the key rotation or re-encryption job.
+func (c *Cluster) getKeyRotationContainer(osdProps osdProperties, name string, args []string, volumeMounts []v1.VolumeMount) (v1.Container, error) {
 	envVars := c.getConfigEnvVars(osdProps, k8sutil.DataDir, true)
 
 	// enable debug logging
@@ -78,12 +216,9 @@ func (c *Cluster) getKeyRotationContainer(osdProps osdProperties, volumeMounts [
 	runAsNonRoot := false
 	readOnlyRootFilesystem := false
 
-	args := []string{"key-management", "rotate-key", osdProps.pvc.ClaimName}
-	args = append(args, devices...)
-
 	osdProvisionContainer := v1.Container{
 		Args:            args,
-		Name:            keyRotationCronJobAppName,
+		Name:            name,
 		Image:           c.rookVersion,
 		ImagePullPolicy: controller.GetContainerImagePullPolicy(c.spec.CephVersion.ImagePullPolicy),
 		VolumeMounts:    volumeMounts,
@@ -107,8 +242,9 @@ func (c *Cluster) getKeyRotationContainer(osdProps osdProperties, volumeMounts [
 	return osdProvisionContainer, nil
 }
 
-// getKeyRotationPodTemplateSpec returns the pod template spec for the key rotation job.
-func (c *Cluster) getKeyRotationPodTemplateSpec(osdProps osdProperties, osd OSDInfo, restart v1.RestartPolicy) (*v1.PodTemplateSpec, error) {
+// getKeyRotationPodTemplateSpec returns the pod template spec for the key rotation or re-encryption
+// job. The encrypted devices of the OSD are appended to the given "key-management" args.
+func (c *Cluster) getKeyRotationPodTemplateSpec(osdProps osdProperties, osd OSDInfo, name string, args []string, restart v1.RestartPolicy) (*v1.PodTemplateSpec, error) {
 	// create a volume on /dev so the pod can access devices on the host
 	devVolume := v1.Volume{Name: "devices", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/dev"}}}
 	udevVolume := v1.Volume{Name: "udev", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/run/udev"}}}
@@ -149,16 +285,17 @@ func (c *Cluster) getKeyRotationPodTemplateSpec(osdProps osdProperties, osd OSDI
 		volumeMounts = append(volumeMounts, volumeMountTLS)
 	}
 
-	keyRotationContainer, err := c.getKeyRotationContainer(osdProps, volumeMounts, devices)
+	args = append(args, devices...)
+	keyRotationContainer, err := c.getKeyRotationContainer(osdProps, name, args, volumeMounts)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate key rotation container")
 	}
 
 	podTemplateSpec := v1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: keyRotationCronJobAppName,
+			Name: name,
 			Labels: map[string]string{
-				k8sutil.AppAttr:     keyRotationCronJobAppName,
+				k8sutil.AppAttr:     name,
 				k8sutil.ClusterAttr: c.clusterInfo.Namespace,
 			},
 			Annotations: map[string]string{},
@@ -201,7 +338,8 @@ func (c *Cluster) getKeyRotationPodTemplateSpec(osdProps osdProperties, osd OSDI
 
 // makeKeyRotationCronJob creates a key rotation cron job for the given OSD.
 func (c *Cluster) makeKeyRotationCronJob(pvcName string, osd OSDInfo, osdProps osdProperties) (*batch.CronJob, error) {
-	podSpec, err := c.getKeyRotationPodTemplateSpec(osdProps, osd, v1.RestartPolicyOnFailure)
+	args := []string{"key-management", "rotate-key", osdProps.pvc.ClaimName}
+	podSpec, err := c.getKeyRotationPodTemplateSpec(osdProps, osd, keyRotationCronJobAppName, args, v1.RestartPolicyOnFailure)
 	if err != nil {
 		return nil, err
 	}
@@ -232,24 +370,87 @@ func (c *Cluster) makeKeyRotationCronJob(pvcName string, osd OSDInfo, osdProps o
 	return cronJob, nil
 }
 
+// makeReencryptionCronJob creates a cron job re-encrypting the volume key of the given OSD.
+func (c *Cluster) makeReencryptionCronJob(osd OSDInfo, osdProps osdProperties) (*batch.CronJob, error) {
+	args := []string{"key-management", "reencrypt", strconv.Itoa(osd.ID), osdProps.pvc.ClaimName}
+	podSpec, err := c.getKeyRotationPodTemplateSpec(osdProps, osd, reencryptionCronJobAppName, args, v1.RestartPolicyOnFailure)
+	if err != nil {
+		return nil, err
+	}
+	// the re-encryption checks that the PGs are clean before starting
+	podSpec.Spec.Volumes = append(podSpec.Spec.Volumes, mon.CephSecretVolume())
+	podSpec.Spec.Containers[0].VolumeMounts = append(podSpec.Spec.Containers[0].VolumeMounts, mon.CephSecretVolumeMount())
+	c.applyResourcesToAllContainers(&podSpec.Spec, cephv1.GetOSDResources(c.spec.Resources, osd.DeviceClass))
+	schedule := reencryptionSchedule(c.spec.Security.KeyRotation.Reencryption.Schedule, osd.ID)
+	cronJob := &batch.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        reencryptionCronJobName(osd.ID),
+			Namespace:   c.clusterInfo.Namespace,
+			Labels:      podSpec.Labels,
+			Annotations: podSpec.Annotations,
+		},
+		Spec: batch.CronJobSpec{
+			ConcurrencyPolicy: batch.ForbidConcurrent,
+			Schedule:          schedule,
+			JobTemplate: batch.JobTemplateSpec{
+				Spec: batch.JobSpec{
+					Template: *podSpec,
+				},
+			},
+		},
+	}
+
+	return cronJob, nil
+}
+
+// reencryptionSchedule returns the schedule of the re-encryption cron job of the given OSD. The
+// re-encryption rewrites the whole device, so the "@yearly", "@monthly" and "@weekly" schedules are
+// staggered to re-encrypt the OSDs on different days instead of all of them at once. Other schedules
+// are returned as they are.
+func reencryptionSchedule(schedule string, osdID int) string {
+	switch schedule {
+	case "", "@yearly", "@annually":
+		// re-encryption defaults to a much lower frequency than key rotation
+		return fmt.Sprintf("0 %d %d %d *", (osdID/(28*12))%24, osdID%28+1, (osdID/28)%12+1)
+	case "@monthly":
+		return fmt.Sprintf("0 %d %d * *", (osdID/28)%24, osdID%28+1)
+	case "@weekly":
+		return fmt.Sprintf("0 %d * * %d", (osdID/7)%24, osdID%7)
+	default:
+		return schedule
+	}
+}
+
+// deleteCronJobs deletes all the cron jobs with the given app label.
+func (c *Cluster) deleteCronJobs(appName string) error {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, appName)}
+	err := c.context.Clientset.BatchV1().
+		CronJobs(c.clusterInfo.Namespace).
+		DeleteCollection(c.clusterInfo.Context,
+			metav1.DeleteOptions{},
+			listOpts)
+	if err != nil && client.IgnoreNotFound(err) != nil {
+		return errors.Wrapf(err, "failed to delete %q cron jobs", appName)
+	}
+	return nil
+}
+
 // reconcileKeyRotationCronJob reconciles the key rotation cron jobs for the OSDs.
 func (c *Cluster) reconcileKeyRotationCronJob() error {
+	reencryptionEnabled := c.spec.Security.KeyRotation.Reencryption.Enabled
+	if !reencryptionEnabled {
+		if err := c.deleteCronJobs(reencryptionCronJobAppName); err != nil {
+			return err
+		}
+	}
 	if !c.spec.Security.KeyRotation.Enabled {
-		listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, keyRotationCronJobAppName)}
-		err := c.context.Clientset.BatchV1().
-			CronJobs(c.clusterInfo.Namespace).
-			DeleteCollection(c.clusterInfo.Context,
-				metav1.DeleteOptions{},
-				listOpts)
-		if err != nil {
-			if client.IgnoreNotFound(err) != nil {
-				return errors.Wrap(err, "failed to delete key rotation cron jobs")
-			}
-			return nil
+		if err := c.deleteCronJobs(keyRotationCronJobAppName); err != nil {
+			return err
 		}
 		logger.Debugf("successfully deleted key rotation cron jobs")
-
-		return nil
+		if !reencryptionEnabled {
+			return nil
+		}
 	}
 
 	// Get the list of OSDs backed by pvc.
@@ -279,24 +480,46 @@ func (c *Cluster) reconcileKeyRotationCronJob() error {
 			continue
 		}
 
-		logger.Infof("starting OSD key rotation cron job for osd %d", osd.ID)
-		cj, err := c.makeKeyRotationCronJob(pvcName, osd, osdProps)
-		if err != nil {
-			return errors.Wrap(err, "failed to make key rotation cron job")
+		if reencryptionEnabled {
+			if err := c.createOrUpdateOSDCronJob(&osdDep, osd, osdProps, c.makeReencryptionCronJob); err != nil {
+				return errors.Wrap(err, "failed to reconcile re-encryption cron job")
+			}
 		}
-
-		err = ctrl.SetOwnerReference(&osdDep, cj, c.context.Client.Scheme())
-		if err != nil {
-			return errors.Wrapf(err, "failed to set controllerReference on cron job %q", cj.Name)
+		if !c.spec.Security.KeyRotation.Enabled {
+			continue
 		}
 
-		_, err = k8sutil.CreateOrUpdateCronJob(c.clusterInfo.Context, c.context.Clientset, cj)
+		logger.Infof("starting OSD key rotation cron job for osd %d", osd.ID)
+		err = c.createOrUpdateOSDCronJob(&osdDep, osd, osdProps, func(osd OSDInfo, osdProps osdProperties) (*batch.CronJob, error) {
+			return c.makeKeyRotationCronJob(pvcName, osd, osdProps)
+		})
 		if err != nil {
-			return errors.Wrapf(err, "failed to create or update key rotation cron job %q", cj.Name)
+			return errors.Wrap(err, "failed to reconcile key rotation cron job")
 		}
-		logger.Infof("started OSD key rotation cron job %q", cj.Name)
 	}
 	logger.Infof("successfully started OSD key rotation cron jobs")
 
 	return nil
 }
+
+// createOrUpdateOSDCronJob creates or updates the cron job generated by makeCronJob, owned by the OSD deployment.
+func (c *Cluster) createOrUpdateOSDCronJob(osdDep *appsv1.Deployment, osd OSDInfo, osdProps osdProperties,
+	makeCronJob func(OSDInfo, osdProperties) (*batch.CronJob, error),
+) error {
+	cj, err := makeCronJob(osd, osdProps)
+	if err != nil {
+		return errors.Wrapf(err, "failed to make cron job for osd %d", osd.ID)
+	}
+
+	err = ctrl.SetOwnerReference(osdDep, cj, c.context.Client.Scheme())
+	if err != nil {
+		return errors.Wrapf(err, "failed to set controllerReference on cron job %q", cj.Name)
+	}
+
+	_, err = k8sutil.CreateOrUpdateCronJob(c.clusterInfo.Context, c.context.Clientset, cj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create or update cron job %q", cj.Name)
+	}
+	logger.Infof("started OSD cron job %q", cj.Name)
+	return nil
+}
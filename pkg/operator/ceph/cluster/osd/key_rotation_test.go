@@ -17,11 +17,19 @@ limitations under the License.
 package osd
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -58,6 +66,27 @@ func Test_keyRotationCronJobName(t *testing.T) {
 	}
 }
 
+func Test_reencryptionSchedule(t *testing.T) {
+	// the OSDs are re-encrypted on different days
+	assert.Equal(t, "0 0 1 1 *", reencryptionSchedule("", 0))
+	assert.Equal(t, "0 0 2 1 *", reencryptionSchedule("@yearly", 1))
+	assert.Equal(t, "0 0 1 2 *", reencryptionSchedule("@annually", 28))
+	assert.Equal(t, "0 1 4 1 *", reencryptionSchedule("", 339))
+	assert.Equal(t, "0 0 6 * *", reencryptionSchedule("@monthly", 5))
+	assert.Equal(t, "0 1 3 * *", reencryptionSchedule("@monthly", 30))
+	assert.Equal(t, "0 0 * * 3", reencryptionSchedule("@weekly", 3))
+	assert.Equal(t, "0 1 * * 0", reencryptionSchedule("@weekly", 7))
+	// other schedules are not changed
+	assert.Equal(t, "@daily", reencryptionSchedule("@daily", 3))
+	assert.Equal(t, "0 2 * * 6", reencryptionSchedule("0 2 * * 6", 3))
+
+	schedules := map[string]bool{}
+	for id := 0; id < 336; id++ {
+		schedules[reencryptionSchedule("", id)] = true
+	}
+	assert.Len(t, schedules, 336)
+}
+
 func Test_applyKeyRotationPlacement(t *testing.T) {
 	type args struct {
 		spec   *v1.PodSpec
@@ -114,3 +143,78 @@ func Test_applyKeyRotationPlacement(t *testing.T) {
 		})
 	}
 }
+
+func TestReencryptionStatus(t *testing.T) {
+	ctx := context.TODO()
+	clientset := test.New(t, 1)
+	namespace := "rook-ceph"
+	kv := k8sutil.NewConfigMapKVStore(namespace, clientset, &k8sutil.OwnerInfo{})
+	c := &Cluster{
+		context:     &clusterd.Context{Clientset: clientset},
+		clusterInfo: &cephclient.ClusterInfo{Namespace: namespace, Context: ctx},
+	}
+
+	t.Run("no status", func(t *testing.T) {
+		status, err := GetReencryptionStatus(ctx, kv, 1)
+		assert.NoError(t, err)
+		assert.Nil(t, status)
+
+		statuses, err := c.getOSDReencryptionStatus()
+		assert.NoError(t, err)
+		assert.Nil(t, statuses)
+	})
+
+	for _, id := range []int{1, 3} {
+		d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("rook-ceph-osd-%d", id),
+			Namespace: namespace,
+			Labels:    map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprintf("%d", id)},
+		}}
+		_, err := clientset.AppsV1().Deployments(namespace).Create(ctx, d, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	t.Run("status of multiple osds", func(t *testing.T) {
+		now := metav1.Now()
+		err := UpdateReencryptionStatus(ctx, kv, cephv1.OSDReencryptionStatus{ID: 3, Phase: ReencryptionPhaseInProgress, Progress: "1/2"})
+		assert.NoError(t, err)
+		err = UpdateReencryptionStatus(ctx, kv, cephv1.OSDReencryptionStatus{ID: 1, Phase: ReencryptionPhaseCompleted, Progress: "1/1", LastReencryptionTime: &now})
+		assert.NoError(t, err)
+
+		status, err := GetReencryptionStatus(ctx, kv, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, "1/2", status.Progress)
+
+		statuses, err := c.getOSDReencryptionStatus()
+		assert.NoError(t, err)
+		assert.Len(t, statuses, 2)
+		assert.Equal(t, 1, statuses[0].ID)
+		assert.Equal(t, ReencryptionPhaseCompleted, statuses[0].Phase)
+		assert.NotNil(t, statuses[0].LastReencryptionTime)
+		assert.Equal(t, 3, statuses[1].ID)
+		assert.Equal(t, ReencryptionPhaseInProgress, statuses[1].Phase)
+	})
+	t.Run("status of removed osd", func(t *testing.T) {
+		err := UpdateReencryptionStatus(ctx, kv, cephv1.OSDReencryptionStatus{ID: 5, Phase: ReencryptionPhaseInProgress, Progress: "0/1"})
+		assert.NoError(t, err)
+
+		statuses, err := c.getOSDReencryptionStatus()
+		assert.NoError(t, err)
+		assert.Len(t, statuses, 2)
+		assert.Equal(t, 1, statuses[0].ID)
+		assert.Equal(t, 3, statuses[1].ID)
+
+		statuses, err = ListReencryptionStatus(ctx, clientset, namespace)
+		assert.NoError(t, err)
+		assert.Len(t, statuses, 3)
+
+		err = DeleteReencryptionStatus(ctx, clientset, namespace, 5)
+		assert.NoError(t, err)
+		_, err = clientset.CoreV1().ConfigMaps(namespace).Get(ctx, reencryptionStatusConfigMapName(5), metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+
+		// deleting again is not an error
+		err = DeleteReencryptionStatus(ctx, clientset, namespace, 5)
+		assert.NoError(t, err)
+	})
+}
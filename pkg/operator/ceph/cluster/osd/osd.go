@@ -89,6 +89,10 @@ type Cluster struct {
 	migrateOSD     *OSDInfo
 	deprecatedOSDs map[string][]int
 	nodeConfigmaps map[string]struct{}
+	// provisioningReport holds the device decisions reported by the prepare jobs, per node or PVC
+	provisioningReport map[string]cephv1.OSDProvisioningStatus
+	// osdMetadata caches the OSD metadata used to match the OSD overrides by device path
+	osdMetadata map[int]cephclient.OSDMetadata
 }
 
 // New creates an instance of the OSD manager
@@ -133,6 +137,8 @@ type OrchestrationStatus struct {
 	Status       string    `json:"status"`
 	PvcBackedOSD bool      `json:"pvc-backed-osd"`
 	Message      string    `json:"message"`
+	// Devices are the decisions taken by the prepare job for each device it discovered
+	Devices []cephv1.OSDDeviceProvisioningStatus `json:"devices,omitempty"`
 }
 
 type osdProperties struct {
@@ -370,8 +376,42 @@ func (c *Cluster) postReconcileUpdateOSDProperties(desiredOSDs map[int]*OSDInfo)
 		return errors.Wrap(err, "failed to get osd usage")
 	}
 	logger.Debugf("post processing osd properties with %d actual osds from ceph osd df and %d existing osds found during reconcile", len(osdUsage.OSDNodes), len(desiredOSDs))
+
+	var primaryAffinities map[int]string
+	if len(c.spec.Storage.OSDOverrides) > 0 {
+		// refresh the metadata to match the overrides of the OSDs created during this reconcile
+		c.osdMetadata = nil
+		primaryAffinities, err = c.getPrimaryAffinities()
+		if err != nil {
+			logger.Errorf("failed to get osd primary affinities, overrides will set them unconditionally. %v", err)
+		}
+	}
+
+	// the crush weight of the OSDs being ramped up is raised by the OSD health monitor
+	rampingUp := map[int]bool{}
+	if c.spec.Storage.AllowOsdCrushWeightUpdate {
+		rampUps, err := listWeightRampUps(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace)
+		if err != nil {
+			return errors.Wrap(err, "failed to list the osds being ramped up before resizing the crush weights")
+		}
+		for _, rampUp := range rampUps {
+			rampingUp[rampUp.status.ID] = true
+		}
+	}
+
 	for _, actualOSD := range osdUsage.OSDNodes {
-		if c.spec.Storage.AllowOsdCrushWeightUpdate {
+		desiredOSD, ok := desiredOSDs[actualOSD.ID]
+		override := c.getOSDOverride(actualOSD.ID, desiredOSD)
+		if override != nil {
+			if err := c.applyOSDOverride(actualOSD, override, primaryAffinities[actualOSD.ID]); err != nil {
+				// Log the error and allow other updates to continue
+				logger.Errorf("failed to apply overrides of osd.%d on cluster in namespace %s: %v", actualOSD.ID, c.clusterInfo.Namespace, err)
+			}
+		}
+
+		if rampingUp[actualOSD.ID] {
+			logger.Debugf("not resizing the crush weight of osd.%d which is being ramped up", actualOSD.ID)
+		} else if c.spec.Storage.AllowOsdCrushWeightUpdate && (override == nil || override.CrushWeight == "") {
 			_, err := cephclient.ResizeOsdCrushWeight(actualOSD, c.context, c.clusterInfo)
 			if err != nil {
 				// Log the error and allow other updates to continue
@@ -379,8 +419,7 @@ func (c *Cluster) postReconcileUpdateOSDProperties(desiredOSDs map[int]*OSDInfo)
 			}
 		}
 
-		desiredOSD, ok := desiredOSDs[actualOSD.ID]
-		if !ok {
+		if !ok || (override != nil && override.DeviceClass != "") {
 			continue
 		}
 		if err := c.updateDeviceClassIfChanged(actualOSD.ID, desiredOSD.DeviceClass, actualOSD.DeviceClass); err != nil {
@@ -434,6 +473,7 @@ func deploymentOnNode(c *Cluster, osd *OSDInfo, nodeName string, config *provisi
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to generate config for %s", osdLongName)
 	}
+	c.applyCrushLocationOverride(osd)
 
 	d, err := c.makeDeployment(osdProps, osd, config)
 	if err != nil {
@@ -455,6 +495,7 @@ func deploymentOnPVC(c *Cluster, osd *OSDInfo, pvcName string, config *provision
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to generate config for %s", osdLongName)
 	}
+	c.applyCrushLocationOverride(osd)
 
 	d, err := c.makeDeployment(osdProps, osd, config)
 	if err != nil {
@@ -473,8 +514,12 @@ func deploymentOnPVC(c *Cluster, osd *OSDInfo, pvcName string, config *provision
 // command-line arguments.
 func setOSDProperties(c *Cluster, osdProps osdProperties, osd *OSDInfo) error {
 	// OSD's 'primary-affinity' has to be configured via command which goes through mons
-	if osdProps.storeConfig.PrimaryAffinity != "" {
-		return cephclient.SetPrimaryAffinity(c.context, c.clusterInfo, osd.ID, osdProps.storeConfig.PrimaryAffinity)
+	primaryAffinity := osdProps.storeConfig.PrimaryAffinity
+	if override := c.getOSDOverride(osd.ID, osd); override != nil && override.PrimaryAffinity != "" {
+		primaryAffinity = override.PrimaryAffinity
+	}
+	if primaryAffinity != "" {
+		return cephclient.SetPrimaryAffinity(c.context, c.clusterInfo, osd.ID, primaryAffinity)
 	}
 	return nil
 }
@@ -946,6 +991,10 @@ func (c *Cluster) deleteOSDDeployment(osdID int) error {
 		}
 		logger.Debugf("osd deployment %q not found. Ignoring since object must be deleted.", deploymentName)
 	}
+	// the re-encryption cron job is owned by the deployment, but not the re-encryption status
+	if err := DeleteReencryptionStatus(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace, osdID); err != nil {
+		logger.Warningf("failed to delete re-encryption status of osd %d. %v", osdID, err)
+	}
 	return nil
 }
 
@@ -990,9 +1039,29 @@ func (c *Cluster) updateCephStorageStatus() error {
 
 	cephClusterStorage.OSD = *osdStore
 
+	reencryptionStatus, err := c.getOSDReencryptionStatus()
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd re-encryption status")
+	}
+	cephClusterStorage.OSD.Reencryption = reencryptionStatus
+
 	// Add the status about deprecated OSDs
 	cephClusterStorage.DeprecatedOSDs = c.deprecatedOSDs
 
+	cephClusterStorage.OSD.Provisioning = c.getOSDProvisioningStatus()
+
+	weightRampUpStatus, err := getWeightRampUpStatus(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd weight ramp-up status")
+	}
+	cephClusterStorage.OSD.WeightRampUp = weightRampUpStatus
+
+	quarantineStatus, err := getQuarantineStatus(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd quarantine status")
+	}
+	cephClusterStorage.OSD.Quarantined = quarantineStatus
+
 	// Update pending migration status
 	if c.isMigrationRequested() {
 		migrationConfig, err := c.newMigrationConfig()
@@ -1020,6 +1089,34 @@ func (c *Cluster) updateCephStorageStatus() error {
 	return nil
 }
 
+// getOSDProvisioningStatus returns the device decisions of the prepare jobs, sorted by node or PVC name.
+func (c *Cluster) getOSDProvisioningStatus() []cephv1.OSDProvisioningStatus {
+	if len(c.provisioningReport) == 0 {
+		return nil
+	}
+	provisioning := make([]cephv1.OSDProvisioningStatus, 0, len(c.provisioningReport))
+	for _, status := range c.provisioningReport {
+		provisioning = append(provisioning, status)
+	}
+	sort.Slice(provisioning, func(i, j int) bool { return provisioning[i].Name < provisioning[j].Name })
+	return provisioning
+}
+
+// recordProvisioningReport saves the device decisions reported by the prepare job of the given node or PVC.
+func (c *Cluster) recordProvisioningReport(nodeOrPVCName string, status *OrchestrationStatus) {
+	if len(status.Devices) == 0 {
+		return
+	}
+	if c.provisioningReport == nil {
+		c.provisioningReport = map[string]cephv1.OSDProvisioningStatus{}
+	}
+	c.provisioningReport[nodeOrPVCName] = cephv1.OSDProvisioningStatus{
+		Name:    nodeOrPVCName,
+		PVC:     status.PvcBackedOSD,
+		Devices: status.Devices,
+	}
+}
+
 func (c *Cluster) getOSDStoreStatus() (*cephv1.OSDStatus, error) {
 	label := fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName)
 	osdDeployments, err := k8sutil.GetDeployments(c.clusterInfo.Context, c.context.Clientset, c.clusterInfo.Namespace, label)
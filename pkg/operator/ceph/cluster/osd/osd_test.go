@@ -377,7 +377,7 @@ func TestAddRemoveNode(t *testing.T) {
 	assert.NoError(t, err)
 
 	removeIfOutAndSafeToRemove := true
-	healthMon := NewOSDHealthMonitor(context, cephclient.AdminTestClusterInfo(namespace), removeIfOutAndSafeToRemove, cephv1.CephClusterHealthCheckSpec{})
+	healthMon := NewOSDHealthMonitor(context, cephclient.AdminTestClusterInfo(namespace), removeIfOutAndSafeToRemove, cephv1.CephClusterHealthCheckSpec{}, nil)
 	healthMon.checkOSDHealth()
 	_, err = clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName(1), metav1.GetOptions{})
 	assert.True(t, k8serrors.IsNotFound(err))
@@ -459,6 +459,18 @@ func TestPostReconcileUpdateOSDProperties(t *testing.T) {
 		assert.Equal(t, []string([]string{"osd.3", "osd.4"}), osdID)
 		assert.Equal(t, []string([]string{"9.166024", "9.305722"}), crushWeight)
 	})
+	t.Run("test resize Osd Crush Weight skips osds being ramped up", func(t *testing.T) {
+		osdID, crushWeight = nil, nil
+		clusterInfo.OwnerInfo = &k8sutil.OwnerInfo{}
+		kv := k8sutil.NewConfigMapKVStore(namespace, clientset, clusterInfo.OwnerInfo)
+		err := saveWeightRampUp(clusterInfo.Context, kv, weightRampUp{status: cephv1.OSDWeightRampUpStatus{ID: 3, CurrentWeight: "0"}, stepPercent: 25})
+		assert.NoError(t, err)
+
+		err = c.postReconcileUpdateOSDProperties(desiredOSDs)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"osd.4"}, osdID)
+		assert.Equal(t, []string{"9.305722"}, crushWeight)
+	})
 }
 
 func TestAddNodeFailure(t *testing.T) {
@@ -905,6 +917,29 @@ func TestUpdateCephStorageStatus(t *testing.T) {
 		assert.Equal(t, 1, cephCluster.Status.CephStorage.OSD.StoreType["bluestore-rdr"])
 		assert.Equal(t, 1, cephCluster.Status.CephStorage.OSD.StoreType["bluestore"])
 	})
+
+	t.Run("verify device provisioning report in storage status", func(t *testing.T) {
+		osdID := 2
+		c.recordProvisioningReport("node2", &OrchestrationStatus{Status: OrchestrationStatusCompleted, Devices: []cephv1.OSDDeviceProvisioningStatus{
+			{Name: "sdb", Decision: cephv1.OSDDeviceUsed, OSDID: &osdID},
+		}})
+		c.recordProvisioningReport("node1", &OrchestrationStatus{Status: OrchestrationStatusCompleted, Devices: []cephv1.OSDDeviceProvisioningStatus{
+			{Name: "sda", Decision: cephv1.OSDDeviceSkipped, Reason: `device contains a filesystem "ext4"`},
+		}})
+		// a prepare job without devices does not override the report
+		c.recordProvisioningReport("node1", &OrchestrationStatus{Status: OrchestrationStatusCompleted})
+
+		err := c.updateCephStorageStatus()
+		assert.NoError(t, err)
+		err = context.Client.Get(clusterInfo.Context, clusterInfo.NamespacedName(), cephCluster)
+		assert.NoError(t, err)
+		provisioning := cephCluster.Status.CephStorage.OSD.Provisioning
+		assert.Equal(t, 2, len(provisioning))
+		assert.Equal(t, "node1", provisioning[0].Name)
+		assert.Equal(t, cephv1.OSDDeviceSkipped, provisioning[0].Devices[0].Decision)
+		assert.Equal(t, "node2", provisioning[1].Name)
+		assert.Equal(t, 2, *provisioning[1].Devices[0].OSDID)
+	})
 }
 
 func TestGetOSDLocationFromArgs(t *testing.T) {
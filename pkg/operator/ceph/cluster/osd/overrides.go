@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+)
+
+// crushWeightTolerance is the difference under which two CRUSH weights are considered equal. Ceph
+// stores the weights as fixed point numbers, so the weight read back is not exactly the one set.
+const crushWeightTolerance = 0.0001
+
+// getOSDOverride returns the override pinned for the given OSD, or nil if there is none. The OSD info
+// is optional and is used to match the overrides keyed by device path or PVC name.
+func (c *Cluster) getOSDOverride(osdID int, osd *OSDInfo) *cephv1.OSDOverride {
+	overrides := c.spec.Storage.OSDOverrides
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	// an override keyed by OSD ID takes precedence
+	if override, ok := overrides[strconv.Itoa(osdID)]; ok {
+		return &override
+	}
+
+	paths := c.getOSDDevicePaths(osdID)
+	if osd != nil {
+		paths = append(paths, osd.BlockPath, osd.PVCName)
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if override, ok := overrides[path]; ok {
+			return &override
+		}
+	}
+	return nil
+}
+
+// getOSDDevicePaths returns the paths of the devices used by the OSD as reported by the OSD metadata.
+// The metadata is fetched once per reconcile.
+func (c *Cluster) getOSDDevicePaths(osdID int) []string {
+	if c.osdMetadata == nil {
+		c.osdMetadata = map[int]cephclient.OSDMetadata{}
+		metadata, err := cephclient.GetOSDMetadata(c.context, c.clusterInfo)
+		if err != nil {
+			// overrides keyed by OSD ID, block path or PVC name can still be matched
+			logger.Warningf("failed to get osd metadata to match osd overrides by device path. %v", err)
+			return nil
+		}
+		for _, m := range *metadata {
+			c.osdMetadata[m.Id] = m
+		}
+	}
+
+	metadata, ok := c.osdMetadata[osdID]
+	if !ok {
+		return nil
+	}
+	return parseOSDMetadataDevicePaths(metadata)
+}
+
+// parseOSDMetadataDevicePaths returns the /dev paths and the persistent paths of the devices in the OSD metadata.
+func parseOSDMetadataDevicePaths(metadata cephclient.OSDMetadata) []string {
+	paths := []string{}
+	for _, device := range strings.Split(metadata.Devices, ",") {
+		if device != "" {
+			paths = append(paths, filepath.Join("/dev", device))
+		}
+	}
+	for _, devicePath := range strings.Split(metadata.DevicePaths, ",") {
+		// each entry is in the form "sdb=/dev/disk/by-path/pci-0000:00:10.0-scsi-0:0:1:0"
+		if _, path, found := strings.Cut(devicePath, "="); found && path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// applyCrushLocationOverride updates the CRUSH location of the OSD if it is pinned by an override.
+// The location is passed to the OSD daemon, which moves itself in the CRUSH map when it starts.
+func (c *Cluster) applyCrushLocationOverride(osd *OSDInfo) {
+	override := c.getOSDOverride(osd.ID, osd)
+	if override == nil || override.CrushLocation == "" || override.CrushLocation == osd.Location {
+		return
+	}
+	logger.Infof("overriding osd.%d crush location from %q to %q", osd.ID, osd.Location, override.CrushLocation)
+	osd.Location = override.CrushLocation
+}
+
+// applyOSDOverride reconciles the CRUSH weight, device class and primary affinity pinned for the OSD.
+func (c *Cluster) applyOSDOverride(actualOSD cephclient.OSDNodeUsage, override *cephv1.OSDOverride, primaryAffinity string) error {
+	if override.CrushWeight != "" {
+		changed, err := valuesDiffer(override.CrushWeight, actualOSD.CrushWeight.String())
+		if err != nil {
+			return errors.Wrapf(err, "failed to compare osd.%d crush weight", actualOSD.ID)
+		}
+		if changed {
+			if err := cephclient.SetCrushWeight(c.context, c.clusterInfo, actualOSD.ID, override.CrushWeight); err != nil {
+				return err
+			}
+		}
+	}
+
+	if override.DeviceClass != "" && override.DeviceClass != actualOSD.DeviceClass {
+		logger.Infof("updating osd.%d device class from %q to %q", actualOSD.ID, actualOSD.DeviceClass, override.DeviceClass)
+		if err := cephclient.SetDeviceClass(c.context, c.clusterInfo, actualOSD.ID, override.DeviceClass); err != nil {
+			return errors.Wrapf(err, "failed to set device class on osd %d", actualOSD.ID)
+		}
+	}
+
+	if override.PrimaryAffinity != "" {
+		changed := true
+		if primaryAffinity != "" {
+			var err error
+			changed, err = valuesDiffer(override.PrimaryAffinity, primaryAffinity)
+			if err != nil {
+				return errors.Wrapf(err, "failed to compare osd.%d primary affinity", actualOSD.ID)
+			}
+		}
+		if changed {
+			if err := cephclient.SetPrimaryAffinity(c.context, c.clusterInfo, actualOSD.ID, override.PrimaryAffinity); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getPrimaryAffinities returns the current primary affinity of each OSD
+func (c *Cluster) getPrimaryAffinities() (map[int]string, error) {
+	dump, err := cephclient.GetOSDDump(c.context, c.clusterInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get osd dump")
+	}
+	affinities := map[int]string{}
+	for _, osd := range dump.OSDs {
+		id, err := osd.OSD.Int64()
+		if err != nil {
+			continue
+		}
+		affinities[int(id)] = osd.PrimaryAffinity.String()
+	}
+	return affinities, nil
+}
+
+// valuesDiffer returns whether the two float values differ
+func valuesDiffer(desired, actual string) (bool, error) {
+	desiredValue, err := strconv.ParseFloat(desired, 64)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse desired value %q", desired)
+	}
+	actualValue, err := strconv.ParseFloat(actual, 64)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse actual value %q", actual)
+	}
+	return math.Abs(desiredValue-actualValue) > crushWeightTolerance, nil
+}
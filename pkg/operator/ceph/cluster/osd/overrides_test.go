@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const osdMetadataResults = `[
+	{"id":0,"hostname":"node1","devices":"sdb","device_paths":"sdb=/dev/disk/by-path/pci-0000:00:10.0-scsi-0:0:1:0"},
+	{"id":1,"hostname":"node1","devices":"sdc","device_paths":"sdc=/dev/disk/by-id/scsi-1234"},
+	{"id":2,"hostname":"node2","devices":"sdb","device_paths":""}
+]`
+
+const osdDumpResults = `{"osds":[
+	{"osd":0,"up":1,"in":1,"primary_affinity":1},
+	{"osd":1,"up":1,"in":1,"primary_affinity":0.5},
+	{"osd":2,"up":1,"in":1,"primary_affinity":1},
+	{"osd":3,"up":1,"in":1,"primary_affinity":1},
+	{"osd":4,"up":1,"in":1,"primary_affinity":1}
+]}`
+
+func TestGetOSDOverride(t *testing.T) {
+	metadataCalls := 0
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "metadata" {
+				metadataCalls++
+				return osdMetadataResults, nil
+			}
+			return "", nil
+		},
+	}
+	c := New(&clusterd.Context{Executor: executor}, cephclient.AdminTestClusterInfo("ns"), cephv1.ClusterSpec{}, "myversion")
+
+	t.Run("no overrides", func(t *testing.T) {
+		assert.Nil(t, c.getOSDOverride(0, nil))
+		assert.Equal(t, 0, metadataCalls)
+	})
+
+	c.spec.Storage.OSDOverrides = map[string]cephv1.OSDOverride{
+		"1":                         {DeviceClass: "by-id"},
+		"/dev/disk/by-id/scsi-1234": {DeviceClass: "by-path"},
+		"/dev/disk/by-path/pci-0000:00:10.0-scsi-0:0:1:0": {CrushWeight: "2.5"},
+		"set1-data-0-abcde": {PrimaryAffinity: "0"},
+	}
+
+	t.Run("override by osd id takes precedence", func(t *testing.T) {
+		override := c.getOSDOverride(1, nil)
+		assert.NotNil(t, override)
+		assert.Equal(t, "by-id", override.DeviceClass)
+	})
+
+	t.Run("override by persistent device path", func(t *testing.T) {
+		override := c.getOSDOverride(0, nil)
+		assert.NotNil(t, override)
+		assert.Equal(t, "2.5", override.CrushWeight)
+	})
+
+	t.Run("override by pvc name", func(t *testing.T) {
+		override := c.getOSDOverride(5, &OSDInfo{ID: 5, PVCName: "set1-data-0-abcde"})
+		assert.NotNil(t, override)
+		assert.Equal(t, "0", override.PrimaryAffinity)
+	})
+
+	t.Run("no matching override", func(t *testing.T) {
+		assert.Nil(t, c.getOSDOverride(2, &OSDInfo{ID: 2, BlockPath: "/dev/ceph-abc/osd-block-def"}))
+		// the metadata is only fetched once
+		assert.Equal(t, 1, metadataCalls)
+	})
+}
+
+func TestApplyCrushLocationOverride(t *testing.T) {
+	c := New(&clusterd.Context{}, cephclient.AdminTestClusterInfo("ns"), cephv1.ClusterSpec{}, "myversion")
+	c.osdMetadata = map[int]cephclient.OSDMetadata{}
+	c.spec.Storage.OSDOverrides = map[string]cephv1.OSDOverride{
+		"0": {CrushLocation: "root=default rack=rack1 host=node1"},
+	}
+
+	osd := &OSDInfo{ID: 0, Location: "root=default host=node1"}
+	c.applyCrushLocationOverride(osd)
+	assert.Equal(t, "root=default rack=rack1 host=node1", osd.Location)
+
+	osd = &OSDInfo{ID: 1, Location: "root=default host=node1"}
+	c.applyCrushLocationOverride(osd)
+	assert.Equal(t, "root=default host=node1", osd.Location)
+}
+
+func TestPostReconcileApplyOSDOverrides(t *testing.T) {
+	var reweighted, deviceClasses, affinities []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			logger.Infof("ExecuteCommandWithOutput: %s %v", command, args)
+			if args[0] != "osd" {
+				return "", nil
+			}
+			switch args[1] {
+			case "df":
+				return osdDFResults, nil
+			case "dump":
+				return osdDumpResults, nil
+			case "metadata":
+				return osdMetadataResults, nil
+			case "primary-affinity":
+				affinities = append(affinities, args[2]+"="+args[3])
+			case "crush":
+				switch args[2] {
+				case "set-device-class":
+					deviceClasses = append(deviceClasses, args[4]+"="+args[3])
+				case "reweight":
+					reweighted = append(reweighted, args[3]+"="+args[4])
+				}
+			}
+			return "", nil
+		},
+	}
+	clusterInfo := cephclient.AdminTestClusterInfo("ns")
+	clusterInfo.Context = context.TODO()
+	c := New(&clusterd.Context{Executor: executor, Clientset: fake.NewSimpleClientset()}, clusterInfo, cephv1.ClusterSpec{}, "myversion")
+	c.spec.Storage = cephv1.StorageScopeSpec{
+		AllowOsdCrushWeightUpdate: true,
+		OSDOverrides: map[string]cephv1.OSDOverride{
+			// same weight as the current one, no reweight expected
+			"0": {CrushWeight: "0.039093017578125", DeviceClass: "nvme"},
+			// same primary affinity as the current one, no update expected
+			"1": {PrimaryAffinity: "0.5"},
+			// the weight is pinned, so it must not be resized
+			"3":                         {CrushWeight: "1"},
+			"/dev/disk/by-id/scsi-1234": {DeviceClass: "ignored"},
+		},
+	}
+
+	err := c.postReconcileUpdateOSDProperties(map[int]*OSDInfo{0: {ID: 0, DeviceClass: "hdd"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"osd.3=1", "osd.4=9.305722"}, reweighted)
+	assert.Equal(t, []string{"osd.0=nvme"}, deviceClasses)
+	assert.Empty(t, affinities)
+}
+
+func TestSetOSDPropertiesWithOverride(t *testing.T) {
+	var affinity string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "primary-affinity" {
+				affinity = args[3]
+			}
+			return "", nil
+		},
+	}
+	c := New(&clusterd.Context{Executor: executor}, cephclient.AdminTestClusterInfo("ns"), cephv1.ClusterSpec{}, "myversion")
+	c.osdMetadata = map[int]cephclient.OSDMetadata{}
+	osdProps := osdProperties{}
+	osdProps.storeConfig.PrimaryAffinity = "0.8"
+
+	err := setOSDProperties(c, osdProps, &OSDInfo{ID: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8", affinity)
+
+	c.spec.Storage.OSDOverrides = map[string]cephv1.OSDOverride{"0": {PrimaryAffinity: "0.2"}}
+	err = setOSDProperties(c, osdProps, &OSDInfo{ID: 0})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.2", affinity)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// QuarantineReasonAnnotation is set on the deployment of a quarantined OSD with the reason of the quarantine
+	QuarantineReasonAnnotation = "ceph.rook.io/osd-quarantine-reason"
+	// QuarantineTimeAnnotation is set on the deployment of a quarantined OSD with the time of the quarantine
+	QuarantineTimeAnnotation = "ceph.rook.io/osd-quarantine-time"
+	// QuarantineReleaseAnnotation releases a quarantined OSD when set to "true" on its deployment
+	QuarantineReleaseAnnotation = "ceph.rook.io/osd-quarantine-release"
+
+	osdQuarantinedReason = "OSDQuarantined"
+	osdReleasedReason    = "OSDReleased"
+
+	defaultQuarantineThreshold = 5
+	defaultQuarantineWindow    = time.Hour
+	defaultMaxQuarantinedOSDs  = 1
+)
+
+// osdFlapState tracks the restarts of an OSD observed by the health monitor
+type osdFlapState struct {
+	// upFrom is the osdmap epoch at which the OSD was last marked up, it changes every time the OSD comes up again
+	upFrom   string
+	restarts []time.Time
+}
+
+// isQuarantined returns whether the OSD deployment is quarantined
+func isQuarantined(dep *appsv1.Deployment) bool {
+	_, ok := dep.Annotations[QuarantineReasonAnnotation]
+	return ok
+}
+
+// getQuarantinedDeployments returns the deployments of the quarantined OSDs
+func getQuarantinedDeployments(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]appsv1.Deployment, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, AppName)}
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list osd deployments")
+	}
+	quarantined := []appsv1.Deployment{}
+	for _, dep := range deployments.Items {
+		if isQuarantined(&dep) {
+			quarantined = append(quarantined, dep)
+		}
+	}
+	return quarantined, nil
+}
+
+// getQuarantineStatus returns the quarantined OSDs for the CephCluster status, sorted by OSD ID
+func getQuarantineStatus(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]cephv1.OSDQuarantineStatus, error) {
+	deployments, err := getQuarantinedDeployments(ctx, clientset, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(deployments) == 0 {
+		return nil, nil
+	}
+	statuses := make([]cephv1.OSDQuarantineStatus, 0, len(deployments))
+	for _, dep := range deployments {
+		osdID, err := strconv.Atoi(dep.Labels[OsdIdLabelKey])
+		if err != nil {
+			logger.Warningf("failed to get the osd id of quarantined deployment %q. %v", dep.Name, err)
+			continue
+		}
+		statuses = append(statuses, cephv1.OSDQuarantineStatus{
+			ID:             osdID,
+			Reason:         dep.Annotations[QuarantineReasonAnnotation],
+			QuarantineTime: dep.Annotations[QuarantineTimeAnnotation],
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses, nil
+}
+
+// checkOSDQuarantine releases the OSDs annotated for release and quarantines the OSDs that are flapping
+func (m *OSDHealthMonitor) checkOSDQuarantine() error {
+	ctx := m.clusterInfo.Context
+	cephCluster := &cephv1.CephCluster{}
+	if err := m.context.Client.Get(ctx, m.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to get ceph cluster to check osd quarantine")
+	}
+
+	quarantined, err := getQuarantinedDeployments(ctx, m.context.Clientset, m.clusterInfo.Namespace)
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	quarantinedIDs := map[string]bool{}
+	for i := range quarantined {
+		dep := &quarantined[i]
+		// releasing the OSDs is allowed even if the quarantine was disabled in the meantime
+		if dep.Annotations[QuarantineReleaseAnnotation] == "true" {
+			if err := m.releaseOSD(cephCluster, dep); err != nil {
+				logger.Errorf("failed to release quarantined osd deployment %q. %v", dep.Name, err)
+			} else {
+				changed = true
+				continue
+			}
+		}
+		quarantinedIDs[dep.Labels[OsdIdLabelKey]] = true
+	}
+
+	spec := cephCluster.Spec.Storage.FlappingQuarantine
+	if spec == nil || !spec.Enabled {
+		m.flapStates = nil
+	} else {
+		detected, err := m.quarantineFlappingOSDs(cephCluster, spec, quarantinedIDs)
+		if err != nil {
+			return err
+		}
+		changed = changed || detected
+	}
+
+	if !changed || cephCluster.Status.CephStorage == nil {
+		return nil
+	}
+	statuses, err := getQuarantineStatus(ctx, m.context.Clientset, m.clusterInfo.Namespace)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(cephCluster.Status.CephStorage.OSD.Quarantined, statuses) {
+		cephCluster.Status.CephStorage.OSD.Quarantined = statuses
+		if err := reporting.UpdateStatus(m.context.Client, cephCluster); err != nil {
+			return errors.Wrap(err, "failed to update the osd quarantine status")
+		}
+	}
+	return nil
+}
+
+// quarantineFlappingOSDs counts the times each OSD came up again and quarantines the OSDs that came
+// up more than the threshold within the window. Returns whether any OSD was quarantined.
+func (m *OSDHealthMonitor) quarantineFlappingOSDs(cephCluster *cephv1.CephCluster, spec *cephv1.OSDFlappingQuarantineSpec, quarantinedIDs map[string]bool) (bool, error) {
+	threshold := defaultQuarantineThreshold
+	if spec.Threshold > 0 {
+		threshold = spec.Threshold
+	}
+	window := defaultQuarantineWindow
+	if spec.Window != nil && spec.Window.Duration > 0 {
+		window = spec.Window.Duration
+	}
+	maxQuarantined := defaultMaxQuarantinedOSDs
+	if spec.MaxQuarantinedOSDs > 0 {
+		maxQuarantined = spec.MaxQuarantinedOSDs
+	}
+
+	osdDump, err := cephclient.GetOSDDump(m.context, m.clusterInfo)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get osd dump to detect flapping osds")
+	}
+
+	if m.flapStates == nil {
+		m.flapStates = map[int]*osdFlapState{}
+	}
+	now := time.Now()
+	quarantinedAny := false
+	for _, osdStatus := range osdDump.OSDs {
+		id64, err := osdStatus.OSD.Int64()
+		if err != nil {
+			continue
+		}
+		osdID := int(id64)
+		if quarantinedIDs[strconv.Itoa(osdID)] {
+			delete(m.flapStates, osdID)
+			continue
+		}
+
+		upFrom := osdStatus.UpFrom.String()
+		state, ok := m.flapStates[osdID]
+		if !ok {
+			// the restarts are counted from the first time the OSD is seen by the monitor
+			m.flapStates[osdID] = &osdFlapState{upFrom: upFrom}
+			continue
+		}
+		if upFrom != state.upFrom && upFrom != "" && upFrom != "0" {
+			logger.Debugf("osd.%d came up again at osdmap epoch %s", osdID, upFrom)
+			state.restarts = append(state.restarts, now)
+			state.upFrom = upFrom
+		}
+		// forget the restarts out of the window
+		for len(state.restarts) > 0 && now.Sub(state.restarts[0]) > window {
+			state.restarts = state.restarts[1:]
+		}
+		if len(state.restarts) < threshold {
+			continue
+		}
+
+		if len(quarantinedIDs) >= maxQuarantined {
+			logger.Warningf("osd.%d is flapping but is not quarantined since %d osd(s) are already quarantined", osdID, len(quarantinedIDs))
+			continue
+		}
+		reason := fmt.Sprintf("osd came up again %d times within %s", len(state.restarts), window.String())
+		if err := m.quarantineOSD(cephCluster, osdID, reason); err != nil {
+			logger.Errorf("failed to quarantine osd.%d. %v", osdID, err)
+			continue
+		}
+		delete(m.flapStates, osdID)
+		quarantinedIDs[strconv.Itoa(osdID)] = true
+		quarantinedAny = true
+	}
+	return quarantinedAny, nil
+}
+
+// quarantineOSD marks the OSD out and scales down its deployment
+func (m *OSDHealthMonitor) quarantineOSD(cephCluster *cephv1.CephCluster, osdID int, reason string) error {
+	ctx := m.clusterInfo.Context
+	dep, err := m.context.Clientset.AppsV1().Deployments(m.clusterInfo.Namespace).Get(ctx, deploymentName(osdID), metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get deployment of osd %d", osdID)
+	}
+
+	logger.Warningf("quarantining osd.%d. %s", osdID, reason)
+	if output, err := cephclient.OSDOut(m.context, m.clusterInfo, osdID); err != nil {
+		return errors.Wrapf(err, "failed to mark osd %d out. %s", osdID, output)
+	}
+
+	if dep.Annotations == nil {
+		dep.Annotations = map[string]string{}
+	}
+	dep.Annotations[QuarantineReasonAnnotation] = reason
+	dep.Annotations[QuarantineTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	delete(dep.Annotations, QuarantineReleaseAnnotation)
+	replicas := int32(0)
+	dep.Spec.Replicas = &replicas
+	if _, err := m.context.Clientset.AppsV1().Deployments(m.clusterInfo.Namespace).Update(ctx, dep, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to scale down deployment of osd %d", osdID)
+	}
+
+	m.recordEvent(cephCluster, corev1.EventTypeWarning, osdQuarantinedReason,
+		fmt.Sprintf("osd.%d was marked out and stopped: %s. Set the annotation %q to \"true\" on deployment %q to release it", osdID, reason, QuarantineReleaseAnnotation, dep.Name))
+	return nil
+}
+
+// releaseOSD scales up the deployment of a quarantined OSD and marks the OSD in
+func (m *OSDHealthMonitor) releaseOSD(cephCluster *cephv1.CephCluster, dep *appsv1.Deployment) error {
+	osdID, err := strconv.Atoi(dep.Labels[OsdIdLabelKey])
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the osd id of deployment %q", dep.Name)
+	}
+
+	logger.Infof("releasing quarantined osd.%d", osdID)
+	delete(dep.Annotations, QuarantineReasonAnnotation)
+	delete(dep.Annotations, QuarantineTimeAnnotation)
+	delete(dep.Annotations, QuarantineReleaseAnnotation)
+	replicas := int32(1)
+	dep.Spec.Replicas = &replicas
+	if _, err := m.context.Clientset.AppsV1().Deployments(m.clusterInfo.Namespace).Update(m.clusterInfo.Context, dep, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrapf(err, "failed to scale up deployment of osd %d", osdID)
+	}
+	if output, err := cephclient.OSDIn(m.context, m.clusterInfo, osdID); err != nil {
+		return errors.Wrapf(err, "failed to mark osd %d in. %s", osdID, output)
+	}
+
+	m.recordEvent(cephCluster, corev1.EventTypeNormal, osdReleasedReason, fmt.Sprintf("osd.%d was released from quarantine", osdID))
+	return nil
+}
+
+func (m *OSDHealthMonitor) recordEvent(cephCluster *cephv1.CephCluster, eventType, reason, message string) {
+	if m.recorder == nil {
+		return
+	}
+	m.recorder.Event(cephCluster, eventType, reason, message)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	testexec "github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCheckOSDQuarantine(t *testing.T) {
+	ctx := context.TODO()
+	upFrom := map[int]int{0: 10, 1: 10}
+	var outOSDs, inOSDs []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] != "osd" {
+				return "", nil
+			}
+			switch args[1] {
+			case "dump":
+				return fmt.Sprintf(`{"osds":[{"osd":0,"up":1,"in":1,"up_from":%d},{"osd":1,"up":1,"in":1,"up_from":%d}]}`, upFrom[0], upFrom[1]), nil
+			case "out":
+				outOSDs = append(outOSDs, args[2])
+			case "in":
+				inOSDs = append(inOSDs, args[2])
+			}
+			return "", nil
+		},
+	}
+
+	clusterInfo := cephclient.AdminTestClusterInfo("fake")
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterInfo.NamespacedName().Name, Namespace: "fake"},
+		Spec: cephv1.ClusterSpec{
+			Storage: cephv1.StorageScopeSpec{
+				FlappingQuarantine: &cephv1.OSDFlappingQuarantineSpec{Enabled: true, Threshold: 2},
+			},
+		},
+		Status: cephv1.ClusterStatus{CephStorage: &cephv1.CephStorage{}},
+	}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects([]runtime.Object{cephCluster}...).WithStatusSubresource(cephCluster).Build()
+	clientset := testexec.New(t, 1)
+	for _, id := range []int{0, 1} {
+		replicas := int32(1)
+		dep := &apps.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      deploymentName(id),
+				Namespace: "fake",
+				Labels:    map[string]string{k8sutil.AppAttr: AppName, OsdIdLabelKey: fmt.Sprintf("%d", id)},
+			},
+			Spec: apps.DeploymentSpec{Replicas: &replicas},
+		}
+		_, err := clientset.AppsV1().Deployments("fake").Create(ctx, dep, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	recorder := record.NewFakeRecorder(10)
+	m := NewOSDHealthMonitor(&clusterd.Context{Executor: executor, Clientset: clientset, Client: client}, clusterInfo, false, cephv1.CephClusterHealthCheckSpec{}, recorder)
+
+	getDeployment := func(id int) *apps.Deployment {
+		dep, err := clientset.AppsV1().Deployments("fake").Get(ctx, deploymentName(id), metav1.GetOptions{})
+		require.NoError(t, err)
+		return dep
+	}
+	getStatus := func() []cephv1.OSDQuarantineStatus {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, client.Get(ctx, clusterInfo.NamespacedName(), cluster))
+		return cluster.Status.CephStorage.OSD.Quarantined
+	}
+
+	t.Run("osd below the threshold", func(t *testing.T) {
+		// the first check only records the current state of the OSDs
+		assert.NoError(t, m.checkOSDQuarantine())
+		upFrom[1] = 20
+		assert.NoError(t, m.checkOSDQuarantine())
+		assert.Empty(t, outOSDs)
+		assert.Equal(t, int32(1), *getDeployment(1).Spec.Replicas)
+	})
+
+	t.Run("flapping osd is quarantined", func(t *testing.T) {
+		upFrom[1] = 30
+		assert.NoError(t, m.checkOSDQuarantine())
+		assert.Equal(t, []string{"1"}, outOSDs)
+		dep := getDeployment(1)
+		assert.Equal(t, int32(0), *dep.Spec.Replicas)
+		assert.True(t, isQuarantined(dep))
+		assert.Contains(t, dep.Annotations[QuarantineReasonAnnotation], "2 times")
+		assert.Contains(t, <-recorder.Events, osdQuarantinedReason)
+
+		status := getStatus()
+		require.Len(t, status, 1)
+		assert.Equal(t, 1, status[0].ID)
+		assert.NotEmpty(t, status[0].QuarantineTime)
+	})
+
+	t.Run("max quarantined osds reached", func(t *testing.T) {
+		upFrom[0] = 20
+		assert.NoError(t, m.checkOSDQuarantine())
+		upFrom[0] = 30
+		assert.NoError(t, m.checkOSDQuarantine())
+		assert.Equal(t, []string{"1"}, outOSDs)
+		assert.False(t, isQuarantined(getDeployment(0)))
+	})
+
+	t.Run("release quarantined osd", func(t *testing.T) {
+		dep := getDeployment(1)
+		dep.Annotations[QuarantineReleaseAnnotation] = "true"
+		_, err := clientset.AppsV1().Deployments("fake").Update(ctx, dep, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		assert.NoError(t, m.checkOSDQuarantine())
+		assert.Equal(t, []string{"1"}, inOSDs)
+		dep = getDeployment(1)
+		assert.Equal(t, int32(1), *dep.Spec.Replicas)
+		assert.False(t, isQuarantined(dep))
+		assert.NotContains(t, dep.Annotations, QuarantineReleaseAnnotation)
+		assert.Contains(t, <-recorder.Events, osdReleasedReason)
+
+		// osd.0 is still flapping and is quarantined now that osd.1 was released
+		assert.Equal(t, []string{"1", "0"}, outOSDs)
+		assert.Contains(t, <-recorder.Events, osdQuarantinedReason)
+		status := getStatus()
+		require.Len(t, status, 1)
+		assert.Equal(t, 0, status[0].ID)
+	})
+
+	t.Run("quarantine disabled", func(t *testing.T) {
+		cluster := &cephv1.CephCluster{}
+		require.NoError(t, client.Get(ctx, clusterInfo.NamespacedName(), cluster))
+		cluster.Spec.Storage.FlappingQuarantine.Enabled = false
+		require.NoError(t, client.Update(ctx, cluster))
+
+		upFrom[1] = 40
+		assert.NoError(t, m.checkOSDQuarantine())
+		assert.Nil(t, m.flapStates)
+		assert.Equal(t, []string{"1", "0"}, outOSDs)
+		// disabling the quarantine does not release the quarantined osds
+		assert.True(t, isQuarantined(getDeployment(0)))
+	})
+}
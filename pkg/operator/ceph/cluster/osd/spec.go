@@ -440,9 +440,21 @@ func (c *Cluster) makeDeployment(osdProps osdProperties, osd *OSDInfo, provision
 		fmt.Sprintf("--crush-location=%s", osd.Location),
 	}...)
 
-	// Ceph expects initial weight as float value in tera-bytes units
+	// Ceph expects initial weight as float value in tera-bytes units. When the weight is ramped up, the
+	// initial weight is the target of the ramp-up and the OSD joins the CRUSH map with a weight of 0,
+	// so the flag must not override the initial weight of 0 set in the mon store for the OSD.
 	if osdProps.storeConfig.InitialWeight != "" {
-		args = append(args, fmt.Sprintf("--osd-crush-initial-weight=%s", osdProps.storeConfig.InitialWeight))
+		rampingUp := false
+		if c.weightRampUpEnabled(osdProps) {
+			var err error
+			rampingUp, err = c.weightRampUpStarted(osd.ID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to generate deployment for OSD %d", osd.ID)
+			}
+		}
+		if !rampingUp {
+			args = append(args, fmt.Sprintf("--osd-crush-initial-weight=%s", osdProps.storeConfig.InitialWeight))
+		}
 	}
 
 	// If the OSD runs on PVC
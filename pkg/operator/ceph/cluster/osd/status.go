@@ -324,6 +324,10 @@ func (c *Cluster) createOSDsForStatusMap(
 
 	logger.Infof("OSD orchestration status for %s %s is %q", nodeOrPVC, nodeOrPVCName, status.Status)
 
+	if status.Status == OrchestrationStatusCompleted || status.Status == OrchestrationStatusFailed {
+		c.recordProvisioningReport(nodeOrPVCName, status)
+	}
+
 	if status.Status == OrchestrationStatusCompleted {
 		createConfig.createNewOSDsFromStatus(status, nodeOrPVCName, errs)
 		c.deleteStatusConfigMap(nodeOrPVCName) // remove the provisioning status configmap
@@ -151,6 +151,11 @@ func (c *updateConfig) updateExistingOSDs(errs *provisionErrors) {
 			continue
 		}
 
+		if isQuarantined(dep) {
+			logger.Warningf("Skipping update for OSD %d since it is quarantined. %s", osdID, dep.Annotations[QuarantineReasonAnnotation])
+			continue
+		}
+
 		// backward compatibility for old deployments
 		// Checking DeviceClass with None too, because ceph-volume lvm list return crush device class as None
 		// Tracker https://tracker.ceph.com/issues/53425
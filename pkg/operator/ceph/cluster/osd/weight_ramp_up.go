@@ -0,0 +1,335 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	weightRampUpAppName        = "rook-ceph-osd-weight-ramp-up"
+	weightRampUpMapNameFmt     = "rook-ceph-osd-%d-weight-ramp-up"
+	weightRampUpStatusKey      = "status"
+	weightRampUpStepPercentKey = "stepPercent"
+	crushInitialWeightOption   = "osd_crush_initial_weight"
+	defaultRampUpStepPercent   = 25
+)
+
+// weightRampUp is the state of the CRUSH weight ramp-up of an OSD, saved in a ConfigMap
+type weightRampUp struct {
+	status      cephv1.OSDWeightRampUpStatus
+	stepPercent int
+}
+
+func weightRampUpConfigMapName(osdID int) string {
+	return fmt.Sprintf(weightRampUpMapNameFmt, osdID)
+}
+
+// getWeightRampUpSpec returns the ramp-up settings of the device set of the OSD, or the cluster-wide settings
+func (c *Cluster) getWeightRampUpSpec(osdProps osdProperties) *cephv1.OSDWeightRampUpSpec {
+	if osdProps.deviceSetName != "" {
+		for _, deviceSet := range c.spec.Storage.StorageClassDeviceSets {
+			if deviceSet.Name == osdProps.deviceSetName && deviceSet.WeightRampUp != nil {
+				return deviceSet.WeightRampUp
+			}
+		}
+	}
+	return c.spec.Storage.WeightRampUp
+}
+
+func (c *Cluster) weightRampUpEnabled(osdProps osdProperties) bool {
+	rampUp := c.getWeightRampUpSpec(osdProps)
+	return rampUp != nil && rampUp.Enabled
+}
+
+// weightRampUpStarted returns whether the crush weight of the OSD is being ramped up, i.e. whether
+// startWeightRampUp saved a ramp-up that is not completed yet
+func (c *Cluster) weightRampUpStarted(osdID int) (bool, error) {
+	_, err := c.context.Clientset.CoreV1().ConfigMaps(c.clusterInfo.Namespace).Get(c.clusterInfo.Context, weightRampUpConfigMapName(osdID), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get weight ramp-up configmap of osd %d", osdID)
+	}
+	return true, nil
+}
+
+// startWeightRampUp configures a new OSD to join the CRUSH map with a weight of 0 and records it so
+// that the OSD health monitor raises its weight gradually. Errors are only logged since the OSD can
+// still be created, it will then join the CRUSH map with its full weight.
+func (c *Cluster) startWeightRampUp(osd *OSDInfo, osdProps osdProperties) {
+	rampUp := c.getWeightRampUpSpec(osdProps)
+	if rampUp == nil || !rampUp.Enabled {
+		return
+	}
+	if override := c.getOSDOverride(osd.ID, osd); override != nil && override.CrushWeight != "" {
+		logger.Infof("not ramping up the crush weight of osd.%d since it is pinned by an override", osd.ID)
+		return
+	}
+
+	// an OSD deployment may be re-created for an OSD that is already in the CRUSH map
+	usage, err := cephclient.GetOSDUsage(c.context, c.clusterInfo)
+	if err != nil {
+		logger.Warningf("failed to get osd usage, not ramping up the crush weight of osd.%d. %v", osd.ID, err)
+		return
+	}
+	for _, existing := range usage.OSDNodes {
+		if existing.ID != osd.ID {
+			continue
+		}
+		if weight, err := existing.CrushWeight.Float64(); err == nil && weight > 0 {
+			logger.Debugf("not ramping up the crush weight of osd.%d which already has a crush weight of %f", osd.ID, weight)
+			return
+		}
+	}
+
+	who := fmt.Sprintf("osd.%d", osd.ID)
+	if err := config.GetMonStore(c.context, c.clusterInfo).Set(who, crushInitialWeightOption, "0"); err != nil {
+		logger.Warningf("failed to set the initial crush weight of osd.%d to 0, not ramping up its crush weight. %v", osd.ID, err)
+		return
+	}
+
+	stepPercent := rampUp.StepPercent
+	if stepPercent <= 0 || stepPercent > 100 {
+		stepPercent = defaultRampUpStepPercent
+	}
+	state := weightRampUp{
+		status: cephv1.OSDWeightRampUpStatus{
+			ID:            osd.ID,
+			CurrentWeight: "0",
+			// the initial weight configured for the OSD becomes the target of the ramp-up
+			TargetWeight: osdProps.storeConfig.InitialWeight,
+			Message:      "waiting for the osd to start",
+		},
+		stepPercent: stepPercent,
+	}
+	kv := k8sutil.NewConfigMapKVStore(c.clusterInfo.Namespace, c.context.Clientset, c.clusterInfo.OwnerInfo)
+	if err := saveWeightRampUp(c.clusterInfo.Context, kv, state); err != nil {
+		logger.Warningf("failed to save the crush weight ramp-up of osd.%d. %v", osd.ID, err)
+		return
+	}
+	logger.Infof("osd.%d will join the crush map with a weight of 0 and be ramped up by steps of %d%%", osd.ID, stepPercent)
+}
+
+func saveWeightRampUp(ctx context.Context, kv *k8sutil.ConfigMapKVStore, state weightRampUp) error {
+	s, err := json.Marshal(state.status)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal weight ramp-up status of osd %d", state.status.ID)
+	}
+	labels := map[string]string{
+		k8sutil.AppAttr: weightRampUpAppName,
+		OsdIdLabelKey:   strconv.Itoa(state.status.ID),
+	}
+	name := weightRampUpConfigMapName(state.status.ID)
+	if err := kv.SetValueWithLabels(ctx, name, weightRampUpStepPercentKey, strconv.Itoa(state.stepPercent), labels); err != nil {
+		return errors.Wrapf(err, "failed to save weight ramp-up step of osd %d", state.status.ID)
+	}
+	if err := kv.SetValueWithLabels(ctx, name, weightRampUpStatusKey, string(s), labels); err != nil {
+		return errors.Wrapf(err, "failed to save weight ramp-up status of osd %d", state.status.ID)
+	}
+	return nil
+}
+
+// listWeightRampUps returns the OSDs being ramped up, sorted by OSD ID
+func listWeightRampUps(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]weightRampUp, error) {
+	listOpts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", k8sutil.AppAttr, weightRampUpAppName)}
+	configMaps, err := clientset.CoreV1().ConfigMaps(namespace).List(ctx, listOpts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list weight ramp-up configmaps")
+	}
+
+	rampUps := []weightRampUp{}
+	for _, cm := range configMaps.Items {
+		state := weightRampUp{stepPercent: defaultRampUpStepPercent}
+		if err := json.Unmarshal([]byte(cm.Data[weightRampUpStatusKey]), &state.status); err != nil {
+			logger.Warningf("failed to parse weight ramp-up status in configmap %q. %v", cm.Name, err)
+			continue
+		}
+		if step, err := strconv.Atoi(cm.Data[weightRampUpStepPercentKey]); err == nil && step > 0 && step <= 100 {
+			state.stepPercent = step
+		}
+		rampUps = append(rampUps, state)
+	}
+	sort.Slice(rampUps, func(i, j int) bool { return rampUps[i].status.ID < rampUps[j].status.ID })
+	return rampUps, nil
+}
+
+// getWeightRampUpStatus returns the progress of the weight ramp-up of the OSDs for the CephCluster status
+func getWeightRampUpStatus(ctx context.Context, clientset kubernetes.Interface, namespace string) ([]cephv1.OSDWeightRampUpStatus, error) {
+	rampUps, err := listWeightRampUps(ctx, clientset, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return weightRampUpStatuses(rampUps), nil
+}
+
+func weightRampUpStatuses(rampUps []weightRampUp) []cephv1.OSDWeightRampUpStatus {
+	if len(rampUps) == 0 {
+		return nil
+	}
+	statuses := make([]cephv1.OSDWeightRampUpStatus, 0, len(rampUps))
+	for _, rampUp := range rampUps {
+		statuses = append(statuses, rampUp.status)
+	}
+	return statuses
+}
+
+// nextRampUpWeight returns the CRUSH weight of the next step, capped at the target weight
+func nextRampUpWeight(current, target float64, stepPercent int) float64 {
+	return math.Min(target, current+target*float64(stepPercent)/100)
+}
+
+// checkWeightRampUp raises the CRUSH weight of the OSDs being ramped up by one step when all the PGs
+// are clean, and completes the ramp-up of the OSDs that reached their target weight.
+func (m *OSDHealthMonitor) checkWeightRampUp() error {
+	ctx := m.clusterInfo.Context
+	rampUps, err := listWeightRampUps(ctx, m.context.Clientset, m.clusterInfo.Namespace)
+	if err != nil {
+		return err
+	}
+	if len(rampUps) == 0 {
+		return nil
+	}
+
+	cephCluster := &cephv1.CephCluster{}
+	if err := m.context.Client.Get(ctx, m.clusterInfo.NamespacedName(), cephCluster); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to get ceph cluster to ramp up osd weights")
+	}
+
+	usage, err := cephclient.GetOSDUsage(m.context, m.clusterInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to get osd usage to ramp up osd weights")
+	}
+	cleanMsg, clean, err := cephclient.IsClusterClean(m.context, m.clusterInfo, cephCluster.Spec.DisruptionManagement.PGHealthyRegex)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if the PGs are clean to ramp up osd weights")
+	}
+
+	kv := k8sutil.NewConfigMapKVStore(m.clusterInfo.Namespace, m.context.Clientset, m.clusterInfo.OwnerInfo)
+	inProgress := []weightRampUp{}
+	for _, rampUp := range rampUps {
+		done, err := m.stepWeightRampUp(&rampUp, usage, clean, cleanMsg)
+		if err != nil {
+			logger.Errorf("failed to ramp up the crush weight of osd.%d. %v", rampUp.status.ID, err)
+		}
+		if done {
+			if err := m.completeWeightRampUp(rampUp.status.ID); err != nil {
+				logger.Errorf("failed to complete the crush weight ramp-up of osd.%d. %v", rampUp.status.ID, err)
+				inProgress = append(inProgress, rampUp)
+			}
+			continue
+		}
+		if err := saveWeightRampUp(ctx, kv, rampUp); err != nil {
+			logger.Errorf("failed to save the crush weight ramp-up of osd.%d. %v", rampUp.status.ID, err)
+		}
+		inProgress = append(inProgress, rampUp)
+	}
+
+	statuses := weightRampUpStatuses(inProgress)
+	if cephCluster.Status.CephStorage != nil && !reflect.DeepEqual(cephCluster.Status.CephStorage.OSD.WeightRampUp, statuses) {
+		cephCluster.Status.CephStorage.OSD.WeightRampUp = statuses
+		if err := reporting.UpdateStatus(m.context.Client, cephCluster); err != nil {
+			return errors.Wrap(err, "failed to update the osd weight ramp-up status")
+		}
+	}
+	return nil
+}
+
+// stepWeightRampUp updates the ramp-up of an OSD and returns whether it reached its target weight
+func (m *OSDHealthMonitor) stepWeightRampUp(rampUp *weightRampUp, usage *cephclient.OSDUsage, clean bool, cleanMsg string) (bool, error) {
+	var actualOSD *cephclient.OSDNodeUsage
+	for i := range usage.OSDNodes {
+		if usage.OSDNodes[i].ID == rampUp.status.ID {
+			actualOSD = &usage.OSDNodes[i]
+			break
+		}
+	}
+	if actualOSD == nil || actualOSD.KB.String() == "" || actualOSD.KB.String() == "0" {
+		rampUp.status.Message = "waiting for the osd to start"
+		return false, nil
+	}
+
+	current, err := actualOSD.CrushWeight.Float64()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse crush weight %q", actualOSD.CrushWeight.String())
+	}
+	var target float64
+	if rampUp.status.TargetWeight != "" {
+		target, err = strconv.ParseFloat(rampUp.status.TargetWeight, 64)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to parse target weight %q", rampUp.status.TargetWeight)
+		}
+	} else {
+		target, err = cephclient.ConvertKibibytesToTebibytes(actualOSD.KB.String())
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to compute the target weight from the osd size %q", actualOSD.KB.String())
+		}
+		rampUp.status.TargetWeight = fmt.Sprintf("%f", target)
+	}
+	rampUp.status.CurrentWeight = fmt.Sprintf("%f", current)
+
+	if current >= target-crushWeightTolerance {
+		logger.Infof("osd.%d reached its target crush weight %f", rampUp.status.ID, target)
+		return true, nil
+	}
+	if !clean {
+		rampUp.status.Message = fmt.Sprintf("waiting for the PGs to be clean. %s", cleanMsg)
+		return false, nil
+	}
+
+	next := fmt.Sprintf("%f", nextRampUpWeight(current, target, rampUp.stepPercent))
+	if err := cephclient.SetCrushWeight(m.context, m.clusterInfo, rampUp.status.ID, next); err != nil {
+		rampUp.status.Message = "failed to raise the crush weight"
+		return false, err
+	}
+	rampUp.status.CurrentWeight = next
+	rampUp.status.Message = fmt.Sprintf("raised the crush weight by %d%% of the target weight", rampUp.stepPercent)
+	return false, nil
+}
+
+// completeWeightRampUp removes the initial weight of the OSD and the state of its ramp-up
+func (m *OSDHealthMonitor) completeWeightRampUp(osdID int) error {
+	if err := config.GetMonStore(m.context, m.clusterInfo).Delete(fmt.Sprintf("osd.%d", osdID), crushInitialWeightOption); err != nil {
+		return err
+	}
+	err := m.context.Clientset.CoreV1().ConfigMaps(m.clusterInfo.Namespace).Delete(m.clusterInfo.Context, weightRampUpConfigMapName(osdID), metav1.DeleteOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete weight ramp-up configmap of osd %d", osdID)
+	}
+	logger.Infof("completed the crush weight ramp-up of osd.%d", osdID)
+	return nil
+}
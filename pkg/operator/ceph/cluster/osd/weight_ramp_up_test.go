@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package osd
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/client/clientset/versioned/scheme"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	testexec "github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// osd.0 is already in the CRUSH map, osd.5 is a new 1 TiB OSD that joined with a weight of 0
+const rampUpOSDDFResults = `{"nodes":[
+	{"id":0,"device_class":"hdd","name":"osd.0","crush_weight":1,"kb":1073741824},
+	{"id":5,"device_class":"hdd","name":"osd.5","crush_weight":0,"kb":1073741824}
+]}`
+
+func TestNextRampUpWeight(t *testing.T) {
+	assert.Equal(t, 0.25, nextRampUpWeight(0, 1, 25))
+	assert.Equal(t, 1.5, nextRampUpWeight(1, 2, 25))
+	assert.Equal(t, 2.0, nextRampUpWeight(1.9, 2, 25))
+	assert.Equal(t, 2.0, nextRampUpWeight(0, 2, 100))
+}
+
+func TestGetWeightRampUpSpec(t *testing.T) {
+	c := New(&clusterd.Context{}, cephclient.AdminTestClusterInfo("ns"), cephv1.ClusterSpec{}, "myversion")
+	assert.False(t, c.weightRampUpEnabled(osdProperties{}))
+
+	c.spec.Storage.WeightRampUp = &cephv1.OSDWeightRampUpSpec{Enabled: true, StepPercent: 10}
+	c.spec.Storage.StorageClassDeviceSets = []cephv1.StorageClassDeviceSet{
+		{Name: "set1", WeightRampUp: &cephv1.OSDWeightRampUpSpec{Enabled: false}},
+		{Name: "set2"},
+	}
+	assert.True(t, c.weightRampUpEnabled(osdProperties{}))
+	assert.False(t, c.weightRampUpEnabled(osdProperties{deviceSetName: "set1"}))
+	assert.True(t, c.weightRampUpEnabled(osdProperties{deviceSetName: "set2"}))
+	assert.Equal(t, 10, c.getWeightRampUpSpec(osdProperties{deviceSetName: "set2"}).StepPercent)
+}
+
+func TestStartWeightRampUp(t *testing.T) {
+	ctx := context.TODO()
+	var configSet []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "osd" && args[1] == "df" {
+				return rampUpOSDDFResults, nil
+			}
+			return "", nil
+		},
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "config" && args[1] == "set" {
+				configSet = append(configSet, args[2]+" "+args[3]+"="+args[4])
+			}
+			return "", nil
+		},
+	}
+	clientset := testexec.New(t, 1)
+	clusterInfo := cephclient.AdminTestClusterInfo("ns")
+	clusterInfo.OwnerInfo = &k8sutil.OwnerInfo{}
+	c := New(&clusterd.Context{Executor: executor, Clientset: clientset}, clusterInfo, cephv1.ClusterSpec{}, "myversion")
+	c.osdMetadata = map[int]cephclient.OSDMetadata{}
+
+	t.Run("ramp-up disabled", func(t *testing.T) {
+		c.startWeightRampUp(&OSDInfo{ID: 5}, osdProperties{})
+		assert.Empty(t, configSet)
+	})
+
+	c.spec.Storage.WeightRampUp = &cephv1.OSDWeightRampUpSpec{Enabled: true}
+
+	t.Run("osd already in the crush map", func(t *testing.T) {
+		c.startWeightRampUp(&OSDInfo{ID: 0}, osdProperties{})
+		assert.Empty(t, configSet)
+	})
+
+	t.Run("weight pinned by an override", func(t *testing.T) {
+		c.spec.Storage.OSDOverrides = map[string]cephv1.OSDOverride{"5": {CrushWeight: "1"}}
+		c.startWeightRampUp(&OSDInfo{ID: 5}, osdProperties{})
+		assert.Empty(t, configSet)
+		c.spec.Storage.OSDOverrides = nil
+	})
+
+	t.Run("new osd", func(t *testing.T) {
+		osdProps := osdProperties{}
+		osdProps.storeConfig.InitialWeight = "0.5"
+		c.startWeightRampUp(&OSDInfo{ID: 5}, osdProps)
+		assert.Equal(t, []string{"osd.5 osd_crush_initial_weight=0"}, configSet)
+
+		rampUps, err := listWeightRampUps(ctx, clientset, "ns")
+		assert.NoError(t, err)
+		require.Len(t, rampUps, 1)
+		assert.Equal(t, defaultRampUpStepPercent, rampUps[0].stepPercent)
+		assert.Equal(t, 5, rampUps[0].status.ID)
+		assert.Equal(t, "0", rampUps[0].status.CurrentWeight)
+		assert.Equal(t, "0.5", rampUps[0].status.TargetWeight)
+	})
+}
+
+func TestWeightRampUpInitialWeightArg(t *testing.T) {
+	clusterInfo := cephclient.AdminTestClusterInfo("ns")
+	clusterInfo.OwnerInfo = &k8sutil.OwnerInfo{}
+	clientset := testexec.New(t, 1)
+	c := New(&clusterd.Context{Clientset: clientset, ConfigDir: "/var/lib/rook", Executor: &exectest.MockExecutor{}}, clusterInfo, cephv1.ClusterSpec{}, "myversion")
+	c.spec.Storage.WeightRampUp = &cephv1.OSDWeightRampUpSpec{Enabled: true}
+
+	osdProps := osdProperties{crushHostname: "node1"}
+	osdProps.storeConfig.InitialWeight = "0.5"
+	provisionConfig := &provisionConfig{DataPathMap: opconfig.NewDatalessDaemonDataPathMap("ns", "/var/lib/rook")}
+	hasInitialWeightArg := func() bool {
+		deployment, err := c.makeDeployment(osdProps, &OSDInfo{ID: 5, CVMode: "raw"}, provisionConfig)
+		require.NoError(t, err)
+		return slices.Contains(deployment.Spec.Template.Spec.Containers[0].Args, "--osd-crush-initial-weight=0.5")
+	}
+
+	t.Run("ramp-up not started", func(t *testing.T) {
+		assert.True(t, hasInitialWeightArg())
+	})
+
+	t.Run("ramp-up in progress", func(t *testing.T) {
+		kv := k8sutil.NewConfigMapKVStore("ns", clientset, clusterInfo.OwnerInfo)
+		err := saveWeightRampUp(context.TODO(), kv, weightRampUp{status: cephv1.OSDWeightRampUpStatus{ID: 5, CurrentWeight: "0"}, stepPercent: 25})
+		require.NoError(t, err)
+		assert.False(t, hasInitialWeightArg())
+	})
+}
+
+func TestCheckWeightRampUp(t *testing.T) {
+	ctx := context.TODO()
+	cephStatus := unHealthyCephStatus
+	crushWeight := "0"
+	var reweights, configRemoved []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithOutput: func(command string, args ...string) (string, error) {
+			if args[0] == "status" {
+				return cephStatus, nil
+			}
+			if args[0] == "osd" && args[1] == "df" {
+				return `{"nodes":[{"id":5,"name":"osd.5","crush_weight":` + crushWeight + `,"kb":1073741824}]}`, nil
+			}
+			if args[0] == "osd" && args[1] == "crush" && args[2] == "reweight" {
+				reweights = append(reweights, args[4])
+				crushWeight = args[4]
+			}
+			return "", nil
+		},
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			if args[0] == "config" && args[1] == "rm" {
+				configRemoved = append(configRemoved, args[2]+" "+args[3])
+			}
+			return "", nil
+		},
+	}
+
+	clusterInfo := cephclient.AdminTestClusterInfo("fake")
+	clusterInfo.OwnerInfo = &k8sutil.OwnerInfo{}
+	cephCluster := &cephv1.CephCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterInfo.NamespacedName().Name, Namespace: "fake"},
+		Status:     cephv1.ClusterStatus{CephStorage: &cephv1.CephStorage{}},
+	}
+	client := clientfake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects([]runtime.Object{cephCluster}...).WithStatusSubresource(cephCluster).Build()
+	clientset := testexec.New(t, 1)
+	clusterdContext := &clusterd.Context{Executor: executor, Clientset: clientset, Client: client}
+	kv := k8sutil.NewConfigMapKVStore("fake", clientset, clusterInfo.OwnerInfo)
+	err := saveWeightRampUp(ctx, kv, weightRampUp{status: cephv1.OSDWeightRampUpStatus{ID: 5, CurrentWeight: "0"}, stepPercent: 50})
+	assert.NoError(t, err)
+
+	m := NewOSDHealthMonitor(clusterdContext, clusterInfo, false, cephv1.CephClusterHealthCheckSpec{}, nil)
+	getStatus := func() []cephv1.OSDWeightRampUpStatus {
+		cluster := &cephv1.CephCluster{}
+		err := client.Get(ctx, clusterInfo.NamespacedName(), cluster)
+		assert.NoError(t, err)
+		return cluster.Status.CephStorage.OSD.WeightRampUp
+	}
+
+	t.Run("pgs not clean", func(t *testing.T) {
+		err := m.checkWeightRampUp()
+		assert.NoError(t, err)
+		assert.Empty(t, reweights)
+		status := getStatus()
+		require.Len(t, status, 1)
+		assert.Equal(t, "1.000000", status[0].TargetWeight)
+		assert.Contains(t, status[0].Message, "waiting for the PGs to be clean")
+	})
+
+	cephStatus = healthyCephStatus
+
+	t.Run("first step", func(t *testing.T) {
+		err := m.checkWeightRampUp()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0.500000"}, reweights)
+		status := getStatus()
+		require.Len(t, status, 1)
+		assert.Equal(t, "0.500000", status[0].CurrentWeight)
+	})
+
+	t.Run("last step", func(t *testing.T) {
+		err := m.checkWeightRampUp()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"0.500000", "1.000000"}, reweights)
+		assert.Empty(t, configRemoved)
+	})
+
+	t.Run("completed", func(t *testing.T) {
+		err := m.checkWeightRampUp()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"osd.5 osd_crush_initial_weight"}, configRemoved)
+		assert.Empty(t, getStatus())
+		rampUps, err := listWeightRampUps(ctx, clientset, "fake")
+		assert.NoError(t, err)
+		assert.Empty(t, rampUps)
+	})
+}
@@ -44,6 +44,24 @@ const (
 	// cephblockpoolradosnamespace env resources
 	CephBlockPoolNameEnv           = "BLOCKPOOL_NAME"
 	CephBlockPoolRadosNamespaceEnv = "RADOS_NAMESPACE"
+
+	// cephobjectstore orphan scan env resources
+	CephObjectStoreRealmEnv     = "OBJECT_STORE_REALM"
+	CephObjectStoreZoneGroupEnv = "OBJECT_STORE_ZONE_GROUP"
+	CephObjectStoreZoneEnv      = "OBJECT_STORE_ZONE"
+	CephObjectStoreDataPoolsEnv = "OBJECT_STORE_DATA_POOLS"
+	CephObjectStoreLogPoolEnv   = "OBJECT_STORE_LOG_POOL"
+	OrphanScanIDEnv             = "ORPHAN_SCAN_ID"
+	OrphanScanRemoveEnv         = "ORPHAN_SCAN_REMOVE"
+
+	// cephobjectstore migration env resources
+	CephObjectStoreBucketEnv        = "OBJECT_STORE_BUCKET"
+	CephObjectStoreEndpointEnv      = "OBJECT_STORE_ENDPOINT"
+	CephObjectStoreTLSCertEnv       = "OBJECT_STORE_TLS_CERT"
+	CephObjectStoreInsecureTLSEnv   = "OBJECT_STORE_INSECURE_TLS"
+	MigrationTargetPlacementEnv     = "MIGRATION_TARGET_PLACEMENT"
+	MigrationConcurrencyEnv         = "MIGRATION_CONCURRENCY"
+	MigrationMaxObjectsPerSecondEnv = "MIGRATION_MAX_OBJECTS_PER_SECOND"
 )
 
 // ResourceCleanup defines an rook ceph resource to be cleaned up
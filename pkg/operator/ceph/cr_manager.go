@@ -38,6 +38,8 @@ import (
 	"github.com/rook/rook/pkg/operator/ceph/object/cosi"
 	"github.com/rook/rook/pkg/operator/ceph/object/notification"
 	"github.com/rook/rook/pkg/operator/ceph/object/realm"
+	objectrole "github.com/rook/rook/pkg/operator/ceph/object/role"
+	objectscript "github.com/rook/rook/pkg/operator/ceph/object/script"
 	"github.com/rook/rook/pkg/operator/ceph/object/topic"
 	objectuser "github.com/rook/rook/pkg/operator/ceph/object/user"
 	"github.com/rook/rook/pkg/operator/ceph/object/zone"
@@ -74,6 +76,8 @@ var AddToManagerFuncs = []func(manager.Manager, *clusterd.Context, context.Conte
 	nodedaemon.Add,
 	pool.Add,
 	objectuser.Add,
+	objectrole.Add,
+	objectscript.Add,
 	realm.Add,
 	zonegroup.Add,
 	zone.Add,
@@ -105,6 +105,7 @@ func getMinimumFailureDomain(poolList []cephv1.PoolSpec) string {
 }
 
 // Setting naive minAvailable for RGW at: n - 1
+// getting n from the minInstances of the autoscaling when set, or the instances otherwise
 func (r *ReconcileClusterDisruption) reconcileCephObjectStore(cephObjectStoreList *cephv1.CephObjectStoreList) error {
 	for _, objectStore := range cephObjectStoreList.Items {
 		storeName := objectStore.ObjectMeta.Name
@@ -115,6 +116,10 @@ func (r *ReconcileClusterDisruption) reconcileCephObjectStore(cephObjectStoreLis
 		}
 
 		rgwCount := objectStore.Spec.Gateway.Instances
+		if autoscaling := objectStore.Spec.Gateway.Autoscaling; autoscaling != nil {
+			// the autoscaler can scale the rgw pods down to the min instances
+			rgwCount = autoscaling.MinInstances
+		}
 		minAvailable := &intstr.IntOrString{IntVal: rgwCount - 1}
 		if minAvailable.IntVal < 1 {
 			continue
@@ -17,11 +17,17 @@ limitations under the License.
 package clusterdisruption
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/disruption/controllerconfig"
 )
 
 func TestGetMinimumFailureDomain(t *testing.T) {
@@ -49,3 +55,32 @@ func TestGetMinimumFailureDomain(t *testing.T) {
 
 	assert.Equal(t, "host", getMinimumFailureDomain(poolList))
 }
+
+func TestReconcileCephObjectStorePDB(t *testing.T) {
+	newStore := func(name string, instances int32, autoscaling *cephv1.GatewayAutoscalingSpec) cephv1.CephObjectStore {
+		return cephv1.CephObjectStore{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: cephv1.ObjectStoreSpec{
+				Gateway: cephv1.GatewaySpec{Instances: instances, Autoscaling: autoscaling},
+			},
+		}
+	}
+	stores := &cephv1.CephObjectStoreList{Items: []cephv1.CephObjectStore{
+		newStore("fixed", 3, nil),
+		newStore("autoscaled", 1, &cephv1.GatewayAutoscalingSpec{MinInstances: 4, MaxInstances: 8}),
+		newStore("single", 3, &cephv1.GatewayAutoscalingSpec{MinInstances: 1, MaxInstances: 4}),
+	}}
+
+	r := getFakeReconciler(t)
+	r.context = &controllerconfig.Context{OpManagerContext: context.TODO()}
+	assert.NoError(t, r.reconcileCephObjectStore(stores))
+
+	pdb := &policyv1.PodDisruptionBudget{}
+	assert.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Name: "rook-ceph-rgw-fixed", Namespace: namespace}, pdb))
+	assert.Equal(t, int32(2), pdb.Spec.MinAvailable.IntVal)
+	// the min instances of the autoscaling are used instead of the instances
+	assert.NoError(t, r.client.Get(context.TODO(), types.NamespacedName{Name: "rook-ceph-rgw-autoscaled", Namespace: namespace}, pdb))
+	assert.Equal(t, int32(3), pdb.Spec.MinAvailable.IntVal)
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: "rook-ceph-rgw-single", Namespace: namespace}, pdb)
+	assert.True(t, kerrors.IsNotFound(err))
+}
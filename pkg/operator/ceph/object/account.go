@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"regexp"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/rook/rook/pkg/util/exec"
+)
+
+// RGW account IDs are "RGW" followed by 17 digits
+var accountIDRegex = regexp.MustCompile(`^RGW[0-9]{17}$`)
+
+// ValidateAccountID returns an error if the ID is not a valid RGW account ID
+func ValidateAccountID(id string) error {
+	if !accountIDRegex.MatchString(id) {
+		return errors.Errorf("invalid account ID %q, must be \"RGW\" followed by 17 digits", id)
+	}
+	return nil
+}
+
+// CreateAccountIfNotExists creates the RGW account with the given ID if it does not exist yet. The
+// name is only set when the account is created.
+func CreateAccountIfNotExists(c *Context, id, name string) error {
+	_, err := runAdminCommand(c, true, "account", "get", "--account-id", id)
+	if err == nil {
+		return nil
+	}
+	// ENOENT means "No such file or directory"
+	if code, ok := exec.ExitStatus(err); !ok || code != int(syscall.ENOENT) {
+		return errors.Wrapf(err, "failed to get account %q", id)
+	}
+
+	args := []string{"account", "create", "--account-id", id}
+	if name != "" {
+		args = append(args, "--account-name", name)
+	}
+	if _, err := runAdminCommand(c, true, args...); err != nil {
+		return errors.Wrapf(err, "failed to create account %q", id)
+	}
+	logger.Infof("created account %q", id)
+
+	return nil
+}
+
+// SetUserAccount places the user in the account, either as its root user or as a regular user
+func SetUserAccount(c *Context, uid, accountID string, root bool) error {
+	_, err := runAdminCommand(c, true, "user", "modify", "--uid", uid, "--account-id", accountID, fmt.Sprintf("--account-root=%t", root))
+	if err != nil {
+		return errors.Wrapf(err, "failed to place user %q in account %q", uid, accountID)
+	}
+	logger.Debugf("placed user %q in account %q (root: %t)", uid, accountID, root)
+
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAccountID = "RGW12345678901234567"
+
+func TestValidateAccountID(t *testing.T) {
+	assert.NoError(t, ValidateAccountID(testAccountID))
+	assert.Error(t, ValidateAccountID(""))
+	assert.Error(t, ValidateAccountID("RGW123"))
+	assert.Error(t, ValidateAccountID("ABC12345678901234567"))
+}
+
+func TestCreateAccountIfNotExists(t *testing.T) {
+	newContext := func(t *testing.T, accountExists bool, commands *[]string) *Context {
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+				*commands = append(*commands, strings.Join(args[:2], " "))
+				if args[1] == "get" && !accountExists {
+					return "", exectest.MockExecCommandReturns(t, "", "", int(syscall.ENOENT))
+				}
+				return `{"id": "` + testAccountID + `"}`, nil
+			},
+		}
+		return NewContext(&clusterd.Context{Executor: executor}, cephclient.AdminTestClusterInfo("ns"), "")
+	}
+
+	t.Run("account exists", func(t *testing.T) {
+		var commands []string
+		assert.NoError(t, CreateAccountIfNotExists(newContext(t, true, &commands), testAccountID, "team-a"))
+		assert.Equal(t, []string{"account get"}, commands)
+	})
+
+	t.Run("account is created", func(t *testing.T) {
+		var commands []string
+		assert.NoError(t, CreateAccountIfNotExists(newContext(t, false, &commands), testAccountID, "team-a"))
+		assert.Equal(t, []string{"account get", "account create"}, commands)
+	})
+}
+
+func TestSetUserAccount(t *testing.T) {
+	var commands []string
+	c := newRateLimitTestContext(&commands, "{}")
+	assert.NoError(t, SetUserAccount(c, "alice", testAccountID, true))
+	assert.Equal(t, []string{"user modify --uid alice --account-id " + testAccountID + " --account-root=true"}, commands)
+}
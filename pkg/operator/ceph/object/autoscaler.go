@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/pkg/errors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultAutoscalingCPUUtilization = int32(80)
+	defaultRequestsMetric            = "ceph_rgw_requests_per_second"
+)
+
+// rgwReplicas returns the number of replicas of the rgw deployment. When the rgw pods are
+// autoscaled, the replicas set by the autoscaler are kept so that the reconcile does not fight it.
+func (c *clusterConfig) rgwReplicas(deploymentName string) int32 {
+	autoscaling := c.store.Spec.Gateway.Autoscaling
+	if autoscaling == nil {
+		return c.store.Spec.Gateway.Instances
+	}
+	replicas := autoscaling.MinInstances
+	existing, err := c.context.Clientset.AppsV1().Deployments(c.store.Namespace).Get(c.clusterInfo.Context, deploymentName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			logger.Warningf("failed to get rgw deployment %q to keep its autoscaled replicas. %v", deploymentName, err)
+		}
+		return replicas
+	}
+	if existing.Spec.Replicas != nil {
+		replicas = *existing.Spec.Replicas
+	}
+	if replicas < autoscaling.MinInstances {
+		return autoscaling.MinInstances
+	}
+	if replicas > autoscaling.MaxInstances {
+		return autoscaling.MaxInstances
+	}
+	return replicas
+}
+
+func (c *clusterConfig) makeHorizontalPodAutoscaler(deploymentName string) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := c.store.Spec.Gateway.Autoscaling
+	var metrics []autoscalingv2.MetricSpec
+	if autoscaling.TargetRequestsPerSecond != nil {
+		metricName := autoscaling.RequestsMetric
+		if metricName == "" {
+			metricName = defaultRequestsMetric
+		}
+		// the external metric is the rate of requests of the whole object store, the autoscaler
+		// divides it by the number of pods to compare it to the average value
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ExternalMetricSourceType,
+			External: &autoscalingv2.ExternalMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{
+					Name: metricName,
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"namespace": c.store.Namespace, "object_store": c.store.Name},
+					},
+				},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: autoscaling.TargetRequestsPerSecond,
+				},
+			},
+		})
+	}
+	if autoscaling.TargetCPUUtilizationPercentage != nil || len(metrics) == 0 {
+		utilization := defaultAutoscalingCPUUtilization
+		if autoscaling.TargetCPUUtilizationPercentage != nil {
+			utilization = *autoscaling.TargetCPUUtilizationPercentage
+		}
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: v1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		})
+	}
+
+	minReplicas := autoscaling.MinInstances
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: c.store.Namespace,
+			Labels:    getLabels(c.store.Name, c.store.Namespace, true),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deploymentName,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: autoscaling.MaxInstances,
+			Metrics:     metrics,
+		},
+	}
+}
+
+// reconcileAutoscaler creates or updates the autoscaler of the rgw deployment, or deletes it when
+// the autoscaling is disabled
+func (c *clusterConfig) reconcileAutoscaler(deploymentName string) error {
+	client := c.context.Clientset.AutoscalingV2().HorizontalPodAutoscalers(c.store.Namespace)
+	if c.store.Spec.Gateway.Autoscaling == nil {
+		err := client.Delete(c.clusterInfo.Context, deploymentName, metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the autoscaler of rgw deployment %q", deploymentName)
+		}
+		return nil
+	}
+
+	hpa := c.makeHorizontalPodAutoscaler(deploymentName)
+	err := c.ownerInfo.SetControllerReference(hpa)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set owner reference for the autoscaler of rgw deployment %q", deploymentName)
+	}
+	existing, err := client.Get(c.clusterInfo.Context, deploymentName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get the autoscaler of rgw deployment %q", deploymentName)
+		}
+		_, err = client.Create(c.clusterInfo.Context, hpa, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the autoscaler of rgw deployment %q", deploymentName)
+		}
+		logger.Infof("created the autoscaler of rgw deployment %q", deploymentName)
+		return nil
+	}
+	existing.Labels = hpa.Labels
+	existing.OwnerReferences = hpa.OwnerReferences
+	existing.Spec = hpa.Spec
+	_, err = client.Update(c.clusterInfo.Context, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the autoscaler of rgw deployment %q", deploymentName)
+	}
+	logger.Debugf("updated the autoscaler of rgw deployment %q", deploymentName)
+	return nil
+}
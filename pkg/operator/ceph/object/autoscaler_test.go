@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRGWAutoscaler(t *testing.T) {
+	ctx := context.TODO()
+	clientset := fake.NewSimpleClientset()
+	store := simpleStore()
+	store.Spec.Gateway.Instances = 2
+	c := &clusterConfig{
+		store:       store,
+		context:     &clusterd.Context{Clientset: clientset},
+		clusterInfo: &cephclient.ClusterInfo{Context: ctx, Namespace: store.Namespace},
+		ownerInfo:   k8sutil.NewOwnerInfoWithOwnerRef(&metav1.OwnerReference{Name: store.Name, UID: "uid"}, store.Namespace),
+	}
+	name := "rook-ceph-rgw-my-store-a"
+	getHPA := func() (*autoscalingv2.HorizontalPodAutoscaler, error) {
+		return clientset.AutoscalingV2().HorizontalPodAutoscalers(store.Namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+
+	t.Run("instances without autoscaling", func(t *testing.T) {
+		assert.Equal(t, int32(2), c.rgwReplicas(name))
+		assert.NoError(t, c.reconcileAutoscaler(name))
+		_, err := getHPA()
+		assert.True(t, kerrors.IsNotFound(err))
+	})
+
+	t.Run("cpu utilization by default", func(t *testing.T) {
+		store.Spec.Gateway.Autoscaling = &cephv1.GatewayAutoscalingSpec{MinInstances: 2, MaxInstances: 5}
+		assert.Equal(t, int32(2), c.rgwReplicas(name))
+		require.NoError(t, c.reconcileAutoscaler(name))
+		hpa, err := getHPA()
+		require.NoError(t, err)
+		assert.Equal(t, name, hpa.Spec.ScaleTargetRef.Name)
+		assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+		assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 1)
+		assert.Equal(t, v1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+		assert.Equal(t, int32(80), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		assert.Len(t, hpa.OwnerReferences, 1)
+	})
+
+	t.Run("requests per second", func(t *testing.T) {
+		rps := resource.MustParse("500")
+		store.Spec.Gateway.Autoscaling = &cephv1.GatewayAutoscalingSpec{MinInstances: 1, MaxInstances: 4, TargetRequestsPerSecond: &rps}
+		require.NoError(t, c.reconcileAutoscaler(name))
+		hpa, err := getHPA()
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 1)
+		external := hpa.Spec.Metrics[0].External
+		require.NotNil(t, external)
+		assert.Equal(t, defaultRequestsMetric, external.Metric.Name)
+		assert.Equal(t, map[string]string{"namespace": store.Namespace, "object_store": store.Name}, external.Metric.Selector.MatchLabels)
+		assert.Equal(t, rps, *external.Target.AverageValue)
+
+		cpu := int32(60)
+		store.Spec.Gateway.Autoscaling.TargetCPUUtilizationPercentage = &cpu
+		store.Spec.Gateway.Autoscaling.RequestsMetric = "my_metric"
+		require.NoError(t, c.reconcileAutoscaler(name))
+		hpa, err = getHPA()
+		require.NoError(t, err)
+		require.Len(t, hpa.Spec.Metrics, 2)
+		assert.Equal(t, "my_metric", hpa.Spec.Metrics[0].External.Metric.Name)
+		assert.Equal(t, int32(60), *hpa.Spec.Metrics[1].Resource.Target.AverageUtilization)
+	})
+
+	t.Run("keep the autoscaled replicas", func(t *testing.T) {
+		replicas := int32(3)
+		_, err := clientset.AppsV1().Deployments(store.Namespace).Create(ctx, &apps.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: store.Namespace},
+			Spec:       apps.DeploymentSpec{Replicas: &replicas},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), c.rgwReplicas(name))
+
+		store.Spec.Gateway.Autoscaling.MaxInstances = 2
+		assert.Equal(t, int32(2), c.rgwReplicas(name))
+		store.Spec.Gateway.Autoscaling.MinInstances = 4
+		store.Spec.Gateway.Autoscaling.MaxInstances = 6
+		assert.Equal(t, int32(4), c.rgwReplicas(name))
+	})
+
+	t.Run("delete the autoscaler when disabled", func(t *testing.T) {
+		store.Spec.Gateway.Autoscaling = nil
+		assert.NoError(t, c.reconcileAutoscaler(name))
+		_, err := getHPA()
+		assert.True(t, kerrors.IsNotFound(err))
+	})
+}
@@ -58,7 +58,25 @@ func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext contex
 		logger.Info("skip running Object Bucket controller")
 		return nil
 	}
-	return add(opManagerContext, mgr, newReconciler(mgr, context, opManagerContext, opConfig))
+	if err := add(opManagerContext, mgr, newReconciler(mgr, context, opManagerContext, opConfig)); err != nil {
+		return err
+	}
+
+	if err := addQuotaReconciler(mgr, &ReconcileBucketQuota{
+		client:           mgr.GetClient(),
+		context:          context,
+		opManagerContext: opManagerContext,
+		recorder:         mgr.GetEventRecorderFor(quotaControllerName),
+	}); err != nil {
+		return err
+	}
+
+	return addIndexReconciler(mgr, &ReconcileBucketIndex{
+		client:           mgr.GetClient(),
+		context:          context,
+		opManagerContext: opManagerContext,
+		recorder:         mgr.GetEventRecorderFor(indexControllerName),
+	})
 }
 
 // newReconciler returns a new reconcile.Reconciler
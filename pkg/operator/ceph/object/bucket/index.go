@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	indexControllerName = "rook-ceph-operator-bucket-index-controller"
+
+	// the field of the ObjectBucket status reporting the sharding of the bucket index
+	bucketIndexStatusField = "index"
+)
+
+// the radosgw-admin commands are overridden in the unit tests
+var (
+	getBucketIndexStatusFunc     = object.GetBucketIndexStatus
+	isBucketReshardingFunc       = object.IsBucketResharding
+	scheduleBucketReshardFunc    = object.ScheduleBucketReshard
+	getBucketAdminOpsContextFunc = getAdminOpsContext
+)
+
+// ReconcileBucketIndex reports the sharding of the index of the buckets of the ObjectBucketClaims
+// provisioned by Rook in the status of their ObjectBucket, and reshards the buckets that request a
+// number of shards
+type ReconcileBucketIndex struct {
+	client           client.Client
+	context          *clusterd.Context
+	opManagerContext context.Context
+	recorder         record.EventRecorder
+}
+
+// obcIndexPredicate reconciles the claims whose bucket or number of shards changed. The index is
+// checked again periodically after that.
+func obcIndexPredicate[T *bktv1alpha1.ObjectBucketClaim]() predicate.TypedFuncs[T] {
+	return predicate.TypedFuncs[T]{
+		CreateFunc: func(e event.TypedCreateEvent[T]) bool {
+			return true
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+			objOld := (*bktv1alpha1.ObjectBucketClaim)(e.ObjectOld)
+			objNew := (*bktv1alpha1.ObjectBucketClaim)(e.ObjectNew)
+			if opcontroller.IsDoNotReconcile(objNew.GetLabels()) {
+				return false
+			}
+			return objOld.Spec.ObjectBucketName != objNew.Spec.ObjectBucketName ||
+				objOld.Spec.AdditionalConfig["bucketNumShards"] != objNew.Spec.AdditionalConfig["bucketNumShards"]
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[T]) bool {
+			return false
+		},
+		GenericFunc: func(e event.TypedGenericEvent[T]) bool {
+			return false
+		},
+	}
+}
+
+func addIndexReconciler(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New(indexControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	logger.Infof("%s successfully started", indexControllerName)
+
+	return c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&bktv1alpha1.ObjectBucketClaim{},
+			&handler.TypedEnqueueRequestForObject[*bktv1alpha1.ObjectBucketClaim]{},
+			obcIndexPredicate(),
+		),
+	)
+}
+
+// Reconcile checks the index of the bucket of an ObjectBucketClaim
+func (r *ReconcileBucketIndex) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, err := r.reconcile(request)
+	if err != nil {
+		logger.Errorf("failed to check the bucket index of ObjectBucketClaim %q. %v", request.NamespacedName, err)
+	}
+
+	return reconcileResponse, err
+}
+
+func (r *ReconcileBucketIndex) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	obc := &bktv1alpha1.ObjectBucketClaim{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, obc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debugf("ObjectBucketClaim %q resource not found. Ignoring since resource must be deleted.", request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrapf(err, "failed to retrieve ObjectBucketClaim %q", request.NamespacedName)
+	}
+	if !obc.GetDeletionTimestamp().IsZero() || obc.Spec.ObjectBucketName == "" {
+		// the claim will be reconciled again once it is bound
+		return reconcile.Result{}, nil
+	}
+
+	// the ObjectBucket is read unstructured to preserve the index status that is not known to the
+	// bucket library
+	ob := &unstructured.Unstructured{}
+	ob.SetGroupVersionKind(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"))
+	if err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: obc.Spec.ObjectBucketName}, ob); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrapf(err, "failed to retrieve ObjectBucket %q", obc.Spec.ObjectBucketName)
+	}
+	if !strings.Contains(ob.GetLabels()[bucketProvisionerLabelKey], bucketProvisionerLabelVal) {
+		logger.Debugf("ObjectBucket %q was not provisioned by the ceph object store provisioner. ignoring", ob.GetName())
+		return reconcile.Result{}, nil
+	}
+
+	additionalConfig, err := additionalConfigSpecFromMap(obc.Spec.AdditionalConfig)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to process additionalConfig")
+	}
+	previous, err := getIndexStatus(ob)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to read the index status of ObjectBucket %q", ob.GetName())
+	}
+
+	typedOB := &bktv1alpha1.ObjectBucket{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ob.Object, typedOB); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to convert ObjectBucket %q", ob.GetName())
+	}
+	opsContext, reconcileResponse, err := getBucketAdminOpsContextFunc(r.opManagerContext, r.client, r.context, typedOB, indexControllerName)
+	if err != nil || opsContext == nil {
+		return reconcileResponse, err
+	}
+
+	status, err := r.checkIndex(&opsContext.Context, obc, getBucketName(typedOB), getCephUser(typedOB), additionalConfig.bucketNumShards, metav1.Now())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if eventType, reason, message, ok := object.BucketIndexEvent(previous, status); ok {
+		logger.Infof("ObjectBucketClaim \"%s/%s\": %s", obc.Namespace, obc.Name, message)
+		r.recorder.Event(obc, eventType, reason, message)
+	}
+	if err := r.updateIndexStatus(ob.GetName(), status); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to update the index status of ObjectBucket %q", ob.GetName())
+	}
+
+	if status.Resharding {
+		return reconcile.Result{RequeueAfter: object.BucketReshardCheckInterval}, nil
+	}
+	return reconcile.Result{RequeueAfter: object.BucketIndexCheckInterval}, nil
+}
+
+// checkIndex returns the sharding of the bucket index, and adds the bucket to the reshard queue
+// if it does not have the requested number of shards
+func (r *ReconcileBucketIndex) checkIndex(objContext *object.Context, obc *bktv1alpha1.ObjectBucketClaim, bucketName, userID string, targetShards *int64, now metav1.Time) (*cephv1.BucketIndexStatus, error) {
+	status, err := getBucketIndexStatusFunc(objContext, userID, bucketName, now)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the index status of bucket %q", bucketName)
+	}
+	status.TargetShards = targetShards
+	if targetShards == nil || *targetShards == status.NumShards {
+		return status, nil
+	}
+
+	status.Resharding, err = isBucketReshardingFunc(objContext, bucketName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check if bucket %q is resharding", bucketName)
+	}
+	if status.Resharding {
+		logger.Debugf("bucket %q is resharding from %d to %d shards", bucketName, status.NumShards, *targetShards)
+		return status, nil
+	}
+	if err := scheduleBucketReshardFunc(objContext, bucketName, *targetShards); err != nil {
+		return nil, err
+	}
+	status.Resharding = true
+	r.recorder.Event(obc, corev1.EventTypeNormal, object.BucketReshardScheduledReason,
+		fmt.Sprintf("bucket added to the reshard queue to change its index from %d to %d shards", status.NumShards, *targetShards))
+	return status, nil
+}
+
+// getIndexStatus returns the index status of the bucket reported in the status of the ObjectBucket
+func getIndexStatus(ob *unstructured.Unstructured) (*cephv1.BucketIndexStatus, error) {
+	value, found, err := unstructured.NestedMap(ob.Object, "status", bucketIndexStatusField)
+	if err != nil || !found {
+		return nil, nil
+	}
+	status := &cephv1.BucketIndexStatus{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(value, status); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse status.%s", bucketIndexStatusField)
+	}
+	return status, nil
+}
+
+// setIndexStatus sets the index status of the bucket in the status of the ObjectBucket
+func setIndexStatus(ob *unstructured.Unstructured, status *cephv1.BucketIndexStatus) error {
+	value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(status)
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert status.%s", bucketIndexStatusField)
+	}
+	if err := unstructured.SetNestedMap(ob.Object, value, "status", bucketIndexStatusField); err != nil {
+		return errors.Wrapf(err, "failed to set status.%s", bucketIndexStatusField)
+	}
+	return nil
+}
+
+func (r *ReconcileBucketIndex) updateIndexStatus(name string, status *cephv1.BucketIndexStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ob := &unstructured.Unstructured{}
+		ob.SetGroupVersionKind(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"))
+		if err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: name}, ob); err != nil {
+			return err
+		}
+		if err := setIndexStatus(ob, status); err != nil {
+			return err
+		}
+		return r.client.Status().Update(r.opManagerContext, ob)
+	})
+}
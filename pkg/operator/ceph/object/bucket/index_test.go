@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestIndexStatus(t *testing.T) {
+	ob := &unstructured.Unstructured{Object: map[string]interface{}{"status": map[string]interface{}{"phase": "Bound"}}}
+
+	status, err := getIndexStatus(ob)
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+
+	now := metav1.NewTime(metav1.Now().Rfc3339Copy().Time)
+	index := &cephv1.BucketIndexStatus{NumShards: 11, Objects: 990000, ObjectsPerShard: 90000, FillStatus: "WARN 90.000000%", State: cephv1.BucketIndexStateNearLimit, LastChecked: &now}
+	require.NoError(t, setIndexStatus(ob, index))
+	status, err = getIndexStatus(ob)
+	assert.NoError(t, err)
+	assert.True(t, now.Equal(status.LastChecked))
+	status.LastChecked = index.LastChecked
+	assert.Equal(t, index, status)
+	// the status of the bucket library is preserved
+	assert.Equal(t, "Bound", ob.Object["status"].(map[string]interface{})["phase"])
+}
+
+func TestReconcileBucketIndex(t *testing.T) {
+	ctx := context.TODO()
+	os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketNumShards")
+	defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+	opcontroller.SetObcAllowAdditionalConfigFields()
+	defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+	obc := &bktv1alpha1.ObjectBucketClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-obc", Namespace: "my-app"},
+		Spec:       bktv1alpha1.ObjectBucketClaimSpec{ObjectBucketName: "obc-my-app-my-obc"},
+	}
+	ob := &unstructured.Unstructured{}
+	ob.SetGroupVersionKind(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"))
+	ob.SetName("obc-my-app-my-obc")
+	ob.SetLabels(map[string]string{bucketProvisionerLabelKey: "rook-ceph.ceph.rook.io-bucket"})
+	require.NoError(t, unstructured.SetNestedField(ob.Object, "my-bucket", "spec", "endpoint", "bucketName"))
+	require.NoError(t, unstructured.SetNestedStringMap(ob.Object, map[string]string{CephUser: "obc-user"}, "spec", "additionalState"))
+
+	s := runtime.NewScheme()
+	s.AddKnownTypes(bktv1alpha1.SchemeGroupVersion, &bktv1alpha1.ObjectBucketClaim{}, &bktv1alpha1.ObjectBucketClaimList{})
+	s.AddKnownTypeWithName(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"), &unstructured.Unstructured{})
+	s.AddKnownTypeWithName(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucketList"), &unstructured.UnstructuredList{})
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(obc, ob).WithStatusSubresource(ob).Build()
+	recorder := record.NewFakeRecorder(5)
+	r := &ReconcileBucketIndex{client: cl, opManagerContext: ctx, recorder: recorder}
+
+	getBucketAdminOpsContextFunc = func(ctx context.Context, c client.Client, context *clusterd.Context, ob *bktv1alpha1.ObjectBucket, controllerName string) (*object.AdminOpsContext, reconcile.Result, error) {
+		return &object.AdminOpsContext{}, reconcile.Result{}, nil
+	}
+	index := &cephv1.BucketIndexStatus{NumShards: 11, Objects: 1100000, ObjectsPerShard: 100000, FillStatus: "OVER 100.000000%", State: cephv1.BucketIndexStateOverLimit}
+	getBucketIndexStatusFunc = func(c *object.Context, userID, bucketName string, now metav1.Time) (*cephv1.BucketIndexStatus, error) {
+		assert.Equal(t, "obc-user", userID)
+		assert.Equal(t, "my-bucket", bucketName)
+		status := *index
+		return &status, nil
+	}
+	resharding := false
+	isBucketReshardingFunc = func(c *object.Context, bucketName string) (bool, error) {
+		return resharding, nil
+	}
+	var scheduled []int64
+	scheduleBucketReshardFunc = func(c *object.Context, bucketName string, numShards int64) error {
+		scheduled = append(scheduled, numShards)
+		return nil
+	}
+	defer func() {
+		getBucketAdminOpsContextFunc = getAdminOpsContext
+		getBucketIndexStatusFunc = object.GetBucketIndexStatus
+		isBucketReshardingFunc = object.IsBucketResharding
+		scheduleBucketReshardFunc = object.ScheduleBucketReshard
+	}()
+
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "my-obc", Namespace: "my-app"}}
+	getStatus := func() *cephv1.BucketIndexStatus {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(ob.GroupVersionKind())
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: "obc-my-app-my-obc"}, u))
+		status, err := getIndexStatus(u)
+		require.NoError(t, err)
+		return status
+	}
+
+	t.Run("over the limit", func(t *testing.T) {
+		res, err := r.Reconcile(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, object.BucketIndexCheckInterval, res.RequeueAfter)
+		status := getStatus()
+		assert.Equal(t, int64(11), status.NumShards)
+		assert.Equal(t, cephv1.BucketIndexStateOverLimit, status.State)
+		assert.Nil(t, status.TargetShards)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, object.BucketIndexOverLimitReason)
+		assert.Empty(t, scheduled)
+	})
+
+	t.Run("reshard requested", func(t *testing.T) {
+		obc.Spec.AdditionalConfig = map[string]string{"bucketNumShards": "101"}
+		require.NoError(t, cl.Update(ctx, obc))
+		res, err := r.Reconcile(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, object.BucketReshardCheckInterval, res.RequeueAfter)
+		assert.Equal(t, []int64{101}, scheduled)
+		status := getStatus()
+		assert.Equal(t, int64(101), *status.TargetShards)
+		assert.True(t, status.Resharding)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, object.BucketReshardScheduledReason)
+	})
+
+	t.Run("reshard in progress", func(t *testing.T) {
+		resharding = true
+		res, err := r.Reconcile(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, object.BucketReshardCheckInterval, res.RequeueAfter)
+		assert.Len(t, scheduled, 1)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("resharded", func(t *testing.T) {
+		index = &cephv1.BucketIndexStatus{NumShards: 101, Objects: 1100000, ObjectsPerShard: 10891, FillStatus: "OK", State: cephv1.BucketIndexStateOk}
+		res, err := r.Reconcile(ctx, request)
+		assert.NoError(t, err)
+		assert.Equal(t, object.BucketIndexCheckInterval, res.RequeueAfter)
+		assert.Len(t, scheduled, 1)
+		status := getStatus()
+		assert.Equal(t, int64(101), status.NumShards)
+		assert.False(t, status.Resharding)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, object.BucketIndexBelowLimitReason)
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/ceph/go-ceph/rgw/admin"
@@ -29,13 +30,16 @@ import (
 	"github.com/google/go-cmp/cmp"
 	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
 	apibkt "github.com/kube-object-storage/lib-bucket-provisioner/pkg/provisioner/api"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/ceph/object"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/pkg/errors"
 	"github.com/rook/rook/pkg/clusterd"
 	"github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/daemon/ceph/osd/kms"
 	cephutil "github.com/rook/rook/pkg/daemon/ceph/util"
 )
 
@@ -57,6 +61,16 @@ type Provisioner struct {
 	insecureTLS          bool
 	adminOpsClient       *admin.API
 	s3Agent              *object.S3Agent
+	// versioning and object lock of the bucket once set, reported in the additional state of the OB
+	bucketVersioning string
+	bucketObjectLock *s3.ObjectLockConfiguration
+	// placement rule of a new bucket, "<placement>" or "<placement>/<storage class>"
+	placementRule string
+	// encryption policy and kms of the object store, the SSE-KMS keys of the buckets are created in the kms
+	bucketEncryptionPolicy *cephv1.BucketEncryptionSpec
+	kms                    *cephv1.KeyManagementServiceSpec
+	// default encryption of the bucket once set, reported in the additional state of the OB
+	bucketEncryption *s3.ServerSideEncryptionByDefault
 }
 
 type additionalConfigSpec struct {
@@ -67,10 +81,31 @@ type additionalConfigSpec struct {
 	bucketPolicy     *string
 	bucketLifecycle  *string
 	bucketOwner      *string
+	// bucket rate limits, in operations or bytes per minute
+	bucketMaxReadOps    *int64
+	bucketMaxWriteOps   *int64
+	bucketMaxReadBytes  *int64
+	bucketMaxWriteBytes *int64
+	// percentage of the quotas used above which the OBC is near full
+	quotaNearFullThreshold *int32
+	// number of shards of the bucket index
+	bucketNumShards *int64
+	// versioning state of the bucket, Enabled or Suspended
+	bucketVersioning *string
+	// default retention of the objects of the bucket, object lock is enabled when the bucket is created
+	bucketObjectLockMode          *string
+	bucketObjectLockRetentionDays *int64
+	// default server-side encryption of the bucket, SSE-S3 or SSE-KMS, and the KMS key of SSE-KMS
+	bucketEncryption      *string
+	bucketEncryptionKeyID *string
 }
 
 var _ apibkt.Provisioner = &Provisioner{}
 
+var createBucketEncryptionKeyFunc = createBucketEncryptionKey
+
+var deleteBucketEncryptionKeyFunc = deleteBucketEncryptionKey
+
 func NewProvisioner(context *clusterd.Context, clusterInfo *client.ClusterInfo) *Provisioner {
 	return &Provisioner{context: context, clusterInfo: clusterInfo}
 }
@@ -124,7 +159,10 @@ func (p Provisioner) Provision(options *apibkt.BucketOptions) (*bktv1alpha1.Obje
 		// if bucket already exists, this returns error: TooManyBuckets because we set the quota
 		// below. If it already exists, assume we are good to go
 		logger.Debugf("creating bucket %q owned by user %q", p.bucketName, p.cephUserName)
-		err = p.s3Agent.CreateBucket(p.bucketName)
+		err = p.s3Agent.CreateBucketWithOptions(p.bucketName, object.BucketCreateOptions{
+			PlacementRule: p.placementRule,
+			ObjectLock:    additionalConfig.bucketObjectLockMode != nil,
+		})
 		if err != nil {
 			return nil, errors.Wrapf(err, "error creating bucket %q", p.bucketName)
 		}
@@ -138,6 +176,9 @@ func (p Provisioner) Provision(options *apibkt.BucketOptions) (*bktv1alpha1.Obje
 	} else {
 		logger.Debugf("bucket %q already exists", p.bucketName)
 	}
+	if bucketExists {
+		p.checkBucketPlacement()
+	}
 
 	// is the bucket owner a provisioner generated user?
 	if p.isObcGeneratedUser(p.cephUserName, options.ObjectBucketClaim) {
@@ -265,6 +306,10 @@ func (p Provisioner) Delete(ob *bktv1alpha1.ObjectBucket) error {
 		return errors.Wrapf(err, "failed to delete bucket %q", p.bucketName)
 	}
 
+	if err := p.deleteOBEncryptionKey(ob); err != nil {
+		return errors.Wrapf(err, "failed to delete encryption key of bucket %q", p.bucketName)
+	}
+
 	logger.Infof("Delete: deleting user %q for OB %q", p.bucketName, ob.Name)
 	if err := p.deleteOBUser(ob); err != nil {
 		return errors.Wrapf(err, "failed to delete user %q", p.cephUserName)
@@ -398,6 +443,11 @@ func (p *Provisioner) initializeCreateOrGrant(bucket *bucket) error {
 		return err
 	}
 
+	err = p.setPlacementRule(sc)
+	if err != nil {
+		return errors.Wrapf(err, "invalid bucket placement in storage class %q", sc.Name)
+	}
+
 	// If an endpoint is declared let's use it
 	err = p.populateDomainAndPort(sc)
 	if err != nil {
@@ -507,6 +557,27 @@ func (p *Provisioner) composeObjectBucket(bucket *bucket) *bktv1alpha1.ObjectBuc
 		conn.AdditionalState["bucketOwner"] = *bucket.additionalConfig.bucketOwner
 	}
 
+	if p.bucketVersioning != "" {
+		conn.AdditionalState["bucketVersioning"] = p.bucketVersioning
+	}
+	if p.bucketObjectLock != nil {
+		conn.AdditionalState["bucketObjectLock"] = aws.StringValue(p.bucketObjectLock.ObjectLockEnabled)
+		if rule := p.bucketObjectLock.Rule; rule != nil && rule.DefaultRetention != nil {
+			conn.AdditionalState["bucketObjectLockMode"] = aws.StringValue(rule.DefaultRetention.Mode)
+			conn.AdditionalState["bucketObjectLockRetentionDays"] = fmt.Sprintf("%d", aws.Int64Value(rule.DefaultRetention.Days))
+		}
+	}
+
+	if p.bucketEncryption != nil {
+		switch aws.StringValue(p.bucketEncryption.SSEAlgorithm) {
+		case s3.ServerSideEncryptionAes256:
+			conn.AdditionalState["bucketEncryption"] = string(cephv1.BucketEncryptionSSES3)
+		case s3.ServerSideEncryptionAwsKms:
+			conn.AdditionalState["bucketEncryption"] = string(cephv1.BucketEncryptionSSEKMS)
+			conn.AdditionalState["bucketEncryptionKeyID"] = aws.StringValue(p.bucketEncryption.KMSMasterKeyID)
+		}
+	}
+
 	return &bktv1alpha1.ObjectBucket{
 		Spec: bktv1alpha1.ObjectBucketSpec{
 			Connection: conn,
@@ -538,6 +609,11 @@ func (p *Provisioner) setObjectContext() error {
 		if err != nil {
 			return errors.Wrap(err, "failed to set multisite on provisioner's objectContext")
 		}
+
+		if store.Spec.Security != nil {
+			p.bucketEncryptionPolicy = store.Spec.Security.BucketEncryption
+			p.kms = &store.Spec.Security.KeyManagementService
+		}
 	}
 
 	return nil
@@ -562,6 +638,52 @@ func (p *Provisioner) setObjectStoreDomainNameAndPort(sc *storagev1.StorageClass
 	return nil
 }
 
+// setPlacementRule sets the placement rule of the new buckets from the storage class parameters.
+// It must be called after setObjectStoreName and setEndpoint.
+func (p *Provisioner) setPlacementRule(sc *storagev1.StorageClass) error {
+	placement, storageClass := getBucketPlacement(sc)
+	if placement == "" && storageClass == "" {
+		p.placementRule = ""
+		return nil
+	}
+
+	// the placements of an external object store which is only known by its endpoint can't be validated
+	if p.endpoint != "" {
+		if placement == "" {
+			return errors.Errorf("%q must be set with %q for object store endpoint %q", bucketPlacement, bucketPlacementStorageClass, p.endpoint)
+		}
+		p.placementRule = placement
+		if storageClass != "" {
+			p.placementRule += "/" + storageClass
+		}
+		return nil
+	}
+
+	store, err := p.getObjectStore()
+	if err != nil {
+		return errors.Wrap(err, "failed to get cephObjectStore")
+	}
+	p.placementRule, err = object.BucketPlacementRule(store, placement, storageClass)
+	return err
+}
+
+// checkBucketPlacement warns when an existing bucket is not in the placement requested by the
+// storage class, the placement of a bucket can't be changed once it is created
+func (p *Provisioner) checkBucketPlacement() {
+	if p.placementRule == "" {
+		return
+	}
+	info, err := p.adminOpsClient.GetBucketInfo(p.clusterInfo.Context, admin.Bucket{Bucket: p.bucketName})
+	if err != nil {
+		logger.Warningf("failed to check placement of bucket %q. %v", p.bucketName, err)
+		return
+	}
+	// rgw omits the default STANDARD storage class from the placement rule
+	if info.PlacementRule != p.placementRule && info.PlacementRule+"/STANDARD" != p.placementRule {
+		logger.Warningf("bucket %q already exists in placement %q instead of %q requested by the storage class", p.bucketName, info.PlacementRule, p.placementRule)
+	}
+}
+
 func (p *Provisioner) setObjectStoreName(sc *storagev1.StorageClass) {
 	p.objectStoreName = sc.Parameters[ObjectStoreName]
 }
@@ -629,6 +751,26 @@ func (p *Provisioner) setAdditionalSettings(additionalConfig *additionalConfigSp
 		return errors.Wrap(err, "failed to set bucket lifecycle")
 	}
 
+	err = p.setBucketRateLimit(additionalConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to set bucket rate limit")
+	}
+
+	err = p.setBucketVersioning(additionalConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to set bucket versioning")
+	}
+
+	err = p.setBucketObjectLock(additionalConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to set bucket object lock")
+	}
+
+	err = p.setBucketEncryption(additionalConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to set bucket encryption")
+	}
+
 	return nil
 }
 
@@ -754,14 +896,22 @@ func (p *Provisioner) setBucketPolicy(additionalConfig *additionalConfigSpec) er
 		livePolicy = policyResp.Policy
 	}
 
-	diff := cmp.Diff(&livePolicy, additionalConfig.bucketPolicy)
+	confPolicy := additionalConfig.bucketPolicy
+	if p.bucketEncryptionPolicy != nil && p.bucketEncryptionPolicy.RequireEncryption {
+		confPolicy, err = withDenyUnencryptedUploads(p.bucketName, confPolicy)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deny unencrypted uploads in the policy of bucket %q", p.bucketName)
+		}
+	}
+
+	diff := cmp.Diff(&livePolicy, confPolicy)
 	if diff == "" {
 		// policy is in sync
 		return nil
 	}
 
 	logger.Debugf("Policy for bucket %q has changed. diff:%s", p.bucketName, diff)
-	if additionalConfig.bucketPolicy == nil {
+	if confPolicy == nil {
 		// if policy is out of sync and the new policy is nil, we should delete the live policy
 		_, err = svc.DeleteBucketPolicy(&s3.DeleteBucketPolicyInput{
 			Bucket: &p.bucketName,
@@ -773,7 +923,7 @@ func (p *Provisioner) setBucketPolicy(additionalConfig *additionalConfigSpec) er
 		// set the new policy
 		_, err = svc.PutBucketPolicy(&s3.PutBucketPolicyInput{
 			Bucket: &p.bucketName,
-			Policy: additionalConfig.bucketPolicy,
+			Policy: confPolicy,
 		})
 		if err != nil {
 			return errors.Wrapf(err, "failed to set policy for bucket %q", p.bucketName)
@@ -854,6 +1004,278 @@ func (p *Provisioner) setBucketLifecycle(additionalConfig *additionalConfigSpec)
 	return nil
 }
 
+func (p *Provisioner) setBucketVersioning(additionalConfig *additionalConfigSpec) error {
+	// the versioning is only reported when it may be set on OBCs
+	if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketVersioning") {
+		return nil
+	}
+	svc := p.s3Agent.Client
+
+	liveVersioning, err := svc.GetBucketVersioning(&s3.GetBucketVersioningInput{
+		Bucket: &p.bucketName,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch versioning of bucket %q", p.bucketName)
+	}
+	// the status is not set until the versioning is enabled once
+	p.bucketVersioning = aws.StringValue(liveVersioning.Status)
+
+	// the versioning of a bucket cannot be disabled once enabled, it is left as is when not set
+	if additionalConfig.bucketVersioning == nil || *additionalConfig.bucketVersioning == p.bucketVersioning {
+		return nil
+	}
+	if p.bucketVersioning == "" && *additionalConfig.bucketVersioning == s3.BucketVersioningStatusSuspended {
+		// a bucket that was never versioned is left unversioned
+		return nil
+	}
+
+	logger.Debugf("versioning of bucket %q has changed from %q to %q", p.bucketName, p.bucketVersioning, *additionalConfig.bucketVersioning)
+	_, err = svc.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  &p.bucketName,
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: additionalConfig.bucketVersioning},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to set versioning of bucket %q to %q", p.bucketName, *additionalConfig.bucketVersioning)
+	}
+	p.bucketVersioning = *additionalConfig.bucketVersioning
+	return nil
+}
+
+func (p *Provisioner) setBucketObjectLock(additionalConfig *additionalConfigSpec) error {
+	// the default retention is only managed when it may be set on OBCs
+	if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketObjectLockMode") {
+		return nil
+	}
+	svc := p.s3Agent.Client
+
+	var liveLock *s3.ObjectLockConfiguration
+	liveLockOutput, err := svc.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{
+		Bucket: &p.bucketName,
+	})
+	if err != nil {
+		// when object lock is not enabled, an err with a "code" of
+		// ObjectLockConfigurationNotFoundError is returned
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "ObjectLockConfigurationNotFoundError" {
+			return errors.Wrapf(err, "failed to fetch object lock configuration of bucket %q", p.bucketName)
+		}
+		logger.Debugf("object lock is not enabled on bucket %q", p.bucketName)
+	} else {
+		liveLock = liveLockOutput.ObjectLockConfiguration
+	}
+
+	if liveLock == nil || aws.StringValue(liveLock.ObjectLockEnabled) != s3.ObjectLockEnabledEnabled {
+		if additionalConfig.bucketObjectLockMode != nil {
+			return errors.Errorf("object lock is not enabled on bucket %q, it can only be enabled when the bucket is created", p.bucketName)
+		}
+		return nil
+	}
+	p.bucketObjectLock = liveLock
+
+	// object lock cannot be disabled once enabled, only the default retention is removed when it is
+	// not set
+	confLock := &s3.ObjectLockConfiguration{ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled)}
+	if additionalConfig.bucketObjectLockMode != nil {
+		confLock.Rule = &s3.ObjectLockRule{
+			DefaultRetention: &s3.DefaultRetention{
+				Mode: additionalConfig.bucketObjectLockMode,
+				Days: additionalConfig.bucketObjectLockRetentionDays,
+			},
+		}
+	}
+	diff := cmp.Diff(liveLock, confLock)
+	if diff == "" {
+		// object lock is in sync
+		return nil
+	}
+
+	logger.Debugf("object lock configuration of bucket %q has changed. diff:%s", p.bucketName, diff)
+	_, err = svc.PutObjectLockConfiguration(&s3.PutObjectLockConfigurationInput{
+		Bucket:                  &p.bucketName,
+		ObjectLockConfiguration: confLock,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to set object lock configuration of bucket %q", p.bucketName)
+	}
+	p.bucketObjectLock = confLock
+	return nil
+}
+
+// bucketEncryptionKeyID returns the ID of the key created in the kms for the SSE-KMS encryption of
+// the bucket when the OBC does not set one
+func (p *Provisioner) bucketEncryptionKeyID() string {
+	return fmt.Sprintf("%s-%s-%s", p.clusterInfo.Namespace, p.objectStoreName, p.bucketName)
+}
+
+// createBucketEncryptionKey creates the SSE-KMS key of the bucket in the vault kms of the object store
+func createBucketEncryptionKey(p *Provisioner, keyID string) error {
+	if p.kms == nil || !p.kms.IsVaultKMS() {
+		return errors.New("the key of the bucket can only be created with the vault kms security settings of the object store")
+	}
+
+	// the connection details are completed by the validation
+	kmsSpec := p.kms.DeepCopy()
+	err := kms.ValidateConnectionDetails(p.clusterInfo.Context, p.context, kmsSpec, p.clusterInfo.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to validate kms connection details")
+	}
+	return kms.CreateRGWEncryptionKey(p.clusterInfo.Context, p.context, p.clusterInfo.Namespace, kmsSpec.ConnectionDetails, keyID)
+}
+
+// deleteBucketEncryptionKey deletes the SSE-KMS key of the bucket from the vault kms of the object store
+func deleteBucketEncryptionKey(p *Provisioner, keyID string) error {
+	if p.kms == nil || !p.kms.IsVaultKMS() {
+		return errors.New("the key of the bucket can only be deleted with the vault kms security settings of the object store")
+	}
+
+	kmsSpec := p.kms.DeepCopy()
+	err := kms.ValidateConnectionDetails(p.clusterInfo.Context, p.context, kmsSpec, p.clusterInfo.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to validate kms connection details")
+	}
+	return kms.DeleteRGWEncryptionKey(p.clusterInfo.Context, p.context, p.clusterInfo.Namespace, kmsSpec.ConnectionDetails, keyID)
+}
+
+// deleteOBEncryptionKey deletes the SSE-KMS key that was created for the bucket of the OB. A key
+// set in the OBC is not owned by the bucket and is left alone.
+func (p *Provisioner) deleteOBEncryptionKey(ob *bktv1alpha1.ObjectBucket) error {
+	if ob.Spec.Connection == nil {
+		return nil
+	}
+	state := ob.Spec.Connection.AdditionalState
+	if state["bucketEncryption"] != string(cephv1.BucketEncryptionSSEKMS) || state["bucketEncryptionKeyID"] != p.bucketEncryptionKeyID() {
+		return nil
+	}
+
+	logger.Infof("Delete: deleting encryption key %q of bucket %q", state["bucketEncryptionKeyID"], p.bucketName)
+	return deleteBucketEncryptionKeyFunc(p, state["bucketEncryptionKeyID"])
+}
+
+func (p *Provisioner) setBucketEncryption(additionalConfig *additionalConfigSpec) error {
+	algorithm := ""
+	if p.bucketEncryptionPolicy != nil {
+		algorithm = string(p.bucketEncryptionPolicy.DefaultAlgorithm)
+	}
+	if additionalConfig.bucketEncryption != nil {
+		algorithm = *additionalConfig.bucketEncryption
+	}
+	// the encryption is left untouched if it has no default and may not be set on OBCs, so that
+	// it can still be managed with the S3 API
+	if algorithm == "" && !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketEncryption") {
+		return nil
+	}
+	svc := p.s3Agent.Client
+
+	p.bucketEncryption = nil
+	liveEncryptionOutput, err := svc.GetBucketEncryption(&s3.GetBucketEncryptionInput{
+		Bucket: &p.bucketName,
+	})
+	if err != nil {
+		// when no default encryption is set, an err with a "code" of
+		// ServerSideEncryptionConfigurationNotFoundError is returned
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "ServerSideEncryptionConfigurationNotFoundError" {
+			return errors.Wrapf(err, "failed to fetch encryption of bucket %q", p.bucketName)
+		}
+		logger.Debugf("no default encryption set for bucket %q", p.bucketName)
+	} else if conf := liveEncryptionOutput.ServerSideEncryptionConfiguration; conf != nil && len(conf.Rules) > 0 {
+		p.bucketEncryption = conf.Rules[0].ApplyServerSideEncryptionByDefault
+	}
+
+	var confEncryption *s3.ServerSideEncryptionByDefault
+	createKey := false
+	switch cephv1.BucketEncryptionAlgorithm(algorithm) {
+	case cephv1.BucketEncryptionSSES3:
+		confEncryption = &s3.ServerSideEncryptionByDefault{SSEAlgorithm: aws.String(s3.ServerSideEncryptionAes256)}
+	case cephv1.BucketEncryptionSSEKMS:
+		keyID := additionalConfig.bucketEncryptionKeyID
+		if keyID == nil {
+			keyID = aws.String(p.bucketEncryptionKeyID())
+			createKey = true
+		}
+		confEncryption = &s3.ServerSideEncryptionByDefault{SSEAlgorithm: aws.String(s3.ServerSideEncryptionAwsKms), KMSMasterKeyID: keyID}
+	}
+
+	diff := cmp.Diff(p.bucketEncryption, confEncryption)
+	if diff == "" {
+		// encryption is in sync
+		return nil
+	}
+
+	logger.Debugf("encryption of bucket %q has changed. diff:%s", p.bucketName, diff)
+	if confEncryption == nil {
+		// objects written before keep their encryption
+		_, err = svc.DeleteBucketEncryption(&s3.DeleteBucketEncryptionInput{
+			Bucket: &p.bucketName,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete encryption of bucket %q", p.bucketName)
+		}
+		p.bucketEncryption = nil
+		return nil
+	}
+
+	if createKey {
+		err = createBucketEncryptionKeyFunc(p, *confEncryption.KMSMasterKeyID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create encryption key of bucket %q", p.bucketName)
+		}
+	}
+	_, err = svc.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+		Bucket: &p.bucketName,
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{{ApplyServerSideEncryptionByDefault: confEncryption}},
+		},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to set encryption of bucket %q", p.bucketName)
+	}
+	p.bucketEncryption = confEncryption
+	return nil
+}
+
+// bucketRateLimit returns the rate limit requested for the bucket, or nil if none of the rate limit
+// options are set
+func (additionalConfig *additionalConfigSpec) bucketRateLimit() *cephv1.RateLimitSpec {
+	if additionalConfig.bucketMaxReadOps == nil && additionalConfig.bucketMaxWriteOps == nil &&
+		additionalConfig.bucketMaxReadBytes == nil && additionalConfig.bucketMaxWriteBytes == nil {
+		return nil
+	}
+
+	limit := &cephv1.RateLimitSpec{
+		MaxReadOps:  additionalConfig.bucketMaxReadOps,
+		MaxWriteOps: additionalConfig.bucketMaxWriteOps,
+	}
+	if additionalConfig.bucketMaxReadBytes != nil {
+		limit.MaxReadBytes = resource.NewQuantity(*additionalConfig.bucketMaxReadBytes, resource.BinarySI)
+	}
+	if additionalConfig.bucketMaxWriteBytes != nil {
+		limit.MaxWriteBytes = resource.NewQuantity(*additionalConfig.bucketMaxWriteBytes, resource.BinarySI)
+	}
+	return limit
+}
+
+func (p *Provisioner) setBucketRateLimit(additionalConfig *additionalConfigSpec) error {
+	// the bucket rate limit is only managed if the admin allowed OBCs to set it, otherwise it is
+	// left untouched so that it can still be managed with radosgw-admin
+	if !bucketRateLimitAllowed() {
+		return nil
+	}
+
+	targetLimit := additionalConfig.bucketRateLimit()
+	if targetLimit == nil {
+		// a rate limit may have been set by a previous version of the OBC
+		liveLimit, err := object.GetRateLimit(p.objectContext, object.RateLimitScopeBucket, p.bucketName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch rate limit of bucket %q", p.bucketName)
+		}
+		if liveLimit == nil || !liveLimit.Enabled {
+			return nil
+		}
+	}
+
+	logger.Debugf("setting rate limit for bucket %q to %+v", p.bucketName, targetLimit)
+	return object.SetRateLimit(p.objectContext, object.RateLimitScopeBucket, p.bucketName, targetLimit)
+}
+
 func (p *Provisioner) setTlsCaCert() error {
 	objStore, err := p.getObjectStore()
 	if err != nil {
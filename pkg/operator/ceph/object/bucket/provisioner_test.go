@@ -22,12 +22,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awssession "github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/ceph/go-ceph/rgw/admin"
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	rookclient "github.com/rook/rook/pkg/client/clientset/versioned/fake"
 	"github.com/rook/rook/pkg/clusterd"
@@ -35,10 +44,12 @@ import (
 	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
 	"github.com/rook/rook/pkg/operator/ceph/object"
 	"github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const (
@@ -119,6 +130,60 @@ func TestPopulateDomainAndPort(t *testing.T) {
 	assert.Equal(t, "rook-ceph-rgw-test-store.ns.svc", p.storeDomainName)
 }
 
+func TestSetPlacementRule(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "ns"
+	p := NewProvisioner(&clusterd.Context{RookClientset: rookclient.NewSimpleClientset()}, client.AdminTestClusterInfo(namespace))
+	sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "bucket-class"}, Parameters: map[string]string{}}
+
+	t.Run("no placement", func(t *testing.T) {
+		assert.NoError(t, p.setPlacementRule(sc))
+		assert.Equal(t, "", p.placementRule)
+	})
+
+	t.Run("object store not found", func(t *testing.T) {
+		p.objectStoreName = "test-store"
+		sc.Parameters["placement"] = "slow"
+		assert.Error(t, p.setPlacementRule(sc))
+	})
+
+	cephObjectStore := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-store", Namespace: namespace},
+		Spec: cephv1.ObjectStoreSpec{
+			SharedPools: cephv1.ObjectSharedPoolsSpec{
+				PoolPlacements: []cephv1.PoolPlacementSpec{
+					{Name: "fast", Default: true},
+					{Name: "slow", StorageClasses: []cephv1.PlacementStorageClassSpec{{Name: "COLD"}}},
+				},
+			},
+		},
+	}
+	_, err := p.context.RookClientset.CephV1().CephObjectStores(namespace).Create(ctx, cephObjectStore, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	t.Run("placement and storage class of the object store", func(t *testing.T) {
+		sc.Parameters["placementStorageClass"] = "COLD"
+		assert.NoError(t, p.setPlacementRule(sc))
+		assert.Equal(t, "slow/COLD", p.placementRule)
+	})
+
+	t.Run("storage class not in the placement", func(t *testing.T) {
+		sc.Parameters["placement"] = "fast"
+		assert.Error(t, p.setPlacementRule(sc))
+	})
+
+	t.Run("object store endpoint", func(t *testing.T) {
+		p.endpoint = "192.168.0.1:80"
+		defer func() { p.endpoint = "" }()
+		sc.Parameters["placement"] = "other"
+		assert.NoError(t, p.setPlacementRule(sc))
+		assert.Equal(t, "other/COLD", p.placementRule)
+
+		delete(sc.Parameters, "placement")
+		assert.Error(t, p.setPlacementRule(sc))
+	})
+}
+
 func TestQuanityToInt64(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -575,10 +640,116 @@ func TestProvisioner_additionalConfigSpecFromMap(t *testing.T) {
 		assert.Equal(t, additionalConfigSpec{bucketOwner: &(&struct{ s string }{"foo"}).s}, *spec)
 	})
 
+	t.Run("bucket rate limit fields should be set", func(t *testing.T) {
+		os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketMaxReadOps,bucketMaxWriteBytes")
+		defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+		spec, err := additionalConfigSpecFromMap(map[string]string{"bucketMaxReadOps": "100", "bucketMaxWriteBytes": "1Mi"})
+		assert.NoError(t, err)
+		assert.Equal(t, additionalConfigSpec{
+			bucketMaxReadOps:    &(&struct{ i int64 }{100}).i,
+			bucketMaxWriteBytes: &(&struct{ i int64 }{1048576}).i,
+		}, *spec)
+
+		_, err = additionalConfigSpecFromMap(map[string]string{"bucketMaxReadOps": "-1"})
+		assert.Error(t, err)
+		_, err = additionalConfigSpecFromMap(map[string]string{"bucketMaxWriteOps": "1"})
+		assert.Error(t, err)
+	})
+
+	t.Run("quotaNearFullThreshold field should be set", func(t *testing.T) {
+		os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "quotaNearFullThreshold")
+		defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+		spec, err := additionalConfigSpecFromMap(map[string]string{"quotaNearFullThreshold": "90"})
+		assert.NoError(t, err)
+		assert.Equal(t, additionalConfigSpec{quotaNearFullThreshold: &(&struct{ i int32 }{90}).i}, *spec)
+
+		for _, threshold := range []string{"0", "101", "90%"} {
+			_, err = additionalConfigSpecFromMap(map[string]string{"quotaNearFullThreshold": threshold})
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("bucketNumShards field should be set", func(t *testing.T) {
+		os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketNumShards")
+		defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+		spec, err := additionalConfigSpecFromMap(map[string]string{"bucketNumShards": "101"})
+		assert.NoError(t, err)
+		assert.Equal(t, additionalConfigSpec{bucketNumShards: &(&struct{ i int64 }{101}).i}, *spec)
+
+		for _, numShards := range []string{"0", "65522", "1k"} {
+			_, err = additionalConfigSpecFromMap(map[string]string{"bucketNumShards": numShards})
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("bucket versioning and object lock fields should be set", func(t *testing.T) {
+		os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketVersioning,bucketObjectLockMode,bucketObjectLockRetentionDays")
+		defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+		spec, err := additionalConfigSpecFromMap(map[string]string{"bucketVersioning": "Enabled", "bucketObjectLockMode": "COMPLIANCE", "bucketObjectLockRetentionDays": "30"})
+		assert.NoError(t, err)
+		assert.Equal(t, additionalConfigSpec{
+			bucketVersioning:              &(&struct{ s string }{"Enabled"}).s,
+			bucketObjectLockMode:          &(&struct{ s string }{"COMPLIANCE"}).s,
+			bucketObjectLockRetentionDays: &(&struct{ i int64 }{30}).i,
+		}, *spec)
+
+		for _, config := range []map[string]string{
+			{"bucketVersioning": "Disabled"},
+			{"bucketObjectLockMode": "LEGAL_HOLD", "bucketObjectLockRetentionDays": "30"},
+			{"bucketObjectLockMode": "GOVERNANCE", "bucketObjectLockRetentionDays": "0"},
+			{"bucketObjectLockMode": "GOVERNANCE"},
+			{"bucketObjectLockRetentionDays": "30"},
+			{"bucketVersioning": "Suspended", "bucketObjectLockMode": "GOVERNANCE", "bucketObjectLockRetentionDays": "30"},
+		} {
+			_, err = additionalConfigSpecFromMap(config)
+			assert.Error(t, err, config)
+		}
+	})
+
+	t.Run("bucket encryption fields should be set", func(t *testing.T) {
+		os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketEncryption,bucketEncryptionKeyID")
+		defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+		spec, err := additionalConfigSpecFromMap(map[string]string{"bucketEncryption": "SSE-KMS", "bucketEncryptionKeyID": "my-key"})
+		assert.NoError(t, err)
+		assert.Equal(t, additionalConfigSpec{
+			bucketEncryption:      &(&struct{ s string }{"SSE-KMS"}).s,
+			bucketEncryptionKeyID: &(&struct{ s string }{"my-key"}).s,
+		}, *spec)
+
+		spec, err = additionalConfigSpecFromMap(map[string]string{"bucketEncryption": "SSE-S3"})
+		assert.NoError(t, err)
+		assert.Equal(t, "SSE-S3", *spec.bucketEncryption)
+
+		for _, config := range []map[string]string{
+			{"bucketEncryption": "AES256"},
+			{"bucketEncryptionKeyID": "my-key"},
+			{"bucketEncryption": "SSE-S3", "bucketEncryptionKeyID": "my-key"},
+			{"bucketEncryption": "SSE-KMS", "bucketEncryptionKeyID": ""},
+		} {
+			_, err = additionalConfigSpecFromMap(config)
+			assert.Error(t, err, config)
+		}
+	})
+
 	t.Run("fields disallowed by default", func(t *testing.T) {
 		opcontroller.SetObcAllowAdditionalConfigFields()
 
-		for _, configKey := range []string{"bucketMaxObjects", "bucketMaxSize", "bucketPolicy", "bucketLifecycle", "bucketOwner"} {
+		for _, configKey := range []string{"bucketMaxObjects", "bucketMaxSize", "bucketPolicy", "bucketLifecycle", "bucketOwner", "bucketMaxReadOps", "bucketMaxWriteOps", "bucketMaxReadBytes", "bucketMaxWriteBytes", "quotaNearFullThreshold", "bucketNumShards", "bucketVersioning", "bucketObjectLockMode", "bucketObjectLockRetentionDays", "bucketEncryption", "bucketEncryptionKeyID"} {
 			_, err := additionalConfigSpecFromMap(map[string]string{configKey: "foo"})
 			assert.Error(t, err)
 		}
@@ -593,6 +764,417 @@ func TestProvisioner_additionalConfigSpecFromMap(t *testing.T) {
 	})
 }
 
+func TestProvisioner_setBucketRateLimit(t *testing.T) {
+	liveRateLimit := `{"bucket_ratelimit":{"max_read_ops":0,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":false}}`
+	var commands []string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			commands = append(commands, args[0]+" "+args[1])
+			if args[1] == "get" {
+				return liveRateLimit, nil
+			}
+			return "", nil
+		},
+	}
+	clusterInfo := client.AdminTestClusterInfo("ns")
+	p := &Provisioner{
+		clusterInfo:   clusterInfo,
+		bucketName:    "my-bucket",
+		objectContext: object.NewContext(&clusterd.Context{Executor: executor}, clusterInfo, ""),
+	}
+	maxReadOps := int64(100)
+
+	t.Run("rate limit not allowed", func(t *testing.T) {
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		assert.NoError(t, p.setBucketRateLimit(&additionalConfigSpec{}))
+		assert.Empty(t, commands)
+	})
+
+	os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketMaxReadOps")
+	defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+	opcontroller.SetObcAllowAdditionalConfigFields()
+	defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+	t.Run("no rate limit set", func(t *testing.T) {
+		commands = nil
+		assert.NoError(t, p.setBucketRateLimit(&additionalConfigSpec{}))
+		assert.Equal(t, []string{"ratelimit get"}, commands)
+	})
+
+	t.Run("rate limit set", func(t *testing.T) {
+		commands = nil
+		assert.NoError(t, p.setBucketRateLimit(&additionalConfigSpec{bucketMaxReadOps: &maxReadOps}))
+		assert.Equal(t, []string{"ratelimit set", "ratelimit enable"}, commands)
+	})
+
+	t.Run("rate limit removed", func(t *testing.T) {
+		commands = nil
+		liveRateLimit = `{"bucket_ratelimit":{"max_read_ops":100,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":true}}`
+		assert.NoError(t, p.setBucketRateLimit(&additionalConfigSpec{}))
+		assert.Equal(t, []string{"ratelimit get", "ratelimit disable"}, commands)
+	})
+}
+
+// s3RoundTripper serves the requests of the S3 client in the unit tests
+type s3RoundTripper func(req *http.Request) (int, string)
+
+func (f s3RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	code, body := f(req)
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{}, Request: req}, nil
+}
+
+func newTestS3Agent(t *testing.T, f s3RoundTripper) *object.S3Agent {
+	// a custom CA bundle needs an http.Transport
+	t.Setenv("AWS_CA_BUNDLE", "")
+	session, err := awssession.NewSession(aws.NewConfig().
+		WithRegion(object.CephRegion).
+		WithCredentials(credentials.NewStaticCredentials("access", "secret", "")).
+		WithEndpoint("rgw.test").
+		WithS3ForcePathStyle(true).
+		WithDisableSSL(true).
+		WithHTTPClient(&http.Client{Transport: f}))
+	assert.NoError(t, err)
+	return &object.S3Agent{Client: s3.New(session)}
+}
+
+func TestProvisioner_setBucketVersioning(t *testing.T) {
+	liveStatus := ""
+	var requests []string
+	p := &Provisioner{bucketName: "my-bucket", clusterInfo: client.AdminTestClusterInfo("ns")}
+	p.s3Agent = newTestS3Agent(t, func(req *http.Request) (int, string) {
+		requests = append(requests, req.Method)
+		if req.Method == http.MethodPut {
+			body, _ := io.ReadAll(req.Body)
+			assert.Contains(t, string(body), "<Status>Enabled</Status>")
+			liveStatus = "Enabled"
+			return 200, ""
+		}
+		return 200, fmt.Sprintf(`<VersioningConfiguration><Status>%s</Status></VersioningConfiguration>`, liveStatus)
+	})
+
+	t.Run("versioning not allowed", func(t *testing.T) {
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		assert.NoError(t, p.setBucketVersioning(&additionalConfigSpec{}))
+		assert.Empty(t, requests)
+	})
+
+	os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketVersioning")
+	defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+	opcontroller.SetObcAllowAdditionalConfigFields()
+	defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+	t.Run("unversioned bucket is not suspended", func(t *testing.T) {
+		requests = nil
+		assert.NoError(t, p.setBucketVersioning(&additionalConfigSpec{bucketVersioning: aws.String("Suspended")}))
+		assert.Equal(t, []string{http.MethodGet}, requests)
+		assert.Equal(t, "", p.bucketVersioning)
+	})
+
+	t.Run("versioning enabled", func(t *testing.T) {
+		requests = nil
+		assert.NoError(t, p.setBucketVersioning(&additionalConfigSpec{bucketVersioning: aws.String("Enabled")}))
+		assert.Equal(t, []string{http.MethodGet, http.MethodPut}, requests)
+		assert.Equal(t, "Enabled", p.bucketVersioning)
+	})
+
+	t.Run("versioning in sync", func(t *testing.T) {
+		requests = nil
+		assert.NoError(t, p.setBucketVersioning(&additionalConfigSpec{bucketVersioning: aws.String("Enabled")}))
+		assert.Equal(t, []string{http.MethodGet}, requests)
+	})
+
+	t.Run("versioning is reported when not set", func(t *testing.T) {
+		p.bucketVersioning = ""
+		assert.NoError(t, p.setBucketVersioning(&additionalConfigSpec{}))
+		assert.Equal(t, "Enabled", p.bucketVersioning)
+		ob := p.composeObjectBucket(&bucket{additionalConfig: &additionalConfigSpec{}})
+		assert.Equal(t, "Enabled", ob.Spec.Connection.AdditionalState["bucketVersioning"])
+	})
+}
+
+func TestProvisioner_setBucketObjectLock(t *testing.T) {
+	liveLock := ""
+	var puts []string
+	p := &Provisioner{bucketName: "my-bucket", clusterInfo: client.AdminTestClusterInfo("ns")}
+	p.s3Agent = newTestS3Agent(t, func(req *http.Request) (int, string) {
+		assert.Contains(t, req.URL.RawQuery, "object-lock")
+		if req.Method == http.MethodPut {
+			body, _ := io.ReadAll(req.Body)
+			puts = append(puts, string(body))
+			return 200, ""
+		}
+		if liveLock == "" {
+			return 404, `<Error><Code>ObjectLockConfigurationNotFoundError</Code></Error>`
+		}
+		return 200, liveLock
+	})
+	mode, days := aws.String("COMPLIANCE"), aws.Int64(30)
+
+	os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketObjectLockMode,bucketObjectLockRetentionDays")
+	defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+	opcontroller.SetObcAllowAdditionalConfigFields()
+	defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+	t.Run("object lock not enabled", func(t *testing.T) {
+		assert.NoError(t, p.setBucketObjectLock(&additionalConfigSpec{}))
+		err := p.setBucketObjectLock(&additionalConfigSpec{bucketObjectLockMode: mode, bucketObjectLockRetentionDays: days})
+		assert.ErrorContains(t, err, "can only be enabled when the bucket is created")
+		assert.Empty(t, puts)
+		assert.Nil(t, p.bucketObjectLock)
+	})
+
+	t.Run("default retention set", func(t *testing.T) {
+		liveLock = `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled></ObjectLockConfiguration>`
+		assert.NoError(t, p.setBucketObjectLock(&additionalConfigSpec{bucketObjectLockMode: mode, bucketObjectLockRetentionDays: days}))
+		assert.Len(t, puts, 1)
+		assert.Contains(t, puts[0], "<Mode>COMPLIANCE</Mode>")
+		assert.Contains(t, puts[0], "<Days>30</Days>")
+
+		ob := p.composeObjectBucket(&bucket{additionalConfig: &additionalConfigSpec{}})
+		assert.Equal(t, "Enabled", ob.Spec.Connection.AdditionalState["bucketObjectLock"])
+		assert.Equal(t, "COMPLIANCE", ob.Spec.Connection.AdditionalState["bucketObjectLockMode"])
+		assert.Equal(t, "30", ob.Spec.Connection.AdditionalState["bucketObjectLockRetentionDays"])
+	})
+
+	t.Run("default retention in sync", func(t *testing.T) {
+		puts = nil
+		liveLock = `<ObjectLockConfiguration><ObjectLockEnabled>Enabled</ObjectLockEnabled><Rule><DefaultRetention><Mode>COMPLIANCE</Mode><Days>30</Days></DefaultRetention></Rule></ObjectLockConfiguration>`
+		assert.NoError(t, p.setBucketObjectLock(&additionalConfigSpec{bucketObjectLockMode: mode, bucketObjectLockRetentionDays: days}))
+		assert.Empty(t, puts)
+	})
+
+	t.Run("default retention removed", func(t *testing.T) {
+		assert.NoError(t, p.setBucketObjectLock(&additionalConfigSpec{}))
+		assert.Len(t, puts, 1)
+		assert.NotContains(t, puts[0], "<Rule>")
+	})
+}
+
+func TestProvisioner_setBucketEncryption(t *testing.T) {
+	liveEncryption := ""
+	var puts []string
+	deletes := 0
+	p := &Provisioner{bucketName: "my-bucket", objectStoreName: "my-store", clusterInfo: client.AdminTestClusterInfo("ns")}
+	p.s3Agent = newTestS3Agent(t, func(req *http.Request) (int, string) {
+		assert.Contains(t, req.URL.RawQuery, "encryption")
+		switch req.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(req.Body)
+			puts = append(puts, string(body))
+			return 200, ""
+		case http.MethodDelete:
+			deletes++
+			return 204, ""
+		}
+		if liveEncryption == "" {
+			return 404, `<Error><Code>ServerSideEncryptionConfigurationNotFoundError</Code></Error>`
+		}
+		return 200, liveEncryption
+	})
+	var createdKeys []string
+	createBucketEncryptionKeyFunc = func(p *Provisioner, keyID string) error {
+		createdKeys = append(createdKeys, keyID)
+		return nil
+	}
+	defer func() { createBucketEncryptionKeyFunc = createBucketEncryptionKey }()
+
+	t.Run("encryption not managed", func(t *testing.T) {
+		opcontroller.SetObcAllowAdditionalConfigFields()
+		assert.NoError(t, p.setBucketEncryption(&additionalConfigSpec{}))
+		assert.Empty(t, puts)
+		assert.Zero(t, deletes)
+	})
+
+	t.Run("default encryption of the object store", func(t *testing.T) {
+		p.bucketEncryptionPolicy = &cephv1.BucketEncryptionSpec{DefaultAlgorithm: cephv1.BucketEncryptionSSES3}
+		defer func() { p.bucketEncryptionPolicy = nil }()
+		assert.NoError(t, p.setBucketEncryption(&additionalConfigSpec{}))
+		assert.Len(t, puts, 1)
+		assert.Contains(t, puts[0], "<SSEAlgorithm>AES256</SSEAlgorithm>")
+		assert.Empty(t, createdKeys)
+
+		ob := p.composeObjectBucket(&bucket{additionalConfig: &additionalConfigSpec{}})
+		assert.Equal(t, "SSE-S3", ob.Spec.Connection.AdditionalState["bucketEncryption"])
+
+		puts = nil
+		liveEncryption = `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`
+		assert.NoError(t, p.setBucketEncryption(&additionalConfigSpec{}))
+		assert.Empty(t, puts)
+	})
+
+	os.Setenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS", "bucketEncryption,bucketEncryptionKeyID")
+	defer os.Unsetenv("ROOK_OBC_ALLOW_ADDITIONAL_CONFIG_FIELDS")
+	opcontroller.SetObcAllowAdditionalConfigFields()
+	defer opcontroller.SetObcAllowAdditionalConfigFields()
+
+	t.Run("SSE-KMS with a key created for the bucket", func(t *testing.T) {
+		puts = nil
+		assert.NoError(t, p.setBucketEncryption(&additionalConfigSpec{bucketEncryption: aws.String("SSE-KMS")}))
+		assert.Equal(t, []string{"ns-my-store-my-bucket"}, createdKeys)
+		assert.Len(t, puts, 1)
+		assert.Contains(t, puts[0], "<SSEAlgorithm>aws:kms</SSEAlgorithm>")
+		assert.Contains(t, puts[0], "<KMSMasterKeyID>ns-my-store-my-bucket</KMSMasterKeyID>")
+
+		ob := p.composeObjectBucket(&bucket{additionalConfig: &additionalConfigSpec{}})
+		assert.Equal(t, "SSE-KMS", ob.Spec.Connection.AdditionalState["bucketEncryption"])
+		assert.Equal(t, "ns-my-store-my-bucket", ob.Spec.Connection.AdditionalState["bucketEncryptionKeyID"])
+	})
+
+	t.Run("SSE-KMS with the key of the OBC", func(t *testing.T) {
+		puts, createdKeys = nil, nil
+		assert.NoError(t, p.setBucketEncryption(&additionalConfigSpec{bucketEncryption: aws.String("SSE-KMS"), bucketEncryptionKeyID: aws.String("my-key")}))
+		assert.Empty(t, createdKeys)
+		assert.Len(t, puts, 1)
+		assert.Contains(t, puts[0], "<KMSMasterKeyID>my-key</KMSMasterKeyID>")
+	})
+
+	t.Run("key creation failure", func(t *testing.T) {
+		puts = nil
+		createBucketEncryptionKeyFunc = createBucketEncryptionKey
+		err := p.setBucketEncryption(&additionalConfigSpec{bucketEncryption: aws.String("SSE-KMS")})
+		assert.ErrorContains(t, err, "vault kms")
+		assert.Empty(t, puts)
+	})
+
+	t.Run("encryption removed", func(t *testing.T) {
+		assert.NoError(t, p.setBucketEncryption(&additionalConfigSpec{}))
+		assert.Equal(t, 1, deletes)
+		assert.Nil(t, p.bucketEncryption)
+	})
+}
+
+func TestProvisioner_DeleteEncryptedBucket(t *testing.T) {
+	ctx := context.TODO()
+	var removedBuckets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && r.URL.Path == "/admin/bucket" {
+			removedBuckets = append(removedBuckets, r.URL.Query().Get("bucket"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	assert.NoError(t, err)
+
+	store := &cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "ns"}}
+	store.Spec.Gateway.Port = int32(port)
+	store.Spec.Gateway.ExternalRgwEndpoints = []cephv1.EndpointAddress{{IP: serverURL.Hostname()}}
+	store.Spec.Security = &cephv1.ObjectStoreSecuritySpec{
+		SecuritySpec: cephv1.SecuritySpec{
+			KeyManagementService: cephv1.KeyManagementServiceSpec{ConnectionDetails: map[string]string{"KMS_PROVIDER": "vault"}},
+		},
+	}
+	sc := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "bucket-class"},
+		Parameters: map[string]string{ObjectStoreName: "my-store", ObjectStoreNamespace: "ns"},
+	}
+	adminOpsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: object.RGWAdminOpsUserSecretName, Namespace: "ns"},
+		Data:       map[string][]byte{"accessKey": []byte("access"), "secretKey": []byte("secret")},
+	}
+	clientset := test.New(t, 1)
+	_, err = clientset.StorageV1().StorageClasses().Create(ctx, sc, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	p := Provisioner{
+		context: &clusterd.Context{
+			Clientset:     clientset,
+			RookClientset: rookclient.NewSimpleClientset(store),
+			Client:        fake.NewClientBuilder().WithObjects(adminOpsSecret).Build(),
+		},
+		clusterInfo: client.AdminTestClusterInfo("ns"),
+	}
+
+	var deletedKeys []string
+	deleteBucketEncryptionKeyFunc = func(p *Provisioner, keyID string) error {
+		assert.True(t, p.kms.IsVaultKMS())
+		deletedKeys = append(deletedKeys, keyID)
+		return nil
+	}
+	defer func() { deleteBucketEncryptionKeyFunc = deleteBucketEncryptionKey }()
+
+	newOB := func(state map[string]string) *bktv1alpha1.ObjectBucket {
+		state[CephUser] = "my-user"
+		return &bktv1alpha1.ObjectBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "obc-default-my-bucket"},
+			Spec: bktv1alpha1.ObjectBucketSpec{
+				StorageClassName: "bucket-class",
+				ClaimRef:         &v1.ObjectReference{Name: "my-bucket", Namespace: "default"},
+				Connection: &bktv1alpha1.Connection{
+					Endpoint:        &bktv1alpha1.Endpoint{BucketName: "my-bucket"},
+					AdditionalState: state,
+				},
+			},
+		}
+	}
+
+	t.Run("SSE-KMS key created for the bucket", func(t *testing.T) {
+		ob := newOB(map[string]string{"bucketEncryption": "SSE-KMS", "bucketEncryptionKeyID": "ns-my-store-my-bucket"})
+		assert.NoError(t, p.Delete(ob))
+		assert.Equal(t, []string{"my-bucket"}, removedBuckets)
+		assert.Equal(t, []string{"ns-my-store-my-bucket"}, deletedKeys)
+	})
+
+	t.Run("SSE-KMS key of the OBC", func(t *testing.T) {
+		removedBuckets, deletedKeys = nil, nil
+		ob := newOB(map[string]string{"bucketEncryption": "SSE-KMS", "bucketEncryptionKeyID": "my-key"})
+		assert.NoError(t, p.Delete(ob))
+		assert.Equal(t, []string{"my-bucket"}, removedBuckets)
+		assert.Empty(t, deletedKeys)
+	})
+
+	t.Run("SSE-S3", func(t *testing.T) {
+		removedBuckets, deletedKeys = nil, nil
+		ob := newOB(map[string]string{"bucketEncryption": "SSE-S3"})
+		assert.NoError(t, p.Delete(ob))
+		assert.Equal(t, []string{"my-bucket"}, removedBuckets)
+		assert.Empty(t, deletedKeys)
+	})
+
+	t.Run("key deletion failure", func(t *testing.T) {
+		deleteBucketEncryptionKeyFunc = func(p *Provisioner, keyID string) error {
+			return errors.New("vault unreachable")
+		}
+		ob := newOB(map[string]string{"bucketEncryption": "SSE-KMS", "bucketEncryptionKeyID": "ns-my-store-my-bucket"})
+		assert.ErrorContains(t, p.Delete(ob), "vault unreachable")
+	})
+}
+
+func TestWithDenyUnencryptedUploads(t *testing.T) {
+	denyStatement := `{
+		"Sid": "RookDenyUnencryptedUploads",
+		"Effect": "Deny",
+		"Principal": {"AWS": ["*"]},
+		"Action": ["s3:PutObject"],
+		"Resource": ["arn:aws:s3:::my-bucket/*"],
+		"Condition": {"Null": {"s3:x-amz-server-side-encryption": "true"}}
+	}`
+
+	t.Run("no policy", func(t *testing.T) {
+		policy, err := withDenyUnencryptedUploads("my-bucket", nil)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"Id": "", "Version": "2012-10-17", "Statement": [`+denyStatement+`]}`, *policy)
+	})
+
+	t.Run("policy of the OBC", func(t *testing.T) {
+		allowStatement := `{"Sid": "Read", "Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::my-bucket/*"}`
+		policy, err := withDenyUnencryptedUploads("my-bucket", aws.String(`{"Version": "2012-10-17", "Statement": [`+allowStatement+`]}`))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"Version": "2012-10-17", "Statement": [`+allowStatement+`, `+denyStatement+`]}`, *policy)
+
+		// a single statement and the statement with the same ID are replaced
+		policy, err = withDenyUnencryptedUploads("my-bucket", aws.String(`{"Version": "2012-10-17", "Statement": {"Sid": "RookDenyUnencryptedUploads"}}`))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"Version": "2012-10-17", "Statement": [`+denyStatement+`]}`, *policy)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := withDenyUnencryptedUploads("my-bucket", aws.String("{"))
+		assert.Error(t, err)
+	})
+}
+
 func numberOfCallsWithValue(substr string, strs []string) int {
 	count := 0
 	for _, s := range strs {
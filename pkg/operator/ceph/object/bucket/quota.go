@@ -0,0 +1,343 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	quotaControllerName = "rook-ceph-operator-bucket-quota-controller"
+
+	bucketProvisionerLabelKey = "bucket-provisioner"
+	bucketProvisionerLabelVal = "ceph.rook.io-bucket"
+
+	// the fields of the ObjectBucket status reporting the usage of the quotas, the status of the
+	// ObjectBucket CRD preserves the fields unknown to the bucket library
+	bucketQuotaStatusField = "quota"
+	userQuotaStatusField   = "userQuota"
+)
+
+var obcQuotaConfigKeys = []string{"maxObjects", "maxSize", "bucketMaxObjects", "bucketMaxSize"}
+
+// ReconcileBucketQuota reports the usage of the quotas of the ObjectBucketClaims provisioned by
+// Rook in the status of their ObjectBucket
+type ReconcileBucketQuota struct {
+	client           client.Client
+	context          *clusterd.Context
+	opManagerContext context.Context
+	recorder         record.EventRecorder
+}
+
+// obcQuotaPredicate reconciles the claims whose quotas or bucket changed. The quotas are checked
+// again periodically after that.
+func obcQuotaPredicate[T *bktv1alpha1.ObjectBucketClaim]() predicate.TypedFuncs[T] {
+	return predicate.TypedFuncs[T]{
+		CreateFunc: func(e event.TypedCreateEvent[T]) bool {
+			return true
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+			objOld := (*bktv1alpha1.ObjectBucketClaim)(e.ObjectOld)
+			objNew := (*bktv1alpha1.ObjectBucketClaim)(e.ObjectNew)
+			if opcontroller.IsDoNotReconcile(objNew.GetLabels()) {
+				return false
+			}
+			return objOld.Spec.ObjectBucketName != objNew.Spec.ObjectBucketName ||
+				!quotaConfigEqual(objOld.Spec.AdditionalConfig, objNew.Spec.AdditionalConfig)
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[T]) bool {
+			return false
+		},
+		GenericFunc: func(e event.TypedGenericEvent[T]) bool {
+			return false
+		},
+	}
+}
+
+func quotaConfigEqual(a, b map[string]string) bool {
+	for _, key := range append(obcQuotaConfigKeys, "quotaNearFullThreshold") {
+		if a[key] != b[key] {
+			return false
+		}
+	}
+	return true
+}
+
+func addQuotaReconciler(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New(quotaControllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	logger.Infof("%s successfully started", quotaControllerName)
+
+	return c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&bktv1alpha1.ObjectBucketClaim{},
+			&handler.TypedEnqueueRequestForObject[*bktv1alpha1.ObjectBucketClaim]{},
+			obcQuotaPredicate(),
+		),
+	)
+}
+
+// Reconcile checks the usage of the quotas of an ObjectBucketClaim
+func (r *ReconcileBucketQuota) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, err := r.reconcile(request)
+	if err != nil {
+		logger.Errorf("failed to check the quotas of ObjectBucketClaim %q. %v", request.NamespacedName, err)
+	}
+
+	return reconcileResponse, err
+}
+
+func (r *ReconcileBucketQuota) reconcile(request reconcile.Request) (reconcile.Result, error) {
+	obc := &bktv1alpha1.ObjectBucketClaim{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, obc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debugf("ObjectBucketClaim %q resource not found. Ignoring since resource must be deleted.", request.NamespacedName)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrapf(err, "failed to retrieve ObjectBucketClaim %q", request.NamespacedName)
+	}
+	if !obc.GetDeletionTimestamp().IsZero() || obc.Spec.ObjectBucketName == "" {
+		// the claim will be reconciled again once it is bound
+		return reconcile.Result{}, nil
+	}
+
+	// the ObjectBucket is read unstructured to preserve the quota status that is not known to the
+	// bucket library
+	ob := &unstructured.Unstructured{}
+	ob.SetGroupVersionKind(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"))
+	if err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: obc.Spec.ObjectBucketName}, ob); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.Wrapf(err, "failed to retrieve ObjectBucket %q", obc.Spec.ObjectBucketName)
+	}
+	if !strings.Contains(ob.GetLabels()[bucketProvisionerLabelKey], bucketProvisionerLabelVal) {
+		logger.Debugf("ObjectBucket %q was not provisioned by the ceph object store provisioner. ignoring", ob.GetName())
+		return reconcile.Result{}, nil
+	}
+
+	hasQuota := false
+	for _, key := range obcQuotaConfigKeys {
+		if _, ok := obc.Spec.AdditionalConfig[key]; ok {
+			hasQuota = true
+		}
+	}
+	previousBucketQuota, previousUserQuota, err := getQuotaStatus(ob)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to read the quota status of ObjectBucket %q", ob.GetName())
+	}
+	if !hasQuota && previousBucketQuota == nil && previousUserQuota == nil {
+		return reconcile.Result{}, nil
+	}
+
+	var bucketQuota, userQuota *cephv1.QuotaStatus
+	if hasQuota {
+		additionalConfig, err := additionalConfigSpecFromMap(obc.Spec.AdditionalConfig)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to process additionalConfig")
+		}
+		threshold := object.DefaultQuotaNearFullThreshold
+		if additionalConfig.quotaNearFullThreshold != nil {
+			threshold = *additionalConfig.quotaNearFullThreshold
+		}
+
+		typedOB := &bktv1alpha1.ObjectBucket{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(ob.Object, typedOB); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "failed to convert ObjectBucket %q", ob.GetName())
+		}
+		adminOpsClient, reconcileResponse, err := r.adminOpsClient(typedOB)
+		if err != nil || adminOpsClient == nil {
+			return reconcileResponse, err
+		}
+		bucketQuota, userQuota, err = checkQuotas(r.opManagerContext, adminOpsClient, getBucketName(typedOB), getCephUser(typedOB), threshold, metav1.Now())
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.recordQuotaEvent(obc, "bucket quota", previousBucketQuota, bucketQuota)
+	r.recordQuotaEvent(obc, "user quota", previousUserQuota, userQuota)
+	if err := r.updateQuotaStatus(ob.GetName(), bucketQuota, userQuota); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to update the quota status of ObjectBucket %q", ob.GetName())
+	}
+
+	if hasQuota {
+		return reconcile.Result{RequeueAfter: object.QuotaCheckInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// adminOpsClient returns the admin ops client of the object store of the bucket, or nil if the
+// cluster is not ready yet
+func (r *ReconcileBucketQuota) adminOpsClient(ob *bktv1alpha1.ObjectBucket) (*admin.API, reconcile.Result, error) {
+	opsContext, reconcileResponse, err := getAdminOpsContext(r.opManagerContext, r.client, r.context, ob, quotaControllerName)
+	if err != nil || opsContext == nil {
+		return nil, reconcileResponse, err
+	}
+	return opsContext.AdminOpsClient, reconcile.Result{}, nil
+}
+
+// getAdminOpsContext returns the admin ops context of the object store of the bucket, or nil if
+// the cluster is not ready yet
+func getAdminOpsContext(ctx context.Context, c client.Client, context *clusterd.Context, ob *bktv1alpha1.ObjectBucket, controllerName string) (*object.AdminOpsContext, reconcile.Result, error) {
+	storeName, err := GetObjectStoreNameFromBucket(ob)
+	if err != nil {
+		return nil, reconcile.Result{}, errors.Wrapf(err, "failed to get object store from ObjectBucket %q", ob.Name)
+	}
+
+	cephCluster, isReadyToReconcile, _, reconcileResponse := opcontroller.IsReadyToReconcile(ctx, c, types.NamespacedName{Namespace: storeName.Namespace}, controllerName)
+	if !isReadyToReconcile {
+		logger.Debugf("ceph cluster in namespace %q is not ready to check ObjectBucket %q", storeName.Namespace, ob.Name)
+		return nil, reconcileResponse, nil
+	}
+	clusterInfo, _, _, err := opcontroller.LoadClusterInfo(context, ctx, cephCluster.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return nil, reconcile.Result{}, errors.Wrap(err, "failed to populate cluster info")
+	}
+
+	store := &cephv1.CephObjectStore{}
+	if err := c.Get(ctx, storeName, store); err != nil {
+		return nil, reconcile.Result{}, errors.Wrapf(err, "failed to get CephObjectStore %q", storeName)
+	}
+	objContext, err := object.NewMultisiteContext(context, clusterInfo, store)
+	if err != nil {
+		return nil, reconcile.Result{}, errors.Wrapf(err, "failed to get object context for CephObjectStore %q", storeName)
+	}
+	opsContext, err := object.NewMultisiteAdminOpsContext(objContext, &store.Spec)
+	if err != nil {
+		return nil, reconcile.Result{}, errors.Wrapf(err, "failed to get admin ops context for CephObjectStore %q", storeName)
+	}
+	return opsContext, reconcile.Result{}, nil
+}
+
+// checkQuotas returns the usage of the bucket against its quota, and the usage of its owner
+// against the user quota
+func checkQuotas(ctx context.Context, client *admin.API, bucketName, userID string, threshold int32, now metav1.Time) (*cephv1.QuotaStatus, *cephv1.QuotaStatus, error) {
+	bucket, err := client.GetBucketInfo(ctx, admin.Bucket{Bucket: bucketName})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get the stats of bucket %q", bucketName)
+	}
+	bucketQuota := object.NewQuotaStatus(bucket.BucketQuota, deref(bucket.Usage.RgwMain.SizeActual), deref(bucket.Usage.RgwMain.NumObjects), threshold, now)
+
+	generateStat := true
+	user, err := client.GetUser(ctx, admin.User{ID: userID, GenerateStat: &generateStat})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get the stats of user %q", userID)
+	}
+	size := deref(user.Stat.Size)
+	if user.Stat.SizeRounded != nil {
+		size = *user.Stat.SizeRounded
+	}
+	userQuota := object.NewQuotaStatus(user.UserQuota, size, deref(user.Stat.NumObjects), threshold, now)
+
+	return bucketQuota, userQuota, nil
+}
+
+func deref(value *uint64) uint64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
+func (r *ReconcileBucketQuota) recordQuotaEvent(obc *bktv1alpha1.ObjectBucketClaim, description string, previous, current *cephv1.QuotaStatus) {
+	if eventType, reason, message, ok := object.QuotaEvent(description, previous, current); ok {
+		logger.Infof("ObjectBucketClaim \"%s/%s\": %s", obc.Namespace, obc.Name, message)
+		r.recorder.Event(obc, eventType, reason, message)
+	}
+}
+
+// getQuotaStatus returns the quota status of the bucket and of its user reported in the status of
+// the ObjectBucket
+func getQuotaStatus(ob *unstructured.Unstructured) (*cephv1.QuotaStatus, *cephv1.QuotaStatus, error) {
+	statuses := []*cephv1.QuotaStatus{}
+	for _, field := range []string{bucketQuotaStatusField, userQuotaStatusField} {
+		value, found, err := unstructured.NestedMap(ob.Object, "status", field)
+		if err != nil || !found {
+			statuses = append(statuses, nil)
+			continue
+		}
+		status := &cephv1.QuotaStatus{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(value, status); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse status.%s", field)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses[0], statuses[1], nil
+}
+
+// setQuotaStatus sets or removes the quota status of the bucket and of its user in the status of
+// the ObjectBucket
+func setQuotaStatus(ob *unstructured.Unstructured, bucketQuota, userQuota *cephv1.QuotaStatus) error {
+	for field, status := range map[string]*cephv1.QuotaStatus{bucketQuotaStatusField: bucketQuota, userQuotaStatusField: userQuota} {
+		if status == nil {
+			unstructured.RemoveNestedField(ob.Object, "status", field)
+			continue
+		}
+		value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(status)
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert status.%s", field)
+		}
+		if err := unstructured.SetNestedMap(ob.Object, value, "status", field); err != nil {
+			return errors.Wrapf(err, "failed to set status.%s", field)
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileBucketQuota) updateQuotaStatus(name string, bucketQuota, userQuota *cephv1.QuotaStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ob := &unstructured.Unstructured{}
+		ob.SetGroupVersionKind(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"))
+		if err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: name}, ob); err != nil {
+			return err
+		}
+		if err := setQuotaStatus(ob, bucketQuota, userQuota); err != nil {
+			return err
+		}
+		return r.client.Status().Update(r.opManagerContext, ob)
+	})
+}
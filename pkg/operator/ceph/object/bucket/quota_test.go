@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestCheckQuotas(t *testing.T) {
+	bucketResponse := `{"bucket":"my-bucket","owner":"obc-user","usage":{"rgw.main":{"size_actual":900,"num_objects":9}},"bucket_quota":{"enabled":true,"max_size":1000,"max_objects":-1}}`
+	userResponse := `{"user_id":"obc-user","user_quota":{"enabled":false,"max_size":-1,"max_objects":-1},"stats":{"size":900,"size_rounded":4096,"num_objects":9}}`
+
+	var requests []string
+	mockClient := &object.MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, fmt.Sprintf("%s %s", req.URL.Path, req.URL.RawQuery))
+			body := ""
+			switch req.URL.Path {
+			case "rgw.test/admin/bucket":
+				body = bucketResponse
+			case "rgw.test/admin/user":
+				body = userResponse
+			default:
+				return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+		},
+	}
+	adminClient, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+
+	now := metav1.Now()
+	bucketQuota, userQuota, err := checkQuotas(context.TODO(), adminClient, "my-bucket", "obc-user", 85, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"rgw.test/admin/bucket bucket=my-bucket&format=json",
+		"rgw.test/admin/user format=json&stats=true&uid=obc-user",
+	}, requests)
+	assert.Equal(t, &cephv1.QuotaStatus{
+		MaxSize: &(&struct{ i int64 }{1000}).i, Size: 900, Objects: 9, PercentUsed: 90, NearFullThreshold: 85, State: cephv1.QuotaStateNearFull, LastChecked: &now,
+	}, bucketQuota)
+	// the user quota is disabled
+	assert.Nil(t, userQuota)
+}
+
+func TestQuotaStatus(t *testing.T) {
+	ob := &unstructured.Unstructured{Object: map[string]interface{}{"status": map[string]interface{}{"phase": "Bound"}}}
+
+	bucketQuota, userQuota, err := getQuotaStatus(ob)
+	assert.NoError(t, err)
+	assert.Nil(t, bucketQuota)
+	assert.Nil(t, userQuota)
+
+	now := metav1.NewTime(metav1.Now().Rfc3339Copy().Time)
+	quota := &cephv1.QuotaStatus{MaxObjects: &(&struct{ i int64 }{10}).i, Objects: 9, PercentUsed: 90, NearFullThreshold: 85, State: cephv1.QuotaStateNearFull, LastChecked: &now}
+	require.NoError(t, setQuotaStatus(ob, nil, quota))
+	bucketQuota, userQuota, err = getQuotaStatus(ob)
+	assert.NoError(t, err)
+	assert.Nil(t, bucketQuota)
+	assert.True(t, now.Equal(userQuota.LastChecked))
+	userQuota.LastChecked = quota.LastChecked
+	assert.Equal(t, quota, userQuota)
+	// the status of the bucket library is preserved
+	assert.Equal(t, "Bound", ob.Object["status"].(map[string]interface{})["phase"])
+
+	require.NoError(t, setQuotaStatus(ob, nil, nil))
+	assert.Equal(t, map[string]interface{}{"phase": "Bound"}, ob.Object["status"])
+}
+
+func TestReconcileBucketQuotaRemoved(t *testing.T) {
+	ctx := context.TODO()
+	obc := &bktv1alpha1.ObjectBucketClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-obc", Namespace: "my-app"},
+		Spec:       bktv1alpha1.ObjectBucketClaimSpec{ObjectBucketName: "obc-my-app-my-obc"},
+	}
+	ob := &unstructured.Unstructured{}
+	ob.SetGroupVersionKind(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"))
+	ob.SetName("obc-my-app-my-obc")
+	ob.SetLabels(map[string]string{bucketProvisionerLabelKey: "rook-ceph.ceph.rook.io-bucket"})
+	require.NoError(t, setQuotaStatus(ob, &cephv1.QuotaStatus{State: cephv1.QuotaStateNearFull}, nil))
+
+	// the ObjectBucket is stored unstructured, as the typed ObjectBucket drops the quota status
+	s := runtime.NewScheme()
+	s.AddKnownTypes(bktv1alpha1.SchemeGroupVersion, &bktv1alpha1.ObjectBucketClaim{}, &bktv1alpha1.ObjectBucketClaimList{})
+	s.AddKnownTypeWithName(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucket"), &unstructured.Unstructured{})
+	s.AddKnownTypeWithName(bktv1alpha1.SchemeGroupVersion.WithKind("ObjectBucketList"), &unstructured.UnstructuredList{})
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(obc, ob).WithStatusSubresource(ob).Build()
+	recorder := record.NewFakeRecorder(5)
+	r := &ReconcileBucketQuota{client: cl, opManagerContext: ctx, recorder: recorder}
+
+	getOB := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(ob.GroupVersionKind())
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: "obc-my-app-my-obc"}, u))
+		return u
+	}
+	bucketQuota, _, err := getQuotaStatus(getOB())
+	require.NoError(t, err)
+	require.NotNil(t, bucketQuota)
+
+	// the quota was removed from the claim, the status is cleared without contacting the object store
+	res, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "my-obc", Namespace: "my-app"}})
+	assert.NoError(t, err)
+	assert.True(t, res.IsZero())
+	assert.Empty(t, recorder.Events)
+
+	bucketQuota, _, err = getQuotaStatus(getOB())
+	assert.NoError(t, err)
+	assert.Nil(t, bucketQuota)
+}
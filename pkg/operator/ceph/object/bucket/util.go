@@ -17,8 +17,11 @@ limitations under the License.
 package bucket
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/coreos/pkg/capnslog"
 	bktv1alpha1 "github.com/kube-object-storage/lib-bucket-provisioner/pkg/apis/objectbucket.io/v1alpha1"
 	"github.com/kube-object-storage/lib-bucket-provisioner/pkg/provisioner"
@@ -32,6 +35,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 )
 
 var logger = capnslog.NewPackageLogger("github.com/rook/rook", "op-bucket-prov")
@@ -41,6 +45,9 @@ const (
 	ObjectStoreName      = "objectStoreName"
 	ObjectStoreNamespace = "objectStoreNamespace"
 	objectStoreEndpoint  = "endpoint"
+	// placement and default storage class of the new buckets, set in the StorageClass parameters
+	bucketPlacement             = "placement"
+	bucketPlacementStorageClass = "placementStorageClass"
 )
 
 func NewBucketController(cfg *rest.Config, p *Provisioner) (*provisioner.Provisioner, error) {
@@ -62,6 +69,10 @@ func getObjectStoreEndpoint(sc *storagev1.StorageClass) string {
 	return sc.Parameters[objectStoreEndpoint]
 }
 
+func getBucketPlacement(sc *storagev1.StorageClass) (string, string) {
+	return sc.Parameters[bucketPlacement], sc.Parameters[bucketPlacementStorageClass]
+}
+
 func getBucketName(ob *bktv1alpha1.ObjectBucket) string {
 	return ob.Spec.Endpoint.BucketName
 }
@@ -157,9 +168,207 @@ func additionalConfigSpecFromMap(config map[string]string) (*additionalConfigSpe
 		spec.bucketOwner = &bucketOwner
 	}
 
+	spec.bucketMaxReadOps, err = rateLimitFromMap(config, "bucketMaxReadOps")
+	if err != nil {
+		return nil, err
+	}
+	spec.bucketMaxWriteOps, err = rateLimitFromMap(config, "bucketMaxWriteOps")
+	if err != nil {
+		return nil, err
+	}
+	spec.bucketMaxReadBytes, err = rateLimitFromMap(config, "bucketMaxReadBytes")
+	if err != nil {
+		return nil, err
+	}
+	spec.bucketMaxWriteBytes, err = rateLimitFromMap(config, "bucketMaxWriteBytes")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := config["quotaNearFullThreshold"]; ok {
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("quotaNearFullThreshold") {
+			return nil, errors.Errorf("OBC config %q is not allowed", "quotaNearFullThreshold")
+		}
+		threshold, err := strconv.ParseInt(config["quotaNearFullThreshold"], 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse quotaNearFullThreshold")
+		}
+		if threshold < 1 || threshold > 100 {
+			return nil, errors.Errorf("invalid quotaNearFullThreshold %d, must be between 1 and 100", threshold)
+		}
+		spec.quotaNearFullThreshold = ptr.To(int32(threshold))
+	}
+
+	if _, ok := config["bucketNumShards"]; ok {
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketNumShards") {
+			return nil, errors.Errorf("OBC config %q is not allowed", "bucketNumShards")
+		}
+		numShards, err := strconv.ParseInt(config["bucketNumShards"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse bucketNumShards")
+		}
+		if numShards < 1 || numShards > cephObject.MaxBucketIndexShards {
+			return nil, errors.Errorf("invalid bucketNumShards %d, must be between 1 and %d", numShards, cephObject.MaxBucketIndexShards)
+		}
+		spec.bucketNumShards = &numShards
+	}
+
+	if _, ok := config["bucketVersioning"]; ok {
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketVersioning") {
+			return nil, errors.Errorf("OBC config %q is not allowed", "bucketVersioning")
+		}
+		versioning := config["bucketVersioning"]
+		if versioning != s3.BucketVersioningStatusEnabled && versioning != s3.BucketVersioningStatusSuspended {
+			return nil, errors.Errorf("invalid bucketVersioning %q, must be %q or %q", versioning, s3.BucketVersioningStatusEnabled, s3.BucketVersioningStatusSuspended)
+		}
+		spec.bucketVersioning = &versioning
+	}
+
+	if _, ok := config["bucketObjectLockMode"]; ok {
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketObjectLockMode") {
+			return nil, errors.Errorf("OBC config %q is not allowed", "bucketObjectLockMode")
+		}
+		mode := config["bucketObjectLockMode"]
+		if mode != s3.ObjectLockRetentionModeGovernance && mode != s3.ObjectLockRetentionModeCompliance {
+			return nil, errors.Errorf("invalid bucketObjectLockMode %q, must be %q or %q", mode, s3.ObjectLockRetentionModeGovernance, s3.ObjectLockRetentionModeCompliance)
+		}
+		spec.bucketObjectLockMode = &mode
+	}
+
+	if _, ok := config["bucketObjectLockRetentionDays"]; ok {
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketObjectLockRetentionDays") {
+			return nil, errors.Errorf("OBC config %q is not allowed", "bucketObjectLockRetentionDays")
+		}
+		days, err := strconv.ParseInt(config["bucketObjectLockRetentionDays"], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse bucketObjectLockRetentionDays")
+		}
+		if days < 1 {
+			return nil, errors.Errorf("invalid bucketObjectLockRetentionDays %d, must be at least 1", days)
+		}
+		spec.bucketObjectLockRetentionDays = &days
+	}
+
+	if _, ok := config["bucketEncryption"]; ok {
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketEncryption") {
+			return nil, errors.Errorf("OBC config %q is not allowed", "bucketEncryption")
+		}
+		algorithm := config["bucketEncryption"]
+		if algorithm != string(cephv1.BucketEncryptionSSES3) && algorithm != string(cephv1.BucketEncryptionSSEKMS) {
+			return nil, errors.Errorf("invalid bucketEncryption %q, must be %q or %q", algorithm, cephv1.BucketEncryptionSSES3, cephv1.BucketEncryptionSSEKMS)
+		}
+		spec.bucketEncryption = &algorithm
+	}
+
+	if _, ok := config["bucketEncryptionKeyID"]; ok {
+		if !opcontroller.ObcAdditionalConfigKeyIsAllowed("bucketEncryptionKeyID") {
+			return nil, errors.Errorf("OBC config %q is not allowed", "bucketEncryptionKeyID")
+		}
+		keyID := config["bucketEncryptionKeyID"]
+		if keyID == "" {
+			return nil, errors.New("bucketEncryptionKeyID cannot be empty")
+		}
+		// the key is only used by SSE-KMS
+		if spec.bucketEncryption == nil || *spec.bucketEncryption != string(cephv1.BucketEncryptionSSEKMS) {
+			return nil, errors.Errorf("bucketEncryptionKeyID requires bucketEncryption %q", cephv1.BucketEncryptionSSEKMS)
+		}
+		spec.bucketEncryptionKeyID = &keyID
+	}
+
+	// the default retention of object lock needs both a mode and a period
+	if (spec.bucketObjectLockMode == nil) != (spec.bucketObjectLockRetentionDays == nil) {
+		return nil, errors.New("bucketObjectLockMode and bucketObjectLockRetentionDays must be set together")
+	}
+	// object lock requires the versioning of the bucket
+	if spec.bucketObjectLockMode != nil && spec.bucketVersioning != nil && *spec.bucketVersioning != s3.BucketVersioningStatusEnabled {
+		return nil, errors.Errorf("bucketVersioning must be %q with object lock", s3.BucketVersioningStatusEnabled)
+	}
+
 	return &spec, nil
 }
 
+// withDenyUnencryptedUploads returns the bucket policy with the statement denying the uploads that
+// do not request server-side encryption
+func withDenyUnencryptedUploads(bucketName string, policy *string) (*string, error) {
+	statement := cephObject.DenyUnencryptedUploadsStatement(bucketName)
+	if policy == nil {
+		serialized, err := json.Marshal(cephObject.NewBucketPolicy(*statement))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to serialize bucket policy")
+		}
+		return ptr.To(string(serialized)), nil
+	}
+
+	// the policy set on the OBC is kept as is, its statements may use fields that
+	// cephObject.BucketPolicy does not know about
+	doc := map[string]interface{}{}
+	err := json.Unmarshal([]byte(*policy), &doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse bucket policy")
+	}
+	var statements []interface{}
+	switch s := doc["Statement"].(type) {
+	case []interface{}:
+		statements = s
+	case map[string]interface{}:
+		// a policy with a single statement may omit the list
+		statements = []interface{}{s}
+	}
+
+	serialized, err := json.Marshal(statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize bucket policy statement")
+	}
+	var denyStatement interface{}
+	err = json.Unmarshal(serialized, &denyStatement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse bucket policy statement")
+	}
+	mergedStatements := []interface{}{}
+	for _, s := range statements {
+		if m, ok := s.(map[string]interface{}); ok && m["Sid"] == cephObject.DenyUnencryptedUploadsSid {
+			continue
+		}
+		mergedStatements = append(mergedStatements, s)
+	}
+	doc["Statement"] = append(mergedStatements, denyStatement)
+
+	serialized, err = json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize bucket policy")
+	}
+	return ptr.To(string(serialized)), nil
+}
+
+var bucketRateLimitConfigKeys = []string{"bucketMaxReadOps", "bucketMaxWriteOps", "bucketMaxReadBytes", "bucketMaxWriteBytes"}
+
+// bucketRateLimitAllowed returns whether any of the bucket rate limit options may be set on OBCs
+func bucketRateLimitAllowed() bool {
+	for _, key := range bucketRateLimitConfigKeys {
+		if opcontroller.ObcAdditionalConfigKeyIsAllowed(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func rateLimitFromMap(config map[string]string, key string) (*int64, error) {
+	if _, ok := config[key]; !ok {
+		return nil, nil
+	}
+	if !opcontroller.ObcAdditionalConfigKeyIsAllowed(key) {
+		return nil, errors.Errorf("OBC config %q is not allowed", key)
+	}
+	limit, err := quanityToInt64(config[key])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s rate limit", key)
+	}
+	if *limit < 0 {
+		return nil, errors.Errorf("invalid %s rate limit %d, must not be negative", key, *limit)
+	}
+	return limit, nil
+}
+
 func GetObjectStoreNameFromBucket(ob *bktv1alpha1.ObjectBucket) (types.NamespacedName, error) {
 	// Rook v1.11 OBCs have additional state labels that tell the object store namespace and name.
 	// This is critical for CephObjectStores in external mode that connect to RGW endpoints directly
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BucketIndexCheckInterval is the interval at which the index shards of buckets are checked
+	BucketIndexCheckInterval = time.Hour
+	// BucketReshardCheckInterval is the interval at which the index shards of a bucket are checked
+	// while it is resharded
+	BucketReshardCheckInterval = 5 * time.Minute
+	// MaxBucketIndexShards is the maximum number of shards of a bucket index allowed by rgw
+	MaxBucketIndexShards = 65521
+
+	// BucketIndexNearLimitReason is the reason of the event emitted when the index shards of a
+	// bucket are close to the maximum number of objects per shard
+	BucketIndexNearLimitReason = "BucketIndexNearLimit"
+	// BucketIndexOverLimitReason is the reason of the event emitted when the index shards of a
+	// bucket are over the maximum number of objects per shard
+	BucketIndexOverLimitReason = "BucketIndexOverLimit"
+	// BucketIndexBelowLimitReason is the reason of the event emitted when the index shards of a
+	// bucket that were near or over the limit have room again
+	BucketIndexBelowLimitReason = "BucketIndexBelowLimit"
+	// BucketReshardScheduledReason is the reason of the event emitted when a bucket is added to the
+	// reshard queue
+	BucketReshardScheduledReason = "BucketReshardScheduled"
+)
+
+type rgwBucketLimit struct {
+	Bucket          string `json:"bucket"`
+	NumObjects      int64  `json:"num_objects"`
+	NumShards       int64  `json:"num_shards"`
+	ObjectsPerShard int64  `json:"objects_per_shard"`
+	FillStatus      string `json:"fill_status"`
+}
+
+type rgwUserBucketLimits struct {
+	UserID  string           `json:"user_id"`
+	Buckets []rgwBucketLimit `json:"buckets"`
+}
+
+type rgwReshardEntry struct {
+	BucketName string `json:"bucket_name"`
+}
+
+// GetBucketIndexStatus returns the sharding of the index of the bucket owned by the user, from
+// `radosgw-admin bucket limit check`
+func GetBucketIndexStatus(c *Context, userID, bucketName string, now metav1.Time) (*cephv1.BucketIndexStatus, error) {
+	output, err := runAdminCommand(c, true, "bucket", "limit", "check", "--uid", userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check the index limits of the buckets of user %q", userID)
+	}
+	var users []rgwUserBucketLimits
+	if err := json.Unmarshal([]byte(output), &users); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the index limits of the buckets of user %q. %s", userID, output)
+	}
+	for _, user := range users {
+		for _, bucket := range user.Buckets {
+			if bucket.Bucket != bucketName {
+				continue
+			}
+			return &cephv1.BucketIndexStatus{
+				NumShards:       bucket.NumShards,
+				Objects:         bucket.NumObjects,
+				ObjectsPerShard: bucket.ObjectsPerShard,
+				FillStatus:      bucket.FillStatus,
+				State:           bucketIndexState(bucket.FillStatus),
+				LastChecked:     &now,
+			}, nil
+		}
+	}
+	return nil, errors.Errorf("bucket %q not found in the index limits of the buckets of user %q", bucketName, userID)
+}
+
+// bucketIndexState converts the fill status of rgw, "OK", "WARN <percent>%" or "OVER <percent>%"
+func bucketIndexState(fillStatus string) cephv1.BucketIndexState {
+	switch {
+	case strings.HasPrefix(fillStatus, "OVER"):
+		return cephv1.BucketIndexStateOverLimit
+	case strings.HasPrefix(fillStatus, "WARN"):
+		return cephv1.BucketIndexStateNearLimit
+	default:
+		return cephv1.BucketIndexStateOk
+	}
+}
+
+// IsBucketResharding returns whether the bucket is in the reshard queue. The entry of a bucket is
+// removed from the queue once its reshard completes.
+func IsBucketResharding(c *Context, bucketName string) (bool, error) {
+	output, err := runAdminCommand(c, true, "reshard", "list")
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list the reshard queue")
+	}
+	var entries []rgwReshardEntry
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		return false, errors.Wrapf(err, "failed to parse the reshard queue. %s", output)
+	}
+	for _, entry := range entries {
+		if entry.BucketName == bucketName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ScheduleBucketReshard adds the bucket to the reshard queue to change the number of shards of
+// its index. The reshard is done in the background by the rgws since it can take a long time for
+// large buckets.
+func ScheduleBucketReshard(c *Context, bucketName string, numShards int64) error {
+	_, err := runAdminCommand(c, false, "reshard", "add", "--bucket", bucketName, "--num-shards", strconv.FormatInt(numShards, 10))
+	if err != nil {
+		return errors.Wrapf(err, "failed to add bucket %q to the reshard queue with %d shards", bucketName, numShards)
+	}
+	logger.Infof("added bucket %q to the reshard queue with %d shards", bucketName, numShards)
+	return nil
+}
+
+// BucketIndexEvent returns the event to emit when the state of the index shards of a bucket
+// changed between the previous and the current check
+func BucketIndexEvent(previous, current *cephv1.BucketIndexStatus) (eventType, reason, message string, ok bool) {
+	previousState := cephv1.BucketIndexStateOk
+	if previous != nil {
+		previousState = previous.State
+	}
+	if current == nil || previousState == current.State {
+		return "", "", "", false
+	}
+
+	switch current.State {
+	case cephv1.BucketIndexStateOverLimit:
+		return corev1.EventTypeWarning, BucketIndexOverLimitReason,
+			fmt.Sprintf("bucket index is over the limit of objects per shard with %d objects per shard in %d shards (%s), the bucket needs to be resharded", current.ObjectsPerShard, current.NumShards, current.FillStatus), true
+	case cephv1.BucketIndexStateNearLimit:
+		if previousState == cephv1.BucketIndexStateOverLimit {
+			return "", "", "", false
+		}
+		return corev1.EventTypeWarning, BucketIndexNearLimitReason,
+			fmt.Sprintf("bucket index is near the limit of objects per shard with %d objects per shard in %d shards (%s)", current.ObjectsPerShard, current.NumShards, current.FillStatus), true
+	default:
+		return corev1.EventTypeNormal, BucketIndexBelowLimitReason,
+			fmt.Sprintf("bucket index is below the limit of objects per shard with %d objects per shard in %d shards", current.ObjectsPerShard, current.NumShards), true
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetBucketIndexStatus(t *testing.T) {
+	limitCheck := `[
+    {
+        "user_id": "obc-user",
+        "buckets": [
+            {"bucket": "other", "tenant": "", "num_objects": 10, "num_shards": 11, "objects_per_shard": 0, "fill_status": "OK"},
+            {"bucket": "my-bucket", "tenant": "", "num_objects": 1100000, "num_shards": 11, "objects_per_shard": 100000, "fill_status": "OVER 100.000000%"}
+        ]
+    }
+]`
+	now := metav1.Now()
+
+	var commands []string
+	c := newRateLimitTestContext(&commands, limitCheck)
+	status, err := GetBucketIndexStatus(c, "obc-user", "my-bucket", now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bucket limit check --uid obc-user"}, commands)
+	assert.Equal(t, &cephv1.BucketIndexStatus{
+		NumShards: 11, Objects: 1100000, ObjectsPerShard: 100000, FillStatus: "OVER 100.000000%", State: cephv1.BucketIndexStateOverLimit, LastChecked: &now,
+	}, status)
+
+	_, err = GetBucketIndexStatus(c, "obc-user", "missing", now)
+	assert.Error(t, err)
+}
+
+func TestBucketIndexState(t *testing.T) {
+	assert.Equal(t, cephv1.BucketIndexStateOk, bucketIndexState("OK"))
+	assert.Equal(t, cephv1.BucketIndexStateNearLimit, bucketIndexState("WARN 90.000000%"))
+	assert.Equal(t, cephv1.BucketIndexStateOverLimit, bucketIndexState("OVER 120.000000%"))
+}
+
+func TestBucketReshard(t *testing.T) {
+	t.Run("resharding", func(t *testing.T) {
+		var commands []string
+		c := newRateLimitTestContext(&commands, `[{"time": "2025-01-01T00:00:00Z", "tenant": "", "bucket_name": "my-bucket", "old_num_shards": 11, "tentative_new_num_shards": 101}]`)
+		resharding, err := IsBucketResharding(c, "my-bucket")
+		assert.NoError(t, err)
+		assert.True(t, resharding)
+		resharding, err = IsBucketResharding(c, "other")
+		assert.NoError(t, err)
+		assert.False(t, resharding)
+		assert.Equal(t, []string{"reshard list", "reshard list"}, commands)
+	})
+
+	t.Run("schedule", func(t *testing.T) {
+		var commands []string
+		c := newRateLimitTestContext(&commands, "")
+		assert.NoError(t, ScheduleBucketReshard(c, "my-bucket", 101))
+		assert.Equal(t, []string{"reshard add --bucket my-bucket --num-shards 101"}, commands)
+	})
+}
+
+func TestBucketIndexEvent(t *testing.T) {
+	ok := &cephv1.BucketIndexStatus{State: cephv1.BucketIndexStateOk, NumShards: 11}
+	near := &cephv1.BucketIndexStatus{State: cephv1.BucketIndexStateNearLimit, NumShards: 11, ObjectsPerShard: 90000, FillStatus: "WARN 90.000000%"}
+	over := &cephv1.BucketIndexStatus{State: cephv1.BucketIndexStateOverLimit, NumShards: 11, ObjectsPerShard: 110000, FillStatus: "OVER 110.000000%"}
+
+	_, _, _, emitted := BucketIndexEvent(nil, ok)
+	assert.False(t, emitted)
+	_, _, _, emitted = BucketIndexEvent(near, near)
+	assert.False(t, emitted)
+
+	eventType, reason, message, emitted := BucketIndexEvent(nil, near)
+	assert.True(t, emitted)
+	assert.Equal(t, corev1.EventTypeWarning, eventType)
+	assert.Equal(t, BucketIndexNearLimitReason, reason)
+	assert.Contains(t, message, "90000 objects per shard in 11 shards")
+
+	eventType, reason, _, emitted = BucketIndexEvent(near, over)
+	assert.True(t, emitted)
+	assert.Equal(t, corev1.EventTypeWarning, eventType)
+	assert.Equal(t, BucketIndexOverLimitReason, reason)
+
+	// the index was resharded, but is still near the limit
+	_, _, _, emitted = BucketIndexEvent(over, near)
+	assert.False(t, emitted)
+
+	eventType, reason, _, emitted = BucketIndexEvent(over, ok)
+	assert.True(t, emitted)
+	assert.Equal(t, corev1.EventTypeNormal, eventType)
+	assert.Equal(t, BucketIndexBelowLimitReason, reason)
+}
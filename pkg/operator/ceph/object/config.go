@@ -197,6 +197,11 @@ func (c *clusterConfig) generateMonConfigOptions(rgwConfig *rgwConfig) (map[stri
 		return configOptions, err
 	}
 
+	configOptions, err = configureSTS(rgwConfig, configOptions)
+	if err != nil {
+		return configOptions, err
+	}
+
 	if s3 := rgwConfig.Protocols.S3; s3 != nil {
 		if s3.AuthUseKeystone != nil {
 			configOptions["rgw_s3_auth_use_keystone"] = fmt.Sprintf("%t", *s3.AuthUseKeystone)
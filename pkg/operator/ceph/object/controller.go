@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -95,6 +96,8 @@ type ReconcileCephObjectStore struct {
 	recorder         record.EventRecorder
 	opManagerContext context.Context
 	opConfig         opcontroller.OperatorConfig
+	// usage exporters of the object stores, by namespaced name
+	usageExporters map[string]*usageExporter
 }
 
 // Add creates a new cephObjectStore Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -146,7 +149,10 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 			mgr.GetCache(),
 			&cephv1.CephObjectStore{TypeMeta: controllerTypeMeta},
 			&handler.TypedEnqueueRequestForObject[*cephv1.CephObjectStore]{},
-			opcontroller.WatchControllerPredicate[*cephv1.CephObjectStore](mgr.GetScheme()),
+			predicate.Or[*cephv1.CephObjectStore](
+				opcontroller.WatchControllerPredicate[*cephv1.CephObjectStore](mgr.GetScheme()),
+				orphanScanAnnotationPredicate(),
+			),
 		),
 	)
 	if err != nil {
@@ -261,6 +267,10 @@ func isObjStoreSpecContainsSecret(spec *cephv1.ObjectStoreSpec, secret *corev1.S
 	if spec.Auth.Keystone != nil && spec.Auth.Keystone.ServiceUserSecretName == secret.Name {
 		return true
 	}
+	// check if secret is referred in object store STS key secret:
+	if spec.Auth.STS != nil && spec.Auth.STS.KeySecretName == secret.Name {
+		return true
+	}
 	return false
 }
 
@@ -390,6 +400,7 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 			clusterInfo: r.clusterInfo,
 		}
 		cfg.deleteStore()
+		r.reconcileUsageExporter(cephObjectStore)
 
 		// Remove finalizer
 		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStore)
@@ -467,10 +478,11 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 		client:                r.client,
 		ownerInfo:             ownerInfo,
 		shouldRotateCephxKeys: shouldRotateCephxKeys,
+		rookImage:             r.opConfig.Image,
 	}
 
 	// CREATE/UPDATE
-	_, err = r.reconcileCreateObjectStore(cephObjectStore, request.NamespacedName, cfg)
+	reconcileResponse, err = r.reconcileCreateObjectStore(cephObjectStore, request.NamespacedName, cfg)
 	if err != nil && kerrors.IsNotFound(err) {
 		// A not found error may mean ceph is still initializing, but there might be some other error
 		// so we log the error and requeue
@@ -481,14 +493,34 @@ func (r *ReconcileCephObjectStore) reconcile(request reconcile.Request) (reconci
 		return result, *cephObjectStore, err
 	}
 
+	if !cephObjectStore.Spec.IsExternal() {
+		r.reconcileUsageExporter(cephObjectStore)
+
+		// a failed orphan scan does not fail the object store, it is reported in the orphan scan status
+		orphanScanResponse, err := r.reconcileOrphanScan(cephObjectStore, &cephCluster)
+		if err != nil {
+			logger.Errorf("failed to reconcile the orphan scan of object store %q. %v", request.NamespacedName.String(), err)
+		} else if reconcileResponse.IsZero() {
+			reconcileResponse = orphanScanResponse
+		}
+
+		// a failed bucket migration does not fail the object store, it is reported in the migration status
+		migrationResponse, err := r.reconcileMigration(cephObjectStore, &cephCluster)
+		if err != nil {
+			logger.Errorf("failed to reconcile the migration of object store %q. %v", request.NamespacedName.String(), err)
+		} else if reconcileResponse.IsZero() || (migrationResponse.RequeueAfter > 0 && migrationResponse.RequeueAfter < reconcileResponse.RequeueAfter) {
+			reconcileResponse = migrationResponse
+		}
+	}
+
 	// update ObservedGeneration in status at the end of reconcile
 	// Set Progressing status, we are done reconciling, the health check go routine will update the status
 	cephxStatus := keyring.UpdatedCephxStatus(shouldRotateCephxKeys, cephCluster.Spec.Security.CephX.Daemon, r.clusterInfo.CephVersion, cephObjectStore.Status.Cephx.Daemon)
 	updateStatus(r.opManagerContext, observedGeneration, r.client, request.NamespacedName, cephv1.ConditionReady, buildStatusInfo(cephObjectStore), &cephxStatus)
 
-	// Return and do not requeue
+	// Return and requeue only if some settings could not be applied until the RGWs are running
 	logger.Debug("done reconciling")
-	return reconcile.Result{}, *cephObjectStore, nil
+	return reconcileResponse, *cephObjectStore, nil
 }
 
 func (r *ReconcileCephObjectStore) reconcileCreateObjectStore(cephObjectStore *cephv1.CephObjectStore, namespacedName types.NamespacedName, cfg clusterConfig) (reconcile.Result, error) {
@@ -573,6 +605,13 @@ func (r *ReconcileCephObjectStore) reconcileCreateObjectStore(cephObjectStore *c
 			return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "failed to reconcile service", err)
 		}
 
+		// RECONCILE INGRESS/HTTPROUTE
+		logger.Debug("reconciling object store exposure")
+		err = cfg.reconcileExpose()
+		if err != nil {
+			return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "failed to expose object store", err)
+		}
+
 		if err := UpdateEndpointForAdminOps(objContext, cephObjectStore); err != nil {
 			return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "failed to set endpoint", err)
 		}
@@ -581,6 +620,12 @@ func (r *ReconcileCephObjectStore) reconcileCreateObjectStore(cephObjectStore *c
 		if err != nil {
 			return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "invalid pool configuration", err)
 		}
+		if err := validateMigration(cephObjectStore); err != nil {
+			return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "invalid migration", err)
+		}
+		if err := validateBucketEncryption(cephObjectStore); err != nil {
+			return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "invalid bucket encryption", err)
+		}
 		// Reconcile Pool Creation
 		if !cephObjectStore.Spec.IsMultisite() {
 			logger.Info("reconciling object store pools")
@@ -616,11 +661,64 @@ func (r *ReconcileCephObjectStore) reconcileCreateObjectStore(cephObjectStore *c
 		if err != nil {
 			return reconcile.Result{}, errors.Wrapf(err, "failed to create object store %q", cephObjectStore.Name)
 		}
+
+		// Only touch the global rate limits if they are configured, or were configured before and
+		// must now be disabled, so that limits set manually with radosgw-admin are left alone
+		if cephObjectStore.Spec.RateLimits != nil || (cephObjectStore.Status != nil && cephObjectStore.Status.RateLimits != nil) {
+			rateLimits, err := reconcileGlobalRateLimits(objContext, cephObjectStore.Spec.RateLimits)
+			if err != nil {
+				return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, namespacedName, "failed to set the global rate limits", err)
+			}
+			updateRateLimitStatus(r.opManagerContext, r.client, namespacedName, rateLimits)
+		}
+
+		// Only touch the OIDC providers if STS is configured, or if providers were configured
+		// before and must now be deleted
+		if cephObjectStore.Spec.Auth.STS != nil || (cephObjectStore.Status != nil && len(cephObjectStore.Status.OIDCProviders) > 0) {
+			err = r.reconcileOIDCProviders(objContext, cephObjectStore, namespacedName)
+			if err != nil {
+				// the IAM API is not available until the RGWs are running, so retry until they are
+				logger.Infof("object store %q OIDC providers are not configured yet, retrying in %s. %v",
+					namespacedName.String(), waitForRequeueIfObjectStoreNotReady.RequeueAfter.String(), err)
+				return waitForRequeueIfObjectStoreNotReady, nil
+			}
+		}
 	}
 
 	return reconcile.Result{}, nil
 }
 
+func (r *ReconcileCephObjectStore) reconcileOIDCProviders(objContext *Context, cephObjectStore *cephv1.CephObjectStore, namespacedName types.NamespacedName) error {
+	if err := EnableAdminOpsUserIAMCaps(objContext); err != nil {
+		return err
+	}
+	opsContext, err := NewMultisiteAdminOpsContext(objContext, &cephObjectStore.Spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get admin ops API context")
+	}
+	iamClient, err := NewIAMClientFunc(opsContext, &cephObjectStore.Spec)
+	if err != nil {
+		return err
+	}
+
+	var specs []cephv1.OIDCProviderSpec
+	if cephObjectStore.Spec.Auth.STS != nil {
+		specs = cephObjectStore.Spec.Auth.STS.OIDCProviders
+	}
+	var previousARNs []string
+	if cephObjectStore.Status != nil {
+		previousARNs = cephObjectStore.Status.OIDCProviders
+	}
+	arns, err := reconcileOIDCProviders(iamClient, specs, previousARNs)
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(arns, previousARNs) {
+		updateOIDCProviderStatus(r.opManagerContext, r.client, namespacedName, arns)
+	}
+	return nil
+}
+
 func (r *ReconcileCephObjectStore) retrieveMultisiteZone(store *cephv1.CephObjectStore, zoneGroupName string, realmName string) (reconcile.Result, error) {
 	realmArg := fmt.Sprintf("--rgw-realm=%s", realmName)
 	zoneGroupArg := fmt.Sprintf("--rgw-zonegroup=%s", zoneGroupName)
@@ -68,7 +68,7 @@ func CephObjectStoreDependents(
 		if !deps.Empty() {
 			// zone is master with peers; require user intervention
 			logger.Errorf("%s. Either change the master or remove its peers before the deletion", zoneIsMasterWithPeersDependentType)
-			return deps, errors.Errorf("%s: %q\nall peer zones must be deleted, or a peer zone must be manually set as the master zone after that peer has all necessary data synched to it", zoneIsMasterWithPeersDependentType, deps.OfKind(zoneIsMasterWithPeersDependentType))
+			return deps, errors.Errorf("%s: %q\nall peer zones must be deleted, or a peer zone must be promoted to master with the failover of the CephObjectZoneGroup after that peer has all necessary data synched to it", zoneIsMasterWithPeersDependentType, deps.OfKind(zoneIsMasterWithPeersDependentType))
 		}
 
 		// this is a master zone with NO peers. it is the last store  that has this data,
@@ -94,6 +94,28 @@ func CephObjectStoreDependents(
 		logger.Debugf("found CephObjectStoreUser %q that does not depend on CephObjectStore %q", user.Name, nsName)
 	}
 
+	// CephObjectStoreRoles
+	roles, err := clusterdCtx.RookClientset.CephV1().CephObjectStoreRoles(store.Namespace).List(clusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return deps, errors.Wrapf(err, "%s. failed to list CephObjectStoreRoles for CephObjectStore %q", baseErrMsg, nsName)
+	}
+	for _, role := range roles.Items {
+		if role.Spec.Store == store.Name {
+			deps.Add("CephObjectStoreRoles", role.Name)
+		}
+	}
+
+	// CephObjectStoreScripts
+	scripts, err := clusterdCtx.RookClientset.CephV1().CephObjectStoreScripts(store.Namespace).List(clusterInfo.Context, metav1.ListOptions{})
+	if err != nil {
+		return deps, errors.Wrapf(err, "%s. failed to list CephObjectStoreScripts for CephObjectStore %q", baseErrMsg, nsName)
+	}
+	for _, script := range scripts.Items {
+		if script.Spec.Store == store.Name {
+			deps.Add("CephObjectStoreScripts", script.Name)
+		}
+	}
+
 	return deps, nil
 }
 
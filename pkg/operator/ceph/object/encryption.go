@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+)
+
+const (
+	// DenyUnencryptedUploadsSid is the ID of the bucket policy statement denying the uploads that
+	// do not request server-side encryption
+	DenyUnencryptedUploadsSid = "RookDenyUnencryptedUploads"
+
+	serverSideEncryptionConditionKey = "s3:x-amz-server-side-encryption"
+)
+
+// DenyUnencryptedUploadsStatement returns the bucket policy statement denying the uploads to the
+// bucket that do not request server-side encryption
+func DenyUnencryptedUploadsStatement(bucket string) *PolicyStatement {
+	return NewPolicyStatement().
+		WithSID(DenyUnencryptedUploadsSid).
+		Denies().
+		ForAllPrincipals().
+		Actions(PutObject).
+		ForSubResources(bucket).
+		WithCondition("Null", serverSideEncryptionConditionKey, "true")
+}
+
+func validateBucketEncryption(store *cephv1.CephObjectStore) error {
+	security := store.Spec.Security
+	if security == nil || security.BucketEncryption == nil {
+		return nil
+	}
+
+	switch security.BucketEncryption.DefaultAlgorithm {
+	case "":
+	case cephv1.BucketEncryptionSSES3:
+		if !security.ServerSideEncryptionS3.IsEnabled() {
+			return errors.New("the SSE-S3 default bucket encryption requires the s3 security settings")
+		}
+	case cephv1.BucketEncryptionSSEKMS:
+		if !security.KeyManagementService.IsVaultKMS() {
+			return errors.New("the SSE-KMS default bucket encryption requires the vault kms security settings")
+		}
+	default:
+		return errors.Errorf("invalid default bucket encryption %q", security.BucketEncryption.DefaultAlgorithm)
+	}
+	return nil
+}
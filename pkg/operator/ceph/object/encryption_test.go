@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDenyUnencryptedUploadsStatement(t *testing.T) {
+	out, err := json.Marshal(DenyUnencryptedUploadsStatement("my-bucket"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Sid": "RookDenyUnencryptedUploads",
+		"Effect": "Deny",
+		"Principal": {"AWS": ["*"]},
+		"Action": ["s3:PutObject"],
+		"Resource": ["arn:aws:s3:::my-bucket/*"],
+		"Condition": {"Null": {"s3:x-amz-server-side-encryption": "true"}}
+	}`, string(out))
+}
+
+func TestValidateBucketEncryption(t *testing.T) {
+	vault := cephv1.KeyManagementServiceSpec{ConnectionDetails: map[string]string{"KMS_PROVIDER": "vault"}}
+	store := func(security *cephv1.ObjectStoreSecuritySpec) *cephv1.CephObjectStore {
+		return &cephv1.CephObjectStore{Spec: cephv1.ObjectStoreSpec{Security: security}}
+	}
+
+	assert.NoError(t, validateBucketEncryption(store(nil)))
+	assert.NoError(t, validateBucketEncryption(store(&cephv1.ObjectStoreSecuritySpec{})))
+	assert.NoError(t, validateBucketEncryption(store(&cephv1.ObjectStoreSecuritySpec{
+		BucketEncryption: &cephv1.BucketEncryptionSpec{RequireEncryption: true},
+	})))
+
+	t.Run("SSE-S3", func(t *testing.T) {
+		security := &cephv1.ObjectStoreSecuritySpec{
+			BucketEncryption: &cephv1.BucketEncryptionSpec{DefaultAlgorithm: cephv1.BucketEncryptionSSES3},
+		}
+		assert.Error(t, validateBucketEncryption(store(security)))
+		security.ServerSideEncryptionS3 = vault
+		assert.NoError(t, validateBucketEncryption(store(security)))
+	})
+
+	t.Run("SSE-KMS", func(t *testing.T) {
+		security := &cephv1.ObjectStoreSecuritySpec{
+			BucketEncryption: &cephv1.BucketEncryptionSpec{DefaultAlgorithm: cephv1.BucketEncryptionSSEKMS},
+		}
+		assert.Error(t, validateBucketEncryption(store(security)))
+		security.KeyManagementService = vault
+		assert.NoError(t, validateBucketEncryption(store(security)))
+	})
+
+	t.Run("invalid algorithm", func(t *testing.T) {
+		security := &cephv1.ObjectStoreSecuritySpec{
+			BucketEncryption: &cephv1.BucketEncryptionSpec{DefaultAlgorithm: "AES"},
+		}
+		assert.Error(t, validateBucketEncryption(store(security)))
+	})
+}
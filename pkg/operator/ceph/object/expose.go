@@ -0,0 +1,410 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	exposedHTTPPort  = int32(80)
+	exposedHTTPSPort = int32(443)
+	ingressKind      = "Ingress"
+)
+
+var (
+	// the Gateway API and cert-manager types are not vendored, their objects are handled unstructured
+	httpRouteGVK   = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+)
+
+func exposeTLSSecretName(s *cephv1.CephObjectStore) string {
+	return instanceName(s.Name) + "-tls"
+}
+
+// exposedHosts returns the hosts on which the object store is exposed, each DNS name and its
+// wildcard for virtual-host style bucket access
+func exposedHosts(s *cephv1.CephObjectStore) []string {
+	hosts := []string{}
+	for _, d := range s.Spec.Hosting.DNSNames {
+		hosts = append(hosts, d, "*."+d)
+	}
+	return hosts
+}
+
+// exposedTLSSecret returns the secret of the certificate of the exposed hosts, the certificate of
+// the gateways if set or else the certificate requested from cert-manager
+func exposedTLSSecret(s *cephv1.CephObjectStore) string {
+	if s.Spec.Gateway.SSLCertificateRef != "" {
+		return s.Spec.Gateway.SSLCertificateRef
+	}
+	if s.Spec.Hosting.Expose.CertManager != nil {
+		return exposeTLSSecretName(s)
+	}
+	return ""
+}
+
+// exposedServicePort returns the port of the service the external traffic is sent to
+func exposedServicePort(s *cephv1.CephObjectStore) int32 {
+	if s.Spec.Gateway.Port != 0 {
+		return s.Spec.Gateway.Port
+	}
+	return s.Spec.Gateway.SecurePort
+}
+
+func isExposed(s *cephv1.CephObjectStore) bool {
+	return s.Spec.Hosting != nil && s.Spec.Hosting.Expose != nil
+}
+
+// getExposedEndpoints returns the external URLs of the object store. They use HTTPS when a
+// certificate is known for the exposed hosts.
+func getExposedEndpoints(s *cephv1.CephObjectStore) (insecure, secure []string) {
+	if !isExposed(s) {
+		return nil, nil
+	}
+	for _, d := range s.Spec.Hosting.DNSNames {
+		if exposedTLSSecret(s) != "" {
+			secure = append(secure, BuildDNSEndpoint(d, exposedHTTPSPort, true))
+		} else {
+			insecure = append(insecure, BuildDNSEndpoint(d, exposedHTTPPort, false))
+		}
+	}
+	return insecure, secure
+}
+
+func validateExpose(s *cephv1.CephObjectStore) error {
+	if !isExposed(s) {
+		return nil
+	}
+	if len(s.Spec.Hosting.DNSNames) == 0 {
+		return errors.New("hosting.dnsNames must be set to expose the object store")
+	}
+	if exposedServicePort(s) == 0 {
+		return errors.New("gateway.port or gateway.securePort must be set to expose the object store")
+	}
+	return nil
+}
+
+func (c *clusterConfig) makeIngress() *networkingv1.Ingress {
+	spec := c.store.Spec.Hosting.Expose.Ingress
+	pathType := networkingv1.PathTypePrefix
+	backend := networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: instanceName(c.store.Name),
+			Port: networkingv1.ServiceBackendPort{Number: exposedServicePort(c.store)},
+		},
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instanceName(c.store.Name),
+			Namespace: c.store.Namespace,
+			Labels:    getLabels(c.store.Name, c.store.Namespace, true),
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: spec.IngressClassName,
+		},
+	}
+	spec.Annotations.ApplyToObjectMeta(&ingress.ObjectMeta)
+	hosts := exposedHosts(c.store)
+	for _, host := range hosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{Path: "/", PathType: &pathType, Backend: backend}},
+				},
+			},
+		})
+	}
+	if secretName := exposedTLSSecret(c.store); secretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: hosts, SecretName: secretName}}
+	}
+	return ingress
+}
+
+func (c *clusterConfig) makeHTTPRoute() *unstructured.Unstructured {
+	spec := c.store.Spec.Hosting.Expose.HTTPRoute
+	parentRefs := []interface{}{}
+	for _, ref := range spec.ParentRefs {
+		parentRef := map[string]interface{}{"name": ref.Name}
+		if ref.Namespace != "" {
+			parentRef["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			parentRef["sectionName"] = ref.SectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+	hostnames := []interface{}{}
+	for _, host := range exposedHosts(c.store) {
+		hostnames = append(hostnames, host)
+	}
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(httpRouteGVK)
+	route.SetName(instanceName(c.store.Name))
+	route.SetNamespace(c.store.Namespace)
+	route.SetLabels(getLabels(c.store.Name, c.store.Namespace, true))
+	if len(spec.Annotations) > 0 {
+		route.SetAnnotations(spec.Annotations)
+	}
+	route.Object["spec"] = map[string]interface{}{
+		"parentRefs": parentRefs,
+		"hostnames":  hostnames,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": instanceName(c.store.Name),
+						"port": int64(exposedServicePort(c.store)),
+					},
+				},
+			},
+		},
+	}
+	return route
+}
+
+func (c *clusterConfig) makeCertificate() *unstructured.Unstructured {
+	issuerRef := c.store.Spec.Hosting.Expose.CertManager.IssuerRef
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+	dnsNames := []interface{}{}
+	for _, host := range exposedHosts(c.store) {
+		dnsNames = append(dnsNames, host)
+	}
+
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetName(exposeTLSSecretName(c.store))
+	certificate.SetNamespace(c.store.Namespace)
+	certificate.SetLabels(getLabels(c.store.Name, c.store.Namespace, true))
+	certificate.Object["spec"] = map[string]interface{}{
+		"secretName": exposeTLSSecretName(c.store),
+		"dnsNames":   dnsNames,
+		"issuerRef": map[string]interface{}{
+			"name":  issuerRef.Name,
+			"kind":  kind,
+			"group": certificateGVK.Group,
+		},
+	}
+	return certificate
+}
+
+// reconcileExpose creates or updates the Ingress or HTTPRoute of the object store and its
+// cert-manager certificate, and deletes the ones that are not configured anymore. Only the resources
+// recorded in the status are deleted, so that the object stores that are not exposed do not need
+// the permissions on these resources.
+func (c *clusterConfig) reconcileExpose() error {
+	if err := validateExpose(c.store); err != nil {
+		return errors.Wrap(err, "invalid expose configuration")
+	}
+	expose := &cephv1.ObjectStoreExposeSpec{}
+	if isExposed(c.store) {
+		expose = c.store.Spec.Hosting.Expose
+	}
+
+	desired := map[string]bool{
+		certificateGVK.Kind: expose.CertManager != nil && c.store.Spec.Gateway.SSLCertificateRef == "",
+		httpRouteGVK.Kind:   expose.HTTPRoute != nil,
+		ingressKind:         expose.Ingress != nil,
+	}
+	created := map[string]bool{}
+	if c.store.Status != nil {
+		for _, kind := range c.store.Status.ExposedResources {
+			created[kind] = true
+		}
+	}
+	// the resources are recorded before they are created, to be deleted even if the reconcile fails
+	if err := c.updateExposedResources(exposedResources(desired, created)); err != nil {
+		return err
+	}
+
+	if desired[certificateGVK.Kind] {
+		if err := c.createOrUpdateUnstructured(c.makeCertificate()); err != nil {
+			return errors.Wrapf(err, "failed to reconcile the certificate of object store %q", c.store.Name)
+		}
+	} else if created[certificateGVK.Kind] {
+		if err := c.deleteUnstructured(certificateGVK, exposeTLSSecretName(c.store)); err != nil {
+			return errors.Wrapf(err, "failed to delete the certificate of object store %q", c.store.Name)
+		}
+	}
+
+	if desired[httpRouteGVK.Kind] {
+		if err := c.createOrUpdateUnstructured(c.makeHTTPRoute()); err != nil {
+			return errors.Wrapf(err, "failed to reconcile the http route of object store %q", c.store.Name)
+		}
+	} else if created[httpRouteGVK.Kind] {
+		if err := c.deleteUnstructured(httpRouteGVK, instanceName(c.store.Name)); err != nil {
+			return errors.Wrapf(err, "failed to delete the http route of object store %q", c.store.Name)
+		}
+	}
+
+	if desired[ingressKind] {
+		if err := c.createOrUpdateIngress(c.makeIngress()); err != nil {
+			return errors.Wrapf(err, "failed to reconcile the ingress of object store %q", c.store.Name)
+		}
+	} else if created[ingressKind] {
+		err := c.context.Clientset.NetworkingV1().Ingresses(c.store.Namespace).Delete(c.clusterInfo.Context, instanceName(c.store.Name), metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete the ingress of object store %q", c.store.Name)
+		}
+	}
+
+	return c.updateExposedResources(exposedResources(desired, nil))
+}
+
+// exposedResources returns the kinds of the resources in any of the sets, in a stable order
+func exposedResources(sets ...map[string]bool) []string {
+	kinds := []string{}
+	for _, kind := range []string{certificateGVK.Kind, httpRouteGVK.Kind, ingressKind} {
+		for _, set := range sets {
+			if set[kind] {
+				kinds = append(kinds, kind)
+				break
+			}
+		}
+	}
+	return kinds
+}
+
+// updateExposedResources records the kinds of the resources created to expose the object store in
+// its status
+func (c *clusterConfig) updateExposedResources(kinds []string) error {
+	var current []string
+	if c.store.Status != nil {
+		current = c.store.Status.ExposedResources
+	}
+	if (len(kinds) == 0 && len(current) == 0) || reflect.DeepEqual(kinds, current) {
+		return nil
+	}
+
+	namespacedName := types.NamespacedName{Name: c.store.Name, Namespace: c.store.Namespace}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := c.client.Get(c.clusterInfo.Context, namespacedName, objectStore); err != nil {
+			return err
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.ExposedResources = kinds
+		return reporting.UpdateStatus(c.client, objectStore)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the exposed resources of object store %q", namespacedName.String())
+	}
+	if c.store.Status == nil {
+		c.store.Status = &cephv1.ObjectStoreStatus{}
+	}
+	c.store.Status.ExposedResources = kinds
+	return nil
+}
+
+func (c *clusterConfig) createOrUpdateIngress(ingress *networkingv1.Ingress) error {
+	err := c.ownerInfo.SetControllerReference(ingress)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set owner reference for ingress %q", ingress.Name)
+	}
+	client := c.context.Clientset.NetworkingV1().Ingresses(c.store.Namespace)
+	existing, err := client.Get(c.clusterInfo.Context, ingress.Name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get ingress %q", ingress.Name)
+		}
+		_, err = client.Create(c.clusterInfo.Context, ingress, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create ingress %q", ingress.Name)
+		}
+		logger.Infof("created ingress %q for object store %q", ingress.Name, c.store.Name)
+		return nil
+	}
+	existing.Labels = ingress.Labels
+	existing.Annotations = ingress.Annotations
+	existing.OwnerReferences = ingress.OwnerReferences
+	existing.Spec = ingress.Spec
+	_, err = client.Update(c.clusterInfo.Context, existing, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update ingress %q", ingress.Name)
+	}
+	logger.Debugf("updated ingress %q for object store %q", ingress.Name, c.store.Name)
+	return nil
+}
+
+func (c *clusterConfig) createOrUpdateUnstructured(obj *unstructured.Unstructured) error {
+	kind := obj.GetKind()
+	err := c.ownerInfo.SetControllerReference(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set owner reference for %s %q", kind, obj.GetName())
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err = c.client.Get(c.clusterInfo.Context, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return errors.Wrapf(err, "the %s CRD is not installed", obj.GroupVersionKind().GroupKind())
+		}
+		if !kerrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get %s %q", kind, obj.GetName())
+		}
+		err = c.client.Create(c.clusterInfo.Context, obj)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create %s %q", kind, obj.GetName())
+		}
+		logger.Infof("created %s %q for object store %q", kind, obj.GetName(), c.store.Name)
+		return nil
+	}
+	existing.SetLabels(obj.GetLabels())
+	existing.SetAnnotations(obj.GetAnnotations())
+	existing.SetOwnerReferences(obj.GetOwnerReferences())
+	existing.Object["spec"] = obj.Object["spec"]
+	err = c.client.Update(c.clusterInfo.Context, existing)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update %s %q", kind, obj.GetName())
+	}
+	logger.Debugf("updated %s %q for object store %q", kind, obj.GetName(), c.store.Name)
+	return nil
+}
+
+// deleteUnstructured deletes an object that is not configured anymore. The CRD of the object does
+// not need to be installed when it is not used.
+func (c *clusterConfig) deleteUnstructured(gvk schema.GroupVersionKind, name string) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(c.store.Namespace)
+	err := c.client.Delete(c.clusterInfo.Context, obj)
+	if err != nil && !kerrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExposeObjectStore(t *testing.T) {
+	ctx := context.TODO()
+	clientset := fake.NewSimpleClientset()
+	store := simpleStore()
+	store.Spec.Gateway.Port = 80
+	s := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(s))
+	cl := ctrlfake.NewClientBuilder().WithScheme(s).WithObjects(store.DeepCopy()).WithStatusSubresource(store).Build()
+	c := &clusterConfig{
+		store:       store,
+		context:     &clusterd.Context{Clientset: clientset},
+		clusterInfo: &cephclient.ClusterInfo{Context: ctx, Namespace: store.Namespace},
+		ownerInfo:   k8sutil.NewOwnerInfoWithOwnerRef(&metav1.OwnerReference{Name: store.Name, UID: "uid"}, store.Namespace),
+		client:      cl,
+	}
+	getUnstructured := func(gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: store.Namespace}, obj)
+		return obj, err
+	}
+
+	getExposedResources := func() []string {
+		current := &cephv1.CephObjectStore{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		if current.Status == nil {
+			return nil
+		}
+		return current.Status.ExposedResources
+	}
+
+	t.Run("not exposed", func(t *testing.T) {
+		// the resources that were never created are not deleted, the operator may not be allowed to
+		clientset.PrependReactor("delete", "ingresses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, kerrors.NewForbidden(schema.GroupResource{Group: "networking.k8s.io", Resource: "ingresses"}, "rook-ceph-rgw-default", errors.New("forbidden"))
+		})
+		require.NoError(t, c.reconcileExpose())
+		clientset.ReactionChain = clientset.ReactionChain[1:]
+		assert.Empty(t, getExposedResources())
+		_, err := clientset.NetworkingV1().Ingresses(store.Namespace).Get(ctx, "rook-ceph-rgw-default", metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+		insecure, secure := getExposedEndpoints(store)
+		assert.Empty(t, insecure)
+		assert.Empty(t, secure)
+	})
+
+	t.Run("dns names are required", func(t *testing.T) {
+		store.Spec.Hosting = &cephv1.ObjectStoreHostingSpec{Expose: &cephv1.ObjectStoreExposeSpec{Ingress: &cephv1.ObjectStoreIngressSpec{}}}
+		assert.Error(t, c.reconcileExpose())
+	})
+
+	t.Run("ingress without tls", func(t *testing.T) {
+		class := "nginx"
+		store.Spec.Hosting.DNSNames = []string{"s3.example.com"}
+		store.Spec.Hosting.Expose.Ingress = &cephv1.ObjectStoreIngressSpec{IngressClassName: &class, Annotations: cephv1.Annotations{"a": "b"}}
+		require.NoError(t, c.reconcileExpose())
+		ingress, err := clientset.NetworkingV1().Ingresses(store.Namespace).Get(ctx, "rook-ceph-rgw-default", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+		assert.Equal(t, "b", ingress.Annotations["a"])
+		require.Len(t, ingress.Spec.Rules, 2)
+		assert.Equal(t, "s3.example.com", ingress.Spec.Rules[0].Host)
+		assert.Equal(t, "*.s3.example.com", ingress.Spec.Rules[1].Host)
+		backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+		assert.Equal(t, "rook-ceph-rgw-default", backend.Name)
+		assert.Equal(t, int32(80), backend.Port.Number)
+		assert.Empty(t, ingress.Spec.TLS)
+		assert.Len(t, ingress.OwnerReferences, 1)
+		assert.Equal(t, []string{"Ingress"}, getExposedResources())
+
+		insecure, secure := getExposedEndpoints(store)
+		assert.Equal(t, []string{"http://s3.example.com:80"}, insecure)
+		assert.Empty(t, secure)
+	})
+
+	t.Run("ingress with cert-manager", func(t *testing.T) {
+		store.Spec.Hosting.Expose.CertManager = &cephv1.ObjectStoreCertManagerSpec{IssuerRef: cephv1.ObjectStoreIssuerRef{Name: "letsencrypt", Kind: "ClusterIssuer"}}
+		require.NoError(t, c.reconcileExpose())
+		ingress, err := clientset.NetworkingV1().Ingresses(store.Namespace).Get(ctx, "rook-ceph-rgw-default", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Len(t, ingress.Spec.TLS, 1)
+		assert.Equal(t, "rook-ceph-rgw-default-tls", ingress.Spec.TLS[0].SecretName)
+		assert.Equal(t, []string{"s3.example.com", "*.s3.example.com"}, ingress.Spec.TLS[0].Hosts)
+
+		certificate, err := getUnstructured(certificateGVK, "rook-ceph-rgw-default-tls")
+		require.NoError(t, err)
+		secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+		assert.Equal(t, "rook-ceph-rgw-default-tls", secretName)
+		dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+		assert.Equal(t, []string{"s3.example.com", "*.s3.example.com"}, dnsNames)
+		kind, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "kind")
+		assert.Equal(t, "ClusterIssuer", kind)
+		assert.Equal(t, []string{"Certificate", "Ingress"}, getExposedResources())
+
+		insecure, secure := getExposedEndpoints(store)
+		assert.Empty(t, insecure)
+		assert.Equal(t, []string{"https://s3.example.com:443"}, secure)
+	})
+
+	t.Run("the gateway certificate is used when set", func(t *testing.T) {
+		store.Spec.Gateway.SSLCertificateRef = "my-cert"
+		require.NoError(t, c.reconcileExpose())
+		ingress, err := clientset.NetworkingV1().Ingresses(store.Namespace).Get(ctx, "rook-ceph-rgw-default", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "my-cert", ingress.Spec.TLS[0].SecretName)
+		_, err = getUnstructured(certificateGVK, "rook-ceph-rgw-default-tls")
+		assert.True(t, kerrors.IsNotFound(err))
+		store.Spec.Gateway.SSLCertificateRef = ""
+	})
+
+	t.Run("http route replaces the ingress", func(t *testing.T) {
+		store.Spec.Hosting.Expose.Ingress = nil
+		store.Spec.Hosting.Expose.HTTPRoute = &cephv1.ObjectStoreHTTPRouteSpec{
+			ParentRefs: []cephv1.ObjectStoreGatewayRef{{Name: "gw", Namespace: "infra", SectionName: "https"}},
+		}
+		require.NoError(t, c.reconcileExpose())
+		_, err := clientset.NetworkingV1().Ingresses(store.Namespace).Get(ctx, "rook-ceph-rgw-default", metav1.GetOptions{})
+		assert.True(t, kerrors.IsNotFound(err))
+
+		route, err := getUnstructured(httpRouteGVK, "rook-ceph-rgw-default")
+		require.NoError(t, err)
+		hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		assert.Equal(t, []string{"s3.example.com", "*.s3.example.com"}, hostnames)
+		parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+		require.Len(t, parentRefs, 1)
+		assert.Equal(t, map[string]interface{}{"name": "gw", "namespace": "infra", "sectionName": "https"}, parentRefs[0])
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		require.Len(t, rules, 1)
+		backendRefs, _, _ := unstructured.NestedSlice(rules[0].(map[string]interface{}), "backendRefs")
+		require.Len(t, backendRefs, 1)
+		assert.Equal(t, "rook-ceph-rgw-default", backendRefs[0].(map[string]interface{})["name"])
+		assert.Len(t, route.GetOwnerReferences(), 1)
+		assert.Equal(t, []string{"Certificate", "HTTPRoute"}, getExposedResources())
+	})
+
+	t.Run("exposure removed", func(t *testing.T) {
+		store.Spec.Hosting.Expose = nil
+		require.NoError(t, c.reconcileExpose())
+		_, err := getUnstructured(httpRouteGVK, "rook-ceph-rgw-default")
+		assert.True(t, kerrors.IsNotFound(err))
+		_, err = getUnstructured(certificateGVK, "rook-ceph-rgw-default-tls")
+		assert.True(t, kerrors.IsNotFound(err))
+		assert.Empty(t, getExposedResources())
+	})
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/util/exec"
+)
+
+// LuaScriptHash returns the hash of a Lua script, as reported in the status of the script
+func LuaScriptHash(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+// luaScriptArgs returns the radosgw-admin arguments selecting the script of the context and tenant.
+// radosgw-admin expects the context in lower case.
+func luaScriptArgs(context cephv1.ObjectStoreScriptContext, tenant string) []string {
+	args := []string{fmt.Sprintf("--context=%s", strings.ToLower(string(context)))}
+	if tenant != "" {
+		args = append(args, fmt.Sprintf("--tenant=%s", tenant))
+	}
+	return args
+}
+
+// GetLuaScript returns the Lua script loaded for the context and tenant, or an empty string if no
+// script is loaded
+func GetLuaScript(c *Context, context cephv1.ObjectStoreScriptContext, tenant string) (string, error) {
+	output, err := runAdminCommand(c, false, append([]string{"script", "get"}, luaScriptArgs(context, tenant)...)...)
+	if err != nil {
+		// ENOENT means "No such file or directory"
+		if code, ok := exec.ExitStatus(err); ok && code == int(syscall.ENOENT) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get the %s lua script", context)
+	}
+	// radosgw-admin prints a message instead of failing when there is no script
+	if strings.HasPrefix(output, "no script exists") {
+		return "", nil
+	}
+	return output, nil
+}
+
+// PutLuaScript loads the Lua script for the context and tenant, replacing the script that was
+// loaded before
+func PutLuaScript(c *Context, context cephv1.ObjectStoreScriptContext, tenant, script string) error {
+	scriptFilename := path.Join(c.Context.ConfigDir, fmt.Sprintf("%s.%s.lua", c.Name, strings.ToLower(string(context))))
+	if err := os.WriteFile(scriptFilename, []byte(script), 0o600); err != nil {
+		return errors.Wrap(err, "failed to write lua script file")
+	}
+	defer os.Remove(scriptFilename)
+
+	args := append([]string{"script", "put", "--infile=" + scriptFilename}, luaScriptArgs(context, tenant)...)
+	if _, err := runAdminCommand(c, false, args...); err != nil {
+		return errors.Wrapf(err, "failed to put the %s lua script", context)
+	}
+	return nil
+}
+
+// RemoveLuaScript removes the Lua script of the context and tenant. Removing a script that does
+// not exist succeeds.
+func RemoveLuaScript(c *Context, context cephv1.ObjectStoreScriptContext, tenant string) error {
+	_, err := runAdminCommand(c, false, append([]string{"script", "rm"}, luaScriptArgs(context, tenant)...)...)
+	if err != nil {
+		if code, ok := exec.ExitStatus(err); ok && code == int(syscall.ENOENT) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to remove the %s lua script", context)
+	}
+	return nil
+}
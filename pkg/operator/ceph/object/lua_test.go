@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuaScriptArgs(t *testing.T) {
+	assert.Equal(t, []string{"--context=prerequest"}, luaScriptArgs(cephv1.ObjectStoreScriptContextPreRequest, ""))
+	assert.Equal(t, []string{"--context=putdata", "--tenant=team-a"}, luaScriptArgs(cephv1.ObjectStoreScriptContextPutData, "team-a"))
+}
+
+func TestPutLuaScript(t *testing.T) {
+	var uploaded string
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			assert.Equal(t, []string{"script", "put"}, args[:2])
+			assert.Equal(t, "--context=background", args[3])
+			content, err := os.ReadFile(strings.TrimPrefix(args[2], "--infile="))
+			assert.NoError(t, err)
+			uploaded = string(content)
+			return "", nil
+		},
+	}
+	c := NewContext(&clusterd.Context{Executor: executor, ConfigDir: t.TempDir()}, cephclient.AdminTestClusterInfo("ns"), "my-store")
+	assert.NoError(t, PutLuaScript(c, cephv1.ObjectStoreScriptContextBackground, "", "RGW_DEBUG_LOG('hello')"))
+	assert.Equal(t, "RGW_DEBUG_LOG('hello')", uploaded)
+}
+
+func TestGetLuaScript(t *testing.T) {
+	newContext := func(output string, exitCode int) *Context {
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+				if exitCode != 0 {
+					return "", exectest.MockExecCommandReturns(t, "", "", exitCode)
+				}
+				return output, nil
+			},
+		}
+		return NewContext(&clusterd.Context{Executor: executor}, cephclient.AdminTestClusterInfo("ns"), "")
+	}
+
+	script, err := GetLuaScript(newContext("RGW_DEBUG_LOG('hello')\n", 0), cephv1.ObjectStoreScriptContextPreRequest, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "RGW_DEBUG_LOG('hello')\n", script)
+
+	script, err = GetLuaScript(newContext("no script exists for context: prerequest\n", 0), cephv1.ObjectStoreScriptContextPreRequest, "")
+	assert.NoError(t, err)
+	assert.Empty(t, script)
+
+	script, err = GetLuaScript(newContext("", int(syscall.ENOENT)), cephv1.ObjectStoreScriptContextPreRequest, "")
+	assert.NoError(t, err)
+	assert.Empty(t, script)
+
+	assert.NoError(t, RemoveLuaScript(newContext("", int(syscall.ENOENT)), cephv1.ObjectStoreScriptContextPreRequest, ""))
+}
+
+func TestLuaScriptHash(t *testing.T) {
+	assert.Len(t, LuaScriptHash("a"), 64)
+	assert.Equal(t, LuaScriptHash("a"), LuaScriptHash("a"))
+	assert.NotEqual(t, LuaScriptHash("a"), LuaScriptHash("b"))
+}
@@ -0,0 +1,420 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/cleanup"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// the interval at which the migration job of a bucket is checked until it completes
+	migrationCheckInterval = time.Minute
+	// the number of objects of a bucket copied in parallel when not set in the spec
+	defaultMigrationConcurrency = 4
+	// the buckets whose migration failed are reported in the status up to this number
+	maxReportedFailedBuckets = 10
+)
+
+// the job and the admin ops API are overridden in the unit tests
+var (
+	startBucketMigrationJobFunc    = startBucketMigrationJob
+	newMigrationAdminOpsClientFunc = newMigrationAdminOpsClient
+)
+
+func migrationJobName(storeName string) string {
+	return k8sutil.TruncateNodeNameForJob("rook-ceph-rgw-%s-migration", storeName)
+}
+
+// migrationDefaultPlacement returns the placement that is the default placement of the new buckets
+// while the object store is migrated, or an empty string
+func migrationDefaultPlacement(store *cephv1.CephObjectStore) string {
+	if store.Spec.Migration == nil {
+		return ""
+	}
+	return store.Spec.Migration.TargetPlacement
+}
+
+// validateMigration returns an error if the object store cannot be migrated to the target placement
+func validateMigration(store *cephv1.CephObjectStore) error {
+	migration := store.Spec.Migration
+	if migration == nil {
+		return nil
+	}
+	if store.Spec.IsMultisite() {
+		return errors.New("migration is not supported for object stores of a multisite zone")
+	}
+	if migration.TargetPlacement == defaultPlacementCephConfigName {
+		return errors.Errorf("the target placement must not be %q, the placement of the existing buckets", defaultPlacementCephConfigName)
+	}
+	for _, p := range store.Spec.SharedPools.PoolPlacements {
+		if p.Name != migration.TargetPlacement {
+			continue
+		}
+		// the pools of the default placement are copied to 'default-placement', which would move the
+		// existing buckets to pools that do not have their objects
+		if p.Default {
+			return errors.Errorf("the target placement %q must not be marked as default", p.Name)
+		}
+		return nil
+	}
+	return errors.Errorf("the target placement %q is not in sharedPools.poolPlacements", migration.TargetPlacement)
+}
+
+// reconcileMigration migrates the buckets of the object store one after the other while it is
+// migrated to another placement, and reports the progress in the status. The objects of each bucket
+// are copied by a job to a bucket created in the target placement, which then replaces the bucket.
+// The default placement of the new buckets is switched when the zone is configured.
+func (r *ReconcileCephObjectStore) reconcileMigration(store *cephv1.CephObjectStore, cephCluster *cephv1.CephCluster) (reconcile.Result, error) {
+	namespacedName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+	var current *cephv1.ObjectStoreMigrationStatus
+	if store.Status != nil {
+		current = store.Status.Migration
+	}
+
+	migration := store.Spec.Migration
+	if migration == nil {
+		if current == nil {
+			return reconcile.Result{}, nil
+		}
+		// the default placement of the pool placements is restored when the migration is removed
+		return reconcile.Result{}, updateMigrationStatus(r.opManagerContext, r.client, namespacedName, nil)
+	}
+	now := metav1.Now()
+
+	var status *cephv1.ObjectStoreMigrationStatus
+	if current != nil && current.TargetPlacement == migration.TargetPlacement {
+		status = current.DeepCopy()
+	}
+	if status == nil {
+		logger.Infof("placement %q is the default placement of the new buckets of object store %q, migrating the existing buckets", migration.TargetPlacement, namespacedName.String())
+		status = &cephv1.ObjectStoreMigrationStatus{
+			TargetPlacement: migration.TargetPlacement,
+			Phase:           cephv1.MigrationPhaseMigrating,
+			SwitchTime:      &now,
+			StartTime:       &now,
+		}
+		if err := updateMigrationStatus(r.opManagerContext, r.client, namespacedName, status); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if status.Phase != cephv1.MigrationPhaseMigrating {
+		return reconcile.Result{}, nil
+	}
+
+	if bucket := currentMigrationBucket(status); bucket != nil && bucket.Phase == cephv1.MigrationPhaseMigrating {
+		done, err := r.checkBucketMigrationJob(store, status, bucket, now)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !done {
+			return reconcile.Result{RequeueAfter: migrationCheckInterval}, nil
+		}
+		if err := updateMigrationStatus(r.opManagerContext, r.client, namespacedName, status); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if wait := migrationPause(migration, status, now); wait > 0 {
+		return reconcile.Result{RequeueAfter: wait}, nil
+	}
+
+	bucket, err := nextMigrationBucket(r, store, status)
+	if err != nil {
+		// the buckets are listed again on the next reconcile, the migration is not failed
+		return reconcile.Result{}, err
+	}
+	if bucket == "" {
+		status.Phase = cephv1.MigrationPhaseCompleted
+		status.CompletionTime = &now
+		logger.Infof("migration of object store %q to placement %q completed: %d buckets migrated, %d skipped, %d failed",
+			namespacedName.String(), status.TargetPlacement, status.MigratedBuckets, status.SkippedBuckets, status.FailedBuckets)
+		return reconcile.Result{}, updateMigrationStatus(r.opManagerContext, r.client, namespacedName, status)
+	}
+
+	status.LastBucket = bucket
+	bucketStatus := cephv1.ObjectStoreBucketMigrationStatus{Name: bucket, Phase: cephv1.MigrationPhaseMigrating, StartTime: &now}
+	if err := startBucketMigrationJobFunc(r, store, cephCluster, bucket); err != nil {
+		bucketStatus.Phase = cephv1.MigrationPhaseFailed
+		bucketStatus.Message = err.Error()
+		bucketStatus.CompletionTime = &now
+		status.FailedBuckets++
+		setMigrationBucket(status, bucketStatus)
+		if updateErr := updateMigrationStatus(r.opManagerContext, r.client, namespacedName, status); updateErr != nil {
+			logger.Error(updateErr)
+		}
+		return reconcile.Result{}, err
+	}
+	setMigrationBucket(status, bucketStatus)
+	if err := updateMigrationStatus(r.opManagerContext, r.client, namespacedName, status); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: migrationCheckInterval}, nil
+}
+
+// currentMigrationBucket returns the bucket being migrated or last migrated
+func currentMigrationBucket(status *cephv1.ObjectStoreMigrationStatus) *cephv1.ObjectStoreBucketMigrationStatus {
+	for i := range status.Buckets {
+		if status.Buckets[i].Phase != cephv1.MigrationPhaseFailed {
+			return &status.Buckets[i]
+		}
+	}
+	return nil
+}
+
+// setMigrationBucket replaces the bucket being migrated or last migrated in the status, only the
+// last failed buckets are kept
+func setMigrationBucket(status *cephv1.ObjectStoreMigrationStatus, bucket cephv1.ObjectStoreBucketMigrationStatus) {
+	failed := []cephv1.ObjectStoreBucketMigrationStatus{}
+	for _, b := range status.Buckets {
+		if b.Phase == cephv1.MigrationPhaseFailed && b.Name != bucket.Name {
+			failed = append(failed, b)
+		}
+	}
+	if bucket.Phase == cephv1.MigrationPhaseFailed {
+		failed = append(failed, bucket)
+	}
+	if len(failed) > maxReportedFailedBuckets {
+		failed = failed[len(failed)-maxReportedFailedBuckets:]
+	}
+	status.Buckets = failed
+	if bucket.Phase != cephv1.MigrationPhaseFailed {
+		status.Buckets = append([]cephv1.ObjectStoreBucketMigrationStatus{bucket}, failed...)
+	}
+}
+
+// migrationPause returns how long to wait before the migration of the next bucket
+func migrationPause(migration *cephv1.ObjectStoreMigrationSpec, status *cephv1.ObjectStoreMigrationStatus, now metav1.Time) time.Duration {
+	if migration.BucketInterval == nil || migration.BucketInterval.Duration <= 0 {
+		return 0
+	}
+	var last time.Time
+	for _, b := range status.Buckets {
+		if b.CompletionTime != nil && b.CompletionTime.After(last) {
+			last = b.CompletionTime.Time
+		}
+	}
+	if last.IsZero() {
+		return 0
+	}
+	return last.Add(migration.BucketInterval.Duration).Sub(now.Time)
+}
+
+// nextMigrationBucket returns the next bucket to migrate in the order of the bucket names, or an
+// empty string when all the buckets were processed. The buckets already in the target placement,
+// like the buckets created since the migration started, are skipped.
+func nextMigrationBucket(r *ReconcileCephObjectStore, store *cephv1.CephObjectStore, status *cephv1.ObjectStoreMigrationStatus) (string, error) {
+	opsClient, err := newMigrationAdminOpsClientFunc(r, store)
+	if err != nil {
+		return "", err
+	}
+	buckets, err := opsClient.ListBuckets(r.opManagerContext)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list the buckets of object store %q", store.Name)
+	}
+	sort.Strings(buckets)
+	status.TotalBuckets = len(buckets)
+
+	for _, bucket := range buckets {
+		if bucket <= status.LastBucket {
+			continue
+		}
+		// the buckets of the migration of a bucket are not migrated themselves
+		name := bucket[strings.LastIndex(bucket, "/")+1:]
+		if strings.HasPrefix(name, cleanup.MigrationBucketPrefix) || strings.HasPrefix(name, cleanup.MigratedBucketPrefix) {
+			continue
+		}
+		info, err := opsClient.GetBucketInfo(r.opManagerContext, admin.Bucket{Bucket: bucket})
+		if err != nil {
+			if errors.Is(err, admin.ErrNoSuchBucket) {
+				// the bucket was removed since the buckets were listed
+				continue
+			}
+			return "", errors.Wrapf(err, "failed to get the placement of bucket %q", bucket)
+		}
+		// the placement rule of the bucket is <placement>[/<storage class>]
+		if info.PlacementRule == status.TargetPlacement || strings.HasPrefix(info.PlacementRule, status.TargetPlacement+"/") {
+			logger.Debugf("skipping the migration of bucket %q already in placement %q", bucket, status.TargetPlacement)
+			status.SkippedBuckets++
+			status.LastBucket = bucket
+			continue
+		}
+		return bucket, nil
+	}
+	return "", nil
+}
+
+func newMigrationAdminOpsClient(r *ReconcileCephObjectStore, store *cephv1.CephObjectStore) (*admin.API, error) {
+	objContext, err := NewMultisiteContext(r.context, r.clusterInfo, store)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object context")
+	}
+	opsCtx, err := NewMultisiteAdminOpsContext(objContext, &store.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get admin ops API context")
+	}
+	return opsCtx.AdminOpsClient, nil
+}
+
+// migrationLogPool returns the pool where the job saves the policy of the bucket it migrates
+func migrationLogPool(store *cephv1.CephObjectStore, zone string) string {
+	if pool := getDefaultMetadataPool(store.Spec.SharedPools); pool != "" {
+		return pool
+	}
+	return poolName(zone, "rgw.log")
+}
+
+// startBucketMigrationJob starts the job copying the objects of the bucket to the target placement
+func startBucketMigrationJob(r *ReconcileCephObjectStore, store *cephv1.CephObjectStore, cephCluster *cephv1.CephCluster, bucket string) error {
+	objContext, err := NewMultisiteContext(r.context, r.clusterInfo, store)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the multisite configuration of the object store")
+	}
+	endpoint, err := GetAdminOpsEndpoint(store)
+	if err != nil {
+		return err
+	}
+	tlsCert, insecure, err := GetTlsCaCert(objContext, &store.Spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the tls certificate of the object store")
+	}
+	migration := store.Spec.Migration
+	concurrency := migration.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultMigrationConcurrency
+	}
+
+	config := map[string]string{
+		opcontroller.CephObjectStoreBucketEnv:        bucket,
+		opcontroller.CephObjectStoreRealmEnv:         objContext.Realm,
+		opcontroller.CephObjectStoreZoneGroupEnv:     objContext.ZoneGroup,
+		opcontroller.CephObjectStoreZoneEnv:          objContext.Zone,
+		opcontroller.CephObjectStoreLogPoolEnv:       migrationLogPool(store, objContext.Zone),
+		opcontroller.CephObjectStoreEndpointEnv:      endpoint,
+		opcontroller.CephObjectStoreTLSCertEnv:       string(tlsCert),
+		opcontroller.CephObjectStoreInsecureTLSEnv:   fmt.Sprintf("%t", insecure),
+		opcontroller.MigrationTargetPlacementEnv:     migration.TargetPlacement,
+		opcontroller.MigrationConcurrencyEnv:         fmt.Sprintf("%d", concurrency),
+		opcontroller.MigrationMaxObjectsPerSecondEnv: fmt.Sprintf("%d", migration.MaxObjectsPerSecond),
+	}
+	// the subcommand of the job is the kind of the resource
+	resource := store.DeepCopy()
+	resource.TypeMeta = metav1.TypeMeta{Kind: "CephObjectStore", APIVersion: cephv1.SchemeGroupVersion.String()}
+	job := opcontroller.NewResourceCleanup(resource, cephCluster, r.opConfig.Image, config)
+	if err := job.StartJob(r.clusterInfo.Context, r.context.Clientset, migrationJobName(store.Name)); err != nil {
+		return errors.Wrapf(err, "failed to start the migration job of bucket %q", bucket)
+	}
+	logger.Infof("started the migration of bucket %q of object store \"%s/%s\" to placement %q", bucket, store.Namespace, store.Name, migration.TargetPlacement)
+	return nil
+}
+
+// checkBucketMigrationJob updates the status with the result of the migration job of the bucket
+// once it is done
+func (r *ReconcileCephObjectStore) checkBucketMigrationJob(store *cephv1.CephObjectStore, status *cephv1.ObjectStoreMigrationStatus, bucket *cephv1.ObjectStoreBucketMigrationStatus, now metav1.Time) (bool, error) {
+	result := *bucket
+	jobName := migrationJobName(store.Name)
+	job, err := r.context.Clientset.BatchV1().Jobs(store.Namespace).Get(r.opManagerContext, jobName, metav1.GetOptions{})
+	if err != nil && !kerrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "failed to get the bucket migration job %q", jobName)
+	}
+
+	skipped := false
+	switch {
+	case err != nil:
+		result.Phase = cephv1.MigrationPhaseFailed
+		result.Message = fmt.Sprintf("bucket migration job %q not found", jobName)
+	case job.Status.Succeeded > 0:
+		result.Phase = cephv1.MigrationPhaseCompleted
+		message, err := r.jobReport(store.Namespace, jobName)
+		if err != nil {
+			return false, err
+		}
+		report := &cleanup.BucketMigrationReport{}
+		if err := json.Unmarshal([]byte(message), report); err != nil || report.Bucket != result.Name {
+			result.Phase = cephv1.MigrationPhaseFailed
+			result.Message = fmt.Sprintf("failed to read the report of the migration of bucket %q. %s", result.Name, message)
+			break
+		}
+		result.CopiedObjects = report.CopiedObjects
+		result.CopiedBytes = report.CopiedBytes
+		skipped = report.Skipped
+	default:
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				result.Phase = cephv1.MigrationPhaseFailed
+				result.Message = fmt.Sprintf("bucket migration job %q failed. %s", jobName, condition.Message)
+			}
+		}
+	}
+	if result.Phase == cephv1.MigrationPhaseMigrating {
+		logger.Debugf("migration of bucket %q of object store \"%s/%s\" is in progress", result.Name, store.Namespace, store.Name)
+		return false, nil
+	}
+
+	result.CompletionTime = &now
+	switch {
+	case result.Phase == cephv1.MigrationPhaseFailed:
+		logger.Errorf("failed to migrate bucket %q of object store \"%s/%s\". %s", result.Name, store.Namespace, store.Name, result.Message)
+		status.FailedBuckets++
+	case skipped:
+		logger.Infof("bucket %q of object store \"%s/%s\" was already in placement %q", result.Name, store.Namespace, store.Name, status.TargetPlacement)
+		status.SkippedBuckets++
+	default:
+		logger.Infof("migrated bucket %q of object store \"%s/%s\": %d objects of %d bytes copied", result.Name, store.Namespace, store.Name, result.CopiedObjects, result.CopiedBytes)
+		status.MigratedBuckets++
+	}
+	setMigrationBucket(status, result)
+	return true, nil
+}
+
+func updateMigrationStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName, status *cephv1.ObjectStoreMigrationStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := k8sClient.Get(ctx, namespacedName, objectStore); err != nil {
+			return err
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.Migration = status
+		return reporting.UpdateStatus(k8sClient, objectStore)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the migration status of object store %q", namespacedName.String())
+	}
+	return nil
+}
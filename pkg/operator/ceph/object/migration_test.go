@@ -0,0 +1,261 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateMigration(t *testing.T) {
+	placements := []cephv1.PoolPlacementSpec{
+		{Name: "fast", Default: true, MetadataPoolName: "meta", DataPoolName: "fast-data"},
+		{Name: "ec", MetadataPoolName: "meta", DataPoolName: "ec-data"},
+	}
+	tests := []struct {
+		name      string
+		migration *cephv1.ObjectStoreMigrationSpec
+		zone      string
+		err       string
+	}{
+		{name: "no migration"},
+		{name: "valid", migration: &cephv1.ObjectStoreMigrationSpec{TargetPlacement: "ec"}},
+		{name: "unknown placement", migration: &cephv1.ObjectStoreMigrationSpec{TargetPlacement: "slow"}, err: "not in sharedPools.poolPlacements"},
+		{name: "default placement", migration: &cephv1.ObjectStoreMigrationSpec{TargetPlacement: "fast"}, err: "must not be marked as default"},
+		{name: "placement of the existing buckets", migration: &cephv1.ObjectStoreMigrationSpec{TargetPlacement: "default-placement"}, err: "placement of the existing buckets"},
+		{name: "multisite", migration: &cephv1.ObjectStoreMigrationSpec{TargetPlacement: "ec"}, zone: "zone-a", err: "multisite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &cephv1.CephObjectStore{Spec: cephv1.ObjectStoreSpec{
+				SharedPools: cephv1.ObjectSharedPoolsSpec{PoolPlacements: placements},
+				Zone:        cephv1.ZoneSpec{Name: tt.zone},
+				Migration:   tt.migration,
+			}}
+			err := validateMigration(store)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSetMigrationBucket(t *testing.T) {
+	status := &cephv1.ObjectStoreMigrationStatus{}
+	for i := 0; i < maxReportedFailedBuckets+2; i++ {
+		setMigrationBucket(status, cephv1.ObjectStoreBucketMigrationStatus{Name: fmt.Sprintf("failed-%02d", i), Phase: cephv1.MigrationPhaseFailed})
+	}
+	setMigrationBucket(status, cephv1.ObjectStoreBucketMigrationStatus{Name: "a", Phase: cephv1.MigrationPhaseMigrating})
+	setMigrationBucket(status, cephv1.ObjectStoreBucketMigrationStatus{Name: "a", Phase: cephv1.MigrationPhaseCompleted})
+	require.Len(t, status.Buckets, maxReportedFailedBuckets+1)
+	assert.Equal(t, cephv1.ObjectStoreBucketMigrationStatus{Name: "a", Phase: cephv1.MigrationPhaseCompleted}, *currentMigrationBucket(status))
+	// the oldest failures are dropped
+	assert.Equal(t, "failed-02", status.Buckets[1].Name)
+	assert.Equal(t, "failed-11", status.Buckets[maxReportedFailedBuckets].Name)
+}
+
+func TestMigrationPause(t *testing.T) {
+	now := metav1.NewTime(time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC))
+	twoMinutesAgo := metav1.NewTime(now.Add(-2 * time.Minute))
+	status := &cephv1.ObjectStoreMigrationStatus{Buckets: []cephv1.ObjectStoreBucketMigrationStatus{{Name: "a", Phase: cephv1.MigrationPhaseCompleted, CompletionTime: &twoMinutesAgo}}}
+
+	assert.Equal(t, time.Duration(0), migrationPause(&cephv1.ObjectStoreMigrationSpec{}, status, now))
+	assert.Equal(t, 3*time.Minute, migrationPause(&cephv1.ObjectStoreMigrationSpec{BucketInterval: &metav1.Duration{Duration: 5 * time.Minute}}, status, now))
+	assert.Equal(t, time.Duration(0), migrationPause(&cephv1.ObjectStoreMigrationSpec{BucketInterval: &metav1.Duration{Duration: 5 * time.Minute}}, &cephv1.ObjectStoreMigrationStatus{}, now))
+}
+
+func TestMigrationLogPool(t *testing.T) {
+	store := &cephv1.CephObjectStore{}
+	assert.Equal(t, "my-store.rgw.log", migrationLogPool(store, "my-store"))
+	store.Spec.SharedPools.PoolPlacements = []cephv1.PoolPlacementSpec{
+		{Name: "fast", Default: true, MetadataPoolName: "meta", DataPoolName: "fast-data"},
+		{Name: "ec", MetadataPoolName: "ec-meta", DataPoolName: "ec-data"},
+	}
+	assert.Equal(t, "meta", migrationLogPool(store, "my-store"))
+}
+
+func TestReconcileMigration(t *testing.T) {
+	ctx := context.TODO()
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "rook-ceph"},
+		Spec:       cephv1.ObjectStoreSpec{Migration: &cephv1.ObjectStoreMigrationSpec{TargetPlacement: "ec"}},
+	}
+	s := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(store).WithStatusSubresource(store).Build()
+	clientset := k8sfake.NewSimpleClientset()
+	r := &ReconcileCephObjectStore{
+		client:           cl,
+		scheme:           s,
+		context:          &clusterd.Context{Clientset: clientset},
+		opManagerContext: ctx,
+	}
+
+	// "new" was created in the target placement after the migration started, and the
+	// "rook-migration-" bucket is the copy of a bucket being migrated
+	placements := map[string]string{"b": "default-placement", "a": "default-placement/STANDARD", "new": "ec", "c": "default-placement"}
+	newMigrationAdminOpsClientFunc = func(r *ReconcileCephObjectStore, store *cephv1.CephObjectStore) (*admin.API, error) {
+		return admin.New("rook-ceph-rgw-my-store.rook-ceph.svc", "access", "secret", &MockClient{
+			MockDo: func(req *http.Request) (*http.Response, error) {
+				if req.Method != http.MethodGet || req.URL.Path != "rook-ceph-rgw-my-store.rook-ceph.svc/admin/bucket" {
+					return nil, errors.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+				}
+				body := `["b","a","new","rook-migration-0123456789abcdef","c"]`
+				if bucket := req.URL.Query().Get("bucket"); bucket != "" {
+					placement, ok := placements[bucket]
+					require.True(t, ok, bucket)
+					body = fmt.Sprintf(`{"bucket":%q,"placement_rule":%q}`, bucket, placement)
+				}
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+			},
+		})
+	}
+	defer func() { newMigrationAdminOpsClientFunc = newMigrationAdminOpsClient }()
+
+	var migrated []string
+	startBucketMigrationJobFunc = func(r *ReconcileCephObjectStore, store *cephv1.CephObjectStore, cephCluster *cephv1.CephCluster, bucket string) error {
+		migrated = append(migrated, bucket)
+		// the job replaces the job of the previous bucket
+		_ = clientset.BatchV1().Jobs(store.Namespace).Delete(ctx, migrationJobName(store.Name), metav1.DeleteOptions{})
+		_ = clientset.CoreV1().Pods(store.Namespace).Delete(ctx, "migration-pod", metav1.DeleteOptions{})
+		_, err := clientset.BatchV1().Jobs(store.Namespace).Create(ctx, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: migrationJobName(store.Name), Namespace: store.Namespace}}, metav1.CreateOptions{})
+		return err
+	}
+	defer func() { startBucketMigrationJobFunc = startBucketMigrationJob }()
+
+	reconcileStore := func() (*cephv1.ObjectStoreMigrationStatus, time.Duration) {
+		current := &cephv1.CephObjectStore{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		res, err := r.reconcileMigration(current, &cephv1.CephCluster{})
+		require.NoError(t, err)
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		return current.Status.Migration, res.RequeueAfter
+	}
+	// finishJob completes the job with the report, or fails it with the condition
+	finishJob := func(report string, condition *batchv1.JobCondition) {
+		job, err := clientset.BatchV1().Jobs(store.Namespace).Get(ctx, migrationJobName(store.Name), metav1.GetOptions{})
+		require.NoError(t, err)
+		if condition != nil {
+			job.Status.Conditions = []batchv1.JobCondition{*condition}
+		} else {
+			job.Status.Succeeded = 1
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "migration-pod", Namespace: store.Namespace, Labels: map[string]string{"job-name": migrationJobName(store.Name)}},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodSucceeded,
+					ContainerStatuses: []corev1.ContainerStatus{
+						{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Message: report}}},
+					},
+				},
+			}
+			_, err = clientset.CoreV1().Pods(store.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		_, err = clientset.BatchV1().Jobs(store.Namespace).UpdateStatus(ctx, job, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	status, requeueAfter := reconcileStore()
+	assert.Equal(t, []string{"a"}, migrated)
+	assert.Equal(t, migrationCheckInterval, requeueAfter)
+	assert.Equal(t, cephv1.MigrationPhaseMigrating, status.Phase)
+	assert.Equal(t, "ec", status.TargetPlacement)
+	assert.NotNil(t, status.SwitchTime)
+	assert.Equal(t, 5, status.TotalBuckets)
+	require.Len(t, status.Buckets, 1)
+	assert.Equal(t, "a", status.Buckets[0].Name)
+	assert.Equal(t, cephv1.MigrationPhaseMigrating, status.Buckets[0].Phase)
+
+	t.Run("migration of a bucket in progress", func(t *testing.T) {
+		_, requeueAfter := reconcileStore()
+		assert.Equal(t, migrationCheckInterval, requeueAfter)
+		assert.Equal(t, []string{"a"}, migrated)
+	})
+
+	t.Run("migration of a bucket completed", func(t *testing.T) {
+		finishJob(`{"bucket":"a","copiedObjects":3,"copiedBytes":300}`, nil)
+		status, _ := reconcileStore()
+		assert.Equal(t, []string{"a", "b"}, migrated)
+		assert.Equal(t, 1, status.MigratedBuckets)
+		assert.Equal(t, "b", status.LastBucket)
+		require.Len(t, status.Buckets, 1)
+		assert.Equal(t, "b", status.Buckets[0].Name)
+		assert.Equal(t, cephv1.MigrationPhaseMigrating, status.Buckets[0].Phase)
+	})
+
+	t.Run("migration of a bucket failed", func(t *testing.T) {
+		finishJob("", &batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"})
+		status, _ := reconcileStore()
+		// the migration goes on with the next bucket
+		assert.Equal(t, []string{"a", "b", "c"}, migrated)
+		assert.Equal(t, 1, status.FailedBuckets)
+		require.Len(t, status.Buckets, 2)
+		assert.Equal(t, "c", status.Buckets[0].Name)
+		assert.Equal(t, "b", status.Buckets[1].Name)
+		assert.Equal(t, cephv1.MigrationPhaseFailed, status.Buckets[1].Phase)
+		assert.Contains(t, status.Buckets[1].Message, "BackoffLimitExceeded")
+	})
+
+	t.Run("migration completed", func(t *testing.T) {
+		finishJob(`{"bucket":"c","copiedObjects":2,"copiedBytes":2048}`, nil)
+		status, requeueAfter := reconcileStore()
+		assert.Equal(t, time.Duration(0), requeueAfter)
+		// the bucket in the target placement and the copy of a bucket are not migrated
+		assert.Equal(t, []string{"a", "b", "c"}, migrated)
+		assert.Equal(t, cephv1.MigrationPhaseCompleted, status.Phase)
+		assert.Equal(t, 2, status.MigratedBuckets)
+		assert.Equal(t, 1, status.SkippedBuckets)
+		assert.Equal(t, 1, status.FailedBuckets)
+		assert.NotNil(t, status.CompletionTime)
+		assert.Equal(t, int64(2), status.Buckets[0].CopiedObjects)
+		assert.Equal(t, int64(2048), status.Buckets[0].CopiedBytes)
+
+		_, _ = reconcileStore()
+		assert.Len(t, migrated, 3)
+	})
+
+	t.Run("migration removed", func(t *testing.T) {
+		current := &cephv1.CephObjectStore{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		current.Spec.Migration = nil
+		require.NoError(t, cl.Update(ctx, current))
+
+		status, _ := reconcileStore()
+		assert.Nil(t, status)
+	})
+}
@@ -84,6 +84,7 @@ type zoneGroupType struct {
 }
 
 type zoneType struct {
+	ID        string   `json:"id"`
 	Name      string   `json:"name"`
 	Endpoints []string `json:"endpoints"`
 }
@@ -449,7 +450,7 @@ func createNonMultisiteStore(objContext *Context, endpointArg string, store *cep
 	logger.Infof("Object store %q: realm=%s, zonegroup=%s, zone=%s", objContext.Name, objContext.Realm, objContext.ZoneGroup, objContext.Zone)
 
 	// Configure the zone for RADOS namespaces
-	err = ConfigureSharedPoolsForZone(objContext, store.Spec.SharedPools)
+	err = configureSharedPoolsForZone(objContext, store.Spec.SharedPools, migrationDefaultPlacement(store))
 	if err != nil {
 		return errors.Wrapf(err, "failed to configure rados namespaces for zone")
 	}
@@ -770,6 +771,12 @@ func CreateObjectStorePools(context *Context, cluster *cephv1.ClusterSpec, metad
 }
 
 func ConfigureSharedPoolsForZone(objContext *Context, sharedPools cephv1.ObjectSharedPoolsSpec) error {
+	return configureSharedPoolsForZone(objContext, sharedPools, "")
+}
+
+// configureSharedPoolsForZone configures the shared pools of the zone. The default placement of the
+// zonegroup is overridden when the object store is migrated to another placement.
+func configureSharedPoolsForZone(objContext *Context, sharedPools cephv1.ObjectSharedPoolsSpec, defaultPlacementOverride string) error {
 	if sharedPools.DataPoolName == "" && sharedPools.MetadataPoolName == "" && len(sharedPools.PoolPlacements) == 0 {
 		logger.Debugf("no shared pools to configure for store %q", objContext.nsName())
 		return nil
@@ -798,8 +805,17 @@ func ConfigureSharedPoolsForZone(objContext *Context, sharedPools cephv1.ObjectS
 	if err != nil {
 		return err
 	}
+	tierTargets, err := toZonegroupTierTargets(sharedPools, func(secretName string) (string, string, error) {
+		return getCloudTierCredentials(objContext, secretName)
+	})
+	if err != nil {
+		return err
+	}
 	defaultPlacement := getDefaultPlacementName(sharedPools)
-	zoneGroupUpdated, err := adjustZoneGroupPlacementTargets(zoneGroupConfig, zoneUpdated, defaultPlacement)
+	if defaultPlacementOverride != "" {
+		defaultPlacement = defaultPlacementOverride
+	}
+	zoneGroupUpdated, err := adjustZoneGroupPlacementTargets(zoneGroupConfig, zoneUpdated, defaultPlacement, tierTargets)
 	if err != nil {
 		return err
 	}
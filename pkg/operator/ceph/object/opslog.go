@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/opslog"
+	cephconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	"github.com/rook/rook/pkg/operator/ceph/controller"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	opsLogContainerName = "ops-log"
+	// keys of the credentials of an S3 ops log sink, matching the keys of an OBC secret
+	opsLogS3AccessKeyKey = "AWS_ACCESS_KEY_ID"
+	opsLogS3SecretKeyKey = "AWS_SECRET_ACCESS_KEY"
+	// keys of the SASL credentials of a Kafka ops log sink
+	opsLogKafkaUsernameKey = "username"
+	opsLogKafkaPasswordKey = "password"
+)
+
+func validateOpsLogSidecar(spec *cephv1.OpsLogSidecar) error {
+	for i := range spec.Sinks {
+		if err := opslog.ValidateSinkSpec(&spec.Sinks[i]); err != nil {
+			return errors.Wrapf(err, "invalid ops log sink %d", i)
+		}
+	}
+	if len(spec.Sinks) == 0 && (spec.Filter != nil || spec.SamplePercent != nil) {
+		return errors.New("ops log filter and sampling require at least one sink")
+	}
+	if p := spec.SamplePercent; p != nil && (*p < 1 || *p > 100) {
+		return errors.Errorf("invalid ops log sample percent %d, must be between 1 and 100", *p)
+	}
+	return nil
+}
+
+// opsLogShipperContainer returns the ops-log sidecar forwarding the ops log entries to the sinks of
+// the spec. It runs the rook image, unlike the sidecar printing the ops log file.
+func (c *clusterConfig) opsLogShipperContainer(spec *cephv1.OpsLogSidecar) (*v1.Container, error) {
+	// the resources are not needed by the sidecar
+	config, err := json.Marshal(&cephv1.OpsLogSidecar{
+		Sinks:         spec.Sinks,
+		Filter:        spec.Filter,
+		SamplePercent: spec.SamplePercent,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal ops log config")
+	}
+
+	env := append([]v1.EnvVar{}, podNameEnvVars...)
+	env = append(env, v1.EnvVar{Name: "ROOK_OPS_LOG_CONFIG", Value: string(config)})
+	for i, sink := range spec.Sinks {
+		if sink.S3 != nil {
+			env = append(env,
+				secretKeyEnvVar(opslog.S3AccessKeyEnvVar(i), sink.S3.CredentialsSecretName, opsLogS3AccessKeyKey),
+				secretKeyEnvVar(opslog.S3SecretKeyEnvVar(i), sink.S3.CredentialsSecretName, opsLogS3SecretKeyKey),
+			)
+		}
+		if sink.Kafka != nil && sink.Kafka.CredentialsSecretName != "" {
+			env = append(env,
+				secretKeyEnvVar(opslog.KafkaUsernameEnvVar(i), sink.Kafka.CredentialsSecretName, opsLogKafkaUsernameKey),
+				secretKeyEnvVar(opslog.KafkaPasswordEnvVar(i), sink.Kafka.CredentialsSecretName, opsLogKafkaPasswordKey),
+			)
+		}
+	}
+
+	return &v1.Container{
+		Name: opsLogContainerName,
+		Args: []string{
+			"ceph", "ops-log",
+			"--log-file", opsLogAbsFilename,
+			"--pod-name", "$(POD_NAME)",
+		},
+		Image:           c.rookImage,
+		ImagePullPolicy: controller.GetContainerImagePullPolicy(c.clusterSpec.CephVersion.ImagePullPolicy),
+		VolumeMounts:    controller.DaemonVolumeMounts(cephconfig.NewDatalessDaemonDataPathMap(c.clusterInfo.Namespace, c.clusterSpec.DataDirHostPath), "", c.clusterSpec.DataDirHostPath),
+		SecurityContext: controller.PodSecurityContext(),
+		Resources:       spec.Resources,
+		Env:             env,
+	}, nil
+}
+
+func secretKeyEnvVar(name, secretName, key string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+	"testing"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	clienttest "github.com/rook/rook/pkg/daemon/ceph/client/test"
+	cephconfig "github.com/rook/rook/pkg/operator/ceph/config"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestValidateOpsLogSidecar(t *testing.T) {
+	percent := func(p int32) *int32 { return &p }
+	stdout := cephv1.OpsLogSinkSpec{Stdout: &cephv1.OpsLogStdoutSinkSpec{}}
+
+	assert.NoError(t, validateOpsLogSidecar(&cephv1.OpsLogSidecar{}))
+	assert.NoError(t, validateOpsLogSidecar(&cephv1.OpsLogSidecar{
+		Sinks:         []cephv1.OpsLogSinkSpec{stdout},
+		Filter:        &cephv1.OpsLogFilterSpec{Buckets: []string{"a"}},
+		SamplePercent: percent(10),
+	}))
+
+	// filter without sink
+	assert.Error(t, validateOpsLogSidecar(&cephv1.OpsLogSidecar{Filter: &cephv1.OpsLogFilterSpec{Buckets: []string{"a"}}}))
+	assert.Error(t, validateOpsLogSidecar(&cephv1.OpsLogSidecar{Sinks: []cephv1.OpsLogSinkSpec{stdout}, SamplePercent: percent(0)}))
+	assert.Error(t, validateOpsLogSidecar(&cephv1.OpsLogSidecar{Sinks: []cephv1.OpsLogSinkSpec{stdout, {}}}))
+}
+
+func TestOpsLogSidecarContainer(t *testing.T) {
+	store := simpleStore()
+	c := &clusterConfig{
+		context:     &clusterd.Context{Executor: &exectest.MockExecutor{}},
+		store:       store,
+		rookVersion: "quay.io/ceph/ceph:v19",
+		rookImage:   "rook/ceph:myversion",
+		clusterSpec: &cephv1.ClusterSpec{CephVersion: cephv1.CephVersionSpec{Image: "quay.io/ceph/ceph:v19"}},
+		clusterInfo: clienttest.CreateTestClusterInfo(1),
+		DataPathMap: cephconfig.NewStatelessDaemonDataPathMap(cephconfig.RgwType, "default", "rook-ceph", "/var/lib/rook/"),
+	}
+	rgwConfig := &rgwConfig{ResourceName: "rook-ceph-rgw-default", DaemonID: "default"}
+
+	opsLogContainer := func() *v1.Container {
+		podTemplate, err := c.makeRGWPodSpec(rgwConfig)
+		require.NoError(t, err)
+		for i := range podTemplate.Spec.Containers {
+			if podTemplate.Spec.Containers[i].Name == opsLogContainerName {
+				return &podTemplate.Spec.Containers[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("no sidecar", func(t *testing.T) {
+		assert.Nil(t, opsLogContainer())
+	})
+
+	t.Run("sidecar printing the ops log", func(t *testing.T) {
+		store.Spec.Gateway.OpsLogSidecar = &cephv1.OpsLogSidecar{}
+		container := opsLogContainer()
+		require.NotNil(t, container)
+		assert.Equal(t, "quay.io/ceph/ceph:v19", container.Image)
+		assert.Equal(t, "bash", container.Command[0])
+	})
+
+	t.Run("sidecar forwarding to sinks", func(t *testing.T) {
+		store.Spec.Gateway.OpsLogSidecar = &cephv1.OpsLogSidecar{
+			Sinks: []cephv1.OpsLogSinkSpec{
+				{Stdout: &cephv1.OpsLogStdoutSinkSpec{}},
+				{S3: &cephv1.OpsLogS3SinkSpec{Endpoint: "http://s3", Bucket: "audit", CredentialsSecretName: "audit-bucket"}},
+				{Kafka: &cephv1.OpsLogKafkaSinkSpec{Brokers: []string{"kafka:9093"}, Topic: "ops", UseSSL: true, Mechanism: "SCRAM-SHA-512", CredentialsSecretName: "kafka-user"}},
+			},
+			Filter: &cephv1.OpsLogFilterSpec{Users: []string{"alice"}},
+		}
+		container := opsLogContainer()
+		require.NotNil(t, container)
+		assert.Equal(t, "rook/ceph:myversion", container.Image)
+		assert.Empty(t, container.Command)
+		assert.Equal(t, []string{"ceph", "ops-log", "--log-file", opsLogAbsFilename, "--pod-name", "$(POD_NAME)"}, container.Args)
+
+		env := map[string]v1.EnvVar{}
+		for _, e := range container.Env {
+			env[e.Name] = e
+		}
+		assert.Contains(t, env, "POD_NS")
+		assert.Contains(t, env, "POD_NAME")
+		config := &cephv1.OpsLogSidecar{}
+		require.NoError(t, json.Unmarshal([]byte(env["ROOK_OPS_LOG_CONFIG"].Value), config))
+		assert.Equal(t, *store.Spec.Gateway.OpsLogSidecar, *config)
+
+		// the credentials of the s3 sink at index 1 come from its secret
+		accessKey := env["ROOK_OPS_LOG_S3_ACCESS_KEY_ID_1"].ValueFrom.SecretKeyRef
+		assert.Equal(t, "audit-bucket", accessKey.Name)
+		assert.Equal(t, "AWS_ACCESS_KEY_ID", accessKey.Key)
+		secretKey := env["ROOK_OPS_LOG_S3_SECRET_ACCESS_KEY_1"].ValueFrom.SecretKeyRef
+		assert.Equal(t, "audit-bucket", secretKey.Name)
+		assert.Equal(t, "AWS_SECRET_ACCESS_KEY", secretKey.Key)
+		// and the credentials of the kafka sink at index 2
+		username := env["ROOK_OPS_LOG_KAFKA_USERNAME_2"].ValueFrom.SecretKeyRef
+		assert.Equal(t, "kafka-user", username.Name)
+		assert.Equal(t, "username", username.Key)
+		password := env["ROOK_OPS_LOG_KAFKA_PASSWORD_2"].ValueFrom.SecretKeyRef
+		assert.Equal(t, "kafka-user", password.Name)
+		assert.Equal(t, "password", password.Key)
+	})
+}
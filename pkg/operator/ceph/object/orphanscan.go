@@ -0,0 +1,321 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/daemon/ceph/cleanup"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// OrphanScanAnnotation requests an orphan scan of the object store each time its value changes
+	OrphanScanAnnotation = "ceph.rook.io/orphan-scan"
+	// OrphanScanRemoveAnnotation confirms the removal of the orphans found by the scan with the ID
+	// it is set to
+	OrphanScanRemoveAnnotation = "ceph.rook.io/orphan-scan-remove"
+
+	// the interval at which the scan job is checked until it completes
+	orphanScanCheckInterval = time.Minute
+	orphanScanReportKey     = "report.json"
+)
+
+// the job is overridden in the unit tests
+var startOrphanScanJobFunc = startOrphanScanJob
+
+func orphanScanJobName(storeName string) string {
+	return k8sutil.TruncateNodeNameForJob("rook-ceph-rgw-%s-orphan-scan", storeName)
+}
+
+func orphanScanConfigMapName(storeName string) string {
+	return fmt.Sprintf("%s-orphan-scan", instanceName(storeName))
+}
+
+// orphanScanAnnotationPredicate reconciles the object store when a scan, or the removal of the
+// orphans of a scan, is requested with the annotations
+func orphanScanAnnotationPredicate() predicate.TypedFuncs[*cephv1.CephObjectStore] {
+	return predicate.TypedFuncs[*cephv1.CephObjectStore]{
+		CreateFunc: func(e event.TypedCreateEvent[*cephv1.CephObjectStore]) bool {
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*cephv1.CephObjectStore]) bool {
+			if opcontroller.IsDoNotReconcile(e.ObjectNew.GetLabels()) {
+				return false
+			}
+			oldAnnotations, newAnnotations := e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations()
+			return oldAnnotations[OrphanScanAnnotation] != newAnnotations[OrphanScanAnnotation] ||
+				oldAnnotations[OrphanScanRemoveAnnotation] != newAnnotations[OrphanScanRemoveAnnotation]
+		},
+		DeleteFunc: func(e event.TypedDeleteEvent[*cephv1.CephObjectStore]) bool {
+			return false
+		},
+		GenericFunc: func(e event.TypedGenericEvent[*cephv1.CephObjectStore]) bool {
+			return false
+		},
+	}
+}
+
+// reconcileOrphanScan starts the scheduled or requested orphan scans of the object store, and the
+// removal of the orphans of a scan once it is confirmed, and reports their result in the status
+func (r *ReconcileCephObjectStore) reconcileOrphanScan(store *cephv1.CephObjectStore, cephCluster *cephv1.CephCluster) (reconcile.Result, error) {
+	var status *cephv1.ObjectStoreOrphanScanStatus
+	if store.Status != nil && store.Status.OrphanScan != nil {
+		status = store.Status.OrphanScan.DeepCopy()
+	}
+	if store.Spec.OrphanScan == nil && store.Annotations[OrphanScanAnnotation] == "" && status == nil {
+		return reconcile.Result{}, nil
+	}
+	namespacedName := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+	now := metav1.Now()
+
+	if status != nil && (status.Phase == cephv1.OrphanScanPhaseScanning || status.Phase == cephv1.OrphanScanPhaseRemoving) {
+		done, err := r.checkOrphanScanJob(store, status, now)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !done {
+			return reconcile.Result{RequeueAfter: orphanScanCheckInterval}, nil
+		}
+		if err := updateOrphanScanStatus(r.opManagerContext, r.client, namespacedName, status); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	scan, remove, requeueAfter := orphanScanAction(store, status, now)
+	if !scan && !remove {
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	if scan {
+		status = &cephv1.ObjectStoreOrphanScanStatus{
+			ID:        now.UTC().Format("20060102-150405"),
+			Phase:     cephv1.OrphanScanPhaseScanning,
+			Request:   store.Annotations[OrphanScanAnnotation],
+			StartTime: &now,
+		}
+		if status.Request == "" && store.Status != nil && store.Status.OrphanScan != nil {
+			status.Request = store.Status.OrphanScan.Request
+		}
+	} else {
+		status.Phase = cephv1.OrphanScanPhaseRemoving
+		status.Message = ""
+	}
+
+	if err := startOrphanScanJobFunc(r, store, cephCluster, status.ID, remove); err != nil {
+		status.Phase = cephv1.OrphanScanPhaseFailed
+		status.Message = err.Error()
+		status.CompletionTime = &now
+		if updateErr := updateOrphanScanStatus(r.opManagerContext, r.client, namespacedName, status); updateErr != nil {
+			logger.Error(updateErr)
+		}
+		return reconcile.Result{}, err
+	}
+	if err := updateOrphanScanStatus(r.opManagerContext, r.client, namespacedName, status); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: orphanScanCheckInterval}, nil
+}
+
+// orphanScanAction returns whether a scan, or the removal of the orphans of the last scan, must be
+// started, or else when the next scheduled scan is due
+func orphanScanAction(store *cephv1.CephObjectStore, status *cephv1.ObjectStoreOrphanScanStatus, now metav1.Time) (scan, remove bool, requeueAfter time.Duration) {
+	if status != nil && status.Phase == cephv1.OrphanScanPhaseCompleted && status.ID != "" &&
+		store.Annotations[OrphanScanRemoveAnnotation] == status.ID {
+		if store.Spec.OrphanScan != nil && store.Spec.OrphanScan.RemoveOrphans {
+			return false, true, 0
+		}
+		logger.Warningf("removal of the orphans of scan %q of object store \"%s/%s\" is requested, but spec.orphanScan.removeOrphans is not set",
+			status.ID, store.Namespace, store.Name)
+	}
+
+	if request := store.Annotations[OrphanScanAnnotation]; request != "" && (status == nil || status.Request != request) {
+		return true, false, 0
+	}
+
+	if store.Spec.OrphanScan == nil || store.Spec.OrphanScan.Interval == nil || store.Spec.OrphanScan.Interval.Duration <= 0 {
+		return false, false, 0
+	}
+	if status == nil || status.StartTime == nil {
+		return true, false, 0
+	}
+	next := status.StartTime.Add(store.Spec.OrphanScan.Interval.Duration)
+	if !now.Time.Before(next) {
+		return true, false, 0
+	}
+	return false, false, next.Sub(now.Time)
+}
+
+// startOrphanScanJob starts the job scanning the object store for orphans, or removing the orphans
+// found by the scan
+func startOrphanScanJob(r *ReconcileCephObjectStore, store *cephv1.CephObjectStore, cephCluster *cephv1.CephCluster, id string, remove bool) error {
+	objContext, err := NewMultisiteContext(r.context, r.clusterInfo, store)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the multisite configuration of the object store")
+	}
+	sharedPools := store.Spec.SharedPools
+	if store.Spec.IsMultisite() {
+		zone := &cephv1.CephObjectZone{}
+		if err := r.client.Get(r.opManagerContext, types.NamespacedName{Namespace: store.Namespace, Name: store.Spec.Zone.Name}, zone); err != nil {
+			return errors.Wrapf(err, "failed to get zone %q", store.Spec.Zone.Name)
+		}
+		sharedPools = zone.Spec.SharedPools
+	}
+	// rgw-orphan-list reports the objects of the other object stores sharing the pool as orphans
+	if sharedPools.DataPoolName != "" || sharedPools.MetadataPoolName != "" || len(sharedPools.PoolPlacements) > 0 {
+		return errors.New("orphan scans are not supported for object stores with shared pools")
+	}
+
+	config := map[string]string{
+		opcontroller.OrphanScanIDEnv:             id,
+		opcontroller.CephObjectStoreRealmEnv:     objContext.Realm,
+		opcontroller.CephObjectStoreZoneGroupEnv: objContext.ZoneGroup,
+		opcontroller.CephObjectStoreZoneEnv:      objContext.Zone,
+		opcontroller.CephObjectStoreDataPoolsEnv: poolName(objContext.Zone, dataPoolName),
+		opcontroller.CephObjectStoreLogPoolEnv:   poolName(objContext.Zone, "rgw.log"),
+		opcontroller.OrphanScanRemoveEnv:         fmt.Sprintf("%t", remove),
+	}
+	// the subcommand of the job is the kind of the resource
+	resource := store.DeepCopy()
+	resource.TypeMeta = metav1.TypeMeta{Kind: "CephObjectStore", APIVersion: cephv1.SchemeGroupVersion.String()}
+	job := opcontroller.NewResourceCleanup(resource, cephCluster, r.opConfig.Image, config)
+	if err := job.StartJob(r.clusterInfo.Context, r.context.Clientset, orphanScanJobName(store.Name)); err != nil {
+		return errors.Wrapf(err, "failed to start the orphan scan job of object store %q", store.Name)
+	}
+	logger.Infof("started orphan scan %q of object store \"%s/%s\" (remove orphans: %t)", id, store.Namespace, store.Name, remove)
+	return nil
+}
+
+// checkOrphanScanJob updates the status with the result of the scan job once it is done
+func (r *ReconcileCephObjectStore) checkOrphanScanJob(store *cephv1.CephObjectStore, status *cephv1.ObjectStoreOrphanScanStatus, now metav1.Time) (bool, error) {
+	jobName := orphanScanJobName(store.Name)
+	job, err := r.context.Clientset.BatchV1().Jobs(store.Namespace).Get(r.opManagerContext, jobName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "failed to get the orphan scan job %q", jobName)
+		}
+		status.Phase = cephv1.OrphanScanPhaseFailed
+		status.Message = fmt.Sprintf("orphan scan job %q not found", jobName)
+		status.CompletionTime = &now
+		return true, nil
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			status.Phase = cephv1.OrphanScanPhaseFailed
+			status.Message = fmt.Sprintf("orphan scan job %q failed. %s", jobName, condition.Message)
+			status.CompletionTime = &now
+			return true, nil
+		}
+	}
+	if job.Status.Succeeded == 0 {
+		logger.Debugf("orphan scan %q of object store \"%s/%s\" is in progress", status.ID, store.Namespace, store.Name)
+		return false, nil
+	}
+
+	message, err := r.jobReport(store.Namespace, jobName)
+	if err != nil {
+		return false, err
+	}
+	report := &cleanup.OrphanScanReport{}
+	if err := json.Unmarshal([]byte(message), report); err != nil || report.ID != status.ID {
+		status.Phase = cephv1.OrphanScanPhaseFailed
+		status.Message = fmt.Sprintf("failed to read the report of orphan scan %q. %s", status.ID, message)
+		status.CompletionTime = &now
+		return true, nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: orphanScanConfigMapName(store.Name), Namespace: store.Namespace},
+		Data:       map[string]string{orphanScanReportKey: message},
+	}
+	if err := k8sutil.NewOwnerInfo(store, r.scheme).SetControllerReference(cm); err != nil {
+		return false, errors.Wrapf(err, "failed to set owner reference of orphan scan configmap %q", cm.Name)
+	}
+	if _, err := k8sutil.CreateOrUpdateConfigMap(r.opManagerContext, r.context.Clientset, cm); err != nil {
+		return false, err
+	}
+
+	status.Orphans = report.Orphans
+	status.ReclaimableBytes = report.ReclaimableBytes
+	status.StaleIndexEntries = report.StaleIndexEntries
+	status.ReportConfigMap = cm.Name
+	status.Message = ""
+	status.CompletionTime = &now
+	status.Phase = cephv1.OrphanScanPhaseCompleted
+	if report.Removed {
+		status.Phase = cephv1.OrphanScanPhaseRemoved
+	}
+	logger.Infof("orphan scan %q of object store \"%s/%s\" is %s with %d orphans of %d bytes and %d stale index entries",
+		status.ID, store.Namespace, store.Name, strings.ToLower(string(status.Phase)), status.Orphans, status.ReclaimableBytes, status.StaleIndexEntries)
+	return true, nil
+}
+
+// jobReport returns the report written by the job in the termination message of its pod
+func (r *ReconcileCephObjectStore) jobReport(namespace, jobName string) (string, error) {
+	pods, err := r.context.Clientset.CoreV1().Pods(namespace).List(r.opManagerContext, metav1.ListOptions{LabelSelector: fmt.Sprintf("job-name=%s", jobName)})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list the pods of job %q", jobName)
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded {
+			continue
+		}
+		for _, container := range pod.Status.ContainerStatuses {
+			if container.State.Terminated != nil && container.State.Terminated.ExitCode == 0 {
+				return container.State.Terminated.Message, nil
+			}
+		}
+	}
+	return "", errors.Errorf("failed to find the report of job %q", jobName)
+}
+
+func updateOrphanScanStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName, status *cephv1.ObjectStoreOrphanScanStatus) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := k8sClient.Get(ctx, namespacedName, objectStore); err != nil {
+			return err
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.OrphanScan = status
+		return reporting.UpdateStatus(k8sClient, objectStore)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the orphan scan status of object store %q", namespacedName.String())
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOrphanScanAction(t *testing.T) {
+	now := metav1.NewTime(time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC))
+	lastWeek := metav1.NewTime(now.Add(-7 * 24 * time.Hour))
+	yesterday := metav1.NewTime(now.Add(-24 * time.Hour))
+	weekly := &cephv1.ObjectStoreOrphanScanSpec{Interval: &metav1.Duration{Duration: 7 * 24 * time.Hour}}
+	completed := &cephv1.ObjectStoreOrphanScanStatus{ID: "20250107-000000", Phase: cephv1.OrphanScanPhaseCompleted, Request: "1", StartTime: &yesterday}
+
+	tests := []struct {
+		name         string
+		spec         *cephv1.ObjectStoreOrphanScanSpec
+		annotations  map[string]string
+		status       *cephv1.ObjectStoreOrphanScanStatus
+		scan         bool
+		remove       bool
+		requeueAfter time.Duration
+	}{
+		{name: "not configured", status: nil},
+		{name: "first scheduled scan", spec: weekly, scan: true},
+		{name: "scheduled scan not due", spec: weekly, status: completed, requeueAfter: 6 * 24 * time.Hour},
+		{name: "scheduled scan due", spec: weekly, status: &cephv1.ObjectStoreOrphanScanStatus{Phase: cephv1.OrphanScanPhaseCompleted, StartTime: &lastWeek}, scan: true},
+		{name: "requested scan", annotations: map[string]string{OrphanScanAnnotation: "2"}, status: completed, scan: true},
+		{name: "request already processed", annotations: map[string]string{OrphanScanAnnotation: "1"}, status: completed},
+		{name: "removal not allowed", annotations: map[string]string{OrphanScanRemoveAnnotation: completed.ID}, status: completed},
+		{name: "removal confirmed", spec: &cephv1.ObjectStoreOrphanScanSpec{RemoveOrphans: true}, annotations: map[string]string{OrphanScanRemoveAnnotation: completed.ID}, status: completed, remove: true},
+		{name: "removal of another scan", spec: &cephv1.ObjectStoreOrphanScanSpec{RemoveOrphans: true}, annotations: map[string]string{OrphanScanRemoveAnnotation: "20250101-000000"}, status: completed},
+		{name: "removal already done", spec: &cephv1.ObjectStoreOrphanScanSpec{RemoveOrphans: true}, annotations: map[string]string{OrphanScanRemoveAnnotation: completed.ID},
+			status: &cephv1.ObjectStoreOrphanScanStatus{ID: completed.ID, Phase: cephv1.OrphanScanPhaseRemoved, StartTime: &yesterday}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "rook-ceph", Annotations: tt.annotations}}
+			store.Spec.OrphanScan = tt.spec
+			scan, remove, requeueAfter := orphanScanAction(store, tt.status, now)
+			assert.Equal(t, tt.scan, scan)
+			assert.Equal(t, tt.remove, remove)
+			assert.Equal(t, tt.requeueAfter, requeueAfter)
+		})
+	}
+}
+
+func TestReconcileOrphanScan(t *testing.T) {
+	ctx := context.TODO()
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "rook-ceph", UID: "store-uid", Annotations: map[string]string{OrphanScanAnnotation: "1"}},
+		Spec:       cephv1.ObjectStoreSpec{OrphanScan: &cephv1.ObjectStoreOrphanScanSpec{RemoveOrphans: true}},
+	}
+	s := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(store).WithStatusSubresource(store).Build()
+	clientset := k8sfake.NewSimpleClientset()
+	r := &ReconcileCephObjectStore{
+		client:           cl,
+		scheme:           s,
+		context:          &clusterd.Context{Clientset: clientset},
+		opManagerContext: ctx,
+	}
+
+	type started struct {
+		id     string
+		remove bool
+	}
+	var jobs []started
+	startOrphanScanJobFunc = func(r *ReconcileCephObjectStore, store *cephv1.CephObjectStore, cephCluster *cephv1.CephCluster, id string, remove bool) error {
+		jobs = append(jobs, started{id: id, remove: remove})
+		// the job replaces the job of the previous scan
+		_ = clientset.BatchV1().Jobs(store.Namespace).Delete(ctx, orphanScanJobName(store.Name), metav1.DeleteOptions{})
+		_, err := clientset.BatchV1().Jobs(store.Namespace).Create(ctx, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: orphanScanJobName(store.Name), Namespace: store.Namespace}}, metav1.CreateOptions{})
+		return err
+	}
+	defer func() { startOrphanScanJobFunc = startOrphanScanJob }()
+
+	reconcileStore := func() (*cephv1.CephObjectStore, time.Duration) {
+		current := &cephv1.CephObjectStore{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		res, err := r.reconcileOrphanScan(current, &cephv1.CephCluster{})
+		require.NoError(t, err)
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		return current, res.RequeueAfter
+	}
+	completeJob := func(report string) {
+		job, err := clientset.BatchV1().Jobs(store.Namespace).Get(ctx, orphanScanJobName(store.Name), metav1.GetOptions{})
+		require.NoError(t, err)
+		job.Status.Succeeded = 1
+		_, err = clientset.BatchV1().Jobs(store.Namespace).UpdateStatus(ctx, job, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		_ = clientset.CoreV1().Pods(store.Namespace).Delete(ctx, "orphan-scan-pod", metav1.DeleteOptions{})
+		_, err = clientset.CoreV1().Pods(store.Namespace).Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "orphan-scan-pod", Namespace: store.Namespace, Labels: map[string]string{"job-name": orphanScanJobName(store.Name)}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Message: report}}},
+				},
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	current, requeueAfter := reconcileStore()
+	require.Len(t, jobs, 1)
+	assert.False(t, jobs[0].remove)
+	id := jobs[0].id
+	assert.Equal(t, orphanScanCheckInterval, requeueAfter)
+	assert.Equal(t, cephv1.OrphanScanPhaseScanning, current.Status.OrphanScan.Phase)
+	assert.Equal(t, id, current.Status.OrphanScan.ID)
+	assert.Equal(t, "1", current.Status.OrphanScan.Request)
+
+	t.Run("scan in progress", func(t *testing.T) {
+		_, requeueAfter := reconcileStore()
+		assert.Equal(t, orphanScanCheckInterval, requeueAfter)
+		assert.Len(t, jobs, 1)
+	})
+
+	t.Run("scan completed", func(t *testing.T) {
+		completeJob(`{"id":"` + id + `","orphans":3,"reclaimableBytes":12582912,"staleIndexEntries":2,"buckets":{"my-bucket":2}}`)
+		current, requeueAfter := reconcileStore()
+		assert.Equal(t, time.Duration(0), requeueAfter)
+		assert.Len(t, jobs, 1)
+		status := current.Status.OrphanScan
+		assert.Equal(t, cephv1.OrphanScanPhaseCompleted, status.Phase)
+		assert.Equal(t, int64(3), status.Orphans)
+		assert.Equal(t, int64(12582912), status.ReclaimableBytes)
+		assert.Equal(t, int64(2), status.StaleIndexEntries)
+		assert.Equal(t, "rook-ceph-rgw-my-store-orphan-scan", status.ReportConfigMap)
+		assert.NotNil(t, status.CompletionTime)
+
+		cm, err := clientset.CoreV1().ConfigMaps(store.Namespace).Get(ctx, status.ReportConfigMap, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, cm.Data[orphanScanReportKey], `"my-bucket":2`)
+		require.Len(t, cm.OwnerReferences, 1)
+		assert.Equal(t, types.UID("store-uid"), cm.OwnerReferences[0].UID)
+	})
+
+	t.Run("removal confirmed", func(t *testing.T) {
+		current := &cephv1.CephObjectStore{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		current.Annotations[OrphanScanRemoveAnnotation] = id
+		require.NoError(t, cl.Update(ctx, current))
+
+		current, requeueAfter := reconcileStore()
+		require.Len(t, jobs, 2)
+		assert.Equal(t, started{id: id, remove: true}, jobs[1])
+		assert.Equal(t, orphanScanCheckInterval, requeueAfter)
+		assert.Equal(t, cephv1.OrphanScanPhaseRemoving, current.Status.OrphanScan.Phase)
+
+		completeJob(`{"id":"` + id + `","removed":true,"orphans":3,"reclaimableBytes":12582912,"staleIndexEntries":2}`)
+		current, _ = reconcileStore()
+		assert.Len(t, jobs, 2)
+		assert.Equal(t, cephv1.OrphanScanPhaseRemoved, current.Status.OrphanScan.Phase)
+	})
+
+	t.Run("scan failed", func(t *testing.T) {
+		current := &cephv1.CephObjectStore{}
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: store.Name, Namespace: store.Namespace}, current))
+		current.Annotations[OrphanScanAnnotation] = "2"
+		require.NoError(t, cl.Update(ctx, current))
+
+		current, _ = reconcileStore()
+		require.Len(t, jobs, 3)
+		assert.False(t, jobs[2].remove)
+		assert.Equal(t, "2", current.Status.OrphanScan.Request)
+
+		job, err := clientset.BatchV1().Jobs(store.Namespace).Get(ctx, orphanScanJobName(store.Name), metav1.GetOptions{})
+		require.NoError(t, err)
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+		_, err = clientset.BatchV1().Jobs(store.Namespace).UpdateStatus(ctx, job, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		current, requeueAfter := reconcileStore()
+		assert.Equal(t, time.Duration(0), requeueAfter)
+		assert.Equal(t, cephv1.OrphanScanPhaseFailed, current.Status.OrphanScan.Phase)
+		assert.Contains(t, current.Status.OrphanScan.Message, "BackoffLimitExceeded")
+		assert.Len(t, jobs, 3)
+	})
+}
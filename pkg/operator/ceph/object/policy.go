@@ -135,6 +135,9 @@ type PolicyStatement struct {
 	// Resource is the ARN identifier for the S3 resource (bucket)
 	// Must be in the format of 'arn:aws:s3:::<bucket>'
 	Resource []string `json:"Resource"`
+	// Condition (optional) restricts the requests the PolicyStatement applies to, keyed by the
+	// condition operator and then by the condition key
+	Condition map[string]map[string]string `json:"Condition,omitempty"`
 }
 
 // BucketPolicy represents set of policy statements for a single bucket.
@@ -267,6 +270,12 @@ func (ps *PolicyStatement) ForPrincipals(users ...string) *PolicyStatement {
 	return ps
 }
 
+// ForAllPrincipals makes the PolicyStatement apply to everyone, including anonymous users
+func (ps *PolicyStatement) ForAllPrincipals() *PolicyStatement {
+	ps.Principal[awsPrinciple] = []string{"*"}
+	return ps
+}
+
 // ForResources adds resources (buckets) to the PolicyStatement with the appropriate ARN prefix
 func (ps *PolicyStatement) ForResources(resources ...string) *PolicyStatement {
 	for _, v := range resources {
@@ -309,6 +318,18 @@ func (ps *PolicyStatement) Actions(actions ...action) *PolicyStatement {
 	return ps
 }
 
+// WithCondition adds a condition to the PolicyStatement, e.g. WithCondition("Null", "s3:prefix", "true")
+func (ps *PolicyStatement) WithCondition(operator, key, value string) *PolicyStatement {
+	if ps.Condition == nil {
+		ps.Condition = map[string]map[string]string{}
+	}
+	if ps.Condition[operator] == nil {
+		ps.Condition[operator] = map[string]string{}
+	}
+	ps.Condition[operator][key] = value
+	return ps
+}
+
 func (ps *PolicyStatement) EjectPrincipals(users ...string) {
 	principals := ps.Principal[awsPrinciple]
 	for _, u := range users {
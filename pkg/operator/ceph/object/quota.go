@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultQuotaNearFullThreshold is the percentage of a quota used above which the quota is near full
+	DefaultQuotaNearFullThreshold int32 = 85
+	// QuotaCheckInterval is the interval at which the usage of users and buckets with a quota is checked
+	QuotaCheckInterval = 5 * time.Minute
+
+	// QuotaNearFullReason is the reason of the event emitted when the near full threshold of a quota is crossed
+	QuotaNearFullReason = "QuotaNearFull"
+	// QuotaFullReason is the reason of the event emitted when a quota is reached
+	QuotaFullReason = "QuotaFull"
+	// QuotaBelowThresholdReason is the reason of the event emitted when the usage of a quota that
+	// was near full falls below the near full threshold
+	QuotaBelowThresholdReason = "QuotaBelowThreshold"
+)
+
+// NewQuotaStatus returns the usage of the given size and objects against the quota, or nil if the
+// quota is disabled or unlimited
+func NewQuotaStatus(quota admin.QuotaSpec, size, objects uint64, threshold int32, now metav1.Time) *cephv1.QuotaStatus {
+	if quota.Enabled == nil || !*quota.Enabled {
+		return nil
+	}
+	// a negative limit is unlimited
+	maxSize := quotaLimit(quota.MaxSize)
+	maxObjects := quotaLimit(quota.MaxObjects)
+	if maxSize == nil && maxObjects == nil {
+		return nil
+	}
+
+	status := &cephv1.QuotaStatus{
+		MaxSize:           maxSize,
+		Size:              int64(size),
+		MaxObjects:        maxObjects,
+		Objects:           int64(objects),
+		NearFullThreshold: threshold,
+		LastChecked:       &now,
+	}
+	if maxSize != nil {
+		status.PercentUsed = max(status.PercentUsed, percentUsed(status.Size, *maxSize))
+	}
+	if maxObjects != nil {
+		status.PercentUsed = max(status.PercentUsed, percentUsed(status.Objects, *maxObjects))
+	}
+
+	switch {
+	case status.PercentUsed >= 100:
+		status.State = cephv1.QuotaStateFull
+	case status.PercentUsed >= threshold:
+		status.State = cephv1.QuotaStateNearFull
+	default:
+		status.State = cephv1.QuotaStateOk
+	}
+	return status
+}
+
+func quotaLimit(limit *int64) *int64 {
+	if limit == nil || *limit < 0 {
+		return nil
+	}
+	value := *limit
+	return &value
+}
+
+func percentUsed(used, limit int64) int32 {
+	if limit == 0 {
+		// nothing can be written
+		return 100
+	}
+	percent := used * 100 / limit
+	// the usage may exceed the limit since the quota is checked before the write completes
+	if percent > 100 {
+		percent = 100
+	}
+	return int32(percent) //nolint:gosec // percent is between 0 and 100
+}
+
+// QuotaEvent returns the event to emit when the usage of a quota crosses the near full threshold
+// or the limit between the previous and the current check. The description names the quota in
+// the message, for example "user quota". No event is emitted when the state did not change or when
+// the usage decreased but is still above the threshold.
+func QuotaEvent(description string, previous, current *cephv1.QuotaStatus) (eventType, reason, message string, ok bool) {
+	previousState := cephv1.QuotaStateOk
+	if previous != nil {
+		previousState = previous.State
+	}
+	currentState := cephv1.QuotaStateOk
+	if current != nil {
+		currentState = current.State
+	}
+	if previousState == currentState {
+		return "", "", "", false
+	}
+
+	switch currentState {
+	case cephv1.QuotaStateFull:
+		return corev1.EventTypeWarning, QuotaFullReason,
+			fmt.Sprintf("%s is full, writes are rejected", description), true
+	case cephv1.QuotaStateNearFull:
+		if previousState == cephv1.QuotaStateFull {
+			return "", "", "", false
+		}
+		return corev1.EventTypeWarning, QuotaNearFullReason,
+			fmt.Sprintf("%s is %d%% used, above the near full threshold of %d%%", description, current.PercentUsed, current.NearFullThreshold), true
+	default:
+		if current == nil {
+			// the quota was removed
+			return "", "", "", false
+		}
+		return corev1.EventTypeNormal, QuotaBelowThresholdReason,
+			fmt.Sprintf("%s is %d%% used, below the near full threshold of %d%%", description, current.PercentUsed, current.NearFullThreshold), true
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewQuotaStatus(t *testing.T) {
+	now := metav1.Now()
+	enabled, disabled := true, false
+	limit := func(l int64) *int64 { return &l }
+
+	// disabled or unlimited quotas are not reported
+	assert.Nil(t, NewQuotaStatus(admin.QuotaSpec{Enabled: &disabled, MaxSize: limit(100)}, 10, 1, 85, now))
+	assert.Nil(t, NewQuotaStatus(admin.QuotaSpec{MaxSize: limit(100)}, 10, 1, 85, now))
+	assert.Nil(t, NewQuotaStatus(admin.QuotaSpec{Enabled: &enabled, MaxSize: limit(-1), MaxObjects: limit(-1)}, 10, 1, 85, now))
+
+	status := NewQuotaStatus(admin.QuotaSpec{Enabled: &enabled, MaxSize: limit(1000), MaxObjects: limit(-1)}, 500, 30, 85, now)
+	assert.Equal(t, &cephv1.QuotaStatus{
+		MaxSize: limit(1000), Size: 500, Objects: 30, PercentUsed: 50, NearFullThreshold: 85, State: cephv1.QuotaStateOk, LastChecked: &now,
+	}, status)
+
+	// the highest usage of the size and objects is reported
+	status = NewQuotaStatus(admin.QuotaSpec{Enabled: &enabled, MaxSize: limit(1000), MaxObjects: limit(40)}, 500, 36, 85, now)
+	assert.Equal(t, int32(90), status.PercentUsed)
+	assert.Equal(t, cephv1.QuotaStateNearFull, status.State)
+
+	status = NewQuotaStatus(admin.QuotaSpec{Enabled: &enabled, MaxObjects: limit(40)}, 500, 41, 85, now)
+	assert.Equal(t, int32(100), status.PercentUsed)
+	assert.Equal(t, cephv1.QuotaStateFull, status.State)
+
+	status = NewQuotaStatus(admin.QuotaSpec{Enabled: &enabled, MaxObjects: limit(0)}, 0, 0, 85, now)
+	assert.Equal(t, cephv1.QuotaStateFull, status.State)
+}
+
+func TestQuotaEvent(t *testing.T) {
+	quota := func(state cephv1.QuotaState, percent int32) *cephv1.QuotaStatus {
+		return &cephv1.QuotaStatus{State: state, PercentUsed: percent, NearFullThreshold: 80}
+	}
+	ok := quota(cephv1.QuotaStateOk, 50)
+	nearFull := quota(cephv1.QuotaStateNearFull, 85)
+	full := quota(cephv1.QuotaStateFull, 100)
+
+	tests := []struct {
+		name      string
+		previous  *cephv1.QuotaStatus
+		current   *cephv1.QuotaStatus
+		eventType string
+		reason    string
+		message   string
+	}{
+		{"new quota", nil, ok, "", "", ""},
+		{"no change", nearFull, nearFull, "", "", ""},
+		{"new near full quota", nil, nearFull, corev1.EventTypeWarning, QuotaNearFullReason, "bucket quota is 85% used, above the near full threshold of 80%"},
+		{"near full", ok, nearFull, corev1.EventTypeWarning, QuotaNearFullReason, "bucket quota is 85% used, above the near full threshold of 80%"},
+		{"full", nearFull, full, corev1.EventTypeWarning, QuotaFullReason, "bucket quota is full, writes are rejected"},
+		{"no longer full", full, nearFull, "", "", ""},
+		{"below threshold", full, ok, corev1.EventTypeNormal, QuotaBelowThresholdReason, "bucket quota is 50% used, below the near full threshold of 80%"},
+		{"quota removed", nearFull, nil, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventType, reason, message, emit := QuotaEvent("bucket quota", tt.previous, tt.current)
+			assert.Equal(t, tt.reason != "", emit)
+			assert.Equal(t, tt.eventType, eventType)
+			assert.Equal(t, tt.reason, reason)
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
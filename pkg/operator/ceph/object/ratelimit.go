@@ -0,0 +1,271 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RateLimitScope is the scope a rate limit applies to
+type RateLimitScope string
+
+const (
+	// RateLimitScopeUser applies the rate limit to a user
+	RateLimitScopeUser RateLimitScope = "user"
+	// RateLimitScopeBucket applies the rate limit to a bucket
+	RateLimitScopeBucket RateLimitScope = "bucket"
+	// RateLimitScopeAnonymous applies the rate limit to unauthenticated requests
+	RateLimitScopeAnonymous RateLimitScope = "anonymous"
+)
+
+// rgwRateLimit is the rate limit as reported by 'radosgw-admin ratelimit get'
+type rgwRateLimit struct {
+	MaxReadOps    int64 `json:"max_read_ops"`
+	MaxWriteOps   int64 `json:"max_write_ops"`
+	MaxReadBytes  int64 `json:"max_read_bytes"`
+	MaxWriteBytes int64 `json:"max_write_bytes"`
+	Enabled       bool  `json:"enabled"`
+}
+
+type rgwRateLimits struct {
+	User      *rgwRateLimit `json:"user_ratelimit,omitempty"`
+	Bucket    *rgwRateLimit `json:"bucket_ratelimit,omitempty"`
+	Anonymous *rgwRateLimit `json:"anonymous_ratelimit,omitempty"`
+}
+
+func (l *rgwRateLimit) toStatus() *cephv1.RateLimitStatus {
+	if l == nil {
+		return nil
+	}
+	return &cephv1.RateLimitStatus{
+		Enabled:       l.Enabled,
+		MaxReadOps:    l.MaxReadOps,
+		MaxWriteOps:   l.MaxWriteOps,
+		MaxReadBytes:  l.MaxReadBytes,
+		MaxWriteBytes: l.MaxWriteBytes,
+	}
+}
+
+// rateLimitArgs returns the radosgw-admin flags for the given rate limit. Limits that are not set
+// are reset to 0, which RGW treats as unlimited.
+func rateLimitArgs(limit *cephv1.RateLimitSpec) []string {
+	var maxReadOps, maxWriteOps, maxReadBytes, maxWriteBytes int64
+	if limit.MaxReadOps != nil {
+		maxReadOps = *limit.MaxReadOps
+	}
+	if limit.MaxWriteOps != nil {
+		maxWriteOps = *limit.MaxWriteOps
+	}
+	if limit.MaxReadBytes != nil {
+		maxReadBytes = limit.MaxReadBytes.Value()
+	}
+	if limit.MaxWriteBytes != nil {
+		maxWriteBytes = limit.MaxWriteBytes.Value()
+	}
+	return []string{
+		fmt.Sprintf("--max-read-ops=%d", maxReadOps),
+		fmt.Sprintf("--max-write-ops=%d", maxWriteOps),
+		fmt.Sprintf("--max-read-bytes=%d", maxReadBytes),
+		fmt.Sprintf("--max-write-bytes=%d", maxWriteBytes),
+	}
+}
+
+// ValidateRateLimit checks that none of the limits of the rate limit are negative
+func ValidateRateLimit(limit *cephv1.RateLimitSpec) error {
+	if limit == nil {
+		return nil
+	}
+	if limit.MaxReadOps != nil && *limit.MaxReadOps < 0 {
+		return errors.Errorf("invalid maxReadOps %d, must not be negative", *limit.MaxReadOps)
+	}
+	if limit.MaxWriteOps != nil && *limit.MaxWriteOps < 0 {
+		return errors.Errorf("invalid maxWriteOps %d, must not be negative", *limit.MaxWriteOps)
+	}
+	if limit.MaxReadBytes != nil && limit.MaxReadBytes.Sign() < 0 {
+		return errors.Errorf("invalid maxReadBytes %q, must not be negative", limit.MaxReadBytes.String())
+	}
+	if limit.MaxWriteBytes != nil && limit.MaxWriteBytes.Sign() < 0 {
+		return errors.Errorf("invalid maxWriteBytes %q, must not be negative", limit.MaxWriteBytes.String())
+	}
+	return nil
+}
+
+func rateLimitTargetArgs(scope RateLimitScope, id string) ([]string, error) {
+	switch scope {
+	case RateLimitScopeUser:
+		return []string{"--ratelimit-scope=user", "--uid", id}, nil
+	case RateLimitScopeBucket:
+		return []string{"--ratelimit-scope=bucket", "--bucket", id}, nil
+	}
+	return nil, errors.Errorf("invalid rate limit scope %q", scope)
+}
+
+// SetRateLimit applies the rate limit to the given user or bucket. If the limit is nil, the rate
+// limit of the user or bucket is disabled.
+func SetRateLimit(c *Context, scope RateLimitScope, id string, limit *cephv1.RateLimitSpec) error {
+	targetArgs, err := rateLimitTargetArgs(scope, id)
+	if err != nil {
+		return err
+	}
+
+	if limit == nil {
+		_, err = runAdminCommand(c, false, append([]string{"ratelimit", "disable"}, targetArgs...)...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to disable the rate limit of %s %q", scope, id)
+		}
+		return nil
+	}
+
+	args := append([]string{"ratelimit", "set"}, targetArgs...)
+	_, err = runAdminCommand(c, false, append(args, rateLimitArgs(limit)...)...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set the rate limit of %s %q", scope, id)
+	}
+	_, err = runAdminCommand(c, false, append([]string{"ratelimit", "enable"}, targetArgs...)...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to enable the rate limit of %s %q", scope, id)
+	}
+	logger.Debugf("set the rate limit of %s %q", scope, id)
+
+	return nil
+}
+
+// GetRateLimit returns the rate limit currently applied to the given user or bucket
+func GetRateLimit(c *Context, scope RateLimitScope, id string) (*cephv1.RateLimitStatus, error) {
+	targetArgs, err := rateLimitTargetArgs(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runAdminCommand(c, true, append([]string{"ratelimit", "get"}, targetArgs...)...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the rate limit of %s %q", scope, id)
+	}
+	var limits rgwRateLimits
+	if err := json.Unmarshal([]byte(output), &limits); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the rate limit of %s %q. %s", scope, id, output)
+	}
+
+	if scope == RateLimitScopeUser {
+		return limits.User.toStatus(), nil
+	}
+	return limits.Bucket.toStatus(), nil
+}
+
+// setGlobalRateLimits applies the global default rate limits of the object store. Scopes without a
+// rate limit in the spec are disabled. The changes are written to the period, which must be
+// committed afterwards for the RGWs to pick them up.
+func setGlobalRateLimits(c *Context, spec *cephv1.ObjectStoreRateLimitSpec) error {
+	scopes := []struct {
+		scope RateLimitScope
+		limit *cephv1.RateLimitSpec
+	}{
+		{RateLimitScopeUser, spec.User},
+		{RateLimitScopeBucket, spec.Bucket},
+		{RateLimitScopeAnonymous, spec.Anonymous},
+	}
+	for _, s := range scopes {
+		scopeArg := fmt.Sprintf("--ratelimit-scope=%s", s.scope)
+		if s.limit == nil {
+			_, err := runAdminCommand(c, false, "global", "ratelimit", "disable", scopeArg)
+			if err != nil {
+				return errors.Wrapf(err, "failed to disable the global %s rate limit", s.scope)
+			}
+			continue
+		}
+
+		args := append([]string{"global", "ratelimit", "set", scopeArg}, rateLimitArgs(s.limit)...)
+		if _, err := runAdminCommand(c, false, args...); err != nil {
+			return errors.Wrapf(err, "failed to set the global %s rate limit", s.scope)
+		}
+		if _, err := runAdminCommand(c, false, "global", "ratelimit", "enable", scopeArg); err != nil {
+			return errors.Wrapf(err, "failed to enable the global %s rate limit", s.scope)
+		}
+	}
+
+	return nil
+}
+
+// getGlobalRateLimits returns the global rate limits currently applied by the object store
+func getGlobalRateLimits(c *Context) (*cephv1.ObjectStoreRateLimitStatus, error) {
+	output, err := runAdminCommand(c, true, "global", "ratelimit", "get")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the global rate limits")
+	}
+	var limits rgwRateLimits
+	if err := json.Unmarshal([]byte(output), &limits); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the global rate limits. %s", output)
+	}
+
+	return &cephv1.ObjectStoreRateLimitStatus{
+		User:      limits.User.toStatus(),
+		Bucket:    limits.Bucket.toStatus(),
+		Anonymous: limits.Anonymous.toStatus(),
+	}, nil
+}
+
+// reconcileGlobalRateLimits applies the global rate limits of the object store and returns the
+// rate limits that are in effect. A nil spec disables all the global rate limits.
+func reconcileGlobalRateLimits(c *Context, spec *cephv1.ObjectStoreRateLimitSpec) (*cephv1.ObjectStoreRateLimitStatus, error) {
+	disable := spec == nil
+	if disable {
+		spec = &cephv1.ObjectStoreRateLimitSpec{}
+	}
+
+	if err := setGlobalRateLimits(c, spec); err != nil {
+		return nil, err
+	}
+	if err := commitConfigChanges(c); err != nil {
+		return nil, errors.Wrap(err, "failed to commit the global rate limits")
+	}
+	if disable {
+		return nil, nil
+	}
+
+	return getGlobalRateLimits(c)
+}
+
+// updateRateLimitStatus records the global rate limits in the object store status
+func updateRateLimitStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName, rateLimits *cephv1.ObjectStoreRateLimitStatus) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := k8sClient.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update the rate limit status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.RateLimits = rateLimits
+		return reporting.UpdateStatus(k8sClient, objectStore)
+	})
+	if err != nil {
+		logger.Errorf("failed to update the rate limit status of object store %q. %v", namespacedName.String(), err)
+	}
+}
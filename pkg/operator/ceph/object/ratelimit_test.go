@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func newRateLimitTestContext(commands *[]string, output string) *Context {
+	executor := &exectest.MockExecutor{
+		MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+			// only keep the radosgw-admin args set by the rate limit functions
+			var cmd []string
+			for _, arg := range args {
+				if strings.HasPrefix(arg, "--cluster") || strings.HasPrefix(arg, "--conf") || strings.HasPrefix(arg, "--name") || strings.HasPrefix(arg, "--keyring") {
+					break
+				}
+				cmd = append(cmd, arg)
+			}
+			*commands = append(*commands, strings.Join(cmd, " "))
+			return output, nil
+		},
+	}
+	return NewContext(&clusterd.Context{Executor: executor}, cephclient.AdminTestClusterInfo("ns"), "")
+}
+
+func TestSetRateLimit(t *testing.T) {
+	t.Run("set user rate limit", func(t *testing.T) {
+		var commands []string
+		c := newRateLimitTestContext(&commands, "")
+		maxReadOps := int64(100)
+		maxWriteBytes := resource.MustParse("1Mi")
+		err := SetRateLimit(c, RateLimitScopeUser, "alice", &cephv1.RateLimitSpec{MaxReadOps: &maxReadOps, MaxWriteBytes: &maxWriteBytes})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"ratelimit set --ratelimit-scope=user --uid alice --max-read-ops=100 --max-write-ops=0 --max-read-bytes=0 --max-write-bytes=1048576",
+			"ratelimit enable --ratelimit-scope=user --uid alice",
+		}, commands)
+	})
+
+	t.Run("disable bucket rate limit", func(t *testing.T) {
+		var commands []string
+		c := newRateLimitTestContext(&commands, "")
+		err := SetRateLimit(c, RateLimitScopeBucket, "my-bucket", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ratelimit disable --ratelimit-scope=bucket --bucket my-bucket"}, commands)
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		var commands []string
+		c := newRateLimitTestContext(&commands, "")
+		err := SetRateLimit(c, RateLimitScopeAnonymous, "", nil)
+		assert.Error(t, err)
+		assert.Empty(t, commands)
+	})
+}
+
+func TestGetRateLimit(t *testing.T) {
+	var commands []string
+	c := newRateLimitTestContext(&commands, `{"bucket_ratelimit":{"max_read_ops":10,"max_write_ops":5,"max_read_bytes":0,"max_write_bytes":1024,"enabled":true}}`)
+	limit, err := GetRateLimit(c, RateLimitScopeBucket, "my-bucket")
+	assert.NoError(t, err)
+	assert.Equal(t, &cephv1.RateLimitStatus{Enabled: true, MaxReadOps: 10, MaxWriteOps: 5, MaxWriteBytes: 1024}, limit)
+	assert.Equal(t, []string{"ratelimit get --ratelimit-scope=bucket --bucket my-bucket"}, commands)
+}
+
+func TestReconcileGlobalRateLimits(t *testing.T) {
+	commitCalled := false
+	commitConfigChanges = func(c *Context) error {
+		commitCalled = true
+		return nil
+	}
+	defer func() { commitConfigChanges = CommitConfigChanges }()
+
+	globalLimits := `{"bucket_ratelimit":{"max_read_ops":0,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":false},` +
+		`"user_ratelimit":{"max_read_ops":0,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":false},` +
+		`"anonymous_ratelimit":{"max_read_ops":50,"max_write_ops":0,"max_read_bytes":0,"max_write_bytes":0,"enabled":true}}`
+
+	t.Run("set anonymous rate limit", func(t *testing.T) {
+		var commands []string
+		c := newRateLimitTestContext(&commands, globalLimits)
+		maxReadOps := int64(50)
+		status, err := reconcileGlobalRateLimits(c, &cephv1.ObjectStoreRateLimitSpec{Anonymous: &cephv1.RateLimitSpec{MaxReadOps: &maxReadOps}})
+		assert.NoError(t, err)
+		assert.True(t, commitCalled)
+		assert.Equal(t, []string{
+			"global ratelimit disable --ratelimit-scope=user",
+			"global ratelimit disable --ratelimit-scope=bucket",
+			"global ratelimit set --ratelimit-scope=anonymous --max-read-ops=50 --max-write-ops=0 --max-read-bytes=0 --max-write-bytes=0",
+			"global ratelimit enable --ratelimit-scope=anonymous",
+			"global ratelimit get",
+		}, commands)
+		require.NotNil(t, status)
+		assert.False(t, status.User.Enabled)
+		assert.Equal(t, &cephv1.RateLimitStatus{Enabled: true, MaxReadOps: 50}, status.Anonymous)
+	})
+
+	t.Run("rate limits removed from the spec", func(t *testing.T) {
+		commitCalled = false
+		var commands []string
+		c := newRateLimitTestContext(&commands, "")
+		status, err := reconcileGlobalRateLimits(c, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, status)
+		assert.True(t, commitCalled)
+		assert.Equal(t, []string{
+			"global ratelimit disable --ratelimit-scope=user",
+			"global ratelimit disable --ratelimit-scope=bucket",
+			"global ratelimit disable --ratelimit-scope=anonymous",
+		}, commands)
+	})
+}
+
+func TestValidateRateLimit(t *testing.T) {
+	assert.NoError(t, ValidateRateLimit(nil))
+
+	maxReadOps := int64(10)
+	maxWriteBytes := resource.MustParse("10Mi")
+	assert.NoError(t, ValidateRateLimit(&cephv1.RateLimitSpec{MaxReadOps: &maxReadOps, MaxWriteBytes: &maxWriteBytes}))
+
+	negativeOps := int64(-1)
+	assert.Error(t, ValidateRateLimit(&cephv1.RateLimitSpec{MaxWriteOps: &negativeOps}))
+	negativeBytes := resource.MustParse("-1Ki")
+	assert.Error(t, ValidateRateLimit(&cephv1.RateLimitSpec{MaxReadBytes: &negativeBytes}))
+}
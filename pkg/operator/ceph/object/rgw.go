@@ -55,6 +55,8 @@ type clusterConfig struct {
 	DataPathMap           *config.DataPathMap
 	client                client.Client
 	shouldRotateCephxKeys bool
+	// rookImage runs the ops log sidecar when it forwards entries to sinks
+	rookImage string
 }
 
 type rgwConfig struct {
@@ -66,6 +68,7 @@ type rgwConfig struct {
 
 	Auth           cephv1.AuthSpec
 	KeystoneSecret *v1.Secret
+	STSKey         string
 	Protocols      cephv1.ProtocolSpec
 }
 
@@ -116,6 +119,12 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 		return nil
 	}
 
+	// Retrieve or generate the key of the secure token service if enabled
+	stsKey, err := c.getSTSKey()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the STS key")
+	}
+
 	// start a new deployment and scale up
 	// We force a single deployment and later set the deployment replica to the "instances" value
 	desiredRgwInstances := 1
@@ -138,6 +147,7 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 			Auth:           c.store.Spec.Auth,
 			Protocols:      c.store.Spec.Protocols,
 			KeystoneSecret: keystoneSecret,
+			STSKey:         stsKey,
 		}
 
 		// We set the owner reference of the Secret to the Object controller instead of the replicaset
@@ -196,6 +206,10 @@ func (c *clusterConfig) startRGWPods(realmName, zoneGroupName, zoneName string,
 			}
 		}
 
+		if err := c.reconcileAutoscaler(deployment.Name); err != nil {
+			return errors.Wrapf(err, "failed to reconcile the autoscaling of object store %q", c.store.Name)
+		}
+
 		// Generate the mime.types file after the rep. controller as well for the same reason as keyring
 		if err := c.generateMimeTypes(); err != nil {
 			return errors.Wrap(err, "failed to generate the rgw mime.types config")
@@ -329,6 +343,36 @@ func (r *ReconcileCephObjectStore) validateStore(s *cephv1.CephObjectStore) erro
 		}
 	}
 
+	if rateLimits := s.Spec.RateLimits; rateLimits != nil {
+		if s.Spec.IsExternal() {
+			return errors.New("global rate limits cannot be configured for an external object store")
+		}
+		for _, limit := range []*cephv1.RateLimitSpec{rateLimits.User, rateLimits.Bucket, rateLimits.Anonymous} {
+			if err := ValidateRateLimit(limit); err != nil {
+				return errors.Wrap(err, "invalid global rate limit")
+			}
+		}
+	}
+
+	if s.Spec.UsageExporter != nil && s.Spec.IsExternal() {
+		return errors.New("the usage exporter cannot be enabled for an external object store")
+	}
+
+	if opsLogSidecar := s.Spec.Gateway.OpsLogSidecar; opsLogSidecar != nil {
+		if err := validateOpsLogSidecar(opsLogSidecar); err != nil {
+			return errors.Wrap(err, "invalid ops log sidecar settings")
+		}
+	}
+
+	if sts := s.Spec.Auth.STS; sts != nil {
+		if s.Spec.IsExternal() {
+			return errors.New("the secure token service cannot be configured for an external object store")
+		}
+		if err := validateSTS(sts); err != nil {
+			return errors.Wrap(err, "invalid secure token service settings")
+		}
+	}
+
 	return nil
 }
 
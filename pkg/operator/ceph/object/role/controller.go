@@ -0,0 +1,540 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectrole to manage the IAM roles of a rook object store.
+package objectrole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+)
+
+const (
+	controllerName = "ceph-object-store-role-controller"
+	// the default path of IAM roles
+	defaultRolePath = "/"
+	// the default maximum session duration of IAM roles in RGW, in seconds
+	defaultMaxSessionDuration int64 = 3600
+)
+
+// newMultisiteAdminOpsCtxFunc help us mocking the admin ops API client in unit test
+var newMultisiteAdminOpsCtxFunc = object.NewMultisiteAdminOpsContext
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+var cephObjectStoreRoleKind = reflect.TypeOf(cephv1.CephObjectStoreRole{}).Name()
+
+// Sets the type meta for the controller main object
+var controllerTypeMeta = metav1.TypeMeta{
+	Kind:       cephObjectStoreRoleKind,
+	APIVersion: fmt.Sprintf("%s/%s", cephv1.CustomResourceGroup, cephv1.Version),
+}
+
+// ReconcileObjectStoreRole reconciles a CephObjectStoreRole object
+type ReconcileObjectStoreRole struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	context          *clusterd.Context
+	iamClient        iamiface.IAMAPI
+	clusterInfo      *cephclient.ClusterInfo
+	opManagerContext context.Context
+	recorder         record.EventRecorder
+}
+
+// Add creates a new CephObjectStoreRole Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return add(mgr, newReconciler(mgr, context, opManagerContext))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context) reconcile.Reconciler {
+	return &ReconcileObjectStoreRole{
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		context:          context,
+		opManagerContext: opManagerContext,
+		recorder:         mgr.GetEventRecorderFor("rook-" + controllerName),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the CephObjectStoreRole CRD object
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephObjectStoreRole{TypeMeta: controllerTypeMeta},
+			&handler.TypedEnqueueRequestForObject[*cephv1.CephObjectStoreRole]{},
+			opcontroller.WatchControllerPredicate[*cephv1.CephObjectStoreRole](mgr.GetScheme()),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reconcile reads that state of the cluster for a CephObjectStoreRole object and makes changes based on the state read
+// and what is in the CephObjectStoreRole.Spec
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileObjectStoreRole) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, cephObjectStoreRole, err := r.reconcile(request)
+	if err != nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, k8sutil.ReconcileFailedStatus, "")
+		logger.Errorf("failed to reconcile %v", err)
+	}
+
+	return reporting.ReportReconcileResult(logger, r.recorder, request, &cephObjectStoreRole, reconcileResponse, err)
+}
+
+func (r *ReconcileObjectStoreRole) reconcile(request reconcile.Request) (reconcile.Result, cephv1.CephObjectStoreRole, error) {
+	// Fetch the CephObjectStoreRole instance
+	cephObjectStoreRole := &cephv1.CephObjectStoreRole{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, cephObjectStoreRole)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectStoreRole resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, *cephObjectStoreRole, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, *cephObjectStoreRole, errors.Wrap(err, "failed to get CephObjectStoreRole")
+	}
+	// update observedGeneration local variable with current generation value,
+	// because generation can be changed before reconcile got completed
+	// CR status will be updated at end of reconcile, so to reflect the reconcile has finished
+	observedGeneration := cephObjectStoreRole.ObjectMeta.Generation
+
+	// Set a finalizer so we can do cleanup before the object goes away
+	generationUpdated, err := opcontroller.AddFinalizerIfNotPresent(r.opManagerContext, r.client, cephObjectStoreRole)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreRole, errors.Wrap(err, "failed to add finalizer")
+	}
+	if generationUpdated {
+		logger.Infof("reconciling the object role %q after adding finalizer", cephObjectStoreRole.Name)
+		return reconcile.Result{}, *cephObjectStoreRole, nil
+	}
+
+	// The CR was just created, initializing status fields
+	if cephObjectStoreRole.Status == nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, k8sutil.EmptyStatus, "")
+	}
+
+	// Make sure a CephCluster is present otherwise do nothing
+	cephCluster, isReadyToReconcile, cephClusterExists, reconcileResponse := opcontroller.IsReadyToReconcile(r.opManagerContext, r.client, request.NamespacedName, controllerName)
+	if !isReadyToReconcile {
+		// This handles the case where the Ceph Cluster is gone and we want to delete that CR
+		// We skip the deleteRole() function since everything is gone already
+		//
+		// Also, only remove the finalizer if the CephCluster is gone
+		// If not, we should wait for it to be ready
+		// This handles the case where the operator is not ready to accept Ceph command but the cluster exists
+		if !cephObjectStoreRole.GetDeletionTimestamp().IsZero() && !cephClusterExists {
+			// Remove finalizer
+			err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStoreRole)
+			if err != nil {
+				return reconcile.Result{}, *cephObjectStoreRole, errors.Wrap(err, "failed to remove finalizer")
+			}
+
+			// Return and do not requeue. Successful deletion.
+			return reconcile.Result{}, *cephObjectStoreRole, nil
+		}
+		return reconcileResponse, *cephObjectStoreRole, nil
+	}
+
+	// Populate clusterInfo during each reconcile
+	r.clusterInfo, _, _, err = opcontroller.LoadClusterInfo(r.context, r.opManagerContext, request.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreRole, errors.Wrap(err, "failed to populate cluster info")
+	}
+
+	// Validate the object store has been initialized
+	err = r.initializeIAMClient(cephObjectStoreRole)
+	if err != nil {
+		if !cephObjectStoreRole.GetDeletionTimestamp().IsZero() {
+			// Remove finalizer
+			err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStoreRole)
+			if err != nil {
+				return reconcile.Result{}, *cephObjectStoreRole, errors.Wrap(err, "failed to remove finalizer")
+			}
+			r.recorder.Event(cephObjectStoreRole, corev1.EventTypeNormal, string(cephv1.ReconcileSucceeded), "successfully removed finalizer")
+
+			// Return and do not requeue. Successful deletion.
+			return reconcile.Result{}, *cephObjectStoreRole, nil
+		}
+		logger.Debugf("ObjectStore resource not ready in namespace %q, retrying in %q. %v",
+			request.Namespace, opcontroller.WaitForRequeueIfCephClusterNotReady.RequeueAfter.String(), err)
+		return opcontroller.WaitForRequeueIfCephClusterNotReady, *cephObjectStoreRole, err
+	}
+
+	// DELETE: the CR was deleted
+	if !cephObjectStoreRole.GetDeletionTimestamp().IsZero() {
+		logger.Debugf("deleting object store role %q", request.NamespacedName)
+		r.recorder.Eventf(cephObjectStoreRole, corev1.EventTypeNormal, string(cephv1.ReconcileStarted), "deleting CephObjectStoreRole %q", cephObjectStoreRole.Name)
+
+		err := r.deleteRole(cephObjectStoreRole)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreRole, errors.Wrapf(err, "failed to delete ceph object role %q", cephObjectStoreRole.Name)
+		}
+
+		// Remove finalizer
+		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStoreRole)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreRole, errors.Wrap(err, "failed to remove finalizer")
+		}
+		r.recorder.Event(cephObjectStoreRole, corev1.EventTypeNormal, string(cephv1.ReconcileSucceeded), "successfully removed finalizer")
+
+		// Return and do not requeue. Successful deletion.
+		return reconcile.Result{}, *cephObjectStoreRole, nil
+	}
+
+	// validate the role settings
+	err = validateRole(cephObjectStoreRole)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreRole, errors.Wrapf(err, "invalid object store role CR %q spec", cephObjectStoreRole.Name)
+	}
+
+	// CREATE/UPDATE ROLE
+	arn, err := r.createOrUpdateRole(cephObjectStoreRole)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreRole, errors.Wrapf(err, "failed to create/update object store role %q", cephObjectStoreRole.Name)
+	}
+
+	err = r.reconcileRolePolicies(cephObjectStoreRole)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreRole, errors.Wrapf(err, "failed to reconcile the policies of object store role %q", cephObjectStoreRole.Name)
+	}
+
+	// update ObservedGeneration in status at the end of reconcile
+	// Set Ready status, we are done reconciling
+	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus, arn)
+
+	// Return and do not requeue
+	logger.Debug("done reconciling")
+	return reconcile.Result{}, *cephObjectStoreRole, nil
+}
+
+func (r *ReconcileObjectStoreRole) initializeIAMClient(role *cephv1.CephObjectStoreRole) error {
+	store, err := r.getObjectStore(role)
+	if err != nil {
+		return err
+	}
+	if store.Spec.Auth.STS == nil {
+		return errors.Errorf("object store %q does not have the secure token service enabled", role.Spec.Store)
+	}
+
+	err = r.objectStoreInitialized(role)
+	if err != nil {
+		return errors.Wrapf(err, "failed to detect if object store %q is initialized", role.Spec.Store)
+	}
+
+	objContext, err := object.NewMultisiteContext(r.context, r.clusterInfo, store)
+	if err != nil {
+		return errors.Wrapf(err, "Multisite failed to set on object context for object store role")
+	}
+
+	opsContext, err := newMultisiteAdminOpsCtxFunc(objContext, &store.Spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialized rgw admin ops client api")
+	}
+
+	r.iamClient, err = object.NewIAMClientFunc(opsContext, &store.Spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to initialize the IAM API client")
+	}
+
+	return nil
+}
+
+func (r *ReconcileObjectStoreRole) getObjectStore(role *cephv1.CephObjectStoreRole) (*cephv1.CephObjectStore, error) {
+	store := &cephv1.CephObjectStore{}
+	err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: role.Spec.Store, Namespace: role.Namespace}, store)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get CephObjectStore %q referenced by CephObjectStoreRole %q", role.Spec.Store, client.ObjectKeyFromObject(role))
+	}
+	return store, nil
+}
+
+func (r *ReconcileObjectStoreRole) objectStoreInitialized(role *cephv1.CephObjectStoreRole) error {
+	// check if at least one RGW pod is running, the IAM API is served by the RGWs
+	pods := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(role.Namespace),
+		client.MatchingLabels(map[string]string{"rgw": role.Spec.Store, k8sutil.AppAttr: object.AppName}),
+	}
+	err := r.client.List(r.opManagerContext, pods, listOpts...)
+	if err != nil {
+		return errors.Wrap(err, "failed to list rgw pods")
+	}
+	if len(pods.Items) == 0 {
+		return errors.New("no rgw pod found")
+	}
+
+	logger.Debugf("%d RGW pods found where object store role %q is created", len(pods.Items), role.Name)
+	return nil
+}
+
+// createOrUpdateRole creates the role, or updates its trust policy and maximum session duration,
+// and returns the ARN of the role
+func (r *ReconcileObjectStoreRole) createOrUpdateRole(role *cephv1.CephObjectStoreRole) (string, error) {
+	maxSessionDuration := defaultMaxSessionDuration
+	if role.Spec.MaxSessionDuration != nil {
+		maxSessionDuration = *role.Spec.MaxSessionDuration
+	}
+
+	output, err := r.iamClient.GetRole(&iam.GetRoleInput{RoleName: aws.String(role.Name)})
+	if err != nil {
+		if !object.IsIAMNotFound(err) {
+			return "", errors.Wrapf(err, "failed to get role %q", role.Name)
+		}
+
+		path := role.Spec.Path
+		if path == "" {
+			path = defaultRolePath
+		}
+		created, err := r.iamClient.CreateRole(&iam.CreateRoleInput{
+			RoleName:                 aws.String(role.Name),
+			Path:                     aws.String(path),
+			AssumeRolePolicyDocument: aws.String(role.Spec.AssumeRolePolicyDocument),
+			MaxSessionDuration:       aws.Int64(maxSessionDuration),
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create role %q", role.Name)
+		}
+		logger.Infof("created ceph object role %q", role.Name)
+		return aws.StringValue(created.Role.Arn), nil
+	}
+
+	existing := output.Role
+	if role.Spec.Path != "" && role.Spec.Path != aws.StringValue(existing.Path) {
+		logger.Warningf("the path of ceph object role %q cannot be changed from %q to %q", role.Name, aws.StringValue(existing.Path), role.Spec.Path)
+	}
+
+	if !object.PolicyDocumentsEqual(aws.StringValue(existing.AssumeRolePolicyDocument), role.Spec.AssumeRolePolicyDocument) {
+		_, err = r.iamClient.UpdateAssumeRolePolicy(&iam.UpdateAssumeRolePolicyInput{
+			RoleName:       aws.String(role.Name),
+			PolicyDocument: aws.String(role.Spec.AssumeRolePolicyDocument),
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to update the trust policy of role %q", role.Name)
+		}
+		logger.Infof("updated the trust policy of ceph object role %q", role.Name)
+	}
+
+	if aws.Int64Value(existing.MaxSessionDuration) != maxSessionDuration {
+		_, err = r.iamClient.UpdateRole(&iam.UpdateRoleInput{
+			RoleName:           aws.String(role.Name),
+			MaxSessionDuration: aws.Int64(maxSessionDuration),
+		})
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to update the maximum session duration of role %q", role.Name)
+		}
+		logger.Infof("updated the maximum session duration of ceph object role %q to %d seconds", role.Name, maxSessionDuration)
+	}
+
+	return aws.StringValue(existing.Arn), nil
+}
+
+// reconcileRolePolicies puts the policies of the spec that differ from the ones of the role, and
+// deletes the policies of the role that are not in the spec
+func (r *ReconcileObjectStoreRole) reconcileRolePolicies(role *cephv1.CephObjectStoreRole) error {
+	existing, err := r.listRolePolicies(role.Name)
+	if err != nil {
+		return err
+	}
+
+	desired := map[string]bool{}
+	for _, policy := range role.Spec.Policies {
+		desired[policy.Name] = true
+		if existing[policy.Name] {
+			current, err := r.iamClient.GetRolePolicy(&iam.GetRolePolicyInput{
+				RoleName:   aws.String(role.Name),
+				PolicyName: aws.String(policy.Name),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "failed to get policy %q of role %q", policy.Name, role.Name)
+			}
+			if object.PolicyDocumentsEqual(aws.StringValue(current.PolicyDocument), policy.Document) {
+				continue
+			}
+		}
+
+		_, err = r.iamClient.PutRolePolicy(&iam.PutRolePolicyInput{
+			RoleName:       aws.String(role.Name),
+			PolicyName:     aws.String(policy.Name),
+			PolicyDocument: aws.String(policy.Document),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to put policy %q of role %q", policy.Name, role.Name)
+		}
+		logger.Infof("put policy %q of ceph object role %q", policy.Name, role.Name)
+	}
+
+	for name := range existing {
+		if desired[name] {
+			continue
+		}
+		if err := r.deleteRolePolicy(role.Name, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ReconcileObjectStoreRole) listRolePolicies(roleName string) (map[string]bool, error) {
+	output, err := r.iamClient.ListRolePolicies(&iam.ListRolePoliciesInput{RoleName: aws.String(roleName)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the policies of role %q", roleName)
+	}
+	policies := map[string]bool{}
+	for _, name := range output.PolicyNames {
+		policies[aws.StringValue(name)] = true
+	}
+	return policies, nil
+}
+
+func (r *ReconcileObjectStoreRole) deleteRolePolicy(roleName, policyName string) error {
+	_, err := r.iamClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(policyName),
+	})
+	if err != nil && !object.IsIAMNotFound(err) {
+		return errors.Wrapf(err, "failed to delete policy %q of role %q", policyName, roleName)
+	}
+	logger.Infof("deleted policy %q of ceph object role %q", policyName, roleName)
+	return nil
+}
+
+// Delete the role. RGW refuses to delete a role that still has policies, so they are deleted first.
+func (r *ReconcileObjectStoreRole) deleteRole(role *cephv1.CephObjectStoreRole) error {
+	policies, err := r.listRolePolicies(role.Name)
+	if err != nil {
+		if object.IsIAMNotFound(err) {
+			logger.Warningf("role %q does not exist, nothing to remove", role.Name)
+			return nil
+		}
+		return err
+	}
+	for name := range policies {
+		if err := r.deleteRolePolicy(role.Name, name); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.iamClient.DeleteRole(&iam.DeleteRoleInput{RoleName: aws.String(role.Name)})
+	if err != nil {
+		if object.IsIAMNotFound(err) {
+			logger.Warningf("role %q does not exist, nothing to remove", role.Name)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to delete ceph object role %q", role.Name)
+	}
+
+	logger.Infof("ceph object role %q deleted successfully", role.Name)
+	return nil
+}
+
+// validateRole validates the role arguments
+func validateRole(role *cephv1.CephObjectStoreRole) error {
+	if role.Spec.Store == "" {
+		return errors.New("missing store")
+	}
+	if role.Spec.Path != "" && (!strings.HasPrefix(role.Spec.Path, "/") || !strings.HasSuffix(role.Spec.Path, "/")) {
+		return errors.Errorf("invalid path %q, must begin and end with /", role.Spec.Path)
+	}
+	if !json.Valid([]byte(role.Spec.AssumeRolePolicyDocument)) {
+		return errors.New("the assume role policy document is not valid JSON")
+	}
+	names := map[string]bool{}
+	for _, policy := range role.Spec.Policies {
+		if names[policy.Name] {
+			return errors.Errorf("duplicate policy %q", policy.Name)
+		}
+		names[policy.Name] = true
+		if !json.Valid([]byte(policy.Document)) {
+			return errors.Errorf("the document of policy %q is not valid JSON", policy.Name)
+		}
+	}
+	return nil
+}
+
+// updateStatus updates an object with a given status
+func (r *ReconcileObjectStoreRole) updateStatus(observedGeneration int64, name types.NamespacedName, status, arn string) {
+	role := &cephv1.CephObjectStoreRole{}
+	if err := r.client.Get(r.opManagerContext, name, role); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectStoreRole resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve object store role %q to update status to %q. %v", name, status, err)
+		return
+	}
+	if role.Status == nil {
+		role.Status = &cephv1.ObjectStoreRoleStatus{}
+	}
+
+	role.Status.Phase = status
+	if arn != "" {
+		role.Status.ARN = arn
+	}
+	if observedGeneration != k8sutil.ObservedGenerationNotAvailable {
+		role.Status.ObservedGeneration = observedGeneration
+	}
+	if err := reporting.UpdateStatus(r.client, role); err != nil {
+		logger.Errorf("failed to set object store role %q status to %q. %v", name, status, err)
+		return
+	}
+	logger.Debugf("object store role %q status updated to %q", name, status)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectrole
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	trustPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Federated":["arn:aws:iam:::oidc-provider/kubernetes.default.svc"]},"Action":["sts:AssumeRoleWithWebIdentity"]}]}`
+	readPolicy  = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"arn:aws:s3:::*"}]}`
+	writePolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObject"],"Resource":"arn:aws:s3:::*"}]}`
+)
+
+// fakeRoleIAM implements the role calls of the IAM API
+type fakeRoleIAM struct {
+	iamiface.IAMAPI
+	roles    map[string]*iam.Role
+	policies map[string]map[string]string
+	calls    []string
+}
+
+func newFakeRoleIAM() *fakeRoleIAM {
+	return &fakeRoleIAM{roles: map[string]*iam.Role{}, policies: map[string]map[string]string{}}
+}
+
+func noSuchEntity() error {
+	return awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil)
+}
+
+func (f *fakeRoleIAM) GetRole(in *iam.GetRoleInput) (*iam.GetRoleOutput, error) {
+	role, ok := f.roles[aws.StringValue(in.RoleName)]
+	if !ok {
+		return nil, noSuchEntity()
+	}
+	return &iam.GetRoleOutput{Role: role}, nil
+}
+
+func (f *fakeRoleIAM) CreateRole(in *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
+	f.calls = append(f.calls, "CreateRole")
+	name := aws.StringValue(in.RoleName)
+	f.roles[name] = &iam.Role{
+		RoleName:                 in.RoleName,
+		Path:                     in.Path,
+		Arn:                      aws.String("arn:aws:iam:::role" + aws.StringValue(in.Path) + name),
+		AssumeRolePolicyDocument: in.AssumeRolePolicyDocument,
+		MaxSessionDuration:       in.MaxSessionDuration,
+	}
+	f.policies[name] = map[string]string{}
+	return &iam.CreateRoleOutput{Role: f.roles[name]}, nil
+}
+
+func (f *fakeRoleIAM) UpdateAssumeRolePolicy(in *iam.UpdateAssumeRolePolicyInput) (*iam.UpdateAssumeRolePolicyOutput, error) {
+	f.calls = append(f.calls, "UpdateAssumeRolePolicy")
+	f.roles[aws.StringValue(in.RoleName)].AssumeRolePolicyDocument = in.PolicyDocument
+	return &iam.UpdateAssumeRolePolicyOutput{}, nil
+}
+
+func (f *fakeRoleIAM) UpdateRole(in *iam.UpdateRoleInput) (*iam.UpdateRoleOutput, error) {
+	f.calls = append(f.calls, "UpdateRole")
+	f.roles[aws.StringValue(in.RoleName)].MaxSessionDuration = in.MaxSessionDuration
+	return &iam.UpdateRoleOutput{}, nil
+}
+
+func (f *fakeRoleIAM) DeleteRole(in *iam.DeleteRoleInput) (*iam.DeleteRoleOutput, error) {
+	name := aws.StringValue(in.RoleName)
+	if _, ok := f.roles[name]; !ok {
+		return nil, noSuchEntity()
+	}
+	if len(f.policies[name]) > 0 {
+		return nil, awserr.New(iam.ErrCodeDeleteConflictException, "role has policies", nil)
+	}
+	f.calls = append(f.calls, "DeleteRole")
+	delete(f.roles, name)
+	return &iam.DeleteRoleOutput{}, nil
+}
+
+func (f *fakeRoleIAM) ListRolePolicies(in *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	policies, ok := f.policies[aws.StringValue(in.RoleName)]
+	if !ok {
+		return nil, noSuchEntity()
+	}
+	out := &iam.ListRolePoliciesOutput{}
+	for name := range policies {
+		out.PolicyNames = append(out.PolicyNames, aws.String(name))
+	}
+	return out, nil
+}
+
+func (f *fakeRoleIAM) GetRolePolicy(in *iam.GetRolePolicyInput) (*iam.GetRolePolicyOutput, error) {
+	doc := f.policies[aws.StringValue(in.RoleName)][aws.StringValue(in.PolicyName)]
+	// the IAM API returns the documents URL-encoded
+	return &iam.GetRolePolicyOutput{PolicyDocument: aws.String(url.QueryEscape(doc))}, nil
+}
+
+func (f *fakeRoleIAM) PutRolePolicy(in *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+	f.calls = append(f.calls, "PutRolePolicy:"+aws.StringValue(in.PolicyName))
+	f.policies[aws.StringValue(in.RoleName)][aws.StringValue(in.PolicyName)] = aws.StringValue(in.PolicyDocument)
+	return &iam.PutRolePolicyOutput{}, nil
+}
+
+func (f *fakeRoleIAM) DeleteRolePolicy(in *iam.DeleteRolePolicyInput) (*iam.DeleteRolePolicyOutput, error) {
+	f.calls = append(f.calls, "DeleteRolePolicy:"+aws.StringValue(in.PolicyName))
+	delete(f.policies[aws.StringValue(in.RoleName)], aws.StringValue(in.PolicyName))
+	return &iam.DeleteRolePolicyOutput{}, nil
+}
+
+func newTestRole() *cephv1.CephObjectStoreRole {
+	return &cephv1.CephObjectStoreRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "s3-reader", Namespace: "rook-ceph"},
+		Spec: cephv1.ObjectStoreRoleSpec{
+			Store:                    "my-store",
+			AssumeRolePolicyDocument: trustPolicy,
+			Policies:                 []cephv1.IAMPolicySpec{{Name: "read", Document: readPolicy}},
+		},
+	}
+}
+
+func TestCreateOrUpdateRole(t *testing.T) {
+	f := newFakeRoleIAM()
+	r := &ReconcileObjectStoreRole{iamClient: f, opManagerContext: context.TODO()}
+	role := newTestRole()
+
+	t.Run("create", func(t *testing.T) {
+		arn, err := r.createOrUpdateRole(role)
+		assert.NoError(t, err)
+		assert.Equal(t, "arn:aws:iam:::role/s3-reader", arn)
+		assert.Equal(t, []string{"CreateRole"}, f.calls)
+		assert.Equal(t, int64(3600), aws.Int64Value(f.roles["s3-reader"].MaxSessionDuration))
+
+		require.NoError(t, r.reconcileRolePolicies(role))
+		assert.Equal(t, map[string]string{"read": readPolicy}, f.policies["s3-reader"])
+	})
+
+	t.Run("nothing to update", func(t *testing.T) {
+		f.calls = nil
+		// the same document with a different formatting is not updated
+		f.roles["s3-reader"].AssumeRolePolicyDocument = aws.String(url.QueryEscape(" " + trustPolicy))
+		_, err := r.createOrUpdateRole(role)
+		assert.NoError(t, err)
+		assert.NoError(t, r.reconcileRolePolicies(role))
+		assert.Empty(t, f.calls)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		f.calls = nil
+		role.Spec.AssumeRolePolicyDocument = `{"Version":"2012-10-17","Statement":[]}`
+		role.Spec.MaxSessionDuration = aws.Int64(7200)
+		role.Spec.Policies = []cephv1.IAMPolicySpec{{Name: "write", Document: writePolicy}}
+
+		_, err := r.createOrUpdateRole(role)
+		assert.NoError(t, err)
+		assert.NoError(t, r.reconcileRolePolicies(role))
+		assert.Equal(t, []string{"UpdateAssumeRolePolicy", "UpdateRole", "PutRolePolicy:write", "DeleteRolePolicy:read"}, f.calls)
+		assert.Equal(t, int64(7200), aws.Int64Value(f.roles["s3-reader"].MaxSessionDuration))
+		assert.Equal(t, map[string]string{"write": writePolicy}, f.policies["s3-reader"])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		f.calls = nil
+		assert.NoError(t, r.deleteRole(role))
+		assert.Equal(t, []string{"DeleteRolePolicy:write", "DeleteRole"}, f.calls)
+		assert.Empty(t, f.roles)
+
+		// deleting a role that does not exist succeeds
+		assert.NoError(t, r.deleteRole(role))
+	})
+}
+
+func TestValidateRole(t *testing.T) {
+	assert.NoError(t, validateRole(newTestRole()))
+
+	role := newTestRole()
+	role.Spec.Store = ""
+	assert.Error(t, validateRole(role))
+
+	role = newTestRole()
+	role.Spec.Path = "/application"
+	assert.Error(t, validateRole(role))
+	role.Spec.Path = "/application/"
+	assert.NoError(t, validateRole(role))
+
+	role = newTestRole()
+	role.Spec.AssumeRolePolicyDocument = "{"
+	assert.Error(t, validateRole(role))
+
+	role = newTestRole()
+	role.Spec.Policies = append(role.Spec.Policies, role.Spec.Policies[0])
+	assert.Error(t, validateRole(role))
+
+	role = newTestRole()
+	role.Spec.Policies[0].Document = "not json"
+	assert.Error(t, validateRole(role))
+}
@@ -79,15 +79,29 @@ func NewS3Agent(accessKey, secretKey, endpoint string, debug bool, tlsCert []byt
 
 // CreateBucket creates a bucket with the given name
 func (s *S3Agent) CreateBucketNoInfoLogging(name string) error {
-	return s.createBucket(name, false)
+	return s.createBucket(name, false, BucketCreateOptions{})
 }
 
 // CreateBucket creates a bucket with the given name
 func (s *S3Agent) CreateBucket(name string) error {
-	return s.createBucket(name, true)
+	return s.createBucket(name, true, BucketCreateOptions{})
 }
 
-func (s *S3Agent) createBucket(name string, infoLogging bool) error {
+// BucketCreateOptions are the settings of a bucket that can only be set when it is created
+type BucketCreateOptions struct {
+	// PlacementRule is the placement of the bucket and the default storage class of its objects,
+	// "<placement>" or "<placement>/<storage class>". The default placement is used if empty.
+	PlacementRule string
+	// ObjectLock enables object lock, which also enables the versioning of the bucket
+	ObjectLock bool
+}
+
+// CreateBucketWithOptions creates a bucket with the given name and options
+func (s *S3Agent) CreateBucketWithOptions(name string, opts BucketCreateOptions) error {
+	return s.createBucket(name, true, opts)
+}
+
+func (s *S3Agent) createBucket(name string, infoLogging bool, opts BucketCreateOptions) error {
 	if infoLogging {
 		logger.Infof("creating bucket %q", name)
 	} else {
@@ -96,6 +110,16 @@ func (s *S3Agent) createBucket(name string, infoLogging bool) error {
 	bucketInput := &s3.CreateBucketInput{
 		Bucket: &name,
 	}
+	if opts.ObjectLock {
+		bucketInput.ObjectLockEnabledForBucket = aws.Bool(true)
+	}
+	if opts.PlacementRule != "" {
+		// rgw reads the location constraint as "<zonegroup>:<placement rule>", the zonegroup of
+		// the object store is used when it is empty
+		bucketInput.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(":" + opts.PlacementRule),
+		}
+	}
 
 	_, err := s.Client.CreateBucket(bucketInput)
 	if err != nil {
@@ -0,0 +1,492 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectscript to manage the Lua scripts of a rook object store.
+package objectscript
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/coreos/pkg/capnslog"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	opcontroller "github.com/rook/rook/pkg/operator/ceph/controller"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+)
+
+const (
+	controllerName = "ceph-object-store-script-controller"
+)
+
+var logger = capnslog.NewPackageLogger("github.com/rook/rook", controllerName)
+
+var cephObjectStoreScriptKind = reflect.TypeOf(cephv1.CephObjectStoreScript{}).Name()
+
+// Sets the type meta for the controller main object
+var controllerTypeMeta = metav1.TypeMeta{
+	Kind:       cephObjectStoreScriptKind,
+	APIVersion: fmt.Sprintf("%s/%s", cephv1.CustomResourceGroup, cephv1.Version),
+}
+
+// ReconcileObjectStoreScript reconciles a CephObjectStoreScript object
+type ReconcileObjectStoreScript struct {
+	client           client.Client
+	scheme           *runtime.Scheme
+	context          *clusterd.Context
+	objContext       *object.Context
+	clusterInfo      *cephclient.ClusterInfo
+	opManagerContext context.Context
+	recorder         record.EventRecorder
+}
+
+// Add creates a new CephObjectStoreScript Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context, opConfig opcontroller.OperatorConfig) error {
+	return add(mgr, newReconciler(mgr, context, opManagerContext))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, context *clusterd.Context, opManagerContext context.Context) reconcile.Reconciler {
+	return &ReconcileObjectStoreScript{
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		context:          context,
+		opManagerContext: opManagerContext,
+		recorder:         mgr.GetEventRecorderFor("rook-" + controllerName),
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+	logger.Info("successfully started")
+
+	// Watch for changes on the CephObjectStoreScript CRD object
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephObjectStoreScript{TypeMeta: controllerTypeMeta},
+			&handler.TypedEnqueueRequestForObject[*cephv1.CephObjectStoreScript]{},
+			opcontroller.WatchControllerPredicate[*cephv1.CephObjectStoreScript](mgr.GetScheme()),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch the ConfigMaps holding the scripts, to reload a script when it changes
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&corev1.ConfigMap{TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: corev1.SchemeGroupVersion.String()}},
+			handler.TypedEnqueueRequestsFromMapFunc(mapConfigMapToScripts(mgr.GetClient())),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// mapConfigMapToScripts maps a ConfigMap to the scripts that reference it
+func mapConfigMapToScripts(k8sClient client.Client) handler.TypedMapFunc[*corev1.ConfigMap, reconcile.Request] {
+	return func(ctx context.Context, configMap *corev1.ConfigMap) []reconcile.Request {
+		scripts := &cephv1.CephObjectStoreScriptList{}
+		err := k8sClient.List(ctx, scripts, client.InNamespace(configMap.Namespace))
+		if err != nil {
+			logger.Errorf("failed to list CephObjectStoreScripts in namespace %q. %v", configMap.Namespace, err)
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, script := range scripts.Items {
+			if script.Spec.ConfigMapKeyRef.Name == configMap.Name {
+				logger.Debugf("ConfigMap %q changed, reconciling CephObjectStoreScript %q", configMap.Name, script.Name)
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: script.Name, Namespace: script.Namespace}})
+			}
+		}
+		return requests
+	}
+}
+
+// Reconcile reads that state of the cluster for a CephObjectStoreScript object and makes changes based on the state read
+// and what is in the CephObjectStoreScript.Spec
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileObjectStoreScript) Reconcile(context context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// workaround because the rook logging mechanism is not compatible with the controller-runtime logging interface
+	reconcileResponse, cephObjectStoreScript, err := r.reconcile(request)
+	if err != nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, k8sutil.ReconcileFailedStatus, nil)
+		logger.Errorf("failed to reconcile %v", err)
+	}
+
+	return reporting.ReportReconcileResult(logger, r.recorder, request, &cephObjectStoreScript, reconcileResponse, err)
+}
+
+func (r *ReconcileObjectStoreScript) reconcile(request reconcile.Request) (reconcile.Result, cephv1.CephObjectStoreScript, error) {
+	// Fetch the CephObjectStoreScript instance
+	cephObjectStoreScript := &cephv1.CephObjectStoreScript{}
+	err := r.client.Get(r.opManagerContext, request.NamespacedName, cephObjectStoreScript)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectStoreScript resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, *cephObjectStoreScript, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, *cephObjectStoreScript, errors.Wrap(err, "failed to get CephObjectStoreScript")
+	}
+	// update observedGeneration local variable with current generation value,
+	// because generation can be changed before reconcile got completed
+	// CR status will be updated at end of reconcile, so to reflect the reconcile has finished
+	observedGeneration := cephObjectStoreScript.ObjectMeta.Generation
+
+	// Set a finalizer so we can do cleanup before the object goes away
+	generationUpdated, err := opcontroller.AddFinalizerIfNotPresent(r.opManagerContext, r.client, cephObjectStoreScript)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreScript, errors.Wrap(err, "failed to add finalizer")
+	}
+	if generationUpdated {
+		logger.Infof("reconciling the object script %q after adding finalizer", cephObjectStoreScript.Name)
+		return reconcile.Result{}, *cephObjectStoreScript, nil
+	}
+
+	// The CR was just created, initializing status fields
+	if cephObjectStoreScript.Status == nil {
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, k8sutil.EmptyStatus, nil)
+	}
+
+	// Make sure a CephCluster is present otherwise do nothing
+	cephCluster, isReadyToReconcile, cephClusterExists, reconcileResponse := opcontroller.IsReadyToReconcile(r.opManagerContext, r.client, request.NamespacedName, controllerName)
+	if !isReadyToReconcile {
+		// This handles the case where the Ceph Cluster is gone and we want to delete that CR
+		// We skip removing the script since everything is gone already
+		//
+		// Also, only remove the finalizer if the CephCluster is gone
+		// If not, we should wait for it to be ready
+		// This handles the case where the operator is not ready to accept Ceph command but the cluster exists
+		if !cephObjectStoreScript.GetDeletionTimestamp().IsZero() && !cephClusterExists {
+			// Remove finalizer
+			err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStoreScript)
+			if err != nil {
+				return reconcile.Result{}, *cephObjectStoreScript, errors.Wrap(err, "failed to remove finalizer")
+			}
+
+			// Return and do not requeue. Successful deletion.
+			return reconcile.Result{}, *cephObjectStoreScript, nil
+		}
+		return reconcileResponse, *cephObjectStoreScript, nil
+	}
+
+	// Populate clusterInfo during each reconcile
+	r.clusterInfo, _, _, err = opcontroller.LoadClusterInfo(r.context, r.opManagerContext, request.Namespace, &cephCluster.Spec)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreScript, errors.Wrap(err, "failed to populate cluster info")
+	}
+
+	// Validate the object store has been initialized
+	err = r.initializeObjectStoreContext(cephObjectStoreScript)
+	if err != nil {
+		if !cephObjectStoreScript.GetDeletionTimestamp().IsZero() {
+			// Remove finalizer
+			err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStoreScript)
+			if err != nil {
+				return reconcile.Result{}, *cephObjectStoreScript, errors.Wrap(err, "failed to remove finalizer")
+			}
+			r.recorder.Event(cephObjectStoreScript, corev1.EventTypeNormal, string(cephv1.ReconcileSucceeded), "successfully removed finalizer")
+
+			// Return and do not requeue. Successful deletion.
+			return reconcile.Result{}, *cephObjectStoreScript, nil
+		}
+		logger.Debugf("ObjectStore resource not ready in namespace %q, retrying in %q. %v",
+			request.Namespace, opcontroller.WaitForRequeueIfCephClusterNotReady.RequeueAfter.String(), err)
+		return opcontroller.WaitForRequeueIfCephClusterNotReady, *cephObjectStoreScript, err
+	}
+
+	// DELETE: the CR was deleted
+	if !cephObjectStoreScript.GetDeletionTimestamp().IsZero() {
+		logger.Debugf("deleting object store script %q", request.NamespacedName)
+		r.recorder.Eventf(cephObjectStoreScript, corev1.EventTypeNormal, string(cephv1.ReconcileStarted), "deleting CephObjectStoreScript %q", cephObjectStoreScript.Name)
+
+		err := r.removeScript(cephObjectStoreScript)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreScript, errors.Wrapf(err, "failed to remove ceph object script %q", cephObjectStoreScript.Name)
+		}
+
+		// Remove finalizer
+		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, cephObjectStoreScript)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreScript, errors.Wrap(err, "failed to remove finalizer")
+		}
+		r.recorder.Event(cephObjectStoreScript, corev1.EventTypeNormal, string(cephv1.ReconcileSucceeded), "successfully removed finalizer")
+
+		// Return and do not requeue. Successful deletion.
+		return reconcile.Result{}, *cephObjectStoreScript, nil
+	}
+
+	// validate the script settings
+	err = r.validateScript(cephObjectStoreScript)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreScript, errors.Wrapf(err, "invalid object store script CR %q spec", cephObjectStoreScript.Name)
+	}
+
+	// REMOVE SCRIPT from the context or tenant it was loaded in before they changed
+	if scriptMoved(cephObjectStoreScript) {
+		err = r.removeScript(cephObjectStoreScript)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreScript, errors.Wrapf(err, "failed to remove object store script %q from its previous context", cephObjectStoreScript.Name)
+		}
+		// forget the removed script so that it is not removed again, another script may be loaded there
+		r.updateStatus(k8sutil.ObservedGenerationNotAvailable, request.NamespacedName, k8sutil.ReconcilingStatus, &cephv1.ObjectStoreScriptStatus{})
+	}
+
+	// LOAD SCRIPT
+	hash, err := r.loadScript(cephObjectStoreScript)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreScript, errors.Wrapf(err, "failed to load object store script %q", cephObjectStoreScript.Name)
+	}
+
+	// update ObservedGeneration in status at the end of reconcile
+	// Set Ready status, we are done reconciling
+	loaded := &cephv1.ObjectStoreScriptStatus{Hash: hash, Context: cephObjectStoreScript.Spec.Context, Tenant: cephObjectStoreScript.Spec.Tenant}
+	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus, loaded)
+
+	// Return and do not requeue
+	logger.Debug("done reconciling")
+	return reconcile.Result{}, *cephObjectStoreScript, nil
+}
+
+func (r *ReconcileObjectStoreScript) initializeObjectStoreContext(script *cephv1.CephObjectStoreScript) error {
+	store := &cephv1.CephObjectStore{}
+	err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: script.Spec.Store, Namespace: script.Namespace}, store)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get CephObjectStore %q referenced by CephObjectStoreScript %q", script.Spec.Store, client.ObjectKeyFromObject(script))
+	}
+	if store.Spec.IsExternal() {
+		return errors.Errorf("lua scripts cannot be loaded in external object store %q", script.Spec.Store)
+	}
+
+	err = r.objectStoreInitialized(script)
+	if err != nil {
+		return errors.Wrapf(err, "failed to detect if object store %q is initialized", script.Spec.Store)
+	}
+
+	r.objContext, err = object.NewMultisiteContext(r.context, r.clusterInfo, store)
+	if err != nil {
+		return errors.Wrapf(err, "Multisite failed to set on object context for object store script")
+	}
+
+	return nil
+}
+
+func (r *ReconcileObjectStoreScript) objectStoreInitialized(script *cephv1.CephObjectStoreScript) error {
+	// check if at least one RGW pod is running, the zone of the object store exists once the RGWs
+	// are started
+	pods := &corev1.PodList{}
+	listOpts := []client.ListOption{
+		client.InNamespace(script.Namespace),
+		client.MatchingLabels(map[string]string{"rgw": script.Spec.Store, k8sutil.AppAttr: object.AppName}),
+	}
+	err := r.client.List(r.opManagerContext, pods, listOpts...)
+	if err != nil {
+		return errors.Wrap(err, "failed to list rgw pods")
+	}
+	if len(pods.Items) == 0 {
+		return errors.New("no rgw pod found")
+	}
+
+	logger.Debugf("%d RGW pods found where object store script %q is created", len(pods.Items), script.Name)
+	return nil
+}
+
+// getScript returns the Lua script from the ConfigMap referenced by the CR
+func (r *ReconcileObjectStoreScript) getScript(script *cephv1.CephObjectStoreScript) (string, error) {
+	ref := script.Spec.ConfigMapKeyRef
+	configMap := &corev1.ConfigMap{}
+	err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: ref.Name, Namespace: script.Namespace}, configMap)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get ConfigMap %q", ref.Name)
+	}
+	content, ok := configMap.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("failed to find key %q in ConfigMap %q", ref.Key, ref.Name)
+	}
+	return content, nil
+}
+
+// loadScript uploads the script to the object store if it differs from the script that is
+// loaded, and returns the hash of the script
+func (r *ReconcileObjectStoreScript) loadScript(script *cephv1.CephObjectStoreScript) (string, error) {
+	content, err := r.getScript(script)
+	if err != nil {
+		return "", err
+	}
+
+	current, err := object.GetLuaScript(r.objContext, script.Spec.Context, script.Spec.Tenant)
+	if err != nil {
+		return "", err
+	}
+	// radosgw-admin adds a trailing new line when printing the script
+	if strings.TrimSpace(current) == strings.TrimSpace(content) {
+		logger.Debugf("ceph object script %q is up to date", script.Name)
+		return object.LuaScriptHash(content), nil
+	}
+
+	err = object.PutLuaScript(r.objContext, script.Spec.Context, script.Spec.Tenant, content)
+	if err != nil {
+		return "", err
+	}
+	logger.Infof("loaded ceph object script %q in the %s context of object store %q", script.Name, script.Spec.Context, script.Spec.Store)
+
+	return object.LuaScriptHash(content), nil
+}
+
+// loadedContext returns the context and tenant in which the script was loaded. The status of the
+// scripts loaded before the context and tenant were recorded falls back to the spec.
+func loadedContext(script *cephv1.CephObjectStoreScript) (cephv1.ObjectStoreScriptContext, string) {
+	if script.Status.Context == "" {
+		return script.Spec.Context, script.Spec.Tenant
+	}
+	return script.Status.Context, script.Status.Tenant
+}
+
+// scriptMoved returns true if the script was loaded in another context or tenant than the ones of
+// the spec
+func scriptMoved(script *cephv1.CephObjectStoreScript) bool {
+	if script.Status == nil || script.Status.Hash == "" {
+		return false
+	}
+	context, tenant := loadedContext(script)
+	return context != script.Spec.Context || tenant != script.Spec.Tenant
+}
+
+// removeScript removes the script from the context and tenant in which it was loaded
+func (r *ReconcileObjectStoreScript) removeScript(script *cephv1.CephObjectStoreScript) error {
+	// another script may own the context after the script was rejected as a duplicate
+	if script.Status == nil || script.Status.Hash == "" {
+		logger.Debugf("ceph object script %q was never loaded, nothing to remove", script.Name)
+		return nil
+	}
+	context, tenant := loadedContext(script)
+	err := object.RemoveLuaScript(r.objContext, context, tenant)
+	if err != nil {
+		return err
+	}
+	logger.Infof("removed ceph object script %q from the %s context of object store %q", script.Name, context, script.Spec.Store)
+	return nil
+}
+
+// validateScript validates the script settings, and that no other script is loaded in the same
+// context of the object store
+func (r *ReconcileObjectStoreScript) validateScript(script *cephv1.CephObjectStoreScript) error {
+	if err := validateScriptSpec(script); err != nil {
+		return err
+	}
+
+	scripts := &cephv1.CephObjectStoreScriptList{}
+	err := r.client.List(r.opManagerContext, scripts, client.InNamespace(script.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "failed to list the object store scripts")
+	}
+	for _, other := range scripts.Items {
+		if other.Name == script.Name || other.Spec.Store != script.Spec.Store ||
+			other.Spec.Context != script.Spec.Context || other.Spec.Tenant != script.Spec.Tenant {
+			continue
+		}
+		// the oldest script wins
+		if other.CreationTimestamp.Before(&script.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&script.CreationTimestamp) && other.Name < script.Name) {
+			return errors.Errorf("script %q is already loaded in the %s context of object store %q", other.Name, script.Spec.Context, script.Spec.Store)
+		}
+	}
+	return nil
+}
+
+func validateScriptSpec(script *cephv1.CephObjectStoreScript) error {
+	if script.Spec.Store == "" {
+		return errors.New("missing store")
+	}
+	switch script.Spec.Context {
+	case cephv1.ObjectStoreScriptContextPreRequest, cephv1.ObjectStoreScriptContextPostRequest,
+		cephv1.ObjectStoreScriptContextGetData, cephv1.ObjectStoreScriptContextPutData:
+	case cephv1.ObjectStoreScriptContextBackground:
+		if script.Spec.Tenant != "" {
+			return errors.New("the background context does not support tenants")
+		}
+	default:
+		return errors.Errorf("invalid context %q", script.Spec.Context)
+	}
+	if script.Spec.ConfigMapKeyRef.Name == "" || script.Spec.ConfigMapKeyRef.Key == "" {
+		return errors.New("missing ConfigMap name or key")
+	}
+	return nil
+}
+
+// updateStatus updates an object with a given status. The hash, context and tenant of the loaded
+// script are updated unless loaded is nil.
+func (r *ReconcileObjectStoreScript) updateStatus(observedGeneration int64, name types.NamespacedName, status string, loaded *cephv1.ObjectStoreScriptStatus) {
+	script := &cephv1.CephObjectStoreScript{}
+	if err := r.client.Get(r.opManagerContext, name, script); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectStoreScript resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve object store script %q to update status to %q. %v", name, status, err)
+		return
+	}
+	if script.Status == nil {
+		script.Status = &cephv1.ObjectStoreScriptStatus{}
+	}
+
+	script.Status.Phase = status
+	if loaded != nil {
+		script.Status.Hash = loaded.Hash
+		script.Status.Context = loaded.Context
+		script.Status.Tenant = loaded.Tenant
+	}
+	if observedGeneration != k8sutil.ObservedGenerationNotAvailable {
+		script.Status.ObservedGeneration = observedGeneration
+	}
+	if err := reporting.UpdateStatus(r.client, script); err != nil {
+		logger.Errorf("failed to set object store script %q status to %q. %v", name, status, err)
+		return
+	}
+	logger.Debugf("object store script %q status updated to %q", name, status)
+}
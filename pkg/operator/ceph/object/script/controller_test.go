@@ -0,0 +1,250 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectscript
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const luaScript = "RGW_DEBUG_LOG('hello')\n"
+
+func newTestScript(name string, created time.Time) *cephv1.CephObjectStoreScript {
+	return &cephv1.CephObjectStoreScript{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "rook-ceph", CreationTimestamp: metav1.NewTime(created)},
+		Spec: cephv1.ObjectStoreScriptSpec{
+			Store:   "my-store",
+			Context: cephv1.ObjectStoreScriptContextPreRequest,
+			ConfigMapKeyRef: corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "lua-scripts"},
+				Key:                  "log.lua",
+			},
+		},
+	}
+}
+
+func newTestReconciler(t *testing.T, objects ...runtime.Object) *ReconcileObjectStoreScript {
+	s := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(s))
+	require.NoError(t, corev1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objects...).Build()
+	return &ReconcileObjectStoreScript{client: cl, opManagerContext: context.TODO()}
+}
+
+func TestValidateScriptSpec(t *testing.T) {
+	assert.NoError(t, validateScriptSpec(newTestScript("log", time.Now())))
+
+	script := newTestScript("log", time.Now())
+	script.Spec.Store = ""
+	assert.Error(t, validateScriptSpec(script))
+
+	script = newTestScript("log", time.Now())
+	script.Spec.Context = "prerequest"
+	assert.Error(t, validateScriptSpec(script))
+
+	script = newTestScript("log", time.Now())
+	script.Spec.Tenant = "team-a"
+	assert.NoError(t, validateScriptSpec(script))
+	script.Spec.Context = cephv1.ObjectStoreScriptContextBackground
+	assert.Error(t, validateScriptSpec(script))
+
+	script = newTestScript("log", time.Now())
+	script.Spec.ConfigMapKeyRef.Key = ""
+	assert.Error(t, validateScriptSpec(script))
+}
+
+func TestValidateScriptConflict(t *testing.T) {
+	now := time.Now()
+	older := newTestScript("older", now.Add(-time.Hour))
+	newer := newTestScript("newer", now)
+	otherTenant := newTestScript("other-tenant", now.Add(-2*time.Hour))
+	otherTenant.Spec.Tenant = "team-a"
+
+	r := newTestReconciler(t, older, newer, otherTenant)
+	assert.NoError(t, r.validateScript(older))
+	assert.NoError(t, r.validateScript(otherTenant))
+	assert.Error(t, r.validateScript(newer))
+}
+
+func TestMapConfigMapToScripts(t *testing.T) {
+	other := newTestScript("other", time.Now())
+	other.Spec.ConfigMapKeyRef.Name = "other-scripts"
+	r := newTestReconciler(t, newTestScript("log", time.Now()), other)
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "lua-scripts", Namespace: "rook-ceph"}}
+	requests := mapConfigMapToScripts(r.client)(context.TODO(), configMap)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "log", requests[0].Name)
+}
+
+func TestLoadScript(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "lua-scripts", Namespace: "rook-ceph"},
+		Data:       map[string]string{"log.lua": luaScript},
+	}
+
+	newReconciler := func(t *testing.T, loaded string, commands *[]string) *ReconcileObjectStoreScript {
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+				*commands = append(*commands, args[0]+" "+args[1])
+				if args[1] == "put" {
+					content, err := os.ReadFile(strings.TrimPrefix(args[2], "--infile="))
+					assert.NoError(t, err)
+					assert.Equal(t, luaScript, string(content))
+				}
+				if args[1] == "get" {
+					return loaded, nil
+				}
+				return "", nil
+			},
+		}
+		r := newTestReconciler(t, configMap)
+		r.objContext = object.NewContext(&clusterd.Context{Executor: executor, ConfigDir: t.TempDir()}, cephclient.AdminTestClusterInfo("rook-ceph"), "my-store")
+		return r
+	}
+
+	t.Run("load the script", func(t *testing.T) {
+		var commands []string
+		hash, err := newReconciler(t, "no script exists for context: prerequest\n", &commands).loadScript(newTestScript("log", time.Now()))
+		assert.NoError(t, err)
+		assert.Equal(t, object.LuaScriptHash(luaScript), hash)
+		assert.Equal(t, []string{"script get", "script put"}, commands)
+	})
+
+	t.Run("script is up to date", func(t *testing.T) {
+		var commands []string
+		hash, err := newReconciler(t, strings.TrimSpace(luaScript)+"\n\n", &commands).loadScript(newTestScript("log", time.Now()))
+		assert.NoError(t, err)
+		assert.Equal(t, object.LuaScriptHash(luaScript), hash)
+		assert.Equal(t, []string{"script get"}, commands)
+	})
+
+	t.Run("configmap key is missing", func(t *testing.T) {
+		var commands []string
+		script := newTestScript("log", time.Now())
+		script.Spec.ConfigMapKeyRef.Key = "missing.lua"
+		_, err := newReconciler(t, "", &commands).loadScript(script)
+		assert.Error(t, err)
+		assert.Empty(t, commands)
+	})
+}
+
+func TestRemoveMovedScript(t *testing.T) {
+	newReconciler := func(t *testing.T, commands *[]string) *ReconcileObjectStoreScript {
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+				cmd := strings.Join(args[:3], " ")
+				if strings.HasPrefix(args[3], "--tenant=") {
+					cmd += " " + args[3]
+				}
+				*commands = append(*commands, cmd)
+				return "", nil
+			},
+		}
+		r := newTestReconciler(t)
+		r.objContext = object.NewContext(&clusterd.Context{Executor: executor, ConfigDir: t.TempDir()}, cephclient.AdminTestClusterInfo("rook-ceph"), "my-store")
+		return r
+	}
+
+	t.Run("never loaded", func(t *testing.T) {
+		var commands []string
+		script := newTestScript("log", time.Now())
+		assert.False(t, scriptMoved(script))
+		assert.NoError(t, newReconciler(t, &commands).removeScript(script))
+		assert.Empty(t, commands)
+	})
+
+	t.Run("not moved", func(t *testing.T) {
+		script := newTestScript("log", time.Now())
+		script.Status = &cephv1.ObjectStoreScriptStatus{Hash: "hash", Context: cephv1.ObjectStoreScriptContextPreRequest}
+		assert.False(t, scriptMoved(script))
+
+		// the status of the scripts loaded by older versions has no context
+		script.Status.Context = ""
+		assert.False(t, scriptMoved(script))
+	})
+
+	t.Run("context changed", func(t *testing.T) {
+		var commands []string
+		script := newTestScript("log", time.Now())
+		script.Status = &cephv1.ObjectStoreScriptStatus{Hash: "hash", Context: cephv1.ObjectStoreScriptContextPreRequest}
+		script.Spec.Context = cephv1.ObjectStoreScriptContextPostRequest
+		assert.True(t, scriptMoved(script))
+		assert.NoError(t, newReconciler(t, &commands).removeScript(script))
+		assert.Equal(t, []string{"script rm --context=prerequest"}, commands)
+	})
+
+	t.Run("tenant changed", func(t *testing.T) {
+		var commands []string
+		script := newTestScript("log", time.Now())
+		script.Status = &cephv1.ObjectStoreScriptStatus{Hash: "hash", Context: cephv1.ObjectStoreScriptContextPreRequest, Tenant: "team-a"}
+		script.Spec.Tenant = "team-b"
+		assert.True(t, scriptMoved(script))
+		assert.NoError(t, newReconciler(t, &commands).removeScript(script))
+		assert.Equal(t, []string{"script rm --context=prerequest --tenant=team-a"}, commands)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	script := newTestScript("log", time.Now())
+	s := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(s))
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(script).WithStatusSubresource(script).Build()
+	r := &ReconcileObjectStoreScript{client: cl, opManagerContext: context.TODO()}
+	name := types.NamespacedName{Name: "log", Namespace: "rook-ceph"}
+
+	loaded := &cephv1.ObjectStoreScriptStatus{Hash: "hash", Context: cephv1.ObjectStoreScriptContextPreRequest, Tenant: "team-a"}
+	r.updateStatus(2, name, k8sutil.ReadyStatus, loaded)
+	updated := &cephv1.CephObjectStoreScript{}
+	require.NoError(t, cl.Get(context.TODO(), name, updated))
+	assert.Equal(t, k8sutil.ReadyStatus, updated.Status.Phase)
+	assert.Equal(t, "hash", updated.Status.Hash)
+	assert.Equal(t, cephv1.ObjectStoreScriptContextPreRequest, updated.Status.Context)
+	assert.Equal(t, "team-a", updated.Status.Tenant)
+	assert.Equal(t, int64(2), updated.Status.ObservedGeneration)
+
+	// the loaded script is kept when no script is given
+	r.updateStatus(k8sutil.ObservedGenerationNotAvailable, name, k8sutil.ReconcileFailedStatus, nil)
+	require.NoError(t, cl.Get(context.TODO(), name, updated))
+	assert.Equal(t, k8sutil.ReconcileFailedStatus, updated.Status.Phase)
+	assert.Equal(t, "hash", updated.Status.Hash)
+
+	// the removed script is forgotten
+	r.updateStatus(k8sutil.ObservedGenerationNotAvailable, name, k8sutil.ReconcilingStatus, &cephv1.ObjectStoreScriptStatus{})
+	require.NoError(t, cl.Get(context.TODO(), name, updated))
+	assert.Empty(t, updated.Status.Hash)
+	assert.Empty(t, updated.Status.Context)
+	assert.Empty(t, updated.Status.Tenant)
+	assert.Equal(t, int64(2), updated.Status.ObservedGeneration)
+}
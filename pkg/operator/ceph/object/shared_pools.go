@@ -6,15 +6,20 @@ import (
 	"os"
 	"path"
 	"sort"
+	"strconv"
 
 	"github.com/pkg/errors"
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
 	defaultPlacementCephConfigName = "default-placement"
 	defaultPlacementStorageClass   = "STANDARD"
+
+	cloudTierAccessKeyKey = "AWS_ACCESS_KEY_ID"
+	cloudTierSecretKeyKey = "AWS_SECRET_ACCESS_KEY"
 )
 
 func IsNeedToCreateObjectStorePools(sharedPools cephv1.ObjectSharedPoolsSpec) bool {
@@ -202,6 +207,55 @@ func getDefaultPlacementName(spec cephv1.ObjectSharedPoolsSpec) string {
 	return defaultPlacementCephConfigName
 }
 
+// BucketPlacementRule returns the placement rule of a new bucket of the object store, formatted as
+// "<placement>" or "<placement>/<storage class>". The placement and the storage class must be
+// defined in the pool placements of the object store, and the default placement of new buckets
+// is used when only the storage class is set. The pool placements of multisite object stores are
+// defined in their zone and are not validated.
+func BucketPlacementRule(store *cephv1.CephObjectStore, placement, storageClass string) (string, error) {
+	if placement == "" && storageClass == "" {
+		return "", nil
+	}
+
+	if store.Spec.IsMultisite() {
+		if placement == "" {
+			return "", fmt.Errorf("a placement must be set with storage class %q for the multisite object store %q", storageClass, store.Name)
+		}
+	} else {
+		if placement == "" {
+			placement = migrationDefaultPlacement(store)
+		}
+		if placement == "" {
+			placement = getDefaultPlacementName(store.Spec.SharedPools)
+		}
+		var placementSpec *cephv1.PoolPlacementSpec
+		for i, pp := range store.Spec.SharedPools.PoolPlacements {
+			if pp.Name == placement {
+				placementSpec = &store.Spec.SharedPools.PoolPlacements[i]
+			}
+		}
+		if placementSpec == nil && placement != defaultPlacementCephConfigName {
+			return "", fmt.Errorf("placement %q is not defined in the pool placements of object store %q", placement, store.Name)
+		}
+		if storageClass != "" && storageClass != defaultPlacementStorageClass {
+			found := false
+			if placementSpec != nil {
+				for _, sc := range placementSpec.StorageClasses {
+					found = found || sc.Name == storageClass
+				}
+			}
+			if !found {
+				return "", fmt.Errorf("storage class %q is not defined in placement %q of object store %q", storageClass, placement, store.Name)
+			}
+		}
+	}
+
+	if storageClass == "" {
+		return placement, nil
+	}
+	return placement + "/" + storageClass, nil
+}
+
 func getDefaultMetadataPool(spec cephv1.ObjectSharedPoolsSpec) string {
 	for _, p := range spec.PoolPlacements {
 		if p.Default {
@@ -268,6 +322,10 @@ func toZonePlacementPool(spec cephv1.PoolPlacementSpec, ns string) ZonePlacement
 		},
 	}
 	for _, v := range spec.StorageClasses {
+		if v.Cloud != nil {
+			// cloud tiers have no pool in the zone, they are only configured on the zonegroup placement target
+			continue
+		}
 		res.Val.StorageClasses[v.Name] = ZonePlacementStorageClass{
 			DataPool: v.DataPoolName + ":" + ns + "." + v.Name,
 		}
@@ -275,7 +333,9 @@ func toZonePlacementPool(spec cephv1.PoolPlacementSpec, ns string) ZonePlacement
 	return res
 }
 
-func adjustZoneGroupPlacementTargets(group, zone map[string]interface{}, defaultPlacement string) (map[string]interface{}, error) {
+// adjustZoneGroupPlacementTargets sets the zonegroup placement targets from the zone pool placements.
+// tierTargets are the cloud tier StorageClasses of each placement, which only exist in the zonegroup.
+func adjustZoneGroupPlacementTargets(group, zone map[string]interface{}, defaultPlacement string, tierTargets map[string][]ZonegroupTierTarget) (map[string]interface{}, error) {
 	name, err := getObjProperty[string](group, "name")
 	if err != nil {
 		return nil, fmt.Errorf("unable to get zonegroup name: %w", err)
@@ -296,6 +356,18 @@ func adjustZoneGroupPlacementTargets(group, zone map[string]interface{}, default
 	if err != nil {
 		return nil, fmt.Errorf("unable to create targets from placements for zonegroup %q: %w", name, err)
 	}
+	for placement, tiers := range tierTargets {
+		target, ok := desiredTargets[placement]
+		if !ok {
+			continue
+		}
+		for _, tier := range tiers {
+			target.StorageClasses = append(target.StorageClasses, tier.Key)
+		}
+		sort.Strings(target.StorageClasses)
+		target.TierTargets = tiers
+		desiredTargets[placement] = target
+	}
 	currentTargets, err := getObjProperty[[]interface{}](group, "placement_targets")
 	if err != nil {
 		return nil, fmt.Errorf("unable to get targets from placements for zonegroup %q: %w", name, err)
@@ -324,6 +396,9 @@ func adjustZoneGroupPlacementTargets(group, zone map[string]interface{}, default
 			if err != nil {
 				return nil, fmt.Errorf("unable to set storage classes to pool placement target %q for zonegroup %q: %w", tName, name, err)
 			}
+			if err = adjustPlacementTierTargets(tObj, desired.TierTargets); err != nil {
+				return nil, fmt.Errorf("unable to set tier targets to pool placement target %q for zonegroup %q: %w", tName, name, err)
+			}
 			applied[tName] = struct{}{}
 		} else {
 			// remove target
@@ -365,6 +440,133 @@ func adjustZoneGroupPlacementTargets(group, zone map[string]interface{}, default
 	return group, nil
 }
 
+// adjustPlacementTierTargets sets the tier targets of a zonegroup placement target. Existing tier
+// targets are updated in place to keep the settings Rook does not manage, like the multipart sizes.
+func adjustPlacementTierTargets(target map[string]interface{}, desired []ZonegroupTierTarget) error {
+	current, err := getObjProperty[[]interface{}](target, "tier_targets")
+	if err != nil {
+		if len(desired) == 0 {
+			// the placement target has no tier targets
+			return nil
+		}
+		current = []interface{}{}
+	}
+
+	existing := make(map[string]map[string]interface{}, len(current))
+	for _, t := range current {
+		tObj, ok := t.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unable to cast tier target to object: %+v", t)
+		}
+		key, err := getObjProperty[string](tObj, "key")
+		if err != nil {
+			return fmt.Errorf("unable to get tier target key: %w", err)
+		}
+		existing[key] = tObj
+	}
+
+	updated := make([]interface{}, 0, len(desired))
+	for _, tier := range desired {
+		val, err := toObj(tier.Val)
+		if err != nil {
+			return fmt.Errorf("unable to convert tier target %q: %w", tier.Key, err)
+		}
+		tObj, ok := existing[tier.Key]
+		if !ok {
+			tObj = map[string]interface{}{"key": tier.Key, "val": val}
+			updated = append(updated, tObj)
+			continue
+		}
+		tObj, err = deepCopyJson(tObj)
+		if err != nil {
+			return fmt.Errorf("unable to deep copy tier target %q: %w", tier.Key, err)
+		}
+		currentVal, err := getObjProperty[map[string]interface{}](tObj, "val")
+		if err != nil {
+			return fmt.Errorf("unable to get tier target %q: %w", tier.Key, err)
+		}
+		mergeJSONObj(currentVal, val)
+		updated = append(updated, tObj)
+	}
+	target["tier_targets"] = updated
+	return nil
+}
+
+// mergeJSONObj recursively sets the properties of src in dst
+func mergeJSONObj(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcObj, srcIsObj := v.(map[string]interface{})
+		dstObj, dstIsObj := dst[k].(map[string]interface{})
+		if srcIsObj && dstIsObj {
+			mergeJSONObj(dstObj, srcObj)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// toZonegroupTierTargets converts the cloud tier StorageClasses of the pool placements to zonegroup
+// tier targets, by placement name. The credentials are read from the secrets of the tiers.
+func toZonegroupTierTargets(spec cephv1.ObjectSharedPoolsSpec, getCredentials func(secretName string) (accessKey, secretKey string, err error)) (map[string][]ZonegroupTierTarget, error) {
+	res := map[string][]ZonegroupTierTarget{}
+	for _, pp := range spec.PoolPlacements {
+		for _, sc := range pp.StorageClasses {
+			if sc.Cloud == nil {
+				continue
+			}
+			accessKey, secretKey, err := getCredentials(sc.Cloud.CredentialsSecretName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the credentials of cloud tier %q of placement %q: %w", sc.Name, pp.Name, err)
+			}
+			tierType := sc.Cloud.TierType
+			if tierType == "" {
+				tierType = cephv1.CloudTierTypeS3
+			}
+			hostStyle := sc.Cloud.HostStyle
+			if hostStyle == "" {
+				hostStyle = "path"
+			}
+			res[pp.Name] = append(res[pp.Name], ZonegroupTierTarget{
+				Key: sc.Name,
+				Val: ZonegroupTier{
+					TierType:         string(tierType),
+					StorageClass:     sc.Name,
+					RetainHeadObject: strconv.FormatBool(sc.Cloud.RetainHeadObject),
+					S3: ZonegroupTierS3{
+						Endpoint: sc.Cloud.Endpoint,
+						Credentials: ZonegroupTierCredentials{
+							AccessKey: accessKey,
+							Secret:    secretKey,
+						},
+						TargetPath:         sc.Cloud.TargetPath,
+						TargetStorageClass: sc.Cloud.TargetStorageClass,
+						Region:             sc.Cloud.Region,
+						HostStyle:          hostStyle,
+					},
+				},
+			})
+		}
+	}
+	return res, nil
+}
+
+// getCloudTierCredentials returns the S3 keys of a cloud tier secret in the object store namespace
+func getCloudTierCredentials(objContext *Context, secretName string) (string, string, error) {
+	secret, err := objContext.Context.Clientset.CoreV1().Secrets(objContext.clusterInfo.Namespace).Get(objContext.clusterInfo.Context, secretName, metav1.GetOptions{})
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to get secret %q", secretName)
+	}
+	accessKey, ok := secret.Data[cloudTierAccessKeyKey]
+	if !ok || len(accessKey) == 0 {
+		return "", "", errors.Errorf("secret %q has no %q key", secretName, cloudTierAccessKeyKey)
+	}
+	secretKey, ok := secret.Data[cloudTierSecretKeyKey]
+	if !ok || len(secretKey) == 0 {
+		return "", "", errors.Errorf("secret %q has no %q key", secretName, cloudTierSecretKeyKey)
+	}
+	return string(accessKey), string(secretKey), nil
+}
+
 func createPlacementTargetsFromZonePoolPlacements(zone map[string]interface{}) (map[string]ZonegroupPlacementTarget, error) {
 	zoneName, err := getObjProperty[string](zone, "name")
 	if err != nil {
@@ -529,8 +731,36 @@ func updateZoneGroupJSON(objContext *Context, group map[string]interface{}) (map
 }
 
 type ZonegroupPlacementTarget struct {
-	Name           string   `json:"name"`
-	StorageClasses []string `json:"storage_classes"`
+	Name           string                `json:"name"`
+	StorageClasses []string              `json:"storage_classes"`
+	TierTargets    []ZonegroupTierTarget `json:"tier_targets,omitempty"`
+}
+
+type ZonegroupTierTarget struct {
+	Key string        `json:"key"`
+	Val ZonegroupTier `json:"val"`
+}
+
+type ZonegroupTier struct {
+	TierType     string `json:"tier_type"`
+	StorageClass string `json:"storage_class"`
+	// radosgw-admin reports the retain_head_object flag as a string
+	RetainHeadObject string          `json:"retain_head_object"`
+	S3               ZonegroupTierS3 `json:"s3"`
+}
+
+type ZonegroupTierS3 struct {
+	Endpoint           string                   `json:"endpoint"`
+	Credentials        ZonegroupTierCredentials `json:"credentials"`
+	TargetPath         string                   `json:"target_path"`
+	TargetStorageClass string                   `json:"target_storage_class"`
+	Region             string                   `json:"region"`
+	HostStyle          string                   `json:"host_style"`
+}
+
+type ZonegroupTierCredentials struct {
+	AccessKey string `json:"access_key"`
+	Secret    string `json:"secret"`
 }
 
 type ZonePlacementPool struct {
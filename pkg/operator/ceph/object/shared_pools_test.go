@@ -2,11 +2,13 @@ package object
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 
 	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_validatePoolPlacements(t *testing.T) {
@@ -459,6 +461,11 @@ func Test_toZonePlacementPool(t *testing.T) {
 							Name:         "REDUCED_REDUNDANCY",
 							DataPoolName: "reduced",
 						},
+						{
+							// cloud tiers are not configured in the zone
+							Name:  "GLACIER",
+							Cloud: &cephv1.PlacementCloudTierSpec{Endpoint: "http://minio:9000", CredentialsSecretName: "minio"},
+						},
 					},
 				},
 				ns: "ns",
@@ -1998,7 +2005,7 @@ func Test_adjustZoneGroupPlacementTargets(t *testing.T) {
 			srcGroup := map[string]interface{}{}
 			err = json.Unmarshal([]byte(tt.args.groupBefore), &srcGroup)
 			assert.NoError(t, err)
-			changedGroup, err := adjustZoneGroupPlacementTargets(srcGroup, zj, tt.args.defaultPlacement)
+			changedGroup, err := adjustZoneGroupPlacementTargets(srcGroup, zj, tt.args.defaultPlacement, nil)
 
 			orig := map[string]interface{}{}
 			jErr := json.Unmarshal([]byte(tt.args.groupBefore), &orig)
@@ -2171,3 +2178,238 @@ func Test_getDefaultPlacementName(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketPlacementRule(t *testing.T) {
+	store := &cephv1.CephObjectStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-store"},
+		Spec: cephv1.ObjectStoreSpec{
+			SharedPools: cephv1.ObjectSharedPoolsSpec{
+				PoolPlacements: []cephv1.PoolPlacementSpec{
+					{Name: "fast", Default: true},
+					{Name: "slow", StorageClasses: []cephv1.PlacementStorageClassSpec{{Name: "COLD"}}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		placement    string
+		storageClass string
+		want         string
+		wantErr      bool
+	}{
+		{name: "nothing requested", want: ""},
+		{name: "placement", placement: "slow", want: "slow"},
+		{name: "placement and storage class", placement: "slow", storageClass: "COLD", want: "slow/COLD"},
+		{name: "standard storage class", placement: "fast", storageClass: "STANDARD", want: "fast/STANDARD"},
+		{name: "storage class of the default placement", storageClass: "STANDARD", want: "fast/STANDARD"},
+		{name: "unknown placement", placement: "other", wantErr: true},
+		{name: "unknown storage class", placement: "fast", storageClass: "COLD", wantErr: true},
+		{name: "default placement", placement: "default-placement", want: "default-placement"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BucketPlacementRule(store, tt.placement, tt.storageClass)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("store without pool placements", func(t *testing.T) {
+		store := &cephv1.CephObjectStore{}
+		got, err := BucketPlacementRule(store, "", "STANDARD")
+		assert.NoError(t, err)
+		assert.Equal(t, "default-placement/STANDARD", got)
+		_, err = BucketPlacementRule(store, "", "COLD")
+		assert.Error(t, err)
+	})
+
+	t.Run("migrating store", func(t *testing.T) {
+		store := store.DeepCopy()
+		store.Spec.Migration = &cephv1.ObjectStoreMigrationSpec{TargetPlacement: "slow"}
+		got, err := BucketPlacementRule(store, "", "COLD")
+		assert.NoError(t, err)
+		assert.Equal(t, "slow/COLD", got)
+	})
+
+	t.Run("multisite store", func(t *testing.T) {
+		store := &cephv1.CephObjectStore{Spec: cephv1.ObjectStoreSpec{Zone: cephv1.ZoneSpec{Name: "zone-a"}}}
+		got, err := BucketPlacementRule(store, "any", "COLD")
+		assert.NoError(t, err)
+		assert.Equal(t, "any/COLD", got)
+		_, err = BucketPlacementRule(store, "", "COLD")
+		assert.Error(t, err)
+	})
+}
+
+func Test_toZonegroupTierTargets(t *testing.T) {
+	spec := cephv1.ObjectSharedPoolsSpec{
+		PoolPlacements: []cephv1.PoolPlacementSpec{
+			{
+				Name:             "default",
+				Default:          true,
+				MetadataPoolName: "meta",
+				DataPoolName:     "data",
+				StorageClasses: []cephv1.PlacementStorageClassSpec{
+					{Name: "REDUCED_REDUNDANCY", DataPoolName: "reduced"},
+					{Name: "GLACIER", Cloud: &cephv1.PlacementCloudTierSpec{
+						Endpoint:              "http://minio.minio.svc:9000",
+						CredentialsSecretName: "minio-creds",
+						TargetPath:            "rook-archive",
+						RetainHeadObject:      true,
+					}},
+				},
+			},
+			{
+				Name:             "fast",
+				MetadataPoolName: "fast-meta",
+				DataPoolName:     "fast-data",
+			},
+		},
+	}
+	getCredentials := func(secretName string) (string, string, error) {
+		if secretName != "minio-creds" {
+			return "", "", fmt.Errorf("secret %q not found", secretName)
+		}
+		return "minio-access", "minio-secret", nil
+	}
+
+	tiers, err := toZonegroupTierTargets(spec, getCredentials)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]ZonegroupTierTarget{
+		"default": {{
+			Key: "GLACIER",
+			Val: ZonegroupTier{
+				TierType:         "cloud-s3",
+				StorageClass:     "GLACIER",
+				RetainHeadObject: "true",
+				S3: ZonegroupTierS3{
+					Endpoint:    "http://minio.minio.svc:9000",
+					Credentials: ZonegroupTierCredentials{AccessKey: "minio-access", Secret: "minio-secret"},
+					TargetPath:  "rook-archive",
+					HostStyle:   "path",
+				},
+			},
+		}},
+	}, tiers)
+
+	spec.PoolPlacements[0].StorageClasses[1].Cloud.CredentialsSecretName = "missing"
+	_, err = toZonegroupTierTargets(spec, getCredentials)
+	assert.Error(t, err)
+}
+
+func Test_adjustZoneGroupPlacementTargetsWithTiers(t *testing.T) {
+	zone := `{
+    "name": "test",
+    "placement_pools": [
+        {
+            "key": "default-placement",
+            "val": {
+                "index_pool": "test.rgw.buckets.index",
+                "storage_classes": {
+                    "STANDARD": {
+                        "data_pool": "test.rgw.buckets.data"
+                    }
+                },
+                "data_extra_pool": "test.rgw.buckets.non-ec"
+            }
+        }
+    ]
+}`
+	group := `{
+    "name": "test",
+    "placement_targets": [
+        {
+            "name": "default-placement",
+            "tags": [],
+            "storage_classes": [
+                "STANDARD"
+            ]
+        }
+    ],
+    "default_placement": "default-placement"
+}`
+	tier := ZonegroupTierTarget{
+		Key: "GLACIER",
+		Val: ZonegroupTier{
+			TierType:         "cloud-s3",
+			StorageClass:     "GLACIER",
+			RetainHeadObject: "false",
+			S3: ZonegroupTierS3{
+				Endpoint:    "http://minio:9000",
+				Credentials: ZonegroupTierCredentials{AccessKey: "access", Secret: "secret"},
+				HostStyle:   "path",
+			},
+		},
+	}
+	tiers := map[string][]ZonegroupTierTarget{defaultPlacementCephConfigName: {tier}}
+
+	zj := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(zone), &zj))
+	gj := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(group), &gj))
+
+	// the tier is added to the placement target
+	added, err := adjustZoneGroupPlacementTargets(gj, zj, defaultPlacementCephConfigName, tiers)
+	assert.NoError(t, err)
+	bytes, err := json.Marshal(added)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+    "name": "test",
+    "placement_targets": [
+        {
+            "name": "default-placement",
+            "tags": [],
+            "storage_classes": ["GLACIER", "STANDARD"],
+            "tier_targets": [
+                {
+                    "key": "GLACIER",
+                    "val": {
+                        "tier_type": "cloud-s3",
+                        "storage_class": "GLACIER",
+                        "retain_head_object": "false",
+                        "s3": {
+                            "endpoint": "http://minio:9000",
+                            "credentials": {"access_key": "access", "secret": "secret"},
+                            "target_path": "",
+                            "target_storage_class": "",
+                            "region": "",
+                            "host_style": "path"
+                        }
+                    }
+                }
+            ]
+        }
+    ],
+    "default_placement": "default-placement"
+}`, string(bytes))
+
+	// settings not managed by rook are kept when the tier is updated
+	s3, err := getObjProperty[map[string]interface{}](added["placement_targets"].([]interface{})[0].(map[string]interface{})["tier_targets"].([]interface{})[0].(map[string]interface{}), "val", "s3")
+	assert.NoError(t, err)
+	s3["multipart_sync_threshold"] = float64(33554432)
+	tiers[defaultPlacementCephConfigName][0].Val.S3.Endpoint = "http://minio2:9000"
+	updated, err := adjustZoneGroupPlacementTargets(added, zj, defaultPlacementCephConfigName, tiers)
+	assert.NoError(t, err)
+	s3, err = getObjProperty[map[string]interface{}](updated["placement_targets"].([]interface{})[0].(map[string]interface{})["tier_targets"].([]interface{})[0].(map[string]interface{}), "val", "s3")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://minio2:9000", s3["endpoint"])
+	assert.Equal(t, float64(33554432), s3["multipart_sync_threshold"])
+
+	// applying the same tiers again is a no-op
+	again, err := adjustZoneGroupPlacementTargets(updated, zj, defaultPlacementCephConfigName, tiers)
+	assert.NoError(t, err)
+	assert.True(t, reflect.DeepEqual(updated, again))
+
+	// the tier is removed from the placement target
+	removed, err := adjustZoneGroupPlacementTargets(updated, zj, defaultPlacementCephConfigName, nil)
+	assert.NoError(t, err)
+	target := removed["placement_targets"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"STANDARD"}, target["storage_classes"])
+	assert.Equal(t, []interface{}{}, target["tier_targets"])
+}
@@ -395,6 +395,11 @@ func TestValidateSpec(t *testing.T) {
 	}
 	err = r.validateStore(s)
 	assert.Nil(t, err)
+
+	// external with usage exporter, failure
+	s.Spec.UsageExporter = &cephv1.ObjectStoreUsageExporterSpec{}
+	err = r.validateStore(s)
+	assert.Error(t, err)
 }
 
 func TestDefaultProbes(t *testing.T) {
@@ -69,14 +69,19 @@ func updateStatus(ctx context.Context, observedGeneration int64, client client.C
 			objectStore.Status.ObservedGeneration = observedGeneration
 		}
 
+		insecure, secure := []string{}, []string{}
 		insecurePort := objectStore.Spec.Gateway.Port
 		if insecurePort > 0 {
-			objectStore.Status.Endpoints.Insecure = getAllDNSEndpoints(objectStore, insecurePort, false)
+			insecure = getAllDNSEndpoints(objectStore, insecurePort, false)
 		}
 		securePort := objectStore.Spec.Gateway.SecurePort
 		if securePort > 0 {
-			objectStore.Status.Endpoints.Secure = getAllDNSEndpoints(objectStore, securePort, true)
+			secure = getAllDNSEndpoints(objectStore, securePort, true)
 		}
+		// unlike the other dns names, the exposed ones are known to be reachable from outside
+		exposedInsecure, exposedSecure := getExposedEndpoints(objectStore)
+		objectStore.Status.Endpoints.Insecure = append(insecure, exposedInsecure...)
+		objectStore.Status.Endpoints.Secure = append(secure, exposedSecure...)
 
 		if cephx != nil {
 			objectStore.Status.Cephx.Daemon = *cephx
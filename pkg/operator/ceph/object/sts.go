@@ -0,0 +1,353 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	awssession "github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/sethvargo/go-password/password"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// stsKeySecretKey is the key of the STS key in the STS key secret
+	stsKeySecretKey = "key"
+	// RGW encrypts the session tokens with AES-128, which requires a 16 characters key
+	stsKeyLength = 16
+	// stsKeySymbols are the printable symbols of the generated STS keys, without the comment and quote
+	// characters of the ini file through which the key is set in the ceph config
+	stsKeySymbols = "~!@$%^&*()_+-={}|[]:<>?,./"
+	// the admin ops user manages the OIDC providers, the roles and the user policies through the
+	// IAM API
+	rgwAdminOpsUserIAMCaps = "roles=*;oidc-provider=*;user-policy=*"
+)
+
+var thumbprintRegex = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// NewIAMClientFunc help us mocking the IAM API client in unit test
+var NewIAMClientFunc = NewIAMClient
+
+// NewIAMClient creates a client for the IAM API of the object store, authenticated as the admin
+// ops user
+func NewIAMClient(objContext *AdminOpsContext, spec *cephv1.ObjectStoreSpec) (iamiface.IAMAPI, error) {
+	return NewIAMClientWithCredentials(&objContext.Context, spec, objContext.AdminOpsUserAccessKey, objContext.AdminOpsUserSecretKey)
+}
+
+// NewIAMClientWithCredentials creates a client for the IAM API of the object store, authenticated
+// with the given S3 keys
+func NewIAMClientWithCredentials(objContext *Context, spec *cephv1.ObjectStoreSpec, accessKey, secretKey string) (iamiface.IAMAPI, error) {
+	httpClient, _, err := genObjectStoreHTTPClientFunc(objContext, spec)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Timeout = HttpTimeOut
+
+	session, err := awssession.NewSession(
+		aws.NewConfig().
+			WithRegion(CephRegion).
+			WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, "")).
+			WithEndpoint(objContext.Endpoint).
+			WithMaxRetries(5).
+			WithHTTPClient(httpClient),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build IAM API connection")
+	}
+	return iam.New(session), nil
+}
+
+// IsIAMNotFound returns true if the IAM API error reports that the entity does not exist
+func IsIAMNotFound(err error) bool {
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && aerr.Code() == iam.ErrCodeNoSuchEntityException
+}
+
+func stsKeySecretName(store *cephv1.CephObjectStore) string {
+	return fmt.Sprintf("%s-%s-sts-key", AppName, store.Name)
+}
+
+func validateSTS(sts *cephv1.STSSpec) error {
+	if sts == nil {
+		return nil
+	}
+	issuers := map[string]bool{}
+	for _, p := range sts.OIDCProviders {
+		if !strings.HasPrefix(p.IssuerURL, "https://") {
+			return errors.Errorf("invalid OIDC provider issuer URL %q, must start with https://", p.IssuerURL)
+		}
+		if issuers[p.IssuerURL] {
+			return errors.Errorf("duplicate OIDC provider issuer URL %q", p.IssuerURL)
+		}
+		issuers[p.IssuerURL] = true
+		if len(p.ClientIDs) == 0 {
+			return errors.Errorf("OIDC provider %q must have at least one client ID", p.IssuerURL)
+		}
+		if len(p.Thumbprints) == 0 {
+			return errors.Errorf("OIDC provider %q must have at least one thumbprint", p.IssuerURL)
+		}
+		for _, t := range p.Thumbprints {
+			if !thumbprintRegex.MatchString(t) {
+				return errors.Errorf("invalid thumbprint %q of OIDC provider %q, must be a SHA-1 fingerprint of 40 hexadecimal characters", t, p.IssuerURL)
+			}
+		}
+	}
+	return nil
+}
+
+// getSTSKey returns the key used by the RGWs to encrypt the session tokens. If the object store
+// does not reference a secret with the key, a key is generated and stored in a secret owned by the
+// object store the first time.
+func (c *clusterConfig) getSTSKey() (string, error) {
+	sts := c.store.Spec.Auth.STS
+	if sts == nil {
+		return "", nil
+	}
+
+	secretName := sts.KeySecretName
+	if secretName == "" {
+		secretName = stsKeySecretName(c.store)
+	}
+	secret, err := c.context.Clientset.CoreV1().Secrets(c.store.Namespace).Get(c.clusterInfo.Context, secretName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) || sts.KeySecretName != "" {
+			return "", errors.Wrapf(err, "failed to get the STS key secret %q", secretName)
+		}
+		return c.createSTSKeySecret(secretName)
+	}
+
+	key, ok := secret.Data[stsKeySecretKey]
+	if !ok {
+		return "", errors.Errorf("failed to find key %q in the STS key secret %q", stsKeySecretKey, secretName)
+	}
+	if len(key) != stsKeyLength {
+		return "", errors.Errorf("invalid STS key in secret %q, must be %d characters long", secretName, stsKeyLength)
+	}
+	return string(key), nil
+}
+
+func (c *clusterConfig) createSTSKeySecret(secretName string) (string, error) {
+	generator, err := password.NewGenerator(&password.GeneratorInput{Symbols: stsKeySymbols})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create the STS key generator")
+	}
+	key, err := generator.Generate(stsKeyLength, stsKeyLength/4, stsKeyLength/4, false, true)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate the STS key")
+	}
+
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: c.store.Namespace,
+			Labels:    getLabels(c.store.Name, c.store.Namespace, false),
+		},
+		Data: map[string][]byte{
+			stsKeySecretKey: []byte(key),
+		},
+		Type: k8sutil.RookType,
+	}
+	if err := c.ownerInfo.SetControllerReference(secret); err != nil {
+		return "", errors.Wrapf(err, "failed to set owner reference of the STS key secret %q", secretName)
+	}
+	if _, err := c.context.Clientset.CoreV1().Secrets(c.store.Namespace).Create(c.clusterInfo.Context, secret, metav1.CreateOptions{}); err != nil {
+		return "", errors.Wrapf(err, "failed to create the STS key secret %q", secretName)
+	}
+	logger.Infof("generated the STS key of object store %q in secret %q", c.store.Name, secretName)
+
+	return key, nil
+}
+
+func configureSTS(rgwConfig *rgwConfig, configOptions map[string]string) (map[string]string, error) {
+	if rgwConfig.Auth.STS == nil {
+		logger.Debug("Secure Token Service is disabled")
+		return configOptions, nil
+	}
+	if len(rgwConfig.STSKey) != stsKeyLength {
+		return nil, errors.New("cannot find the STS key")
+	}
+
+	configOptions["rgw_s3_auth_use_sts"] = "true"
+	configOptions["rgw_sts_key"] = rgwConfig.STSKey
+	return configOptions, nil
+}
+
+// EnableAdminOpsUserIAMCaps grants the admin ops user the capabilities to manage the OIDC
+// providers, the roles and the user policies. The admin ops user of existing object stores was
+// created without them.
+func EnableAdminOpsUserIAMCaps(c *Context) error {
+	_, err := runAdminCommand(c, false, "caps", "add", "--uid", RGWAdminOpsUserSecretName, "--caps", rgwAdminOpsUserIAMCaps)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add capabilities %q to the admin ops user", rgwAdminOpsUserIAMCaps)
+	}
+	return nil
+}
+
+// oidcProviderURL returns the URL of the provider as stored by RGW, which drops the scheme
+func oidcProviderURL(issuerURL string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(issuerURL, "https://"), "http://")
+}
+
+type oidcProvider struct {
+	arn         string
+	clientIDs   []string
+	thumbprints []string
+}
+
+func (p *oidcProvider) matches(spec cephv1.OIDCProviderSpec) bool {
+	return sameElements(p.clientIDs, spec.ClientIDs) && sameElements(p.thumbprints, spec.Thumbprints)
+}
+
+func sameElements(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+func listOIDCProviders(iamClient iamiface.IAMAPI) (map[string]*oidcProvider, error) {
+	list, err := iamClient.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the OIDC providers")
+	}
+
+	providers := map[string]*oidcProvider{}
+	for _, entry := range list.OpenIDConnectProviderList {
+		arn := aws.StringValue(entry.Arn)
+		provider, err := iamClient.GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{OpenIDConnectProviderArn: entry.Arn})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the OIDC provider %q", arn)
+		}
+		providers[oidcProviderURL(aws.StringValue(provider.Url))] = &oidcProvider{
+			arn:         arn,
+			clientIDs:   aws.StringValueSlice(provider.ClientIDList),
+			thumbprints: aws.StringValueSlice(provider.ThumbprintList),
+		}
+	}
+	return providers, nil
+}
+
+func deleteOIDCProvider(iamClient iamiface.IAMAPI, arn string) error {
+	_, err := iamClient.DeleteOpenIDConnectProvider(&iam.DeleteOpenIDConnectProviderInput{OpenIDConnectProviderArn: aws.String(arn)})
+	if err != nil && !IsIAMNotFound(err) {
+		return errors.Wrapf(err, "failed to delete the OIDC provider %q", arn)
+	}
+	return nil
+}
+
+// reconcileOIDCProviders creates the OIDC providers of the spec, and returns their ARNs. A
+// provider whose client IDs or thumbprints changed is recreated. Providers that were previously
+// configured by Rook and are no longer in the spec are deleted, other providers are left alone.
+func reconcileOIDCProviders(iamClient iamiface.IAMAPI, specs []cephv1.OIDCProviderSpec, previousARNs []string) ([]string, error) {
+	existing, err := listOIDCProviders(iamClient)
+	if err != nil {
+		return nil, err
+	}
+
+	arns := []string{}
+	for _, spec := range specs {
+		if p, ok := existing[oidcProviderURL(spec.IssuerURL)]; ok {
+			if p.matches(spec) {
+				arns = append(arns, p.arn)
+				continue
+			}
+			logger.Infof("recreating OIDC provider %q to update its client IDs and thumbprints", spec.IssuerURL)
+			if err := deleteOIDCProvider(iamClient, p.arn); err != nil {
+				return nil, err
+			}
+		}
+
+		created, err := iamClient.CreateOpenIDConnectProvider(&iam.CreateOpenIDConnectProviderInput{
+			Url:            aws.String(spec.IssuerURL),
+			ClientIDList:   aws.StringSlice(spec.ClientIDs),
+			ThumbprintList: aws.StringSlice(spec.Thumbprints),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create the OIDC provider %q", spec.IssuerURL)
+		}
+		logger.Infof("created OIDC provider %q", spec.IssuerURL)
+		arns = append(arns, aws.StringValue(created.OpenIDConnectProviderArn))
+	}
+
+	for _, arn := range previousARNs {
+		if slices.Contains(arns, arn) {
+			continue
+		}
+		if err := deleteOIDCProvider(iamClient, arn); err != nil {
+			return nil, err
+		}
+		logger.Infof("deleted OIDC provider %q", arn)
+	}
+
+	slices.Sort(arns)
+	return arns, nil
+}
+
+// PolicyDocumentsEqual returns true if both policy documents are the same JSON document. The IAM
+// API may return the documents URL-encoded.
+func PolicyDocumentsEqual(current, desired string) bool {
+	if decoded, err := url.QueryUnescape(current); err == nil && !strings.HasPrefix(strings.TrimSpace(current), "{") {
+		current = decoded
+	}
+	var currentDoc, desiredDoc interface{}
+	if json.Unmarshal([]byte(current), &currentDoc) != nil || json.Unmarshal([]byte(desired), &desiredDoc) != nil {
+		return current == desired
+	}
+	return reflect.DeepEqual(currentDoc, desiredDoc)
+}
+
+// updateOIDCProviderStatus records the OIDC providers in the object store status
+func updateOIDCProviderStatus(ctx context.Context, k8sClient client.Client, namespacedName types.NamespacedName, arns []string) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		objectStore := &cephv1.CephObjectStore{}
+		if err := k8sClient.Get(ctx, namespacedName, objectStore); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to retrieve object store %q to update the OIDC provider status", namespacedName.String())
+		}
+		if objectStore.Status == nil {
+			objectStore.Status = &cephv1.ObjectStoreStatus{}
+		}
+		objectStore.Status.OIDCProviders = arns
+		return reporting.UpdateStatus(k8sClient, objectStore)
+	})
+	if err != nil {
+		logger.Errorf("failed to update the OIDC provider status of object store %q. %v", namespacedName.String(), err)
+	}
+}
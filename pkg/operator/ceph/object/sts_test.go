@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/k8sutil"
+	"github.com/rook/rook/pkg/operator/test"
+	"github.com/sethvargo/go-password/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testIssuer     = "https://kubernetes.default.svc"
+	testThumbprint = "0123456789abcdef0123456789abcdef01234567"
+
+	testReadPolicy  = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"arn:aws:s3:::*"}]}`
+	testWritePolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObject"],"Resource":"arn:aws:s3:::*"}]}`
+)
+
+// fakeOIDCProviderIAM implements the OIDC provider calls of the IAM API
+type fakeOIDCProviderIAM struct {
+	iamiface.IAMAPI
+	providers map[string]*iam.GetOpenIDConnectProviderOutput
+	created   []string
+	deleted   []string
+}
+
+func (f *fakeOIDCProviderIAM) ListOpenIDConnectProviders(*iam.ListOpenIDConnectProvidersInput) (*iam.ListOpenIDConnectProvidersOutput, error) {
+	out := &iam.ListOpenIDConnectProvidersOutput{}
+	for arn := range f.providers {
+		out.OpenIDConnectProviderList = append(out.OpenIDConnectProviderList, &iam.OpenIDConnectProviderListEntry{Arn: aws.String(arn)})
+	}
+	return out, nil
+}
+
+func (f *fakeOIDCProviderIAM) GetOpenIDConnectProvider(in *iam.GetOpenIDConnectProviderInput) (*iam.GetOpenIDConnectProviderOutput, error) {
+	return f.providers[aws.StringValue(in.OpenIDConnectProviderArn)], nil
+}
+
+func (f *fakeOIDCProviderIAM) CreateOpenIDConnectProvider(in *iam.CreateOpenIDConnectProviderInput) (*iam.CreateOpenIDConnectProviderOutput, error) {
+	arn := "arn:aws:iam:::oidc-provider/" + oidcProviderURL(aws.StringValue(in.Url))
+	f.providers[arn] = &iam.GetOpenIDConnectProviderOutput{
+		Url:            aws.String(oidcProviderURL(aws.StringValue(in.Url))),
+		ClientIDList:   in.ClientIDList,
+		ThumbprintList: in.ThumbprintList,
+	}
+	f.created = append(f.created, arn)
+	return &iam.CreateOpenIDConnectProviderOutput{OpenIDConnectProviderArn: aws.String(arn)}, nil
+}
+
+func (f *fakeOIDCProviderIAM) DeleteOpenIDConnectProvider(in *iam.DeleteOpenIDConnectProviderInput) (*iam.DeleteOpenIDConnectProviderOutput, error) {
+	arn := aws.StringValue(in.OpenIDConnectProviderArn)
+	if _, ok := f.providers[arn]; !ok {
+		return nil, awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil)
+	}
+	delete(f.providers, arn)
+	f.deleted = append(f.deleted, arn)
+	return &iam.DeleteOpenIDConnectProviderOutput{}, nil
+}
+
+func TestReconcileOIDCProviders(t *testing.T) {
+	arn := "arn:aws:iam:::oidc-provider/kubernetes.default.svc"
+	spec := cephv1.OIDCProviderSpec{IssuerURL: testIssuer, ClientIDs: []string{"sts.ceph"}, Thumbprints: []string{testThumbprint}}
+
+	t.Run("create the provider", func(t *testing.T) {
+		f := &fakeOIDCProviderIAM{providers: map[string]*iam.GetOpenIDConnectProviderOutput{}}
+		arns, err := reconcileOIDCProviders(f, []cephv1.OIDCProviderSpec{spec}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{arn}, arns)
+		assert.Equal(t, []string{arn}, f.created)
+	})
+
+	t.Run("provider is up to date", func(t *testing.T) {
+		f := &fakeOIDCProviderIAM{providers: map[string]*iam.GetOpenIDConnectProviderOutput{}}
+		_, err := reconcileOIDCProviders(f, []cephv1.OIDCProviderSpec{spec}, nil)
+		require.NoError(t, err)
+		f.created = nil
+
+		arns, err := reconcileOIDCProviders(f, []cephv1.OIDCProviderSpec{spec}, []string{arn})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{arn}, arns)
+		assert.Empty(t, f.created)
+		assert.Empty(t, f.deleted)
+	})
+
+	t.Run("recreate the provider when the client IDs change", func(t *testing.T) {
+		f := &fakeOIDCProviderIAM{providers: map[string]*iam.GetOpenIDConnectProviderOutput{}}
+		_, err := reconcileOIDCProviders(f, []cephv1.OIDCProviderSpec{spec}, nil)
+		require.NoError(t, err)
+
+		updated := spec
+		updated.ClientIDs = []string{"sts.ceph", "other"}
+		arns, err := reconcileOIDCProviders(f, []cephv1.OIDCProviderSpec{updated}, []string{arn})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{arn}, arns)
+		assert.Equal(t, []string{arn}, f.deleted)
+		assert.ElementsMatch(t, []string{"sts.ceph", "other"}, aws.StringValueSlice(f.providers[arn].ClientIDList))
+	})
+
+	t.Run("delete the providers removed from the spec only", func(t *testing.T) {
+		unmanaged := "arn:aws:iam:::oidc-provider/example.com"
+		f := &fakeOIDCProviderIAM{providers: map[string]*iam.GetOpenIDConnectProviderOutput{
+			unmanaged: {Url: aws.String("example.com")},
+		}}
+		_, err := reconcileOIDCProviders(f, []cephv1.OIDCProviderSpec{spec}, nil)
+		require.NoError(t, err)
+
+		arns, err := reconcileOIDCProviders(f, nil, []string{arn})
+		assert.NoError(t, err)
+		assert.Empty(t, arns)
+		assert.Equal(t, []string{arn}, f.deleted)
+		assert.Contains(t, f.providers, unmanaged)
+	})
+}
+
+func TestValidateSTS(t *testing.T) {
+	valid := cephv1.OIDCProviderSpec{IssuerURL: testIssuer, ClientIDs: []string{"sts.ceph"}, Thumbprints: []string{testThumbprint}}
+	assert.NoError(t, validateSTS(nil))
+	assert.NoError(t, validateSTS(&cephv1.STSSpec{}))
+	assert.NoError(t, validateSTS(&cephv1.STSSpec{OIDCProviders: []cephv1.OIDCProviderSpec{valid}}))
+
+	p := valid
+	p.IssuerURL = "http://kubernetes.default.svc"
+	assert.Error(t, validateSTS(&cephv1.STSSpec{OIDCProviders: []cephv1.OIDCProviderSpec{p}}))
+
+	p = valid
+	p.ClientIDs = nil
+	assert.Error(t, validateSTS(&cephv1.STSSpec{OIDCProviders: []cephv1.OIDCProviderSpec{p}}))
+
+	p = valid
+	p.Thumbprints = []string{"not-a-thumbprint"}
+	assert.Error(t, validateSTS(&cephv1.STSSpec{OIDCProviders: []cephv1.OIDCProviderSpec{p}}))
+
+	assert.Error(t, validateSTS(&cephv1.STSSpec{OIDCProviders: []cephv1.OIDCProviderSpec{valid, valid}}))
+}
+
+func TestGetSTSKey(t *testing.T) {
+	newConfig := func(sts *cephv1.STSSpec, objects ...*v1.Secret) *clusterConfig {
+		clientset := test.New(t, 3)
+		for _, s := range objects {
+			_, err := clientset.CoreV1().Secrets(s.Namespace).Create(context.TODO(), s, metav1.CreateOptions{})
+			require.NoError(t, err)
+		}
+		store := &cephv1.CephObjectStore{ObjectMeta: metav1.ObjectMeta{Name: "my-store", Namespace: "ns"}}
+		store.Spec.Auth.STS = sts
+		return &clusterConfig{
+			context:     &clusterd.Context{Clientset: clientset},
+			clusterInfo: &cephclient.ClusterInfo{Context: context.TODO()},
+			store:       store,
+			ownerInfo:   k8sutil.NewOwnerInfoWithOwnerRef(&metav1.OwnerReference{}, "ns"),
+		}
+	}
+
+	t.Run("sts disabled", func(t *testing.T) {
+		key, err := newConfig(nil).getSTSKey()
+		assert.NoError(t, err)
+		assert.Empty(t, key)
+	})
+
+	t.Run("generate the key once", func(t *testing.T) {
+		c := newConfig(&cephv1.STSSpec{})
+		key, err := c.getSTSKey()
+		assert.NoError(t, err)
+		assert.Len(t, key, stsKeyLength)
+		digits, symbols := 0, 0
+		for _, r := range key {
+			assert.True(t, strings.ContainsRune(password.LowerLetters+password.UpperLetters+password.Digits+stsKeySymbols, r), "unexpected character %q", r)
+			if strings.ContainsRune(password.Digits, r) {
+				digits++
+			}
+			if strings.ContainsRune(stsKeySymbols, r) {
+				symbols++
+			}
+		}
+		assert.Equal(t, stsKeyLength/4, digits)
+		assert.Equal(t, stsKeyLength/4, symbols)
+
+		secret, err := c.context.Clientset.CoreV1().Secrets("ns").Get(context.TODO(), "rook-ceph-rgw-my-store-sts-key", metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, key, string(secret.Data["key"]))
+
+		again, err := c.getSTSKey()
+		assert.NoError(t, err)
+		assert.Equal(t, key, again)
+	})
+
+	t.Run("key from secret", func(t *testing.T) {
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-sts-key", Namespace: "ns"},
+			Data:       map[string][]byte{"key": []byte("abcdefghijklmnop")},
+		}
+		key, err := newConfig(&cephv1.STSSpec{KeySecretName: "my-sts-key"}, secret).getSTSKey()
+		assert.NoError(t, err)
+		assert.Equal(t, "abcdefghijklmnop", key)
+	})
+
+	t.Run("key from secret has the wrong length", func(t *testing.T) {
+		secret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-sts-key", Namespace: "ns"},
+			Data:       map[string][]byte{"key": []byte("short")},
+		}
+		_, err := newConfig(&cephv1.STSSpec{KeySecretName: "my-sts-key"}, secret).getSTSKey()
+		assert.Error(t, err)
+	})
+
+	t.Run("referenced secret does not exist", func(t *testing.T) {
+		_, err := newConfig(&cephv1.STSSpec{KeySecretName: "my-sts-key"}).getSTSKey()
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigureSTS(t *testing.T) {
+	configOptions, err := configureSTS(&rgwConfig{}, map[string]string{})
+	assert.NoError(t, err)
+	assert.Empty(t, configOptions)
+
+	cfg := &rgwConfig{Auth: cephv1.AuthSpec{STS: &cephv1.STSSpec{}}}
+	_, err = configureSTS(cfg, map[string]string{})
+	assert.Error(t, err)
+
+	cfg.STSKey = strings.Repeat("a", stsKeyLength)
+	configOptions, err = configureSTS(cfg, map[string]string{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"rgw_s3_auth_use_sts": "true",
+		"rgw_sts_key":         cfg.STSKey,
+	}, configOptions)
+}
+
+func TestPolicyDocumentsEqual(t *testing.T) {
+	assert.True(t, PolicyDocumentsEqual(testReadPolicy, testReadPolicy))
+	assert.True(t, PolicyDocumentsEqual(url.QueryEscape(testReadPolicy), testReadPolicy))
+	assert.True(t, PolicyDocumentsEqual(`{"Version": "2012-10-17", "Statement": []}`, `{"Statement":[],"Version":"2012-10-17"}`))
+	assert.False(t, PolicyDocumentsEqual(testReadPolicy, testWritePolicy))
+}
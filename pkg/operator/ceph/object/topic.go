@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rgwTopicStats is the output of 'radosgw-admin topic stats'
+type rgwTopicStats struct {
+	Stats struct {
+		Reservations int64 `json:"Reservations"`
+		Size         int64 `json:"Size"`
+		Entries      int64 `json:"Entries"`
+	} `json:"Topic Stats"`
+}
+
+// GetTopicDeliveryStatus returns the queue of notifications pending delivery of a persistent topic
+func GetTopicDeliveryStatus(c *Context, topic string) (*cephv1.TopicDeliveryStatus, error) {
+	output, err := runAdminCommand(c, true, "topic", "stats", "--topic", topic)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the stats of topic %q", topic)
+	}
+	var stats rgwTopicStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the stats of topic %q. %s", topic, output)
+	}
+
+	now := metav1.Now()
+	return &cephv1.TopicDeliveryStatus{
+		PendingEntries: stats.Stats.Entries,
+		PendingSize:    stats.Stats.Size,
+		Reservations:   stats.Stats.Reservations,
+		LastChecked:    &now,
+	}, nil
+}
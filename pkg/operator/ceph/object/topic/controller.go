@@ -21,6 +21,7 @@ import (
 	"context"
 	"slices"
 	"sort"
+	"time"
 
 	"github.com/coreos/pkg/capnslog"
 	"github.com/pkg/errors"
@@ -47,6 +48,9 @@ import (
 const (
 	packageName    = "ceph-bucket-topic"
 	controllerName = packageName + "-controller"
+
+	// deliveryStatusInterval is how often the delivery queue of persistent topics is checked
+	deliveryStatusInterval = 5 * time.Minute
 )
 
 var logger = capnslog.NewPackageLogger("github.com/rook/rook", packageName)
@@ -290,8 +294,23 @@ func (r *ReconcileBucketTopic) reconcile(request reconcile.Request) (reconcile.R
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus, topicARN, referencedSecrets)
 
-	// Return and do not requeue
-	return reconcile.Result{}, nil
+	if !cephBucketTopic.Spec.Persistent {
+		if cephBucketTopic.Status != nil && cephBucketTopic.Status.Delivery != nil {
+			r.updateDeliveryStatus(request.NamespacedName, nil)
+		}
+		// Return and do not requeue
+		return reconcile.Result{}, nil
+	}
+
+	// the queue of persistent topics is checked periodically to report the pending notifications
+	delivery, err := r.getCephBucketTopicDeliveryStatus(cephBucketTopic)
+	if err != nil {
+		logger.Warningf("failed to get the delivery status of CephBucketTopic %q. %v", request.NamespacedName, err)
+	} else {
+		r.updateDeliveryStatus(request.NamespacedName, delivery)
+	}
+	logger.Debugf("checking the delivery status of CephBucketTopic %q again in %s", request.NamespacedName, deliveryStatusInterval)
+	return reconcile.Result{RequeueAfter: deliveryStatusInterval}, nil
 }
 
 func (r *ReconcileBucketTopic) createCephBucketTopic(topic *cephv1.CephBucketTopic) (topicARN *string, referencedSecrets *map[types.UID]*corev1.Secret, err error) {
@@ -308,6 +327,19 @@ func (r *ReconcileBucketTopic) createCephBucketTopic(topic *cephv1.CephBucketTop
 	return
 }
 
+func (r *ReconcileBucketTopic) getCephBucketTopicDeliveryStatus(topic *cephv1.CephBucketTopic) (*cephv1.TopicDeliveryStatus, error) {
+	return getDeliveryStatusFunc(
+		provisioner{
+			client:           r.client,
+			context:          r.context,
+			clusterInfo:      r.clusterInfo,
+			clusterSpec:      r.clusterSpec,
+			opManagerContext: r.opManagerContext,
+		},
+		topic,
+	)
+}
+
 func (r *ReconcileBucketTopic) deleteCephBucketTopic(topic *cephv1.CephBucketTopic) error {
 	return deleteTopicFunc(
 		provisioner{
@@ -373,3 +405,26 @@ func (r *ReconcileBucketTopic) updateStatus(observedGeneration int64, nsName typ
 	}
 	logger.Debugf("CephbucketTopic %q status updated to %q", nsName, status)
 }
+
+// updateDeliveryStatus sets the delivery queue of the topic in its status
+func (r *ReconcileBucketTopic) updateDeliveryStatus(nsName types.NamespacedName, delivery *cephv1.TopicDeliveryStatus) {
+	topic := &cephv1.CephBucketTopic{}
+	if err := r.client.Get(r.opManagerContext, nsName, topic); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debugf("CephBucketTopic %q not found. Ignoring since resource must be deleted", nsName)
+			return
+		}
+		logger.Warningf("failed to retrieve CephBucketTopic %q to update its delivery status. error %v", nsName, err)
+		return
+	}
+	if topic.Status == nil {
+		topic.Status = &cephv1.BucketTopicStatus{}
+	}
+
+	topic.Status.Delivery = delivery
+	if err := reporting.UpdateStatus(r.client, topic); err != nil {
+		logger.Errorf("failed to set CephBucketTopic %q delivery status. error %v", nsName, err)
+		return
+	}
+	logger.Debugf("CephBucketTopic %q delivery status updated", nsName)
+}
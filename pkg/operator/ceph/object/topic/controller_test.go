@@ -244,5 +244,31 @@ func TestCephBucketTopicController(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, bucketTopic.Status.ARN)
 		assert.Equal(t, *bucketTopic.Status.ARN, expectedARN)
+
+		// the delivery queue of a persistent topic is reported and checked periodically
+		bucketTopic.Spec.Persistent = true
+		err = r.client.Update(ctx, bucketTopic)
+		assert.NoError(t, err)
+		getDeliveryStatusFunc = func(p provisioner, topic *cephv1.CephBucketTopic) (*cephv1.TopicDeliveryStatus, error) {
+			return &cephv1.TopicDeliveryStatus{PendingEntries: 3, PendingSize: 1024}, nil
+		}
+		defer func() { getDeliveryStatusFunc = getDeliveryStatus }()
+		res, err = r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, deliveryStatusInterval, res.RequeueAfter)
+		err = r.client.Get(ctx, req.NamespacedName, bucketTopic)
+		assert.NoError(t, err)
+		assert.Equal(t, &cephv1.TopicDeliveryStatus{PendingEntries: 3, PendingSize: 1024}, bucketTopic.Status.Delivery)
+
+		// the delivery status is removed when the topic is no longer persistent
+		bucketTopic.Spec.Persistent = false
+		err = r.client.Update(ctx, bucketTopic)
+		assert.NoError(t, err)
+		res, err = r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsZero())
+		err = r.client.Get(ctx, req.NamespacedName, bucketTopic)
+		assert.NoError(t, err)
+		assert.Nil(t, bucketTopic.Status.Delivery)
 	})
 }
@@ -154,6 +154,11 @@ func createTopicAttributes(p provisioner, topic *cephv1.CephBucketTopic) (map[st
 	attr["OpaqueData"] = &topic.Spec.OpaqueData
 	persistent := strconv.FormatBool(topic.Spec.Persistent)
 	attr["persistent"] = &persistent
+	if topic.Spec.Persistent && topic.Spec.Delivery != nil {
+		setDeliveryAttribute(attr, "time_to_live", topic.Spec.Delivery.TimeToLive)
+		setDeliveryAttribute(attr, "max_retries", topic.Spec.Delivery.MaxRetries)
+		setDeliveryAttribute(attr, "retry_sleep_duration", topic.Spec.Delivery.RetrySleepDuration)
+	}
 	var verifySSL string
 	var useSSL string
 	if topic.Spec.Endpoint.AMQP != nil {
@@ -226,6 +231,15 @@ func createTopicAttributes(p provisioner, topic *cephv1.CephBucketTopic) (map[st
 	return attr, &referencedSecrets, nil
 }
 
+// setDeliveryAttribute sets a persistent topic attribute if the setting is in the spec
+func setDeliveryAttribute(attr map[string]*string, name string, value *int32) {
+	if value == nil {
+		return
+	}
+	v := strconv.FormatInt(int64(*value), 10)
+	attr[name] = &v
+}
+
 // Allow overriding this function for unit tests
 var createTopicFunc = createTopic
 
@@ -283,6 +297,24 @@ func deleteTopic(p provisioner, topic *cephv1.CephBucketTopic) error {
 	return nil
 }
 
+// Allow overriding this function for unit tests
+var getDeliveryStatusFunc = getDeliveryStatus
+
+func getDeliveryStatus(p provisioner, topic *cephv1.CephBucketTopic) (*cephv1.TopicDeliveryStatus, error) {
+	objectStoreName := types.NamespacedName{Name: topic.Spec.ObjectStoreName, Namespace: topic.Spec.ObjectStoreNamespace}
+	objStore, err := p.context.RookClientset.CephV1().CephObjectStores(objectStoreName.Namespace).Get(p.opManagerContext, objectStoreName.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get CephObjectStore %v", objectStoreName)
+	}
+
+	objContext, err := object.NewMultisiteContext(p.context, p.clusterInfo, objStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object context for CephObjectStore %v", objectStoreName)
+	}
+
+	return object.GetTopicDeliveryStatus(objContext, topic.Name)
+}
+
 func GetProvisioned(cl client.Client, ctx context.Context, topicName types.NamespacedName) (*cephv1.CephBucketTopic, error) {
 	bucketTopic := &cephv1.CephBucketTopic{}
 	if err := cl.Get(ctx, topicName, bucketTopic); err != nil {
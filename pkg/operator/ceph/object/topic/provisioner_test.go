@@ -69,6 +69,42 @@ func TestTopicAttributesCreation(t *testing.T) {
 		assert.Equal(t, expectedAttrs, attrs)
 	})
 
+	t.Run("test persistent delivery attributes", func(t *testing.T) {
+		uri := "http://localhost"
+		ttl, maxRetries := int32(3600), int32(10)
+		ttlString, maxRetriesString := "3600", "10"
+		expectedAttrs := map[string]*string{
+			"OpaqueData":    &emptyString,
+			"cloudevents":   &falseString,
+			"persistent":    &trueString,
+			"push-endpoint": &uri,
+			"verify-ssl":    &trueString,
+			"time_to_live":  &ttlString,
+			"max_retries":   &maxRetriesString,
+		}
+		bucketTopic := &cephv1.CephBucketTopic{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: cephv1.BucketTopicSpec{
+				ObjectStoreName:      store,
+				ObjectStoreNamespace: namespace,
+				Persistent:           true,
+				Delivery:             &cephv1.TopicDeliverySpec{TimeToLive: &ttl, MaxRetries: &maxRetries},
+				Endpoint: cephv1.TopicEndpointSpec{
+					HTTP: &cephv1.HTTPEndpointSpec{
+						URI: uri,
+					},
+				},
+			},
+		}
+
+		attrs, _, err := createTopicAttributes(provisioner{}, bucketTopic)
+		require.NoError(t, err)
+		assert.Equal(t, expectedAttrs, attrs)
+	})
+
 	t.Run("test AMQP attributes", func(t *testing.T) {
 		uri := "amqp://my-rabbitmq-service:5672/vhost1"
 		ackLevel := "broker"
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTopicDeliveryStatus(t *testing.T) {
+	var commands []string
+	c := newRateLimitTestContext(&commands, `{"Topic Stats": {"Reservations": 2, "Size": 4096, "Entries": 12}}`)
+
+	status, err := GetTopicDeliveryStatus(c, "my-topic")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"topic stats --topic my-topic"}, commands)
+	assert.Equal(t, int64(12), status.PendingEntries)
+	assert.Equal(t, int64(4096), status.PendingSize)
+	assert.Equal(t, int64(2), status.Reservations)
+	assert.NotNil(t, status.LastChecked)
+
+	c = newRateLimitTestContext(&commands, `not json`)
+	_, err = GetTopicDeliveryStatus(c, "my-topic")
+	assert.Error(t, err)
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	defaultUsageInterval  = 5 * time.Minute
+	defaultUsageRetention = 30 * 24 * time.Hour
+	// format of the start and end times of the usage admin ops API
+	usageTimeFormat = "2006-01-02 15:04:05"
+)
+
+// usageGauges are the metrics of the usage of a user or a bucket
+type usageGauges struct {
+	ops           *prometheus.GaugeVec
+	successfulOps *prometheus.GaugeVec
+	bytesSent     *prometheus.GaugeVec
+	bytesReceived *prometheus.GaugeVec
+	storedBytes   *prometheus.GaugeVec
+	objects       *prometheus.GaugeVec
+}
+
+func newUsageGauges(subject string) *usageGauges {
+	labels := []string{"namespace", "object_store", subject}
+	gauge := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "rook_ceph",
+			Subsystem: "object_" + subject,
+			Name:      name,
+			Help:      help,
+		}, labels)
+	}
+	return &usageGauges{
+		ops:           gauge("ops", "Number of operations in the retained usage log records"),
+		successfulOps: gauge("successful_ops", "Number of successful operations in the retained usage log records"),
+		bytesSent:     gauge("bytes_sent", "Bytes sent to the clients in the retained usage log records"),
+		bytesReceived: gauge("bytes_received", "Bytes received from the clients in the retained usage log records"),
+		storedBytes:   gauge("stored_bytes", "Size of the stored objects"),
+		objects:       gauge("objects", "Number of stored objects"),
+	}
+}
+
+func (g *usageGauges) all() []*prometheus.GaugeVec {
+	return []*prometheus.GaugeVec{g.ops, g.successfulOps, g.bytesSent, g.bytesReceived, g.storedBytes, g.objects}
+}
+
+// set replaces the metrics of the object store with the usage
+func (g *usageGauges) set(store types.NamespacedName, usage map[string]*cephv1.ObjectUserUsageStatus) {
+	g.delete(store)
+	for name, u := range usage {
+		labels := []string{store.Namespace, store.Name, name}
+		g.ops.WithLabelValues(labels...).Set(float64(u.Ops))
+		g.successfulOps.WithLabelValues(labels...).Set(float64(u.SuccessfulOps))
+		g.bytesSent.WithLabelValues(labels...).Set(float64(u.BytesSent))
+		g.bytesReceived.WithLabelValues(labels...).Set(float64(u.BytesReceived))
+		g.storedBytes.WithLabelValues(labels...).Set(float64(u.StoredBytes))
+		g.objects.WithLabelValues(labels...).Set(float64(u.Objects))
+	}
+}
+
+// delete removes the metrics of the object store
+func (g *usageGauges) delete(store types.NamespacedName) {
+	for _, gauge := range g.all() {
+		gauge.DeletePartialMatch(prometheus.Labels{"namespace": store.Namespace, "object_store": store.Name})
+	}
+}
+
+var (
+	userUsageGauges   = newUsageGauges("user")
+	bucketUsageGauges = newUsageGauges("bucket")
+)
+
+func init() {
+	for _, gauges := range []*usageGauges{userUsageGauges, bucketUsageGauges} {
+		for _, gauge := range gauges.all() {
+			metrics.Registry.MustRegister(gauge)
+		}
+	}
+}
+
+// objectStoreUsage is the usage of the users and buckets of an object store
+type objectStoreUsage struct {
+	users   map[string]*cephv1.ObjectUserUsageStatus
+	buckets map[string]*cephv1.ObjectUserUsageStatus
+}
+
+func (u *objectStoreUsage) user(name string) *cephv1.ObjectUserUsageStatus {
+	if _, ok := u.users[name]; !ok {
+		u.users[name] = &cephv1.ObjectUserUsageStatus{}
+	}
+	return u.users[name]
+}
+
+func (u *objectStoreUsage) bucket(name string) *cephv1.ObjectUserUsageStatus {
+	if _, ok := u.buckets[name]; !ok {
+		u.buckets[name] = &cephv1.ObjectUserUsageStatus{}
+	}
+	return u.buckets[name]
+}
+
+// collectUsage gets the operations and bytes transferred of the users and buckets from the usage
+// log, and the size of their objects from the bucket stats
+func collectUsage(ctx context.Context, opsCtx *AdminOpsContext) (*objectStoreUsage, error) {
+	showEntries, showSummary := true, true
+	log, err := opsCtx.AdminOpsClient.GetUsage(ctx, admin.Usage{ShowEntries: &showEntries, ShowSummary: &showSummary})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get usage log")
+	}
+
+	usage := &objectStoreUsage{users: map[string]*cephv1.ObjectUserUsageStatus{}, buckets: map[string]*cephv1.ObjectUserUsageStatus{}}
+	for _, summary := range log.Summary {
+		u := usage.user(summary.User)
+		u.Ops = summary.Total.Ops
+		u.SuccessfulOps = summary.Total.SuccessfulOps
+		u.BytesSent = summary.Total.BytesSent
+		u.BytesReceived = summary.Total.BytesReceived
+	}
+	for _, entry := range log.Entries {
+		for _, bucket := range entry.Buckets {
+			// operations that do not target a bucket, like listing the buckets, have no bucket name
+			if bucket.Bucket == "" || bucket.Bucket == "-" {
+				continue
+			}
+			b := usage.bucket(bucket.Bucket)
+			for _, category := range bucket.Categories {
+				b.Ops += category.Ops
+				b.SuccessfulOps += category.SuccessfulOps
+				b.BytesSent += category.BytesSent
+				b.BytesReceived += category.BytesReceived
+			}
+		}
+	}
+
+	stats, err := getBucketStats(&opsCtx.Context)
+	if err != nil {
+		return nil, err
+	}
+	for _, stat := range stats {
+		b := usage.bucket(stat.Bucket)
+		b.StoredBytes = stat.Usage.RgwMain.SizeActual
+		b.Objects = stat.Usage.RgwMain.NumObjects
+		u := usage.user(stat.Owner)
+		u.StoredBytes += stat.Usage.RgwMain.SizeActual
+		u.Objects += stat.Usage.RgwMain.NumObjects
+	}
+	return usage, nil
+}
+
+type bucketStats struct {
+	Bucket string `json:"bucket"`
+	Owner  string `json:"owner"`
+	Usage  struct {
+		RgwMain struct {
+			SizeActual uint64 `json:"size_actual"`
+			NumObjects uint64 `json:"num_objects"`
+		} `json:"rgw.main"`
+	} `json:"usage"`
+}
+
+// getBucketStats returns the stats of all the buckets of the object store
+func getBucketStats(c *Context) ([]bucketStats, error) {
+	output, err := runAdminCommand(c, true, "bucket", "stats")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get bucket stats")
+	}
+	var stats []bucketStats
+	if err := json.Unmarshal([]byte(output), &stats); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse bucket stats %q", output)
+	}
+	return stats, nil
+}
+
+// trimUsage removes the usage log records older than the retention
+func trimUsage(ctx context.Context, opsCtx *AdminOpsContext, retention time.Duration, now time.Time) error {
+	// remove-all is required to trim the records of all users
+	removeAll := true
+	end := now.Add(-retention).UTC().Format(usageTimeFormat)
+	if err := opsCtx.AdminOpsClient.TrimUsage(ctx, admin.Usage{End: end, RemoveAll: &removeAll}); err != nil {
+		return errors.Wrapf(err, "failed to trim usage log records before %q", end)
+	}
+	return nil
+}
+
+// usageExporter periodically collects the usage of an object store
+type usageExporter struct {
+	context        *clusterd.Context
+	clusterInfo    *cephclient.ClusterInfo
+	client         client.Client
+	store          *cephv1.CephObjectStore
+	namespacedName types.NamespacedName
+	interval       time.Duration
+	retention      time.Duration
+	cancel         context.CancelFunc
+}
+
+func newUsageExporter(context *clusterd.Context, clusterInfo *cephclient.ClusterInfo, k8sClient client.Client, store *cephv1.CephObjectStore) *usageExporter {
+	e := &usageExporter{
+		context:        context,
+		clusterInfo:    clusterInfo,
+		client:         k8sClient,
+		store:          store.DeepCopy(),
+		namespacedName: types.NamespacedName{Namespace: store.Namespace, Name: store.Name},
+		interval:       defaultUsageInterval,
+		retention:      defaultUsageRetention,
+	}
+	if spec := store.Spec.UsageExporter; spec != nil {
+		if spec.Interval != nil && spec.Interval.Duration > 0 {
+			e.interval = spec.Interval.Duration
+		}
+		if spec.Retention != nil && spec.Retention.Duration > 0 {
+			e.retention = spec.Retention.Duration
+		}
+	}
+	return e
+}
+
+// run collects the usage until the context is canceled
+func (e *usageExporter) run(ctx context.Context) {
+	logger.Infof("starting the usage exporter of object store %q, collecting every %s", e.namespacedName.String(), e.interval.String())
+	for {
+		if err := e.export(ctx); err != nil {
+			logger.Errorf("failed to export the usage of object store %q. %v", e.namespacedName.String(), err)
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Infof("stopping the usage exporter of object store %q", e.namespacedName.String())
+			return
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// export collects the usage once, publishes it and trims the old usage log records
+func (e *usageExporter) export(ctx context.Context) error {
+	objContext, err := NewMultisiteContext(e.context, e.clusterInfo, e.store)
+	if err != nil {
+		return errors.Wrap(err, "failed to get object context")
+	}
+	opsCtx, err := NewMultisiteAdminOpsContext(objContext, &e.store.Spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to get admin ops API context")
+	}
+
+	now := time.Now()
+	if err := trimUsage(ctx, opsCtx, e.retention, now); err != nil {
+		// collect the usage anyway
+		logger.Warningf("failed to trim the usage log of object store %q. %v", e.namespacedName.String(), err)
+	}
+	usage, err := collectUsage(ctx, opsCtx)
+	if err != nil {
+		return err
+	}
+	userUsageGauges.set(e.namespacedName, usage.users)
+	bucketUsageGauges.set(e.namespacedName, usage.buckets)
+
+	return updateUserUsageStatus(ctx, e.client, e.namespacedName, usage.users, metav1.NewTime(now))
+}
+
+// updateUserUsageStatus sets the usage in the status of the CephObjectStoreUsers of the object store
+func updateUserUsageStatus(ctx context.Context, k8sClient client.Client, store types.NamespacedName, usage map[string]*cephv1.ObjectUserUsageStatus, now metav1.Time) error {
+	users := &cephv1.CephObjectStoreUserList{}
+	if err := k8sClient.List(ctx, users, client.InNamespace(store.Namespace)); err != nil {
+		return errors.Wrap(err, "failed to list object store users")
+	}
+	for _, user := range users.Items {
+		if user.Spec.Store != store.Name {
+			continue
+		}
+		u := &cephv1.ObjectUserUsageStatus{}
+		if collected, ok := usage[user.Name]; ok {
+			u = collected.DeepCopy()
+		}
+		u.LastUpdated = &now
+
+		name := types.NamespacedName{Namespace: user.Namespace, Name: user.Name}
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			latest := &cephv1.CephObjectStoreUser{}
+			if err := k8sClient.Get(ctx, name, latest); err != nil {
+				if kerrors.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			if latest.Status == nil {
+				latest.Status = &cephv1.ObjectStoreUserStatus{}
+			}
+			latest.Status.Usage = u
+			return reporting.UpdateStatus(k8sClient, latest)
+		})
+		if err != nil {
+			logger.Errorf("failed to update the usage status of object store user %q. %v", name.String(), err)
+		}
+	}
+	return nil
+}
+
+// reconcileUsageExporter starts the usage exporter of the object store, restarts it when the object
+// store spec changed, or stops it when the exporter is disabled or the object store deleted
+func (r *ReconcileCephObjectStore) reconcileUsageExporter(store *cephv1.CephObjectStore) {
+	if r.usageExporters == nil {
+		r.usageExporters = map[string]*usageExporter{}
+	}
+	key := types.NamespacedName{Namespace: store.Namespace, Name: store.Name}
+	running, ok := r.usageExporters[key.String()]
+	if store.Spec.UsageExporter == nil || !store.DeletionTimestamp.IsZero() {
+		if ok {
+			running.cancel()
+			delete(r.usageExporters, key.String())
+			userUsageGauges.delete(key)
+			bucketUsageGauges.delete(key)
+		}
+		return
+	}
+	if ok && running.store.Generation == store.Generation {
+		return
+	}
+	if ok {
+		running.cancel()
+	}
+
+	exporter := newUsageExporter(r.context, r.clusterInfo, r.client, store)
+	ctx, cancel := context.WithCancel(r.opManagerContext)
+	exporter.cancel = cancel
+	r.usageExporters[key.String()] = exporter
+	go exporter.run(ctx)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	usageResponse = `{
+  "entries": [
+    {"user": "alice", "buckets": [
+      {"bucket": "", "time": "2025-05-06T10:00:00.000000Z", "owner": "alice", "categories": [
+        {"category": "list_buckets", "bytes_sent": 100, "bytes_received": 0, "ops": 1, "successful_ops": 1}]},
+      {"bucket": "photos", "time": "2025-05-06T10:00:00.000000Z", "owner": "alice", "categories": [
+        {"category": "get_obj", "bytes_sent": 2000, "bytes_received": 0, "ops": 4, "successful_ops": 3},
+        {"category": "put_obj", "bytes_sent": 0, "bytes_received": 5000, "ops": 2, "successful_ops": 2}]}]}
+  ],
+  "summary": [
+    {"user": "alice", "categories": [], "total": {"bytes_sent": 2100, "bytes_received": 5000, "ops": 7, "successful_ops": 6}}
+  ]
+}`
+	bucketStatsResponse = `[
+  {"bucket": "photos", "owner": "alice", "usage": {"rgw.main": {"size_actual": 8192, "num_objects": 2}}},
+  {"bucket": "backups", "owner": "bob", "usage": {}}
+]`
+)
+
+func newUsageTestOpsContext(t *testing.T, requests *[]string) *AdminOpsContext {
+	var commands []string
+	objContext := newRateLimitTestContext(&commands, bucketStatsResponse)
+	mockClient := &MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			*requests = append(*requests, fmt.Sprintf("%s %s", req.Method, req.URL.RawQuery))
+			if req.URL.Path == "rgw.test/admin/usage" {
+				body := ""
+				if req.Method == http.MethodGet {
+					body = usageResponse
+				}
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(body)))}, nil
+			}
+			return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+		},
+	}
+	adminClient, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+	return &AdminOpsContext{Context: *objContext, AdminOpsClient: adminClient}
+}
+
+func TestCollectUsage(t *testing.T) {
+	var requests []string
+	opsCtx := newUsageTestOpsContext(t, &requests)
+
+	usage, err := collectUsage(context.TODO(), opsCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GET format=json&show-entries=true&show-summary=true"}, requests)
+
+	assert.Equal(t, map[string]*cephv1.ObjectUserUsageStatus{
+		"alice": {Ops: 7, SuccessfulOps: 6, BytesSent: 2100, BytesReceived: 5000, StoredBytes: 8192, Objects: 2},
+		"bob":   {},
+	}, usage.users)
+	assert.Equal(t, map[string]*cephv1.ObjectUserUsageStatus{
+		"photos":  {Ops: 6, SuccessfulOps: 5, BytesSent: 2000, BytesReceived: 5000, StoredBytes: 8192, Objects: 2},
+		"backups": {},
+	}, usage.buckets)
+}
+
+func TestTrimUsage(t *testing.T) {
+	var requests []string
+	opsCtx := newUsageTestOpsContext(t, &requests)
+
+	now := time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC)
+	err := trimUsage(context.TODO(), opsCtx, 48*time.Hour, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"DELETE end=2025-05-04%2010%3A00%3A00&format=json&remove-all=true"}, requests)
+}
+
+func TestUsageGauges(t *testing.T) {
+	store := types.NamespacedName{Namespace: "ns", Name: "my-store"}
+	other := types.NamespacedName{Namespace: "ns", Name: "other-store"}
+	gauges := newUsageGauges("user")
+
+	gauges.set(store, map[string]*cephv1.ObjectUserUsageStatus{"alice": {Ops: 7, StoredBytes: 8192}, "bob": {}})
+	gauges.set(other, map[string]*cephv1.ObjectUserUsageStatus{"carol": {Ops: 1}})
+	assert.Equal(t, float64(7), testutil.ToFloat64(gauges.ops.WithLabelValues("ns", "my-store", "alice")))
+	assert.Equal(t, float64(8192), testutil.ToFloat64(gauges.storedBytes.WithLabelValues("ns", "my-store", "alice")))
+	assert.Equal(t, 3, testutil.CollectAndCount(gauges.ops))
+
+	// the users that are gone are removed from the metrics
+	gauges.set(store, map[string]*cephv1.ObjectUserUsageStatus{"alice": {Ops: 8}})
+	assert.Equal(t, 2, testutil.CollectAndCount(gauges.ops))
+	assert.Equal(t, float64(8), testutil.ToFloat64(gauges.ops.WithLabelValues("ns", "my-store", "alice")))
+
+	gauges.delete(store)
+	assert.Equal(t, 1, testutil.CollectAndCount(gauges.ops))
+}
+
+func TestUpdateUserUsageStatus(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephObjectStoreUser{}, &cephv1.CephObjectStoreUserList{})
+	user := func(name, store string) *cephv1.CephObjectStoreUser {
+		return &cephv1.CephObjectStoreUser{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec:       cephv1.ObjectStoreUserSpec{Store: store},
+			Status:     &cephv1.ObjectStoreUserStatus{Phase: "Ready"},
+		}
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(
+		user("alice", "my-store"), user("bob", "my-store"), user("carol", "other-store")).Build()
+
+	now := metav1.NewTime(time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC))
+	usage := map[string]*cephv1.ObjectUserUsageStatus{"alice": {Ops: 7, StoredBytes: 8192}, "carol": {Ops: 1}}
+	err := updateUserUsageStatus(context.TODO(), cl, types.NamespacedName{Namespace: "ns", Name: "my-store"}, usage, now)
+	require.NoError(t, err)
+
+	get := func(name string) *cephv1.CephObjectStoreUser {
+		u := &cephv1.CephObjectStoreUser{}
+		require.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: name}, u))
+		return u
+	}
+	alice := get("alice")
+	assert.Equal(t, "Ready", alice.Status.Phase)
+	assert.Equal(t, uint64(7), alice.Status.Usage.Ops)
+	assert.Equal(t, uint64(8192), alice.Status.Usage.StoredBytes)
+	assert.True(t, now.Equal(alice.Status.Usage.LastUpdated))
+	// users without usage report zero usage
+	assert.Equal(t, uint64(0), get("bob").Status.Usage.Ops)
+	// users of other object stores are not updated
+	assert.Nil(t, get("carol").Status.Usage)
+	// the usage map is not modified
+	assert.Nil(t, usage["alice"].LastUpdated)
+}
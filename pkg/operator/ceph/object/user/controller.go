@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"slices"
 	"sort"
+	"time"
 
 	"github.com/ceph/go-ceph/rgw/admin"
 	"github.com/coreos/pkg/capnslog"
@@ -347,7 +348,11 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 	}
 
 	// validate the user settings
-	err = r.validateUser(cephObjectStoreUser)
+	store, err := r.getObjectStore(cephObjectStoreUser)
+	if err != nil {
+		return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "failed to get object store %q", cephObjectStoreUser.Spec.Store)
+	}
+	err = r.validateUser(cephObjectStoreUser, store)
 	if err != nil {
 		return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "invalid pool CR %q spec", cephObjectStoreUser.Name)
 	}
@@ -358,15 +363,37 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 		return reconcileResponse, *cephObjectStoreUser, err
 	}
 
+	// Only touch the rate limit if it is configured, or was configured before and must now be
+	// disabled, so that a rate limit set manually with radosgw-admin is left alone
+	if cephObjectStoreUser.Spec.RateLimit != nil || (cephObjectStoreUser.Status != nil && cephObjectStoreUser.Status.RateLimit != nil) {
+		rateLimit, err := r.reconcileRateLimit(cephObjectStoreUser)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "failed to set the rate limit of object store user %q", cephObjectStoreUser.Name)
+		}
+		r.updateRateLimitStatus(request.NamespacedName, rateLimit)
+	}
+
+	if cephObjectStoreUser.Spec.Account != nil {
+		err = r.reconcileAccount(cephObjectStoreUser)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "failed to place object store user %q in its account", cephObjectStoreUser.Name)
+		}
+		r.updateAccountStatus(request.NamespacedName, cephObjectStoreUser.Spec.Account.ID)
+	}
+
 	// Update status of referenced secrets only after the rgw user has
 	// reconciled. Update even when no secrets are referenced as this could be a
 	// transition from explicit keys -> automatic secret generation.
 	r.updateKeyStatus(request.NamespacedName, referencedSecrets)
 
 	// CREATE/UPDATE KUBERNETES SECRET
-	store, err := r.getObjectStore(cephObjectStoreUser)
-	if err != nil {
-		return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "failed to get object store %q", cephObjectStoreUser.Spec.Store)
+	// Only touch the groups and policies of the user if they are configured, or were configured
+	// before and must now be removed, so that the policies set manually are left alone
+	if usesIAM(cephObjectStoreUser) {
+		err = r.reconcileIAM(cephObjectStoreUser, store)
+		if err != nil {
+			return reconcile.Result{}, *cephObjectStoreUser, errors.Wrapf(err, "failed to reconcile the groups and policies of object store user %q", cephObjectStoreUser.Name)
+		}
 	}
 
 	tlsSecretName := store.Spec.Gateway.SSLCertificateRef
@@ -375,10 +402,41 @@ func (r *ReconcileObjectStoreUser) reconcile(request reconcile.Request) (reconci
 		return reconcileResponse, *cephObjectStoreUser, err
 	}
 
+	// Report the usage of the quota, or clear the report if the quota was removed
+	if hasQuota(cephObjectStoreUser) || (cephObjectStoreUser.Status != nil && cephObjectStoreUser.Status.Quota != nil) {
+		err = r.reconcileQuotaStatus(cephObjectStoreUser)
+		if err != nil {
+			logger.Warningf("failed to check the quota usage of object store user %q. %v", cephObjectStoreUser.Name, err)
+		}
+	}
+
+	// Clear the key ages once the keys are not rotated anymore
+	if !rotatesKeys(cephObjectStoreUser) && cephObjectStoreUser.Status != nil && cephObjectStoreUser.Status.KeyRotation != nil {
+		r.updateUserStatus(request.NamespacedName, ".status.keyRotation", func(status *cephv1.ObjectStoreUserStatus) {
+			status.KeyRotation = nil
+		})
+	}
+
 	// update ObservedGeneration in status at the end of reconcile
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus)
 
+	// Check the usage of the quota and the age of the keys again later
+	var requeueAfter time.Duration
+	if hasQuota(cephObjectStoreUser) {
+		requeueAfter = object.QuotaCheckInterval
+	}
+	if rotatesKeys(cephObjectStoreUser) && cephObjectStoreUser.Status != nil {
+		next := nextKeyRotationCheck(cephObjectStoreUser.Status.KeyRotation, time.Now())
+		if next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+			requeueAfter = next
+		}
+	}
+	if requeueAfter > 0 {
+		logger.Debugf("done reconciling, checking the user again in %s", requeueAfter.String())
+		return reconcile.Result{RequeueAfter: requeueAfter}, *cephObjectStoreUser, nil
+	}
+
 	// Return and do not requeue
 	logger.Debug("done reconciling")
 	return reconcile.Result{}, *cephObjectStoreUser, nil
@@ -476,8 +534,16 @@ func (r *ReconcileObjectStoreUser) createOrUpdateCephUser(u *cephv1.CephObjectSt
 			return errors.Wrapf(err, "no keys set for user %q", u.Name)
 		}
 
-		userConfig.Keys = []admin.UserKeySpec{user.Keys[0]}
-		logger.Debugf("reducing user %q keypairs to %v", u.Name, userConfig.Keys)
+		if rotatesKeys(u) {
+			// keep the previous keys until their grace period expires
+			userConfig.Keys, err = r.rotateKeys(u, user.Keys, time.Now())
+			if err != nil {
+				return errors.Wrapf(err, "failed to rotate the keys of user %q", u.Name)
+			}
+		} else {
+			userConfig.Keys = []admin.UserKeySpec{user.Keys[0]}
+			logger.Debugf("reducing user %q keypairs to %v", u.Name, userConfig.Keys)
+		}
 	}
 
 	if err := r.reconcileUserKeys(u.Name, userConfig.Keys); err != nil {
@@ -488,6 +554,20 @@ func (r *ReconcileObjectStoreUser) createOrUpdateCephUser(u *cephv1.CephObjectSt
 	return nil
 }
 
+// reconcileRateLimit applies the rate limit of the user and returns the rate limit that is in
+// effect, or nil if the user has no rate limit
+func (r *ReconcileObjectStoreUser) reconcileRateLimit(u *cephv1.CephObjectStoreUser) (*cephv1.RateLimitStatus, error) {
+	err := object.SetRateLimit(&r.objContext.Context, object.RateLimitScopeUser, u.Name, u.Spec.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+	if u.Spec.RateLimit == nil {
+		return nil, nil
+	}
+
+	return object.GetRateLimit(&r.objContext.Context, object.RateLimitScopeUser, u.Name)
+}
+
 func (r *ReconcileObjectStoreUser) initializeObjectStoreContext(u *cephv1.CephObjectStoreUser) error {
 	err := r.objectStoreInitialized(u)
 	if err != nil {
@@ -642,6 +722,11 @@ func (r *ReconcileObjectStoreUser) generateCephUserSecret(u *cephv1.CephObjectSt
 	if tlsSecretName != "" {
 		secrets["SSLCertSecretName"] = tlsSecretName
 	}
+	if rotatesKeys(u) && len(userConfig.Keys) > 1 {
+		// publish the previous key while it is still valid, so that clients can switch over
+		secrets["PreviousAccessKey"] = userConfig.Keys[1].AccessKey
+		secrets["PreviousSecretKey"] = userConfig.Keys[1].SecretKey
+	}
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      generateCephUserSecretName(u),
@@ -774,7 +859,7 @@ func (r *ReconcileObjectStoreUser) deleteUser(u *cephv1.CephObjectStoreUser) err
 }
 
 // validateUser validates the user arguments
-func (r *ReconcileObjectStoreUser) validateUser(u *cephv1.CephObjectStoreUser) error {
+func (r *ReconcileObjectStoreUser) validateUser(u *cephv1.CephObjectStoreUser, store *cephv1.CephObjectStore) error {
 	if u.Name == "" {
 		return errors.New("missing name")
 	}
@@ -784,6 +869,19 @@ func (r *ReconcileObjectStoreUser) validateUser(u *cephv1.CephObjectStoreUser) e
 	if u.Spec.Store == "" {
 		return errors.New("missing store")
 	}
+	if u.Spec.RateLimit != nil && store.Spec.IsExternal() {
+		// the rate limits are set with radosgw-admin, which cannot reach an external object store
+		return errors.New("rate limits cannot be configured for the users of an external object store")
+	}
+	if err := object.ValidateRateLimit(u.Spec.RateLimit); err != nil {
+		return errors.Wrap(err, "invalid rate limit")
+	}
+	if err := validateAccount(u, store); err != nil {
+		return errors.Wrap(err, "invalid account")
+	}
+	if err := validateKeyRotation(u); err != nil {
+		return errors.Wrap(err, "invalid key rotation")
+	}
 	return nil
 }
 
@@ -869,6 +967,29 @@ func (r *ReconcileObjectStoreUser) updateKeyStatus(name types.NamespacedName, re
 	logger.Debugf("updated CephObjectStoreUser %q .status.keys.", name)
 }
 
+// updateRateLimitStatus updates `.status.rateLimit` with the rate limit currently applied to the user
+func (r *ReconcileObjectStoreUser) updateRateLimitStatus(name types.NamespacedName, rateLimit *cephv1.RateLimitStatus) {
+	user := &cephv1.CephObjectStoreUser{}
+	if err := r.client.Get(r.opManagerContext, name, user); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectStoreUser resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve CephObjectStoreUser %q to update .status.rateLimit. %v", name, err)
+		return
+	}
+	if user.Status == nil {
+		user.Status = &cephv1.ObjectStoreUserStatus{}
+	}
+	user.Status.RateLimit = rateLimit
+
+	if err := reporting.UpdateStatus(r.client, user); err != nil {
+		logger.Warningf("failed to update CephObjectStoreUser %q .status.rateLimit. %v", name, err)
+		return
+	}
+	logger.Debugf("updated CephObjectStoreUser %q .status.rateLimit.", name)
+}
+
 // getSecretValue returns the value of key in a kubernetes secret
 func (r *ReconcileObjectStoreUser) getSecretValue(selector *corev1.SecretKeySelector, namespace string) (string, *corev1.Secret, error) {
 	secret := &corev1.Secret{}
@@ -592,3 +592,24 @@ func TestCreateOrUpdateCephUser(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestValidateUser(t *testing.T) {
+	r := &ReconcileObjectStoreUser{}
+	store := &cephv1.CephObjectStore{}
+	externalStore := &cephv1.CephObjectStore{}
+	externalStore.Spec.Gateway.ExternalRgwEndpoints = []cephv1.EndpointAddress{{IP: "192.168.0.1"}}
+	u := &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "rook-ceph"},
+		Spec:       cephv1.ObjectStoreUserSpec{Store: "my-store"},
+	}
+
+	assert.NoError(t, r.validateUser(u, store))
+	assert.NoError(t, r.validateUser(u, externalStore))
+
+	maxReadOps := int64(100)
+	u.Spec.RateLimit = &cephv1.RateLimitSpec{MaxReadOps: &maxReadOps}
+	assert.NoError(t, r.validateUser(u, store))
+	err := r.validateUser(u, externalStore)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "external object store")
+}
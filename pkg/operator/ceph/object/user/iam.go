@@ -0,0 +1,322 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newIAMClientFunc help us mocking the IAM API client in unit test
+var newIAMClientFunc = object.NewIAMClientWithCredentials
+
+// usesIAM returns true if the groups or policies of the user are managed, or were managed before
+// and must now be removed
+func usesIAM(u *cephv1.CephObjectStoreUser) bool {
+	if len(u.Spec.Policies) > 0 || (u.Spec.Account != nil && len(u.Spec.Account.Groups) > 0) {
+		return true
+	}
+	return u.Status != nil && (len(u.Status.Policies) > 0 || len(u.Status.Groups) > 0)
+}
+
+// iamUserName returns the name of the user in the IAM API. The users of an account are identified
+// by their display name, other users by their ID.
+func iamUserName(u *cephv1.CephObjectStoreUser) string {
+	if u.Spec.Account == nil {
+		return u.Name
+	}
+	return generateUserConfig(u).DisplayName
+}
+
+// validateAccount validates the account and IAM settings of the user
+func validateAccount(u *cephv1.CephObjectStoreUser, store *cephv1.CephObjectStore) error {
+	policies := map[string]bool{}
+	for _, policy := range u.Spec.Policies {
+		if policy.Name == "" {
+			return errors.New("missing policy name")
+		}
+		if policies[policy.Name] {
+			return errors.Errorf("duplicate policy %q", policy.Name)
+		}
+		policies[policy.Name] = true
+		if !json.Valid([]byte(policy.Document)) {
+			return errors.Errorf("the document of policy %q is not valid JSON", policy.Name)
+		}
+	}
+
+	account := u.Spec.Account
+	if account == nil {
+		if u.Status != nil && u.Status.AccountID != "" {
+			return errors.Errorf("the user cannot be removed from account %q", u.Status.AccountID)
+		}
+		return nil
+	}
+	if store.Spec.IsExternal() {
+		// the accounts are managed with radosgw-admin, which cannot reach an external object store
+		return errors.New("accounts cannot be configured for the users of an external object store")
+	}
+	if err := object.ValidateAccountID(account.ID); err != nil {
+		return err
+	}
+	if u.Status != nil && u.Status.AccountID != "" && u.Status.AccountID != account.ID {
+		return errors.Errorf("the account of the user cannot be changed from %q to %q", u.Status.AccountID, account.ID)
+	}
+	for _, group := range account.Groups {
+		if group == "" {
+			return errors.New("missing group name")
+		}
+	}
+	return nil
+}
+
+// reconcileAccount creates the account of the user if needed, and places the user in it
+func (r *ReconcileObjectStoreUser) reconcileAccount(u *cephv1.CephObjectStoreUser) error {
+	account := u.Spec.Account
+	if err := object.CreateAccountIfNotExists(&r.objContext.Context, account.ID, account.Name); err != nil {
+		return err
+	}
+	return object.SetUserAccount(&r.objContext.Context, u.Name, account.ID, account.Root)
+}
+
+// newIAMClient creates a client for the IAM API to manage the groups and policies of the user.
+// The IAM resources of an account are managed by its root user, the policies of other users by
+// the admin ops user.
+func (r *ReconcileObjectStoreUser) newIAMClient(u *cephv1.CephObjectStoreUser, store *cephv1.CephObjectStore) (iamiface.IAMAPI, error) {
+	if u.Spec.Account == nil {
+		if !store.Spec.IsExternal() {
+			if err := object.EnableAdminOpsUserIAMCaps(&r.objContext.Context); err != nil {
+				return nil, err
+			}
+		}
+		return newIAMClientFunc(&r.objContext.Context, &store.Spec, r.objContext.AdminOpsUserAccessKey, r.objContext.AdminOpsUserSecretKey)
+	}
+
+	rootUser := u.Name
+	if !u.Spec.Account.Root {
+		var err error
+		rootUser, err = r.accountRootUser(u)
+		if err != nil {
+			return nil, err
+		}
+	}
+	user, err := r.objContext.AdminOpsClient.GetUser(r.opManagerContext, admin.User{ID: rootUser})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the root user %q of account %q", rootUser, u.Spec.Account.ID)
+	}
+	if len(user.Keys) == 0 {
+		return nil, errors.Errorf("the root user %q of account %q has no keys", rootUser, u.Spec.Account.ID)
+	}
+	return newIAMClientFunc(&r.objContext.Context, &store.Spec, user.Keys[0].AccessKey, user.Keys[0].SecretKey)
+}
+
+// accountRootUser returns the name of a root user of the account of the user
+func (r *ReconcileObjectStoreUser) accountRootUser(u *cephv1.CephObjectStoreUser) (string, error) {
+	users := &cephv1.CephObjectStoreUserList{}
+	if err := r.client.List(r.opManagerContext, users); err != nil {
+		return "", errors.Wrap(err, "failed to list the object store users")
+	}
+	for _, other := range users.Items {
+		if other.Spec.Store != u.Spec.Store || clusterStoreNamespace(&other) != clusterStoreNamespace(u) {
+			continue
+		}
+		if other.Spec.Account != nil && other.Spec.Account.ID == u.Spec.Account.ID && other.Spec.Account.Root {
+			return other.Name, nil
+		}
+	}
+	return "", errors.Errorf("account %q has no root user. a CephObjectStoreUser with account.root set is required to manage the groups and policies of the users of the account", u.Spec.Account.ID)
+}
+
+// reconcileUserPolicies puts the inline policies of the user, and returns their names. Policies
+// that were previously applied by Rook and are no longer in the spec are deleted, other policies
+// are left alone.
+func reconcileUserPolicies(iamClient iamiface.IAMAPI, userName string, specs []cephv1.IAMPolicySpec, previous []string) ([]string, error) {
+	names := []string{}
+	for _, policy := range specs {
+		names = append(names, policy.Name)
+		current, err := iamClient.GetUserPolicy(&iam.GetUserPolicyInput{
+			UserName:   aws.String(userName),
+			PolicyName: aws.String(policy.Name),
+		})
+		if err != nil && !object.IsIAMNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get policy %q of user %q", policy.Name, userName)
+		}
+		if err == nil && object.PolicyDocumentsEqual(aws.StringValue(current.PolicyDocument), policy.Document) {
+			continue
+		}
+
+		_, err = iamClient.PutUserPolicy(&iam.PutUserPolicyInput{
+			UserName:       aws.String(userName),
+			PolicyName:     aws.String(policy.Name),
+			PolicyDocument: aws.String(policy.Document),
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to put policy %q of user %q", policy.Name, userName)
+		}
+		logger.Infof("put policy %q of ceph object user %q", policy.Name, userName)
+	}
+
+	for _, name := range previous {
+		if slices.Contains(names, name) {
+			continue
+		}
+		_, err := iamClient.DeleteUserPolicy(&iam.DeleteUserPolicyInput{
+			UserName:   aws.String(userName),
+			PolicyName: aws.String(name),
+		})
+		if err != nil && !object.IsIAMNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to delete policy %q of user %q", name, userName)
+		}
+		logger.Infof("deleted policy %q of ceph object user %q", name, userName)
+	}
+
+	slices.Sort(names)
+	return names, nil
+}
+
+// reconcileUserGroups adds the user to the groups of the account, creating the groups that do not
+// exist, and returns the groups. The user is removed from the groups it was previously added to by
+// Rook and that are no longer in the spec, other groups are left alone.
+func reconcileUserGroups(iamClient iamiface.IAMAPI, userName string, groups, previous []string) ([]string, error) {
+	output, err := iamClient.ListGroupsForUser(&iam.ListGroupsForUserInput{UserName: aws.String(userName)})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the groups of user %q", userName)
+	}
+	memberOf := []string{}
+	for _, group := range output.Groups {
+		memberOf = append(memberOf, aws.StringValue(group.GroupName))
+	}
+
+	names := []string{}
+	for _, group := range groups {
+		if slices.Contains(names, group) {
+			continue
+		}
+		names = append(names, group)
+		if slices.Contains(memberOf, group) {
+			continue
+		}
+
+		_, err := iamClient.GetGroup(&iam.GetGroupInput{GroupName: aws.String(group)})
+		if err != nil {
+			if !object.IsIAMNotFound(err) {
+				return nil, errors.Wrapf(err, "failed to get group %q", group)
+			}
+			if _, err := iamClient.CreateGroup(&iam.CreateGroupInput{GroupName: aws.String(group)}); err != nil {
+				return nil, errors.Wrapf(err, "failed to create group %q", group)
+			}
+			logger.Infof("created group %q", group)
+		}
+
+		_, err = iamClient.AddUserToGroup(&iam.AddUserToGroupInput{UserName: aws.String(userName), GroupName: aws.String(group)})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to add user %q to group %q", userName, group)
+		}
+		logger.Infof("added ceph object user %q to group %q", userName, group)
+	}
+
+	for _, group := range previous {
+		if slices.Contains(names, group) || !slices.Contains(memberOf, group) {
+			continue
+		}
+		_, err := iamClient.RemoveUserFromGroup(&iam.RemoveUserFromGroupInput{UserName: aws.String(userName), GroupName: aws.String(group)})
+		if err != nil && !object.IsIAMNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to remove user %q from group %q", userName, group)
+		}
+		logger.Infof("removed ceph object user %q from group %q", userName, group)
+	}
+
+	slices.Sort(names)
+	return names, nil
+}
+
+// reconcileIAM applies the groups and inline policies of the user and records them in the status
+func (r *ReconcileObjectStoreUser) reconcileIAM(u *cephv1.CephObjectStoreUser, store *cephv1.CephObjectStore) error {
+	iamClient, err := r.newIAMClient(u, store)
+	if err != nil {
+		return err
+	}
+	userName := iamUserName(u)
+
+	var previousPolicies, previousGroups []string
+	if u.Status != nil {
+		previousPolicies, previousGroups = u.Status.Policies, u.Status.Groups
+	}
+
+	var groups []string
+	if u.Spec.Account != nil {
+		groups, err = reconcileUserGroups(iamClient, userName, u.Spec.Account.Groups, previousGroups)
+		if err != nil {
+			return err
+		}
+	}
+	policies, err := reconcileUserPolicies(iamClient, userName, u.Spec.Policies, previousPolicies)
+	if err != nil {
+		return err
+	}
+
+	r.updateIAMStatus(types.NamespacedName{Name: u.Name, Namespace: u.Namespace}, policies, groups)
+	return nil
+}
+
+// updateAccountStatus records the account of the user in `.status.accountID`
+func (r *ReconcileObjectStoreUser) updateAccountStatus(name types.NamespacedName, accountID string) {
+	r.updateUserStatus(name, ".status.accountID", func(status *cephv1.ObjectStoreUserStatus) {
+		status.AccountID = accountID
+	})
+}
+
+// updateIAMStatus records the policies and groups managed by the operator in `.status.policies`
+// and `.status.groups`
+func (r *ReconcileObjectStoreUser) updateIAMStatus(name types.NamespacedName, policies, groups []string) {
+	r.updateUserStatus(name, ".status.policies", func(status *cephv1.ObjectStoreUserStatus) {
+		status.Policies = policies
+		status.Groups = groups
+	})
+}
+
+func (r *ReconcileObjectStoreUser) updateUserStatus(name types.NamespacedName, field string, update func(*cephv1.ObjectStoreUserStatus)) {
+	user := &cephv1.CephObjectStoreUser{}
+	if err := r.client.Get(r.opManagerContext, name, user); err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Debug("CephObjectStoreUser resource not found. Ignoring since object must be deleted.")
+			return
+		}
+		logger.Warningf("failed to retrieve CephObjectStoreUser %q to update %s. %v", name, field, err)
+		return
+	}
+	if user.Status == nil {
+		user.Status = &cephv1.ObjectStoreUserStatus{}
+	}
+	update(user.Status)
+
+	if err := reporting.UpdateStatus(r.client, user); err != nil {
+		logger.Warningf("failed to update CephObjectStoreUser %q %s. %v", name, field, err)
+		return
+	}
+	logger.Debugf("updated CephObjectStoreUser %q %s.", name, field)
+}
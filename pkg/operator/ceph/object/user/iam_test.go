@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testAccountID = "RGW12345678901234567"
+	readPolicy    = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":"arn:aws:s3:::*"}]}`
+	writePolicy   = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:PutObject"],"Resource":"arn:aws:s3:::*"}]}`
+)
+
+// fakeUserIAM implements the user policy and group calls of the IAM API
+type fakeUserIAM struct {
+	iamiface.IAMAPI
+	policies map[string]string
+	groups   map[string][]string
+	calls    []string
+}
+
+func newFakeUserIAM() *fakeUserIAM {
+	return &fakeUserIAM{policies: map[string]string{}, groups: map[string][]string{}}
+}
+
+func noSuchEntity() error {
+	return awserr.New(iam.ErrCodeNoSuchEntityException, "not found", nil)
+}
+
+func (f *fakeUserIAM) GetUserPolicy(in *iam.GetUserPolicyInput) (*iam.GetUserPolicyOutput, error) {
+	doc, ok := f.policies[aws.StringValue(in.PolicyName)]
+	if !ok {
+		return nil, noSuchEntity()
+	}
+	// the IAM API returns the documents URL-encoded
+	return &iam.GetUserPolicyOutput{PolicyDocument: aws.String(url.QueryEscape(doc))}, nil
+}
+
+func (f *fakeUserIAM) PutUserPolicy(in *iam.PutUserPolicyInput) (*iam.PutUserPolicyOutput, error) {
+	f.calls = append(f.calls, "PutUserPolicy:"+aws.StringValue(in.PolicyName))
+	f.policies[aws.StringValue(in.PolicyName)] = aws.StringValue(in.PolicyDocument)
+	return &iam.PutUserPolicyOutput{}, nil
+}
+
+func (f *fakeUserIAM) DeleteUserPolicy(in *iam.DeleteUserPolicyInput) (*iam.DeleteUserPolicyOutput, error) {
+	if _, ok := f.policies[aws.StringValue(in.PolicyName)]; !ok {
+		return nil, noSuchEntity()
+	}
+	f.calls = append(f.calls, "DeleteUserPolicy:"+aws.StringValue(in.PolicyName))
+	delete(f.policies, aws.StringValue(in.PolicyName))
+	return &iam.DeleteUserPolicyOutput{}, nil
+}
+
+func (f *fakeUserIAM) ListGroupsForUser(in *iam.ListGroupsForUserInput) (*iam.ListGroupsForUserOutput, error) {
+	out := &iam.ListGroupsForUserOutput{}
+	for group, members := range f.groups {
+		for _, member := range members {
+			if member == aws.StringValue(in.UserName) {
+				out.Groups = append(out.Groups, &iam.Group{GroupName: aws.String(group)})
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeUserIAM) GetGroup(in *iam.GetGroupInput) (*iam.GetGroupOutput, error) {
+	if _, ok := f.groups[aws.StringValue(in.GroupName)]; !ok {
+		return nil, noSuchEntity()
+	}
+	return &iam.GetGroupOutput{Group: &iam.Group{GroupName: in.GroupName}}, nil
+}
+
+func (f *fakeUserIAM) CreateGroup(in *iam.CreateGroupInput) (*iam.CreateGroupOutput, error) {
+	f.calls = append(f.calls, "CreateGroup:"+aws.StringValue(in.GroupName))
+	f.groups[aws.StringValue(in.GroupName)] = []string{}
+	return &iam.CreateGroupOutput{Group: &iam.Group{GroupName: in.GroupName}}, nil
+}
+
+func (f *fakeUserIAM) AddUserToGroup(in *iam.AddUserToGroupInput) (*iam.AddUserToGroupOutput, error) {
+	f.calls = append(f.calls, "AddUserToGroup:"+aws.StringValue(in.GroupName))
+	group := aws.StringValue(in.GroupName)
+	f.groups[group] = append(f.groups[group], aws.StringValue(in.UserName))
+	return &iam.AddUserToGroupOutput{}, nil
+}
+
+func (f *fakeUserIAM) RemoveUserFromGroup(in *iam.RemoveUserFromGroupInput) (*iam.RemoveUserFromGroupOutput, error) {
+	f.calls = append(f.calls, "RemoveUserFromGroup:"+aws.StringValue(in.GroupName))
+	group := aws.StringValue(in.GroupName)
+	members := []string{}
+	for _, member := range f.groups[group] {
+		if member != aws.StringValue(in.UserName) {
+			members = append(members, member)
+		}
+	}
+	f.groups[group] = members
+	return &iam.RemoveUserFromGroupOutput{}, nil
+}
+
+func TestReconcileUserPolicies(t *testing.T) {
+	f := newFakeUserIAM()
+	// a policy set manually is left alone
+	f.policies["manual"] = writePolicy
+
+	names, err := reconcileUserPolicies(f, "alice", []cephv1.IAMPolicySpec{{Name: "read", Document: readPolicy}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read"}, names)
+	assert.Equal(t, []string{"PutUserPolicy:read"}, f.calls)
+
+	f.calls = nil
+	names, err = reconcileUserPolicies(f, "alice", []cephv1.IAMPolicySpec{{Name: "read", Document: " " + readPolicy}}, names)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"read"}, names)
+	assert.Empty(t, f.calls)
+
+	f.calls = nil
+	names, err = reconcileUserPolicies(f, "alice", []cephv1.IAMPolicySpec{{Name: "write", Document: writePolicy}}, names)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"write"}, names)
+	assert.Equal(t, []string{"PutUserPolicy:write", "DeleteUserPolicy:read"}, f.calls)
+	assert.Contains(t, f.policies, "manual")
+
+	// removing a policy that is already gone succeeds
+	_, err = reconcileUserPolicies(f, "alice", nil, []string{"read", "write"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"manual": writePolicy}, f.policies)
+}
+
+func TestReconcileUserGroups(t *testing.T) {
+	f := newFakeUserIAM()
+	f.groups["devs"] = []string{}
+	// a group the user was added to manually is left alone
+	f.groups["manual"] = []string{"alice"}
+
+	groups, err := reconcileUserGroups(f, "alice", []string{"devs", "ops", "devs"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"devs", "ops"}, groups)
+	assert.Equal(t, []string{"AddUserToGroup:devs", "CreateGroup:ops", "AddUserToGroup:ops"}, f.calls)
+
+	f.calls = nil
+	groups, err = reconcileUserGroups(f, "alice", []string{"ops"}, groups)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ops"}, groups)
+	assert.Equal(t, []string{"RemoveUserFromGroup:devs"}, f.calls)
+	assert.Equal(t, []string{"alice"}, f.groups["manual"])
+}
+
+func newAccountUser(name string, root bool) *cephv1.CephObjectStoreUser {
+	return &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "rook-ceph"},
+		Spec: cephv1.ObjectStoreUserSpec{
+			Store:   "my-store",
+			Account: &cephv1.ObjectUserAccountSpec{ID: testAccountID, Root: root},
+		},
+	}
+}
+
+func TestValidateAccount(t *testing.T) {
+	store := &cephv1.CephObjectStore{}
+	assert.NoError(t, validateAccount(&cephv1.CephObjectStoreUser{}, store))
+	assert.NoError(t, validateAccount(newAccountUser("alice", false), store))
+
+	u := newAccountUser("alice", false)
+	u.Spec.Account.ID = "RGW123"
+	assert.Error(t, validateAccount(u, store))
+
+	u = newAccountUser("alice", false)
+	u.Status = &cephv1.ObjectStoreUserStatus{AccountID: "RGW00000000000000000"}
+	assert.Error(t, validateAccount(u, store))
+
+	u.Spec.Account = nil
+	assert.Error(t, validateAccount(u, store))
+
+	u = newAccountUser("alice", false)
+	u.Spec.Account.Groups = []string{""}
+	assert.Error(t, validateAccount(u, store))
+
+	u = newAccountUser("alice", false)
+	u.Spec.Policies = []cephv1.IAMPolicySpec{{Name: "read", Document: readPolicy}, {Name: "read", Document: readPolicy}}
+	assert.Error(t, validateAccount(u, store))
+
+	u.Spec.Policies = []cephv1.IAMPolicySpec{{Name: "read", Document: "not json"}}
+	assert.Error(t, validateAccount(u, store))
+
+	externalStore := &cephv1.CephObjectStore{}
+	externalStore.Spec.Gateway.ExternalRgwEndpoints = []cephv1.EndpointAddress{{IP: "192.168.0.1"}}
+	assert.NoError(t, validateAccount(&cephv1.CephObjectStoreUser{}, externalStore))
+	err := validateAccount(newAccountUser("alice", false), externalStore)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "external object store")
+}
+
+func TestAccountRootUser(t *testing.T) {
+	s := runtime.NewScheme()
+	require.NoError(t, cephv1.AddToScheme(s))
+
+	alice := newAccountUser("alice", false)
+	otherStore := newAccountUser("other-store-root", true)
+	otherStore.Spec.Store = "other-store"
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(alice, otherStore).Build()
+	r := &ReconcileObjectStoreUser{client: cl, opManagerContext: context.TODO()}
+	_, err := r.accountRootUser(alice)
+	assert.Error(t, err)
+
+	cl = fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(alice, otherStore, newAccountUser("root", true)).Build()
+	r.client = cl
+	root, err := r.accountRootUser(alice)
+	assert.NoError(t, err)
+	assert.Equal(t, "root", root)
+}
+
+func TestIAMUserName(t *testing.T) {
+	u := &cephv1.CephObjectStoreUser{ObjectMeta: metav1.ObjectMeta{Name: "alice"}}
+	u.Spec.DisplayName = "Alice"
+	assert.Equal(t, "alice", iamUserName(u))
+
+	u.Spec.Account = &cephv1.ObjectUserAccountSpec{ID: testAccountID}
+	assert.Equal(t, "Alice", iamUserName(u))
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultKeyRotationGracePeriod = 24 * time.Hour
+	// minKeyRotationCheckInterval avoids a tight requeue loop when a rotation or expiry is due
+	minKeyRotationCheckInterval = time.Minute
+
+	keyRotatedReason = "KeyRotated"
+	keyExpiredReason = "KeyExpired"
+)
+
+// rotatesKeys returns whether the keys of the user are generated and rotated by the operator
+func rotatesKeys(u *cephv1.CephObjectStoreUser) bool {
+	return u.Spec.KeyRotation != nil && len(u.Spec.Keys) == 0
+}
+
+func keyRotationGracePeriod(u *cephv1.CephObjectStoreUser) time.Duration {
+	if u.Spec.KeyRotation.GracePeriod != nil {
+		return u.Spec.KeyRotation.GracePeriod.Duration
+	}
+	return defaultKeyRotationGracePeriod
+}
+
+func validateKeyRotation(u *cephv1.CephObjectStoreUser) error {
+	if u.Spec.KeyRotation == nil {
+		return nil
+	}
+	if len(u.Spec.Keys) > 0 {
+		return errors.New("keys provided in spec.keys cannot be rotated")
+	}
+	if u.Spec.KeyRotation.Period.Duration <= 0 {
+		return errors.Errorf("period must be positive, got %q", u.Spec.KeyRotation.Period.Duration)
+	}
+	if u.Spec.KeyRotation.GracePeriod != nil && u.Spec.KeyRotation.GracePeriod.Duration < 0 {
+		return errors.Errorf("grace period cannot be negative, got %q", u.Spec.KeyRotation.GracePeriod.Duration)
+	}
+	return nil
+}
+
+// rotateKeys returns the keys the user must have, the current key first. A new key is generated
+// when the current key is older than the rotation period, and the previous keys are kept until
+// their grace period expires. The keys and their ages are reported in `.status.keyRotation`.
+func (r *ReconcileObjectStoreUser) rotateKeys(u *cephv1.CephObjectStoreUser, rgwKeys []admin.UserKeySpec, now time.Time) ([]admin.UserKeySpec, error) {
+	rgwKeysByAccessKey := make(map[string]admin.UserKeySpec, len(rgwKeys))
+	for _, k := range rgwKeys {
+		rgwKeysByAccessKey[k.AccessKey] = k
+	}
+
+	// keep the keys tracked in the status that still exist and have not expired
+	var keys []cephv1.ObjectUserKeyStatus
+	if u.Status != nil && u.Status.KeyRotation != nil {
+		for _, k := range u.Status.KeyRotation.Keys {
+			if _, ok := rgwKeysByAccessKey[k.AccessKey]; !ok {
+				continue
+			}
+			if k.Expires != nil && !now.Before(k.Expires.Time) {
+				logger.Infof("removing expired key %q of ceph object user %q", k.AccessKey, u.Name)
+				r.recorder.Eventf(u, corev1.EventTypeNormal, keyExpiredReason, "removed previous access key %q after its grace period", k.AccessKey)
+				continue
+			}
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		// start tracking the key the user already has. Its age is unknown, so it counts from now.
+		keys = []cephv1.ObjectUserKeyStatus{{AccessKey: rgwKeys[0].AccessKey, Created: metav1.NewTime(now)}}
+	}
+
+	current := keys[len(keys)-1]
+	if !now.Before(current.Created.Add(u.Spec.KeyRotation.Period.Duration)) {
+		generateKey := true
+		allKeys, err := r.objContext.AdminOpsClient.CreateKey(r.opManagerContext, admin.UserKeySpec{UID: u.Name, KeyType: "s3", GenerateKey: &generateKey})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate a new key for ceph object user %q", u.Name)
+		}
+		var newKey *admin.UserKeySpec
+		for i, k := range *allKeys {
+			if _, ok := rgwKeysByAccessKey[k.AccessKey]; !ok {
+				newKey = &(*allKeys)[i]
+				break
+			}
+		}
+		if newKey == nil {
+			return nil, errors.Errorf("failed to find the new key generated for ceph object user %q", u.Name)
+		}
+		rgwKeysByAccessKey[newKey.AccessKey] = *newKey
+
+		expires := metav1.NewTime(now.Add(keyRotationGracePeriod(u)))
+		for i := range keys {
+			if keys[i].Expires == nil {
+				keys[i].Expires = &expires
+			}
+		}
+		keys = append(keys, cephv1.ObjectUserKeyStatus{AccessKey: newKey.AccessKey, Created: metav1.NewTime(now)})
+		logger.Infof("rotated the key of ceph object user %q to %q", u.Name, newKey.AccessKey)
+		r.recorder.Eventf(u, corev1.EventTypeNormal, keyRotatedReason, "generated access key %q, the previous access key %q expires at %s", newKey.AccessKey, current.AccessKey, expires.UTC().Format(time.RFC3339))
+		current = keys[len(keys)-1]
+	}
+
+	// the current key is the first key, which is published as the main key of the user secret
+	targetKeys := make([]admin.UserKeySpec, 0, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		k := rgwKeysByAccessKey[keys[i].AccessKey]
+		k.UID = u.Name
+		targetKeys = append(targetKeys, k)
+	}
+
+	nextRotation := metav1.NewTime(current.Created.Add(u.Spec.KeyRotation.Period.Duration))
+	status := &cephv1.ObjectUserKeyRotationStatus{Keys: keys, NextRotation: &nextRotation}
+	if u.Status == nil {
+		u.Status = &cephv1.ObjectStoreUserStatus{}
+	}
+	u.Status.KeyRotation = status
+	r.updateUserStatus(types.NamespacedName{Name: u.Name, Namespace: u.Namespace}, ".status.keyRotation", func(s *cephv1.ObjectStoreUserStatus) {
+		s.KeyRotation = status
+	})
+
+	return targetKeys, nil
+}
+
+// nextKeyRotationCheck returns when the keys of the user must be checked again, at the next
+// rotation or the expiry of a previous key
+func nextKeyRotationCheck(status *cephv1.ObjectUserKeyRotationStatus, now time.Time) time.Duration {
+	if status == nil || status.NextRotation == nil {
+		return 0
+	}
+	next := status.NextRotation.Time
+	for _, k := range status.Keys {
+		if k.Expires != nil && k.Expires.Before(&metav1.Time{Time: next}) {
+			next = k.Expires.Time
+		}
+	}
+	wait := next.Sub(now)
+	if wait < minKeyRotationCheckInterval {
+		return minKeyRotationCheckInterval
+	}
+	return wait
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephobject "github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRotateKeys(t *testing.T) {
+	ctx := context.TODO()
+	name := types.NamespacedName{Name: "my-user", Namespace: "rook-ceph"}
+	createKeyResponse := `[{"user":"my-user","access_key":"old-access","secret_key":"old-secret"},{"user":"my-user","access_key":"new-access","secret_key":"new-secret"}]`
+
+	var createKeyRequests []string
+	mockClient := &cephobject.MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPut && req.URL.Path == "rgw.test/admin/user" {
+				createKeyRequests = append(createKeyRequests, req.URL.RawQuery)
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(createKeyResponse)))}, nil
+			}
+			return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+		},
+	}
+	adminClient, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+
+	s := scheme.Scheme
+	s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephObjectStoreUser{}, &cephv1.CephObjectStoreUserList{})
+	grace := metav1.Duration{Duration: time.Hour}
+	user := &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+		Spec: cephv1.ObjectStoreUserSpec{
+			Store:       "my-store",
+			KeyRotation: &cephv1.ObjectUserKeyRotationSpec{Period: metav1.Duration{Duration: 24 * time.Hour}, GracePeriod: &grace},
+		},
+		Status: &cephv1.ObjectStoreUserStatus{Phase: "Ready"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(user).Build()
+	recorder := record.NewFakeRecorder(5)
+	r := &ReconcileObjectStoreUser{
+		client:           cl,
+		opManagerContext: ctx,
+		recorder:         recorder,
+		objContext:       &cephobject.AdminOpsContext{AdminOpsClient: adminClient},
+	}
+
+	getStatus := func() *cephv1.ObjectUserKeyRotationStatus {
+		u := &cephv1.CephObjectStoreUser{}
+		require.NoError(t, cl.Get(ctx, name, u))
+		return u.Status.KeyRotation
+	}
+	oldKey := admin.UserKeySpec{User: "my-user", AccessKey: "old-access", SecretKey: "old-secret"}
+	newKey := admin.UserKeySpec{User: "my-user", AccessKey: "new-access", SecretKey: "new-secret"}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("start tracking the existing key", func(t *testing.T) {
+		keys, err := r.rotateKeys(user, []admin.UserKeySpec{oldKey}, start)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old-access"}, accessKeys(keys))
+		assert.Empty(t, createKeyRequests)
+
+		status := getStatus()
+		require.NotNil(t, status)
+		require.Len(t, status.Keys, 1)
+		assert.Equal(t, "old-access", status.Keys[0].AccessKey)
+		assert.True(t, status.Keys[0].Created.Time.Equal(start))
+		assert.Nil(t, status.Keys[0].Expires)
+		assert.True(t, status.NextRotation.Time.Equal(start.Add(24*time.Hour)))
+	})
+
+	t.Run("no rotation before the period", func(t *testing.T) {
+		keys, err := r.rotateKeys(user, []admin.UserKeySpec{oldKey}, start.Add(23*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old-access"}, accessKeys(keys))
+		assert.Empty(t, createKeyRequests)
+	})
+
+	t.Run("rotate after the period and keep the previous key", func(t *testing.T) {
+		now := start.Add(25 * time.Hour)
+		keys, err := r.rotateKeys(user, []admin.UserKeySpec{oldKey}, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"new-access", "old-access"}, accessKeys(keys))
+		assert.Equal(t, "new-secret", keys[0].SecretKey)
+		assert.Equal(t, "my-user", keys[0].UID)
+		require.Len(t, createKeyRequests, 1)
+		assert.Contains(t, createKeyRequests[0], "generate-key=true")
+		assert.Contains(t, <-recorder.Events, keyRotatedReason)
+
+		status := getStatus()
+		require.Len(t, status.Keys, 2)
+		assert.Equal(t, "old-access", status.Keys[0].AccessKey)
+		require.NotNil(t, status.Keys[0].Expires)
+		assert.True(t, status.Keys[0].Expires.Time.Equal(now.Add(time.Hour)))
+		assert.Equal(t, "new-access", status.Keys[1].AccessKey)
+		assert.Nil(t, status.Keys[1].Expires)
+		assert.True(t, status.NextRotation.Time.Equal(now.Add(24*time.Hour)))
+		assert.Equal(t, time.Hour, nextKeyRotationCheck(status, now))
+	})
+
+	t.Run("remove the previous key after the grace period", func(t *testing.T) {
+		now := start.Add(27 * time.Hour)
+		keys, err := r.rotateKeys(user, []admin.UserKeySpec{oldKey, newKey}, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"new-access"}, accessKeys(keys))
+		assert.Len(t, createKeyRequests, 1)
+		assert.Contains(t, <-recorder.Events, keyExpiredReason)
+
+		status := getStatus()
+		require.Len(t, status.Keys, 1)
+		assert.Equal(t, "new-access", status.Keys[0].AccessKey)
+		assert.Equal(t, 22*time.Hour, nextKeyRotationCheck(status, now))
+	})
+}
+
+func TestValidateKeyRotation(t *testing.T) {
+	u := &cephv1.CephObjectStoreUser{}
+	assert.NoError(t, validateKeyRotation(u))
+
+	u.Spec.KeyRotation = &cephv1.ObjectUserKeyRotationSpec{Period: metav1.Duration{Duration: time.Hour}}
+	assert.NoError(t, validateKeyRotation(u))
+	assert.True(t, rotatesKeys(u))
+
+	u.Spec.KeyRotation.Period.Duration = 0
+	assert.Error(t, validateKeyRotation(u))
+
+	u.Spec.KeyRotation.Period.Duration = time.Hour
+	u.Spec.Keys = []cephv1.ObjectUserKey{{}}
+	assert.Error(t, validateKeyRotation(u))
+	assert.False(t, rotatesKeys(u))
+}
+
+func accessKeys(keys []admin.UserKeySpec) []string {
+	var accessKeys []string
+	for _, k := range keys {
+		accessKeys = append(accessKeys, k.AccessKey)
+	}
+	return accessKeys
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"github.com/ceph/go-ceph/rgw/admin"
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// hasQuota returns whether the size or number of objects of the user is limited
+func hasQuota(u *cephv1.CephObjectStoreUser) bool {
+	return u.Spec.Quotas != nil && (u.Spec.Quotas.MaxSize != nil || u.Spec.Quotas.MaxObjects != nil)
+}
+
+func nearFullThreshold(u *cephv1.CephObjectStoreUser) int32 {
+	if u.Spec.Quotas != nil && u.Spec.Quotas.NearFullThreshold != nil {
+		return *u.Spec.Quotas.NearFullThreshold
+	}
+	return object.DefaultQuotaNearFullThreshold
+}
+
+// reconcileQuotaStatus checks the usage of the user against its quota, reports it in
+// `.status.quota` and emits an event when the near full threshold or the quota is crossed
+func (r *ReconcileObjectStoreUser) reconcileQuotaStatus(u *cephv1.CephObjectStoreUser) error {
+	var quota *cephv1.QuotaStatus
+	if hasQuota(u) {
+		generateStat := true
+		user, err := r.objContext.AdminOpsClient.GetUser(r.opManagerContext, admin.User{ID: u.Name, GenerateStat: &generateStat})
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the stats of ceph object user %q", u.Name)
+		}
+		quota = object.NewQuotaStatus(user.UserQuota, userStatSize(user.Stat), deref(user.Stat.NumObjects), nearFullThreshold(u), metav1.Now())
+	}
+
+	var previous *cephv1.QuotaStatus
+	if u.Status != nil {
+		previous = u.Status.Quota
+	}
+	if eventType, reason, message, ok := object.QuotaEvent("user quota", previous, quota); ok {
+		logger.Infof("object store user %q: %s", u.Name, message)
+		r.recorder.Event(u, eventType, reason, message)
+	}
+
+	r.updateUserStatus(types.NamespacedName{Name: u.Name, Namespace: u.Namespace}, ".status.quota", func(status *cephv1.ObjectStoreUserStatus) {
+		status.Quota = quota
+	})
+	return nil
+}
+
+// userStatSize returns the size used by the user as counted by the quota, which rounds the size of
+// each object up to 4KiB
+func userStatSize(stat admin.UserStat) uint64 {
+	if stat.SizeRounded != nil {
+		return *stat.SizeRounded
+	}
+	return deref(stat.Size)
+}
+
+func deref(value *uint64) uint64 {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
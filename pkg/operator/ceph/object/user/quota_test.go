@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectuser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ceph/go-ceph/rgw/admin"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	cephobject "github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileQuotaStatus(t *testing.T) {
+	ctx := context.TODO()
+	name := types.NamespacedName{Name: "my-user", Namespace: "rook-ceph"}
+	size := resource.MustParse("1Mi")
+	userResponse := `{"user_id":"my-user","user_quota":{"enabled":true,"max_size":1048576,"max_objects":-1},"stats":{"size":880000,"size_rounded":917504,"num_objects":200}}`
+
+	var requests []string
+	mockClient := &cephobject.MockClient{
+		MockDo: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.URL.RawQuery)
+			if req.Method == http.MethodGet && req.URL.Path == "rgw.test/admin/user" {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte(userResponse)))}, nil
+			}
+			return nil, fmt.Errorf("unexpected request: %q. method %q. path %q", req.URL.RawQuery, req.Method, req.URL.Path)
+		},
+	}
+	adminClient, err := admin.New("rgw.test", "accesskey", "secretkey", mockClient)
+	require.NoError(t, err)
+
+	s := scheme.Scheme
+	s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephObjectStoreUser{}, &cephv1.CephObjectStoreUserList{})
+	user := &cephv1.CephObjectStoreUser{
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+		Spec:       cephv1.ObjectStoreUserSpec{Store: "my-store", Quotas: &cephv1.ObjectUserQuotaSpec{MaxSize: &size}},
+		Status:     &cephv1.ObjectStoreUserStatus{Phase: "Ready"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(user).Build()
+	recorder := record.NewFakeRecorder(5)
+	r := &ReconcileObjectStoreUser{
+		client:           cl,
+		opManagerContext: ctx,
+		recorder:         recorder,
+		objContext:       &cephobject.AdminOpsContext{AdminOpsClient: adminClient},
+	}
+
+	getQuota := func() *cephv1.QuotaStatus {
+		u := &cephv1.CephObjectStoreUser{}
+		require.NoError(t, cl.Get(ctx, name, u))
+		return u.Status.Quota
+	}
+
+	t.Run("quota crosses the default threshold", func(t *testing.T) {
+		require.NoError(t, r.reconcileQuotaStatus(user))
+		assert.Equal(t, []string{"format=json&stats=true&uid=my-user"}, requests)
+
+		quota := getQuota()
+		require.NotNil(t, quota)
+		assert.Equal(t, int64(1048576), *quota.MaxSize)
+		assert.Nil(t, quota.MaxObjects)
+		assert.Equal(t, int64(917504), quota.Size)
+		assert.Equal(t, int64(200), quota.Objects)
+		assert.Equal(t, int32(87), quota.PercentUsed)
+		assert.Equal(t, int32(85), quota.NearFullThreshold)
+		assert.Equal(t, cephv1.QuotaStateNearFull, quota.State)
+		assert.NotNil(t, quota.LastChecked)
+		assert.Equal(t, "Warning QuotaNearFull user quota is 87% used, above the near full threshold of 85%", <-recorder.Events)
+	})
+
+	t.Run("no event while the quota stays near full", func(t *testing.T) {
+		user.Status.Quota = getQuota()
+		require.NoError(t, r.reconcileQuotaStatus(user))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("higher threshold", func(t *testing.T) {
+		threshold := int32(90)
+		user.Spec.Quotas.NearFullThreshold = &threshold
+		require.NoError(t, r.reconcileQuotaStatus(user))
+		assert.Equal(t, cephv1.QuotaStateOk, getQuota().State)
+		assert.Equal(t, "Normal QuotaBelowThreshold user quota is 87% used, below the near full threshold of 90%", <-recorder.Events)
+	})
+
+	t.Run("quota removed", func(t *testing.T) {
+		requests = nil
+		user.Status.Quota = getQuota()
+		user.Spec.Quotas = nil
+		require.NoError(t, r.reconcileQuotaStatus(user))
+		assert.Empty(t, requests)
+		assert.Nil(t, getQuota())
+		assert.Empty(t, recorder.Events)
+	})
+}
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -126,9 +127,43 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for spec changes on the CephObjectZoneGroup CRD object, to fail over its zones
+	err = c.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&cephv1.CephObjectZoneGroup{TypeMeta: metav1.TypeMeta{Kind: reflect.TypeOf(cephv1.CephObjectZoneGroup{}).Name(), APIVersion: controllerTypeMeta.APIVersion}},
+			handler.TypedEnqueueRequestsFromMapFunc(mapZoneGroupToZones(mgr.GetClient())),
+			predicate.TypedGenerationChangedPredicate[*cephv1.CephObjectZoneGroup]{},
+		),
+	)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// mapZoneGroupToZones maps a CephObjectZoneGroup to the zones of the zone group
+func mapZoneGroupToZones(k8sClient client.Client) handler.TypedMapFunc[*cephv1.CephObjectZoneGroup, reconcile.Request] {
+	return func(ctx context.Context, zoneGroup *cephv1.CephObjectZoneGroup) []reconcile.Request {
+		zones := &cephv1.CephObjectZoneList{}
+		err := k8sClient.List(ctx, zones, client.InNamespace(zoneGroup.Namespace))
+		if err != nil {
+			logger.Errorf("failed to list CephObjectZones in namespace %q. %v", zoneGroup.Namespace, err)
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, zone := range zones.Items {
+			if zone.Spec.ZoneGroup == zoneGroup.Name {
+				logger.Debugf("CephObjectZoneGroup %q changed, reconciling CephObjectZone %q", zoneGroup.Name, zone.Name)
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: zone.Name, Namespace: zone.Namespace}})
+			}
+		}
+		return requests
+	}
+}
+
 // Reconcile reads that state of the cluster for a CephObjectZone object and makes changes based on the state read
 // and what is in the CephObjectZone.Spec
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
@@ -204,10 +239,11 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 	}
 
 	// Make sure an ObjectZoneGroup is present
-	realmName, reconcileResponse, err := r.getCephObjectZoneGroup(cephObjectZone)
+	cephObjectZoneGroup, reconcileResponse, err := r.getCephObjectZoneGroup(cephObjectZone)
 	if err != nil {
 		return reconcileResponse, *cephObjectZone, err
 	}
+	realmName := cephObjectZoneGroup.Spec.Realm
 
 	// DELETE: the CR was deleted
 	if !cephObjectZone.GetDeletionTimestamp().IsZero() {
@@ -224,6 +260,13 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 		return reconcileResponse, *cephObjectZone, err
 	}
 
+	// Promote or demote the zone before its configuration is committed, so that a previous master
+	// coming back does not commit a period where it is still the master
+	err = r.reconcileFailover(r.newZoneContext(cephObjectZone, realmName), cephObjectZone, cephObjectZoneGroup)
+	if err != nil {
+		return r.setFailedStatus(k8sutil.ObservedGenerationNotAvailable, cephObjectZone, request.NamespacedName, "failed to fail over ceph zone", err)
+	}
+
 	// Create/Update Ceph Zone
 	_, err = r.createorUpdateCephZone(cephObjectZone, realmName)
 	if err != nil {
@@ -234,6 +277,17 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 	// Set Ready status, we are done reconciling
 	r.updateStatus(observedGeneration, request.NamespacedName, k8sutil.ReadyStatus)
 
+	// Report the sync status and check it again later
+	if syncStatusEnabled(cephObjectZone) {
+		err = r.reconcileSyncStatus(r.newZoneContext(cephObjectZone, realmName), cephObjectZone)
+		if err != nil {
+			logger.Warningf("failed to report the sync status of object zone %q. %v", request.NamespacedName, err)
+		}
+		logger.Debugf("zone done reconciling, checking the sync status again in %s", syncStatusInterval(cephObjectZone))
+		return reconcile.Result{RequeueAfter: syncStatusInterval(cephObjectZone)}, *cephObjectZone, nil
+	}
+	r.clearSyncStatus(cephObjectZone)
+
 	// Return and do not requeue
 	logger.Debug("zone done reconciling")
 	return reconcile.Result{}, *cephObjectZone, nil
@@ -242,12 +296,7 @@ func (r *ReconcileObjectZone) reconcile(request reconcile.Request) (reconcile.Re
 func (r *ReconcileObjectZone) createorUpdateCephZone(zone *cephv1.CephObjectZone, realmName string) (reconcile.Result, error) {
 	logger.Infof("creating object zone %q in zonegroup %q in realm %q", zone.Name, zone.Spec.ZoneGroup, realmName)
 
-	objContext := object.NewContext(r.context, r.clusterInfo, zone.Name)
-	objContext.Realm = realmName
-	objContext.ZoneGroup = zone.Spec.ZoneGroup
-	objContext.Zone = zone.Name
-
-	err := r.createPoolsAndZone(objContext, zone)
+	err := r.createPoolsAndZone(r.newZoneContext(zone, realmName), zone)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -255,6 +304,14 @@ func (r *ReconcileObjectZone) createorUpdateCephZone(zone *cephv1.CephObjectZone
 	return reconcile.Result{}, nil
 }
 
+func (r *ReconcileObjectZone) newZoneContext(zone *cephv1.CephObjectZone, realmName string) *object.Context {
+	objContext := object.NewContext(r.context, r.clusterInfo, zone.Name)
+	objContext.Realm = realmName
+	objContext.ZoneGroup = zone.Spec.ZoneGroup
+	objContext.Zone = zone.Name
+	return objContext
+}
+
 func (r *ReconcileObjectZone) createPoolsAndZone(objContext *object.Context, zone *cephv1.CephObjectZone) error {
 	// create pools for zone
 	logger.Debugf("creating pools ceph zone %q", zone.Name)
@@ -363,19 +420,19 @@ func (r *ReconcileObjectZone) createZoneIfNotExists(objContext *object.Context,
 	return nil
 }
 
-func (r *ReconcileObjectZone) getCephObjectZoneGroup(zone *cephv1.CephObjectZone) (string, reconcile.Result, error) {
+func (r *ReconcileObjectZone) getCephObjectZoneGroup(zone *cephv1.CephObjectZone) (*cephv1.CephObjectZoneGroup, reconcile.Result, error) {
 	// empty zoneGroup gets filled by r.client.Get()
 	zoneGroup := &cephv1.CephObjectZoneGroup{}
 	err := r.client.Get(r.opManagerContext, types.NamespacedName{Name: zone.Spec.ZoneGroup, Namespace: zone.Namespace}, zoneGroup)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
-			return "", waitForRequeueIfObjectZoneGroupNotReady, err
+			return nil, waitForRequeueIfObjectZoneGroupNotReady, err
 		}
-		return "", waitForRequeueIfObjectZoneGroupNotReady, errors.Wrapf(err, "error getting cephObjectZoneGroup %v", zone.Spec.ZoneGroup)
+		return nil, waitForRequeueIfObjectZoneGroupNotReady, errors.Wrapf(err, "error getting cephObjectZoneGroup %v", zone.Spec.ZoneGroup)
 	}
 
 	logger.Debugf("CephObjectZoneGroup %v found", zoneGroup.Name)
-	return zoneGroup.Spec.Realm, reconcile.Result{}, nil
+	return zoneGroup, reconcile.Result{}, nil
 }
 
 func (r *ReconcileObjectZone) reconcileCephZoneGroup(zone *cephv1.CephObjectZone, realmName string) (reconcile.Result, error) {
@@ -433,7 +490,7 @@ func (r *ReconcileObjectZone) updateStatus(observedGeneration int64, name types.
 		return
 	}
 	if objectZone.Status == nil {
-		objectZone.Status = &cephv1.Status{}
+		objectZone.Status = &cephv1.ObjectZoneStatus{}
 	}
 
 	objectZone.Status.Phase = status
@@ -567,6 +624,7 @@ func (r *ReconcileObjectZone) deleteCephObjectZone(zone *cephv1.CephObjectZone,
 		if err != nil {
 			return reconcile.Result{}, errors.Wrapf(err, "failed to delete zone %s", objContext.Name)
 		}
+		deleteSyncMetrics(zone)
 		// Remove finalizer
 		err = opcontroller.RemoveFinalizer(r.opManagerContext, r.client, zone)
 		if err != nil {
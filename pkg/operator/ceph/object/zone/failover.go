@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zone
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	failoverPromoted = "Promoted"
+	failoverDemoted  = "Demoted"
+
+	// maxFailoverHistory is the number of failovers kept in the zone status
+	maxFailoverHistory = 10
+	// maxSyncStatusLength bounds the sync status recorded with a failover
+	maxSyncStatusLength = 4096
+)
+
+// reconcileFailover promotes the zone to master when the failover of its zone group names it. When
+// the zone is still master in the local configuration period while another zone is the failover
+// master, it was the master before the failover and is demoted if the zone group asks for it.
+func (r *ReconcileObjectZone) reconcileFailover(objContext *object.Context, zone *cephv1.CephObjectZone, zoneGroup *cephv1.CephObjectZoneGroup) error {
+	realmArg := fmt.Sprintf("--rgw-realm=%s", objContext.Realm)
+	zoneGroupArg := fmt.Sprintf("--rgw-zonegroup=%s", objContext.ZoneGroup)
+	zoneArg := fmt.Sprintf("--rgw-zone=%s", objContext.Zone)
+
+	output, err := object.RunAdminCommandNoMultisite(objContext, true, "zonegroup", "get", realmArg, zoneGroupArg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get ceph zone group %q", objContext.ZoneGroup)
+	}
+	zoneGroupJson, err := object.DecodeZoneGroupConfig(output)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse `radosgw-admin zonegroup get` output")
+	}
+	var masterZone string
+	var zoneExists bool
+	var failoverEndpoints []string
+	for _, z := range zoneGroupJson.Zones {
+		if z.ID == zoneGroupJson.MasterZoneID {
+			masterZone = z.Name
+		}
+		if z.Name == zone.Name {
+			zoneExists = true
+		}
+		if zoneGroup.Spec.Failover != nil && z.Name == zoneGroup.Spec.Failover.MasterZone {
+			failoverEndpoints = z.Endpoints
+		}
+	}
+	if !zoneExists {
+		// the zone is created by the rest of the reconcile
+		return nil
+	}
+
+	failover := zoneGroup.Spec.Failover
+	var record *cephv1.ObjectZoneFailoverRecord
+	switch {
+	case failover == nil:
+	case failover.MasterZone == zone.Name && masterZone != zone.Name:
+		logger.Infof("promoting zone %q to master of zone group %q, replacing zone %q", zone.Name, objContext.ZoneGroup, masterZone)
+		// the sync status shows how far behind the previous master the zone was when it took over
+		syncStatus, err := object.RunAdminCommandNoMultisite(objContext, false, "sync", "status", realmArg, zoneGroupArg, zoneArg)
+		if err != nil {
+			logger.Warningf("failed to get the sync status of zone %q before its promotion. %v", zone.Name, err)
+		}
+		output, err := object.RunAdminCommandNoMultisite(objContext, false, "zone", "modify", realmArg, zoneGroupArg, zoneArg, "--master", "--default")
+		if err != nil {
+			return errors.Wrapf(err, "failed to promote zone %q to master for reason %q", zone.Name, output)
+		}
+		err = commitConfigChangesFunc(objContext)
+		if err != nil {
+			return errors.Wrapf(err, "failed to commit the promotion of zone %q to master", zone.Name)
+		}
+		record = &cephv1.ObjectZoneFailoverRecord{Action: failoverPromoted, PreviousMaster: masterZone, NewMaster: zone.Name, SyncStatus: truncateSyncStatus(syncStatus)}
+		masterZone = zone.Name
+		r.recorder.Eventf(zone, corev1.EventTypeNormal, failoverPromoted, "promoted zone %q to master of zone group %q", zone.Name, objContext.ZoneGroup)
+	case failover.MasterZone != zone.Name && masterZone == zone.Name && failover.DemoteOldMaster:
+		if len(failoverEndpoints) == 0 {
+			return errors.Errorf("failed to demote zone %q, no endpoints known for the new master zone %q", zone.Name, failover.MasterZone)
+		}
+		logger.Infof("demoting zone %q, pulling realm %q from the new master zone %q", zone.Name, objContext.Realm, failover.MasterZone)
+		accessKeyArg, secretKeyArg, err := object.GetRealmKeyArgs(r.opManagerContext, r.context, objContext.Realm, zone.Namespace)
+		if err != nil {
+			return errors.Wrap(err, "failed to get keys for realm")
+		}
+		urlArg := fmt.Sprintf("--url=%s", failoverEndpoints[0])
+		output, err := object.RunAdminCommandNoMultisite(objContext, false, "realm", "pull", realmArg, urlArg, accessKeyArg, secretKeyArg)
+		if err != nil {
+			return errors.Wrapf(err, "failed to pull realm %q from the new master zone %q for reason %q", objContext.Realm, failover.MasterZone, output)
+		}
+		record = &cephv1.ObjectZoneFailoverRecord{Action: failoverDemoted, PreviousMaster: zone.Name, NewMaster: failover.MasterZone}
+		masterZone = failover.MasterZone
+		r.recorder.Eventf(zone, corev1.EventTypeNormal, failoverDemoted, "demoted zone %q, zone %q is the master of zone group %q", zone.Name, failover.MasterZone, objContext.ZoneGroup)
+	case failover.MasterZone != zone.Name && masterZone == zone.Name:
+		logger.Warningf("zone %q is still master in its configuration period while zone %q is the failover master of zone group %q. set demoteOldMaster to demote it", zone.Name, failover.MasterZone, objContext.ZoneGroup)
+	}
+
+	r.updateFailoverStatus(zone, masterZone == zone.Name, record)
+	return nil
+}
+
+// updateFailoverStatus updates `.status.failover` with the role of the zone and records the
+// failover in the history if any
+func (r *ReconcileObjectZone) updateFailoverStatus(zone *cephv1.CephObjectZone, master bool, record *cephv1.ObjectZoneFailoverRecord) {
+	if record == nil && zone.Status != nil && zone.Status.Failover != nil && zone.Status.Failover.Master == master {
+		return
+	}
+	name := types.NamespacedName{Name: zone.Name, Namespace: zone.Namespace}
+	objectZone := &cephv1.CephObjectZone{}
+	if err := r.client.Get(r.opManagerContext, name, objectZone); err != nil {
+		logger.Warningf("failed to retrieve object zone %q to update the failover status. %v", name, err)
+		return
+	}
+	if objectZone.Status == nil {
+		objectZone.Status = &cephv1.ObjectZoneStatus{}
+	}
+	if objectZone.Status.Failover == nil {
+		objectZone.Status.Failover = &cephv1.ObjectZoneFailoverStatus{}
+	}
+	objectZone.Status.Failover.Master = master
+	if record != nil {
+		record.Time = metav1.NewTime(time.Now())
+		history := append(objectZone.Status.Failover.History, *record)
+		if len(history) > maxFailoverHistory {
+			history = history[len(history)-maxFailoverHistory:]
+		}
+		objectZone.Status.Failover.History = history
+	}
+	if err := reporting.UpdateStatus(r.client, objectZone); err != nil {
+		logger.Warningf("failed to update the failover status of object zone %q. %v", name, err)
+		return
+	}
+	zone.Status = objectZone.Status
+	logger.Debugf("object zone %q failover status updated", name)
+}
+
+func truncateSyncStatus(syncStatus string) string {
+	syncStatus = strings.TrimSpace(syncStatus)
+	if len(syncStatus) > maxSyncStatusLength {
+		return syncStatus[:maxSyncStatusLength]
+	}
+	return syncStatus
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zone
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/clusterd"
+	cephclient "github.com/rook/rook/pkg/daemon/ceph/client"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/test"
+	exectest "github.com/rook/rook/pkg/util/exec/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const failoverZoneGroupJSON = `{
+	"name": "zonegroup-a",
+	"master_zone": "zone-a-id",
+	"zones": [
+		{"id": "zone-a-id", "name": "zone-a", "endpoints": ["http://zone-a:80"]},
+		{"id": "zone-b-id", "name": "zone-b", "endpoints": ["http://zone-b:80"]}
+	]
+}`
+
+func TestReconcileFailover(t *testing.T) {
+	ctx := context.TODO()
+	namespace := "rook-ceph"
+
+	var commands []string
+	commitCalled := false
+	commitConfigChangesFunc = func(c *object.Context) error {
+		commitCalled = true
+		return nil
+	}
+	defer func() {
+		commitConfigChangesFunc = object.CommitConfigChanges
+	}()
+
+	setup := func(zoneName string, failover *cephv1.ObjectZoneGroupFailoverSpec) (*ReconcileObjectZone, *cephv1.CephObjectZone, *cephv1.CephObjectZoneGroup) {
+		commands = nil
+		commitCalled = false
+		executor := &exectest.MockExecutor{
+			MockExecuteCommandWithTimeout: func(timeout time.Duration, command string, args ...string) (string, error) {
+				commands = append(commands, strings.Join(args[:2], " "))
+				if args[0] == "zonegroup" && args[1] == "get" {
+					return failoverZoneGroupJSON, nil
+				}
+				if args[0] == "sync" && args[1] == "status" {
+					return "  metadata sync syncing\n", nil
+				}
+				return "", nil
+			},
+		}
+		clientset := test.New(t, 3)
+		_, err := clientset.CoreV1().Secrets(namespace).Create(ctx, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "realm-a-keys", Namespace: namespace},
+			Data:       map[string][]byte{object.AccessKeyName: []byte("access"), object.SecretKeyName: []byte("secret")},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		zone := &cephv1.CephObjectZone{
+			ObjectMeta: metav1.ObjectMeta{Name: zoneName, Namespace: namespace},
+			Spec:       cephv1.ObjectZoneSpec{ZoneGroup: "zonegroup-a"},
+		}
+		zoneGroup := &cephv1.CephObjectZoneGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "zonegroup-a", Namespace: namespace},
+			Spec:       cephv1.ObjectZoneGroupSpec{Realm: "realm-a", Failover: failover},
+		}
+		s := scheme.Scheme
+		s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephObjectZone{}, &cephv1.CephObjectZoneList{})
+		cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(zone).WithStatusSubresource(zone).Build()
+		r := &ReconcileObjectZone{
+			client:           cl,
+			scheme:           s,
+			context:          &clusterd.Context{Executor: executor, Clientset: clientset},
+			clusterInfo:      cephclient.AdminTestClusterInfo(namespace),
+			opManagerContext: ctx,
+			recorder:         record.NewFakeRecorder(5),
+		}
+		return r, zone, zoneGroup
+	}
+	getStatus := func(r *ReconcileObjectZone, name string) *cephv1.ObjectZoneFailoverStatus {
+		zone := &cephv1.CephObjectZone{}
+		require.NoError(t, r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, zone))
+		require.NotNil(t, zone.Status)
+		return zone.Status.Failover
+	}
+
+	t.Run("no failover reports the role of the zone", func(t *testing.T) {
+		r, zone, zoneGroup := setup("zone-b", nil)
+		err := r.reconcileFailover(r.newZoneContext(zone, "realm-a"), zone, zoneGroup)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup get"}, commands)
+		status := getStatus(r, "zone-b")
+		assert.False(t, status.Master)
+		assert.Empty(t, status.History)
+	})
+
+	t.Run("promote the failover zone", func(t *testing.T) {
+		r, zone, zoneGroup := setup("zone-b", &cephv1.ObjectZoneGroupFailoverSpec{MasterZone: "zone-b"})
+		err := r.reconcileFailover(r.newZoneContext(zone, "realm-a"), zone, zoneGroup)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup get", "sync status", "zone modify"}, commands)
+		assert.True(t, commitCalled)
+		status := getStatus(r, "zone-b")
+		assert.True(t, status.Master)
+		require.Len(t, status.History, 1)
+		assert.Equal(t, failoverPromoted, status.History[0].Action)
+		assert.Equal(t, "zone-a", status.History[0].PreviousMaster)
+		assert.Equal(t, "zone-b", status.History[0].NewMaster)
+		assert.Equal(t, "metadata sync syncing", status.History[0].SyncStatus)
+	})
+
+	t.Run("the master zone is left alone", func(t *testing.T) {
+		r, zone, zoneGroup := setup("zone-a", &cephv1.ObjectZoneGroupFailoverSpec{MasterZone: "zone-a"})
+		err := r.reconcileFailover(r.newZoneContext(zone, "realm-a"), zone, zoneGroup)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup get"}, commands)
+		assert.False(t, commitCalled)
+		assert.True(t, getStatus(r, "zone-a").Master)
+	})
+
+	t.Run("the previous master is not demoted by default", func(t *testing.T) {
+		r, zone, zoneGroup := setup("zone-a", &cephv1.ObjectZoneGroupFailoverSpec{MasterZone: "zone-b"})
+		err := r.reconcileFailover(r.newZoneContext(zone, "realm-a"), zone, zoneGroup)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup get"}, commands)
+		assert.True(t, getStatus(r, "zone-a").Master)
+	})
+
+	t.Run("demote the previous master", func(t *testing.T) {
+		r, zone, zoneGroup := setup("zone-a", &cephv1.ObjectZoneGroupFailoverSpec{MasterZone: "zone-b", DemoteOldMaster: true})
+		err := r.reconcileFailover(r.newZoneContext(zone, "realm-a"), zone, zoneGroup)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup get", "realm pull"}, commands)
+		assert.False(t, commitCalled)
+		status := getStatus(r, "zone-a")
+		assert.False(t, status.Master)
+		require.Len(t, status.History, 1)
+		assert.Equal(t, failoverDemoted, status.History[0].Action)
+		assert.Equal(t, "zone-b", status.History[0].NewMaster)
+	})
+
+	t.Run("a zone group spec change promotes its zone", func(t *testing.T) {
+		r, zone, zoneGroup := setup("zone-b", nil)
+		otherZone := &cephv1.CephObjectZone{
+			ObjectMeta: metav1.ObjectMeta{Name: "zone-x", Namespace: namespace},
+			Spec:       cephv1.ObjectZoneSpec{ZoneGroup: "zonegroup-x"},
+		}
+		require.NoError(t, r.client.Create(ctx, otherZone))
+
+		updated := zoneGroup.DeepCopy()
+		updated.Generation++
+		updated.Spec.Failover = &cephv1.ObjectZoneGroupFailoverSpec{MasterZone: "zone-b"}
+		changed := predicate.TypedGenerationChangedPredicate[*cephv1.CephObjectZoneGroup]{}
+		assert.True(t, changed.Update(event.TypedUpdateEvent[*cephv1.CephObjectZoneGroup]{ObjectOld: zoneGroup, ObjectNew: updated}))
+
+		requests := mapZoneGroupToZones(r.client)(ctx, updated)
+		assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "zone-b", Namespace: namespace}}}, requests)
+
+		err := r.reconcileFailover(r.newZoneContext(zone, "realm-a"), zone, updated)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup get", "sync status", "zone modify"}, commands)
+		assert.True(t, getStatus(r, "zone-b").Master)
+	})
+
+	t.Run("the zone is not created yet", func(t *testing.T) {
+		r, zone, zoneGroup := setup("zone-c", &cephv1.ObjectZoneGroupFailoverSpec{MasterZone: "zone-c"})
+		err := r.reconcileFailover(r.newZoneContext(zone, "realm-a"), zone, zoneGroup)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zonegroup get"}, commands)
+	})
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zone
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/rook/rook/pkg/operator/ceph/reporting"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	defaultSyncStatusInterval = 5 * time.Minute
+	defaultSyncLagThreshold   = time.Hour
+	// maxRecentSyncErrors is the number of sync error messages kept in the zone status
+	maxRecentSyncErrors = 5
+	metadataSyncType    = "metadata"
+	dataSyncType        = "data"
+)
+
+var (
+	syncShardsBehindGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rook_ceph",
+		Subsystem: "object_zone_sync",
+		Name:      "shards_behind",
+		Help:      "Number of log shards with changes not synced from the source",
+	}, []string{"namespace", "zone", "source_zone", "type"})
+	syncLagGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rook_ceph",
+		Subsystem: "object_zone_sync",
+		Name:      "lag_seconds",
+		Help:      "Age of the oldest change not synced from the source, 0 when caught up",
+	}, []string{"namespace", "zone", "source_zone", "type"})
+	syncErrorsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "rook_ceph",
+		Subsystem: "object_zone_sync",
+		Name:      "errors",
+		Help:      "Number of entries in the sync error log",
+	}, []string{"namespace", "zone"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(syncShardsBehindGauge, syncLagGauge, syncErrorsGauge)
+}
+
+var (
+	dataSyncSourceRegex = regexp.MustCompile(`^data sync source: \S+ \((.*)\)$`)
+	shardsBehindRegex   = regexp.MustCompile(`is behind on (\d+) shards`)
+	// the time is followed by the shard the change belongs to, like "2025-01-01T00:00:00.000000+0000 [31]"
+	oldestChangeRegex   = regexp.MustCompile(`^oldest incremental change not applied: ([^\[]+)`)
+	oldestChangeLayouts = []string{"2006-01-02T15:04:05.999999-0700", time.RFC3339Nano, "2006-01-02 15:04:05.999999"}
+)
+
+// allow this to be overridden for unit tests
+var getSyncStatusFunc = getSyncStatus
+
+// syncErrorShard is a shard of the output of `radosgw-admin sync error list`
+type syncErrorShard struct {
+	Entries []struct {
+		Timestamp string `json:"timestamp"`
+		Info      struct {
+			SourceZone string `json:"source_zone"`
+			Message    string `json:"message"`
+		} `json:"info"`
+	} `json:"entries"`
+}
+
+func syncStatusInterval(zone *cephv1.CephObjectZone) time.Duration {
+	if zone.Spec.SyncStatus != nil && zone.Spec.SyncStatus.Interval != nil && zone.Spec.SyncStatus.Interval.Duration > 0 {
+		return zone.Spec.SyncStatus.Interval.Duration
+	}
+	return defaultSyncStatusInterval
+}
+
+func syncLagThreshold(zone *cephv1.CephObjectZone) time.Duration {
+	if zone.Spec.SyncStatus != nil && zone.Spec.SyncStatus.LagThreshold != nil && zone.Spec.SyncStatus.LagThreshold.Duration > 0 {
+		return zone.Spec.SyncStatus.LagThreshold.Duration
+	}
+	return defaultSyncLagThreshold
+}
+
+func syncStatusEnabled(zone *cephv1.CephObjectZone) bool {
+	return zone.Spec.SyncStatus == nil || !zone.Spec.SyncStatus.Disabled
+}
+
+// getSyncStatus collects the metadata and data sync status of the zone and its sync errors
+func getSyncStatus(objContext *object.Context) (*cephv1.ObjectZoneSyncStatus, error) {
+	realmArg := fmt.Sprintf("--rgw-realm=%s", objContext.Realm)
+	zoneGroupArg := fmt.Sprintf("--rgw-zonegroup=%s", objContext.ZoneGroup)
+	zoneArg := fmt.Sprintf("--rgw-zone=%s", objContext.Zone)
+
+	output, err := object.RunAdminCommandNoMultisite(objContext, false, "sync", "status", realmArg, zoneGroupArg, zoneArg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the sync status of zone %q", objContext.Zone)
+	}
+	status := parseSyncStatus(output)
+
+	output, err = object.RunAdminCommandNoMultisite(objContext, true, "sync", "error", "list", realmArg, zoneGroupArg, zoneArg)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the sync errors of zone %q", objContext.Zone)
+	}
+	status.Errors, status.RecentErrors, err = parseSyncErrors(output)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the sync errors of zone %q", objContext.Zone)
+	}
+	return status, nil
+}
+
+// parseSyncStatus parses the output of `radosgw-admin sync status`, which is only available as text
+func parseSyncStatus(output string) *cephv1.ObjectZoneSyncStatus {
+	status := &cephv1.ObjectZoneSyncStatus{}
+	var current *cephv1.ObjectZoneSyncSourceStatus
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "metadata sync "):
+			status.Metadata = &cephv1.ObjectZoneSyncSourceStatus{State: strings.TrimPrefix(line, "metadata sync ")}
+			current = status.Metadata
+		case strings.HasPrefix(line, "data sync source:"):
+			source := cephv1.ObjectZoneSyncSourceStatus{}
+			if m := dataSyncSourceRegex.FindStringSubmatch(line); m != nil {
+				source.SourceZone = m[1]
+			}
+			status.Data = append(status.Data, source)
+			current = &status.Data[len(status.Data)-1]
+		case current == nil || line == "":
+		case shardsBehindRegex.MatchString(line):
+			current.ShardsBehind, _ = strconv.Atoi(shardsBehindRegex.FindStringSubmatch(line)[1])
+		case oldestChangeRegex.MatchString(line):
+			if t, ok := parseSyncTime(oldestChangeRegex.FindStringSubmatch(line)[1]); ok {
+				current.OldestIncrementalChange = &t
+			}
+		case current.State == "" && !strings.Contains(line, ":"):
+			// the state of a data sync source is on the line after the source
+			current.State = line
+		}
+	}
+	return status
+}
+
+func parseSyncTime(value string) (metav1.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range oldestChangeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return metav1.NewTime(t), true
+		}
+	}
+	logger.Debugf("failed to parse the time of the oldest change not synced %q", value)
+	return metav1.Time{}, false
+}
+
+// parseSyncErrors returns the number of sync errors and the messages of the most recent ones
+func parseSyncErrors(output string) (int, []string, error) {
+	if strings.TrimSpace(output) == "" {
+		return 0, nil, nil
+	}
+	var shards []syncErrorShard
+	if err := json.Unmarshal([]byte(output), &shards); err != nil {
+		return 0, nil, errors.Wrap(err, "failed to unmarshal sync error list")
+	}
+	type syncError struct{ timestamp, message string }
+	var syncErrors []syncError
+	for _, shard := range shards {
+		for _, e := range shard.Entries {
+			syncErrors = append(syncErrors, syncError{timestamp: e.Timestamp, message: fmt.Sprintf("%s %s", e.Timestamp, e.Info.Message)})
+		}
+	}
+	sort.SliceStable(syncErrors, func(i, j int) bool { return syncErrors[i].timestamp > syncErrors[j].timestamp })
+	var recent []string
+	for i := 0; i < len(syncErrors) && i < maxRecentSyncErrors; i++ {
+		recent = append(recent, syncErrors[i].message)
+	}
+	return len(syncErrors), recent, nil
+}
+
+// laggingSources returns the sources with changes older than the threshold not synced yet
+func laggingSources(status *cephv1.ObjectZoneSyncStatus, threshold time.Duration, now time.Time) []string {
+	var lagging []string
+	isLagging := func(s *cephv1.ObjectZoneSyncSourceStatus) bool {
+		return s.OldestIncrementalChange != nil && now.Sub(s.OldestIncrementalChange.Time) > threshold
+	}
+	if status.Metadata != nil && isLagging(status.Metadata) {
+		lagging = append(lagging, metadataSyncType)
+	}
+	for i := range status.Data {
+		if isLagging(&status.Data[i]) {
+			lagging = append(lagging, fmt.Sprintf("data from %q", status.Data[i].SourceZone))
+		}
+	}
+	return lagging
+}
+
+// reconcileSyncStatus collects the sync status of the zone and reports it in `.status.syncStatus`,
+// the SyncLagging condition and the metrics
+func (r *ReconcileObjectZone) reconcileSyncStatus(objContext *object.Context, zone *cephv1.CephObjectZone) error {
+	status, err := getSyncStatusFunc(objContext)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	lastChecked := metav1.NewTime(now)
+	status.LastChecked = &lastChecked
+	setSyncMetrics(zone, status, now)
+
+	condition := cephv1.Condition{Type: cephv1.ConditionSyncLagging, Status: v1.ConditionFalse, Reason: cephv1.SyncCaughtUpReason, Message: "the zone has no changes left to sync older than the lag threshold"}
+	if lagging := laggingSources(status, syncLagThreshold(zone), now); len(lagging) > 0 {
+		condition = cephv1.Condition{Type: cephv1.ConditionSyncLagging, Status: v1.ConditionTrue, Reason: cephv1.SyncBehindReason,
+			Message: fmt.Sprintf("changes older than %s are not synced: %s", syncLagThreshold(zone), strings.Join(lagging, ", "))}
+	}
+
+	name := types.NamespacedName{Name: zone.Name, Namespace: zone.Namespace}
+	objectZone := &cephv1.CephObjectZone{}
+	if err := r.client.Get(r.opManagerContext, name, objectZone); err != nil {
+		return errors.Wrapf(err, "failed to retrieve object zone %q to update the sync status", name)
+	}
+	if objectZone.Status == nil {
+		objectZone.Status = &cephv1.ObjectZoneStatus{}
+	}
+	if existing := cephv1.FindStatusCondition(objectZone.Status.Conditions, cephv1.ConditionSyncLagging); (existing == nil || existing.Status != condition.Status) && condition.Status == v1.ConditionTrue {
+		r.recorder.Event(zone, v1.EventTypeWarning, string(condition.Reason), condition.Message)
+	}
+	objectZone.Status.SyncStatus = status
+	cephv1.SetStatusCondition(&objectZone.Status.Conditions, condition)
+	if err := reporting.UpdateStatus(r.client, objectZone); err != nil {
+		return errors.Wrapf(err, "failed to update the sync status of object zone %q", name)
+	}
+	logger.Debugf("object zone %q sync status updated", name)
+	return nil
+}
+
+// clearSyncStatus removes the sync status and the metrics of a zone when the report is disabled
+func (r *ReconcileObjectZone) clearSyncStatus(zone *cephv1.CephObjectZone) {
+	deleteSyncMetrics(zone)
+	if zone.Status == nil || zone.Status.SyncStatus == nil {
+		return
+	}
+	name := types.NamespacedName{Name: zone.Name, Namespace: zone.Namespace}
+	objectZone := &cephv1.CephObjectZone{}
+	if err := r.client.Get(r.opManagerContext, name, objectZone); err != nil {
+		logger.Warningf("failed to retrieve object zone %q to clear the sync status. %v", name, err)
+		return
+	}
+	if objectZone.Status == nil {
+		return
+	}
+	objectZone.Status.SyncStatus = nil
+	var conditions []cephv1.Condition
+	for _, c := range objectZone.Status.Conditions {
+		if c.Type != cephv1.ConditionSyncLagging {
+			conditions = append(conditions, c)
+		}
+	}
+	objectZone.Status.Conditions = conditions
+	if err := reporting.UpdateStatus(r.client, objectZone); err != nil {
+		logger.Warningf("failed to clear the sync status of object zone %q. %v", name, err)
+	}
+}
+
+// setSyncMetrics replaces the sync metrics of the zone
+func setSyncMetrics(zone *cephv1.CephObjectZone, status *cephv1.ObjectZoneSyncStatus, now time.Time) {
+	deleteSyncMetrics(zone)
+	set := func(syncType string, s *cephv1.ObjectZoneSyncSourceStatus) {
+		labels := []string{zone.Namespace, zone.Name, s.SourceZone, syncType}
+		syncShardsBehindGauge.WithLabelValues(labels...).Set(float64(s.ShardsBehind))
+		lag := 0.0
+		if s.OldestIncrementalChange != nil {
+			lag = now.Sub(s.OldestIncrementalChange.Time).Seconds()
+		}
+		syncLagGauge.WithLabelValues(labels...).Set(lag)
+	}
+	if status.Metadata != nil {
+		set(metadataSyncType, status.Metadata)
+	}
+	for i := range status.Data {
+		set(dataSyncType, &status.Data[i])
+	}
+	syncErrorsGauge.WithLabelValues(zone.Namespace, zone.Name).Set(float64(status.Errors))
+}
+
+// deleteSyncMetrics removes the sync metrics of the zone
+func deleteSyncMetrics(zone *cephv1.CephObjectZone) {
+	labels := prometheus.Labels{"namespace": zone.Namespace, "zone": zone.Name}
+	syncShardsBehindGauge.DeletePartialMatch(labels)
+	syncLagGauge.DeletePartialMatch(labels)
+	syncErrorsGauge.DeletePartialMatch(labels)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 The Rook Authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package zone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	cephv1 "github.com/rook/rook/pkg/apis/ceph.rook.io/v1"
+	"github.com/rook/rook/pkg/operator/ceph/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const syncStatusOutput = `          realm 1e2f8a8c-3c5b-4f5e-9f6a-0b8d7c6e5d4a (realm-a)
+      zonegroup 8a1b2c3d-4e5f-6a7b-8c9d-0e1f2a3b4c5d (zonegroup-a)
+           zone 2b5c6d7e-8f9a-0b1c-2d3e-4f5a6b7c8d9e (zone-b)
+   current time 2025-01-01T02:00:00Z
+zonegroup features enabled: resharding
+  metadata sync syncing
+                full sync: 0/64 shards
+                incremental sync: 64/64 shards
+                metadata is caught up with master
+      data sync source: 5a6b7c8d-9e0f-1a2b-3c4d-5e6f7a8b9c0d (zone-a)
+                        syncing
+                        full sync: 0/128 shards
+                        incremental sync: 128/128 shards
+                        data is behind on 2 shards
+                        behind shards: [10,20]
+                        oldest incremental change not applied: 2025-01-01T00:00:00.000000+0000 [10]
+      data sync source: 6b7c8d9e-0f1a-2b3c-4d5e-6f7a8b9c0d1e (zone-c)
+                        syncing
+                        full sync: 0/128 shards
+                        incremental sync: 128/128 shards
+                        data is caught up with source
+`
+
+const syncErrorListOutput = `[
+	{"shard_id": 0, "entries": [
+		{"id": "1", "section": "data", "name": "bucket-a", "timestamp": "2025-01-01T00:00:01.000000Z", "info": {"source_zone": "zone-a", "error_code": 5, "message": "failed to sync bucket instance: (5) Input/output error"}}
+	]},
+	{"shard_id": 1, "entries": [
+		{"id": "2", "section": "data", "name": "bucket-b", "timestamp": "2025-01-01T00:00:02.000000Z", "info": {"source_zone": "zone-a", "error_code": 2, "message": "failed to sync object"}}
+	]},
+	{"shard_id": 2, "entries": []}
+]`
+
+func TestParseSyncStatus(t *testing.T) {
+	status := parseSyncStatus(syncStatusOutput)
+	require.NotNil(t, status.Metadata)
+	assert.Equal(t, "syncing", status.Metadata.State)
+	assert.Equal(t, 0, status.Metadata.ShardsBehind)
+	assert.Nil(t, status.Metadata.OldestIncrementalChange)
+
+	require.Len(t, status.Data, 2)
+	assert.Equal(t, "zone-a", status.Data[0].SourceZone)
+	assert.Equal(t, "syncing", status.Data[0].State)
+	assert.Equal(t, 2, status.Data[0].ShardsBehind)
+	require.NotNil(t, status.Data[0].OldestIncrementalChange)
+	assert.True(t, status.Data[0].OldestIncrementalChange.Time.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "zone-c", status.Data[1].SourceZone)
+	assert.Equal(t, 0, status.Data[1].ShardsBehind)
+
+	status = parseSyncStatus("  metadata sync no sync (zone is master)\n")
+	assert.Equal(t, "no sync (zone is master)", status.Metadata.State)
+	assert.Empty(t, status.Data)
+
+	now := time.Date(2025, 1, 1, 2, 0, 0, 0, time.UTC)
+	status = parseSyncStatus(syncStatusOutput)
+	assert.Equal(t, []string{`data from "zone-a"`}, laggingSources(status, time.Hour, now))
+	assert.Empty(t, laggingSources(status, 3*time.Hour, now))
+}
+
+func TestParseSyncErrors(t *testing.T) {
+	count, recent, err := parseSyncErrors(syncErrorListOutput)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{
+		"2025-01-01T00:00:02.000000Z failed to sync object",
+		"2025-01-01T00:00:01.000000Z failed to sync bucket instance: (5) Input/output error",
+	}, recent)
+
+	count, recent, err = parseSyncErrors("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, recent)
+
+	_, _, err = parseSyncErrors("not json")
+	assert.Error(t, err)
+}
+
+func TestReconcileSyncStatus(t *testing.T) {
+	ctx := context.TODO()
+	name := types.NamespacedName{Name: "zone-b", Namespace: "rook-ceph"}
+	oldest := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	syncStatus := &cephv1.ObjectZoneSyncStatus{
+		Metadata: &cephv1.ObjectZoneSyncSourceStatus{State: "syncing"},
+		Data:     []cephv1.ObjectZoneSyncSourceStatus{{SourceZone: "zone-a", State: "syncing", ShardsBehind: 3, OldestIncrementalChange: &oldest}},
+		Errors:   4,
+	}
+	getSyncStatusFunc = func(objContext *object.Context) (*cephv1.ObjectZoneSyncStatus, error) {
+		return syncStatus.DeepCopy(), nil
+	}
+	defer func() { getSyncStatusFunc = getSyncStatus }()
+
+	zone := &cephv1.CephObjectZone{
+		ObjectMeta: metav1.ObjectMeta{Name: name.Name, Namespace: name.Namespace},
+		Spec:       cephv1.ObjectZoneSpec{ZoneGroup: "zonegroup-a"},
+		Status:     &cephv1.ObjectZoneStatus{Phase: "Ready"},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(cephv1.SchemeGroupVersion, &cephv1.CephObjectZone{}, &cephv1.CephObjectZoneList{})
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(zone).WithStatusSubresource(zone).Build()
+	recorder := record.NewFakeRecorder(5)
+	r := &ReconcileObjectZone{client: cl, scheme: s, opManagerContext: ctx, recorder: recorder}
+
+	getZone := func() *cephv1.CephObjectZone {
+		z := &cephv1.CephObjectZone{}
+		require.NoError(t, cl.Get(ctx, name, z))
+		return z
+	}
+
+	t.Run("lagging", func(t *testing.T) {
+		err := r.reconcileSyncStatus(nil, zone)
+		assert.NoError(t, err)
+		z := getZone()
+		require.NotNil(t, z.Status.SyncStatus)
+		assert.Equal(t, 4, z.Status.SyncStatus.Errors)
+		assert.NotNil(t, z.Status.SyncStatus.LastChecked)
+		condition := cephv1.FindStatusCondition(z.Status.Conditions, cephv1.ConditionSyncLagging)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ConditionTrue, condition.Status)
+		assert.Equal(t, cephv1.SyncBehindReason, condition.Reason)
+		assert.Contains(t, <-recorder.Events, string(cephv1.SyncBehindReason))
+
+		assert.Equal(t, float64(3), testutil.ToFloat64(syncShardsBehindGauge.WithLabelValues("rook-ceph", "zone-b", "zone-a", "data")))
+		assert.Equal(t, float64(4), testutil.ToFloat64(syncErrorsGauge.WithLabelValues("rook-ceph", "zone-b")))
+		assert.Less(t, 7000.0, testutil.ToFloat64(syncLagGauge.WithLabelValues("rook-ceph", "zone-b", "zone-a", "data")))
+	})
+
+	t.Run("caught up after a larger threshold", func(t *testing.T) {
+		zone.Spec.SyncStatus = &cephv1.ObjectZoneSyncStatusSpec{LagThreshold: &metav1.Duration{Duration: 3 * time.Hour}}
+		err := r.reconcileSyncStatus(nil, zone)
+		assert.NoError(t, err)
+		condition := cephv1.FindStatusCondition(getZone().Status.Conditions, cephv1.ConditionSyncLagging)
+		require.NotNil(t, condition)
+		assert.Equal(t, v1.ConditionFalse, condition.Status)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		zone.Spec.SyncStatus = &cephv1.ObjectZoneSyncStatusSpec{Disabled: true}
+		assert.False(t, syncStatusEnabled(zone))
+		zone.Status = getZone().Status
+		r.clearSyncStatus(zone)
+		z := getZone()
+		assert.Nil(t, z.Status.SyncStatus)
+		assert.Nil(t, cephv1.FindStatusCondition(z.Status.Conditions, cephv1.ConditionSyncLagging))
+		assert.Equal(t, 0, testutil.CollectAndCount(syncErrorsGauge))
+	})
+}